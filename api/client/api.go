@@ -146,7 +146,7 @@ func (c *Client) getRequest(relPath string, data interface{}, response interface
 	}
 
 	if httpErr != nil {
-		return fmt.Errorf("%v", httpErr.Error)
+		return externalErrorToError(httpErr)
 	}
 
 	return err
@@ -189,6 +189,11 @@ func (c *Client) postRequest(relPath string, data interface{}, response interfac
 			return nil
 		}
 
+		// a deploy which is blocked by policies is blocked again when retried
+		if httpErr != nil && httpErr.Code == types.ErrCodePolicyViolation {
+			break
+		}
+
 		if i != int(retryCount)-1 {
 			if httpErr != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s (status code %d), retrying request...\n", httpErr.Error, httpErr.Code)
@@ -199,7 +204,7 @@ func (c *Client) postRequest(relPath string, data interface{}, response interfac
 	}
 
 	if httpErr != nil {
-		return fmt.Errorf("%v", httpErr.Error)
+		return externalErrorToError(httpErr)
 	}
 
 	return err
@@ -242,6 +247,11 @@ func (c *Client) patchRequest(relPath string, data interface{}, response interfa
 			return nil
 		}
 
+		// a deploy which is blocked by policies is blocked again when retried
+		if httpErr != nil && httpErr.Code == types.ErrCodePolicyViolation {
+			break
+		}
+
 		if i != int(retryCount)-1 {
 			if httpErr != nil {
 				fmt.Fprintf(os.Stderr, "Error: %s (status code %d), retrying request...\n", httpErr.Error, httpErr.Code)
@@ -252,12 +262,34 @@ func (c *Client) patchRequest(relPath string, data interface{}, response interfa
 	}
 
 	if httpErr != nil {
-		return fmt.Errorf("%v", httpErr.Error)
+		return externalErrorToError(httpErr)
 	}
 
 	return err
 }
 
+// PolicyViolationError is returned when a deploy is blocked by the policies of a project
+type PolicyViolationError struct {
+	Message    string
+	Violations []*types.PolicyViolation
+}
+
+func (e *PolicyViolationError) Error() string {
+	return e.Message
+}
+
+// externalErrorToError converts an API error to an error, keeping the violations of a deploy blocked by policies
+func externalErrorToError(httpErr *types.ExternalError) error {
+	if httpErr.Code == types.ErrCodePolicyViolation {
+		return &PolicyViolationError{
+			Message:    httpErr.Error,
+			Violations: httpErr.PolicyViolations,
+		}
+	}
+
+	return fmt.Errorf("%v", httpErr.Error)
+}
+
 func (c *Client) deleteRequest(relPath string, data interface{}, response interface{}) error {
 	strData, err := json.Marshal(data)
 	if err != nil {
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListAppSleepSchedules lists the sleep schedules in a cluster
+func (c *Client) ListAppSleepSchedules(
+	ctx context.Context,
+	projectID, clusterID uint,
+) (types.ListAppSleepSchedulesResponse, error) {
+	var resp types.ListAppSleepSchedulesResponse
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/sleep_schedules",
+			projectID, clusterID,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// CreateAppSleepSchedule creates a schedule which puts the apps of a deployment target to sleep outside of its awake hours
+func (c *Client) CreateAppSleepSchedule(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *types.CreateAppSleepScheduleRequest,
+) (*types.AppSleepSchedule, error) {
+	resp := &types.AppSleepSchedule{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/sleep_schedules",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteAppSleepSchedule deletes a sleep schedule
+func (c *Client) DeleteAppSleepSchedule(
+	ctx context.Context,
+	projectID, clusterID, scheduleID uint,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/sleep_schedules/%d",
+			projectID, clusterID, scheduleID,
+		),
+		nil,
+		nil,
+	)
+}
+
+// SleepApp scales the web and worker services of an app to zero until the next transition of its sleep schedule
+func (c *Client) SleepApp(
+	ctx context.Context,
+	projectID, clusterID uint,
+	appName string,
+	req *types.SleepAppRequest,
+) (*types.AppSleepState, error) {
+	resp := &types.AppSleepState{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/apps/%s/sleep",
+			projectID, clusterID, appName,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// WakeApp restores the original scale of an app which was put to sleep
+func (c *Client) WakeApp(
+	ctx context.Context,
+	projectID, clusterID uint,
+	appName string,
+	req *types.SleepAppRequest,
+) (*types.AppSleepState, error) {
+	resp := &types.AppSleepState{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/apps/%s/wake",
+			projectID, clusterID, appName,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -87,25 +87,30 @@ func (c *Client) DeployAddon(
 	)
 }
 
-// UpgradeRelease upgrades a specific release with new values or chart version
+// UpgradeRelease upgrades a specific release with new values or chart version, and returns the policy
+// warnings of the upgrade. If the upgrade is blocked by policies, the error is a *PolicyViolationError.
 func (c *Client) UpgradeRelease(
 	ctx context.Context,
 	projID, clusterID uint,
 	namespace, name string,
 	req *types.UpgradeReleaseRequest,
-) error {
-	return c.postRequest(
+) (*types.DeployReleaseResponse, error) {
+	resp := &types.DeployReleaseResponse{}
+
+	err := c.postRequest(
 		fmt.Sprintf(
 			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade",
 			projID, clusterID,
 			namespace, name,
 		),
 		req,
-		nil,
+		resp,
 		postRequestOpts{
 			retryCount: 3,
 		},
 	)
+
+	return resp, err
 }
 
 // DeleteRelease deletes a Porter release
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListDeploymentTargets lists the deployment targets across all clusters in the project
+func (c *Client) ListDeploymentTargets(
+	ctx context.Context,
+	projectID uint,
+) (types.ListDeploymentTargetsResponse, error) {
+	var resp types.ListDeploymentTargetsResponse
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/deployment-targets",
+			projectID,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// CreateDeploymentTarget creates a named deployment target for a namespace on a cluster in the project
+func (c *Client) CreateDeploymentTarget(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateDeploymentTargetRequest,
+) (*types.DeploymentTarget, error) {
+	resp := &types.DeploymentTarget{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/deployment-targets",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// UpdateDeploymentTargetEnvOverrides replaces the env overrides of a deployment target
+func (c *Client) UpdateDeploymentTargetEnvOverrides(
+	ctx context.Context,
+	projectID uint,
+	deploymentTargetID string,
+	req *types.UpdateDeploymentTargetEnvOverridesRequest,
+) (*types.DeploymentTarget, error) {
+	resp := &types.DeploymentTarget{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/deployment-targets/%s/env-overrides",
+			projectID, deploymentTargetID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteDeploymentTarget deletes a named deployment target
+func (c *Client) DeleteDeploymentTarget(
+	ctx context.Context,
+	projectID uint,
+	deploymentTargetID string,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/deployment-targets/%s",
+			projectID, deploymentTargetID,
+		),
+		nil,
+		nil,
+	)
+}
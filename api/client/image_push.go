@@ -0,0 +1,122 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListImagePushPolicies lists the image push policies in a cluster
+func (c *Client) ListImagePushPolicies(
+	ctx context.Context,
+	projectID, clusterID uint,
+) (types.ListImagePushPoliciesResponse, error) {
+	var resp types.ListImagePushPoliciesResponse
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/image_push/policies",
+			projectID, clusterID,
+		),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// CreateImagePushPolicy creates a policy which upgrades an app or a release when a matching image is pushed
+func (c *Client) CreateImagePushPolicy(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *types.CreateImagePushPolicyRequest,
+) (*types.ImagePushPolicy, error) {
+	resp := &types.ImagePushPolicy{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/image_push/policies",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// DeleteImagePushPolicy deletes an image push policy
+func (c *Client) DeleteImagePushPolicy(
+	ctx context.Context,
+	projectID, clusterID, policyID uint,
+) error {
+	return c.deleteRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/image_push/policies/%d",
+			projectID, clusterID, policyID,
+		),
+		nil,
+		nil,
+	)
+}
+
+// GetImagePushWebhook returns the urls that registries should send push events to, which are empty until the
+// webhook token is first rotated
+func (c *Client) GetImagePushWebhook(
+	ctx context.Context,
+	projectID uint,
+) (*types.ImagePushWebhookResponse, error) {
+	resp := &types.ImagePushWebhookResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/image_push/webhook",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RotateImagePushWebhook generates a new token and secret for the image push webhook, so that the previous urls
+// stop working, and returns the new urls
+func (c *Client) RotateImagePushWebhook(
+	ctx context.Context,
+	projectID uint,
+) (*types.ImagePushWebhookResponse, error) {
+	resp := &types.ImagePushWebhookResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/image_push/webhook/rotate",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListImagePushEvents lists the most recent automatic upgrades triggered by image pushes in a project
+func (c *Client) ListImagePushEvents(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListImagePushEventsRequest,
+) (types.ListImagePushEventsResponse, error) {
+	var resp types.ListImagePushEventsResponse
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/image_push/events",
+			projectID,
+		),
+		req,
+		&resp,
+	)
+
+	return resp, err
+}
@@ -58,6 +58,27 @@ func (c *Client) CreateNewK8sNamespace(
 	return resp, err
 }
 
+// UpdateClusterHelmStorage moves the Helm releases of a cluster to another storage driver
+func (c *Client) UpdateClusterHelmStorage(
+	ctx context.Context,
+	projectID uint,
+	clusterID uint,
+	req *types.UpdateClusterHelmStorageRequest,
+) (*types.UpdateClusterHelmStorageResponse, error) {
+	resp := &types.UpdateClusterHelmStorageResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/helm_storage",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) GetKubeconfig(
 	ctx context.Context,
 	projectID uint,
@@ -146,6 +167,29 @@ func (c *Client) CreateEnvGroup(
 	return resp, err
 }
 
+// ExportEnvGroup returns all variables of a legacy env group. Secret values are masked unless RevealSecrets is set
+// and the caller is a project admin
+func (c *Client) ExportEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace string,
+	req *types.ExportEnvGroupRequest,
+) (*types.ExportEnvGroupResponse, error) {
+	resp := &types.ExportEnvGroupResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/envgroup/export",
+			projectID, clusterID,
+			namespace,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 func (c *Client) CloneEnvGroup(
 	ctx context.Context,
 	projectID, clusterID uint,
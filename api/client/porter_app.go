@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/porter-dev/porter/api/server/handlers/environment_groups"
 	"github.com/porter-dev/porter/api/server/handlers/porter_app"
 	"github.com/porter-dev/porter/internal/models"
 
@@ -150,6 +151,48 @@ func (c *Client) ListEnvGroups(
 	return *resp, err
 }
 
+// UpdateEnvironmentGroup creates a new version of an environment group
+func (c *Client) UpdateEnvironmentGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *environment_groups.UpdateEnvironmentGroupRequest,
+) (*environment_groups.UpdateEnvironmentGroupResponse, error) {
+	resp := &environment_groups.UpdateEnvironmentGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environment-groups",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ExportEnvironmentGroup returns all variables of an environment group. Secret values are masked unless RevealSecrets is set
+// and the caller is a project admin
+func (c *Client) ExportEnvironmentGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	name string,
+	req *environment_groups.ExportEnvironmentGroupRequest,
+) (*environment_groups.ExportEnvironmentGroupResponse, error) {
+	resp := &environment_groups.ExportEnvironmentGroupResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environment-groups/%s/export",
+			projectID, clusterID, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // ParseYAML takes in a base64 encoded porter yaml and returns an app proto
 func (c *Client) ParseYAML(
 	ctx context.Context,
@@ -463,3 +506,24 @@ func (c *Client) CreateOrUpdateAppEnvironment(
 
 	return resp, err
 }
+
+// DiffAppRevisions returns a semantic diff between two revisions of an app, or between a revision and an app which has not been applied
+func (c *Client) DiffAppRevisions(
+	ctx context.Context,
+	projectID, clusterID uint,
+	appName string,
+	req *porter_app.DiffAppRevisionsRequest,
+) (*porter_app.DiffAppRevisionsResponse, error) {
+	resp := &porter_app.DiffAppRevisionsResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/apps/%s/diff",
+			projectID, clusterID, appName,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -46,6 +46,26 @@ func (c *Client) GetProjectCluster(
 	return resp, err
 }
 
+// GetClusterHealth runs the health checks for a project's cluster and returns the report
+func (c *Client) GetClusterHealth(
+	ctx context.Context,
+	projectID uint,
+	clusterID uint,
+) (*types.GetClusterHealthResponse, error) {
+	resp := &types.GetClusterHealthResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/health",
+			projectID, clusterID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
 // ListProjectClusters creates a list of clusters for a given project
 func (c *Client) ListProjectClusters(
 	ctx context.Context,
@@ -177,3 +197,43 @@ func (c *Client) DeleteProject(
 		nil,
 	)
 }
+
+// ExportProject exports every project-scoped model of a project into an archive
+func (c *Client) ExportProject(
+	ctx context.Context,
+	projectID uint,
+	req *types.ExportProjectRequest,
+) (*types.ExportProjectResponse, error) {
+	resp := &types.ExportProjectResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/export",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ImportProject imports a project export archive into a project
+func (c *Client) ImportProject(
+	ctx context.Context,
+	projectID uint,
+	req *types.ImportProjectRequest,
+) (*types.ImportProjectResponse, error) {
+	resp := &types.ImportProjectResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/import",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -28,3 +28,23 @@ func (c *Client) ListReleases(
 
 	return resp, err
 }
+
+// BulkReleaseOperation applies an operation to every release in a cluster with a tag
+func (c *Client) BulkReleaseOperation(
+	ctx context.Context,
+	projectID, clusterID uint,
+	req *types.BulkReleaseOperationRequest,
+) (*types.BulkReleaseOperationResponse, error) {
+	resp := &types.BulkReleaseOperationResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/releases/bulk",
+			projectID, clusterID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -23,8 +23,8 @@ func (c *Client) AuthCheck(ctx context.Context) (*types.GetAuthenticatedUserResp
 }
 
 // Login authorizes the user and grants them a cookie-based session
-func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types.GetAuthenticatedUserResponse, error) {
-	resp := &types.GetAuthenticatedUserResponse{}
+func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types.LoginUserResponse, error) {
+	resp := &types.LoginUserResponse{}
 
 	err := c.postRequest(
 		fmt.Sprintf(
@@ -37,6 +37,19 @@ func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types
 	return resp, err
 }
 
+// LoginTwoFactor completes a login which was challenged for a second factor
+func (c *Client) LoginTwoFactor(ctx context.Context, req *types.LoginTwoFactorRequest) (*types.LoginUserResponse, error) {
+	resp := &types.LoginUserResponse{}
+
+	err := c.postRequest(
+		"/login/2fa",
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // Logout logs the user out and deauthorizes the cookie-based session
 func (c *Client) Logout(ctx context.Context) error {
 	err := c.postRequest(
@@ -99,3 +112,44 @@ func (c *Client) DeleteUser(
 		nil,
 	)
 }
+
+// ListSessions lists the active sessions of the current user
+func (c *Client) ListSessions(ctx context.Context) (types.ListSessionsResponse, error) {
+	resp := types.ListSessionsResponse{}
+
+	err := c.getRequest(
+		"/users/current/sessions",
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// RevokeSession revokes a single session of the current user
+func (c *Client) RevokeSession(ctx context.Context, sessionID uint) error {
+	return c.deleteRequest(
+		fmt.Sprintf("/users/current/sessions/%d", sessionID),
+		nil,
+		nil,
+	)
+}
+
+// RevokeAllSessions revokes all sessions of the current user except for the calling dashboard session. User
+// tokens issued until now, including the token of the client, are revoked as well.
+func (c *Client) RevokeAllSessions(ctx context.Context) error {
+	return c.deleteRequest(
+		"/users/current/sessions",
+		nil,
+		nil,
+	)
+}
+
+// RevokeCollaboratorSessions revokes all sessions of a collaborator in the project
+func (c *Client) RevokeCollaboratorSessions(ctx context.Context, projectID, userID uint) error {
+	return c.deleteRequest(
+		fmt.Sprintf("/projects/%d/roles/sessions?user_id=%d", projectID, userID),
+		nil,
+		nil,
+	)
+}
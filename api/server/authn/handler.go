@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/models"
@@ -93,7 +95,9 @@ func (authn *AuthN) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	authn.nextWithUserID(w, r, userID)
+	twoFactorVerified, _ := session.Values["two_factor_verified"].(bool)
+
+	authn.nextWithUserID(w, r, userID, twoFactorVerified)
 }
 
 func (authn *AuthN) handleForbiddenForSession(
@@ -156,13 +160,69 @@ func (authn *AuthN) verifyTokenWithNext(w http.ResponseWriter, r *http.Request,
 			return
 		}
 
+		// tokens issued without a secret key (such as the porter agent token) do not carry
+		// a secret, so we only verify the secret when one has been stored
+		if len(apiToken.SecretKey) > 0 && !apiToken.VerifySecret(tok.Secret) {
+			authn.sendForbiddenError(fmt.Errorf("token with id %s not valid", tok.TokenID), w, r)
+			return
+		}
+
+		ip := requestutils.GetRequestIP(r, authn.config.TrustedProxies)
+
+		if !apiToken.IsAllowedIP(ip) {
+			authn.sendForbiddenError(fmt.Errorf("token with id %s not allowed from ip %s", tok.TokenID, ip), w, r)
+			return
+		}
+
+		authn.recordAPITokenUsage(apiToken, ip)
+
 		authn.nextWithAPIToken(w, r, apiToken)
 	} else {
 		// otherwise we just use nextWithUser using the `iby` field for the token
-		authn.nextWithUserID(w, r, tok.IBy)
+		authn.nextWithUserToken(w, r, tok)
 	}
 }
 
+// nextWithUserToken calls the next handler with the user who issued a user token. User tokens are not
+// stored, so tokens issued before the user's sessions were last revoked are rejected by their issue time.
+func (authn *AuthN) nextWithUserToken(w http.ResponseWriter, r *http.Request, tok *token.Token) {
+	user, err := authn.config.Repo.User().ReadUser(tok.IBy)
+	if err != nil {
+		authn.sendForbiddenError(fmt.Errorf("user with id %d not found in database", tok.IBy), w, r)
+		return
+	}
+
+	// issue times are stored in seconds, so tokens issued in the same second as the revocation are also rejected
+	if user.SessionsRevokedAt != nil && (tok.IAt == nil || !tok.IAt.After(user.SessionsRevokedAt.Truncate(time.Second))) {
+		authn.sendForbiddenError(fmt.Errorf("token for user %d was issued before their sessions were revoked", user.ID), w, r)
+		return
+	}
+
+	authn.nextWithUser(w, r, user, tok.TwoFactorVerified)
+}
+
+// apiTokenUsageInterval is the minimum amount of time between writes of the last used
+// timestamp for an API token, so that each request does not result in a database write
+const apiTokenUsageInterval = time.Minute
+
+// recordAPITokenUsage stores the last used timestamp and ip address for the token. Errors
+// are logged but do not block the request.
+func (authn *AuthN) recordAPITokenUsage(apiToken *models.APIToken, ip string) {
+	now := time.Now()
+
+	if apiToken.LastUsedAt != nil && apiToken.LastUsedIP == ip && now.Sub(*apiToken.LastUsedAt) < apiTokenUsageInterval {
+		return
+	}
+
+	if err := authn.config.Repo.APIToken().UpdateAPITokenLastUsed(apiToken.ID, now, ip); err != nil {
+		authn.config.Logger.Warn().Err(err).Msgf("could not record usage for token with id %s", apiToken.UniqueID)
+		return
+	}
+
+	apiToken.LastUsedAt = &now
+	apiToken.LastUsedIP = ip
+}
+
 // nextWithAPIToken sets the token in context
 func (authn *AuthN) nextWithAPIToken(w http.ResponseWriter, r *http.Request, tok *models.APIToken) {
 	ctx := r.Context()
@@ -179,9 +239,8 @@ func (authn *AuthN) nextWithAPIToken(w http.ResponseWriter, r *http.Request, tok
 	authn.next.ServeHTTP(w, r)
 }
 
-// nextWithUserID calls the next handler with the user set in the context with key
-// `types.UserScope`.
-func (authn *AuthN) nextWithUserID(w http.ResponseWriter, r *http.Request, userID uint) {
+// nextWithUserID calls the next handler with the user with the given id, see nextWithUser
+func (authn *AuthN) nextWithUserID(w http.ResponseWriter, r *http.Request, userID uint, twoFactorVerified bool) {
 	// search for the user
 	user, err := authn.config.Repo.User().ReadUser(userID)
 	if err != nil {
@@ -189,9 +248,16 @@ func (authn *AuthN) nextWithUserID(w http.ResponseWriter, r *http.Request, userI
 		return
 	}
 
+	authn.nextWithUser(w, r, user, twoFactorVerified)
+}
+
+// nextWithUser calls the next handler with the user set in the context with key `types.UserScope`, and
+// whether the user provided a second factor when logging in with key `types.TwoFactorVerifiedCtxKey`.
+func (authn *AuthN) nextWithUser(w http.ResponseWriter, r *http.Request, user *models.User, twoFactorVerified bool) {
 	// add the user to the context
 	ctx := r.Context()
 	ctx = context.WithValue(ctx, types.UserScope, user)
+	ctx = context.WithValue(ctx, types.TwoFactorVerifiedCtxKey, twoFactorVerified)
 
 	r = r.Clone(ctx)
 	authn.next.ServeHTTP(w, r)
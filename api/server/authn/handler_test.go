@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/shared/apitest"
@@ -246,3 +247,64 @@ func assertNextHandlerCalled(
 	assert.Equal(expUser, next.User, "user should be equal")
 	assert.Equal(http.StatusOK, rr.Result().StatusCode, "status code should be ok")
 }
+
+func TestUserTokenAfterSessionsRevoked(t *testing.T) {
+	tests := []struct {
+		name        string
+		revokedAgo  time.Duration
+		issueBefore bool
+		wantAllowed bool
+	}{
+		{
+			name:        "token issued before sessions were revoked",
+			issueBefore: true,
+		},
+		{
+			name:        "token issued after sessions were revoked",
+			revokedAgo:  2 * time.Second,
+			wantAllowed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, handler, next := loadHandlers(t)
+
+			user := apitest.CreateTestUser(t, config, true)
+
+			var tokenStr string
+			if tt.issueBefore {
+				tokenStr = apitest.AuthenticateUserWithToken(t, config, user.ID)
+			}
+
+			revokedAt := time.Now().Add(-tt.revokedAgo)
+			user.SessionsRevokedAt = &revokedAt
+
+			if _, err := config.Repo.User().UpdateUser(user); err != nil {
+				t.Fatal(err)
+			}
+
+			if !tt.issueBefore {
+				tokenStr = apitest.AuthenticateUserWithToken(t, config, user.ID)
+			}
+
+			req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if tt.wantAllowed {
+				assertNextHandlerCalled(t, next, rr, user)
+				return
+			}
+
+			assertForbiddenError(t, next, rr)
+		})
+	}
+}
@@ -1,17 +1,24 @@
 package authn
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/internal/models"
 )
 
+// SaveUserAuthenticated marks the session as authenticated for the user. twoFactorVerified records whether
+// the user provided a second factor during this login, which is required to access projects that enforce
+// two-factor authentication.
 func SaveUserAuthenticated(
 	w http.ResponseWriter,
 	r *http.Request,
 	config *config.Config,
 	user *models.User,
+	twoFactorVerified bool,
 ) (string, error) {
 	session, err := config.Store.Get(r, config.ServerConf.CookieName)
 	if err != nil {
@@ -27,10 +34,13 @@ func SaveUserAuthenticated(
 	session.Values["authenticated"] = true
 	session.Values["user_id"] = user.ID
 	session.Values["email"] = user.Email
+	session.Values["two_factor_verified"] = twoFactorVerified
 
 	// we unset the redirect uri after login
 	session.Values["redirect_uri"] = ""
 
+	clearPendingTwoFactor(session)
+
 	return redirect, session.Save(r, w)
 }
 
@@ -47,5 +57,100 @@ func SaveUserUnauthenticated(
 	session.Values["authenticated"] = false
 	session.Values["user_id"] = nil
 	session.Values["email"] = nil
+	session.Values["two_factor_verified"] = false
+	return session.Save(r, w)
+}
+
+// SaveUserTwoFactorVerified records that the user of an authenticated session has provided a second
+// factor, such as when two-factor authentication is first enabled. Requests authenticated with a token
+// have no session, and are left unchanged.
+func SaveUserTwoFactorVerified(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+) error {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+	if err != nil {
+		return err
+	}
+
+	if auth, _ := session.Values["authenticated"].(bool); !auth {
+		return nil
+	}
+
+	session.Values["two_factor_verified"] = true
+
+	return session.Save(r, w)
+}
+
+// twoFactorChallengeTTL is how long a password login waits for its second factor
+const twoFactorChallengeTTL = 5 * time.Minute
+
+// SaveUserPendingTwoFactor records that the user passed the password check but still has to
+// provide a second factor. The session stays unauthenticated until SaveUserAuthenticated is
+// called.
+func SaveUserPendingTwoFactor(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	user *models.User,
+) error {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+	if err != nil {
+		return err
+	}
+
+	session.Values["authenticated"] = false
+	session.Values["user_id"] = nil
+	session.Values["email"] = nil
+	session.Values["two_factor_verified"] = false
+
+	session.Values["two_factor_user_id"] = user.ID
+	session.Values["two_factor_expiry"] = time.Now().Add(twoFactorChallengeTTL).Unix()
+	session.Values["two_factor_attempts"] = 0
+
 	return session.Save(r, w)
 }
+
+// GetPendingTwoFactorUserID returns the id of the user with an unexpired two-factor challenge
+// in the session, and increments the number of attempts made against the challenge. Once
+// maxAttempts is exceeded the challenge is cleared and the user has to log in again.
+func GetPendingTwoFactorUserID(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	maxAttempts int,
+) (uint, error) {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+	if err != nil {
+		return 0, err
+	}
+
+	userID, ok := session.Values["two_factor_user_id"].(uint)
+	if !ok || userID == 0 {
+		return 0, fmt.Errorf("no pending two-factor challenge")
+	}
+
+	expiry, _ := session.Values["two_factor_expiry"].(int64)
+	attempts, _ := session.Values["two_factor_attempts"].(int)
+
+	if time.Now().Unix() > expiry || attempts >= maxAttempts {
+		clearPendingTwoFactor(session)
+
+		if err := session.Save(r, w); err != nil {
+			return 0, err
+		}
+
+		return 0, fmt.Errorf("two-factor challenge expired, please log in again")
+	}
+
+	session.Values["two_factor_attempts"] = attempts + 1
+
+	return userID, session.Save(r, w)
+}
+
+func clearPendingTwoFactor(session *sessions.Session) {
+	delete(session.Values, "two_factor_user_id")
+	delete(session.Values, "two_factor_expiry")
+	delete(session.Values, "two_factor_attempts")
+}
@@ -177,7 +177,7 @@ func (d *OutOfClusterAgentGetter) GetHelmAgent(ctx context.Context, r *http.Requ
 
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
 
-	helmAgent, err := helm.GetAgentFromK8sAgent("secret", namespace, d.config.Logger, k8sAgent)
+	helmAgent, err := helm.GetAgentForCluster(cluster, d.config.DBConf.HelmSQLConnectionString, namespace, d.config.Logger, k8sAgent)
 	if err != nil {
 		return nil, telemetry.Error(ctx, span, err, "failed to get Helm agent")
 	}
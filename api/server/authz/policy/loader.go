@@ -70,6 +70,26 @@ func (b *RepoPolicyDocumentLoader) LoadPolicyDocuments(
 			return types.DeveloperPolicy, nil
 		case types.RoleViewer:
 			return types.ViewerPolicy, nil
+		case types.RoleCustom:
+			if role.PolicyUID == "" || b.policyRepo == nil {
+				return nil, apierrors.NewErrForbidden(
+					fmt.Errorf("custom role for user %d, project %d does not reference a policy", userID, projectID),
+				)
+			}
+
+			apiPolicy, reqErr := GetAPIPolicyFromUID(b.policyRepo, projectID, role.PolicyUID)
+			if reqErr != nil {
+				// a role whose policy was deleted grants no access
+				if reqErr.GetStatusCode() == http.StatusBadRequest {
+					return nil, apierrors.NewErrForbidden(
+						fmt.Errorf("custom role for user %d, project %d references policy %s, which does not exist", userID, projectID, role.PolicyUID),
+					)
+				}
+
+				return nil, reqErr
+			}
+
+			return apiPolicy.Policy, nil
 		default:
 			return nil, apierrors.NewErrForbidden(
 				fmt.Errorf("%s role not supported for user %d, project %d", string(role.Kind), userID, projectID),
@@ -1,6 +1,7 @@
 package policy_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -16,6 +17,7 @@ import (
 type basicLoaderTest struct {
 	description      string
 	roleKind         types.RoleKind
+	policyUID        string
 	expErr           bool
 	expErrString     string
 	expErrStatusCode int
@@ -39,11 +41,63 @@ var basicLoaderTests = []basicLoaderTest{
 		expPolicy:   types.ViewerPolicy,
 	},
 	{
-		description:      "should not load custom policy for basic loader",
+		description:      "should not load custom role without a policy",
 		roleKind:         types.RoleCustom,
 		expErr:           true,
 		expErrStatusCode: http.StatusForbidden,
-		expErrString:     "custom role not supported for user 1, project 1",
+		expErrString:     "custom role for user 1, project 1 does not reference a policy",
+	},
+	{
+		description: "should load custom role from project policy",
+		roleKind:    types.RoleCustom,
+		policyUID:   "staging-deployer",
+		expPolicy:   stagingDeployerPolicy,
+	},
+	{
+		description:      "should not load custom role with missing policy",
+		roleKind:         types.RoleCustom,
+		policyUID:        "missing",
+		expErr:           true,
+		expErrStatusCode: http.StatusForbidden,
+		expErrString:     "custom role for user 1, project 1 references policy missing, which does not exist",
+	},
+}
+
+// stagingDeployerPolicy can deploy to the staging namespace but only view production
+var stagingDeployerPolicy = []*types.PolicyDocument{
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope:     types.NamespaceScope,
+						Resources: []types.NameOrUInt{{Name: "staging"}},
+						Verbs:     types.ReadWriteVerbGroup(),
+					},
+				},
+			},
+		},
+	},
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope:     types.NamespaceScope,
+						Resources: []types.NameOrUInt{{Name: "production"}},
+						Verbs:     types.ReadVerbGroup(),
+					},
+				},
+			},
+		},
 	},
 }
 
@@ -51,16 +105,30 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 	assert := assert.New(t)
 
 	for _, basicTest := range basicLoaderTests {
-		// use the in-memory project repo
+		// use the in-memory project and policy repos
 		projRepo := test.NewProjectRepository(true)
-		loader := policy.NewBasicPolicyDocumentLoader(projRepo, nil)
+		policyRepo := test.NewPolicyRepository(true)
+		loader := policy.NewBasicPolicyDocumentLoader(projRepo, policyRepo)
+
+		policyBytes, err := json.Marshal(stagingDeployerPolicy)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		_, err = policyRepo.CreatePolicy(&models.Policy{
+			UniqueID:    "staging-deployer",
+			ProjectID:   1,
+			Name:        "staging-deployer",
+			PolicyBytes: policyBytes,
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
 
 		project := &models.Project{
 			Name: "test-project",
 		}
 
-		var err error
-
 		project, err = projRepo.CreateProject(project)
 
 		if err != nil {
@@ -72,6 +140,7 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 				UserID:    1,
 				ProjectID: 1,
 				Kind:      basicTest.roleKind,
+				PolicyUID: basicTest.policyUID,
 			},
 		})
 
@@ -0,0 +1,53 @@
+package policy
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ValidateRoleAssignment checks that a role assigned to a collaborator or invite is one of the
+// built-in role kinds, or a custom role which references an existing policy in the project
+func ValidateRoleAssignment(
+	policyRepo repository.PolicyRepository,
+	projectID uint,
+	kind types.RoleKind,
+	policyUID string,
+) apierrors.RequestError {
+	switch kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+		if policyUID != "" {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy_uid can only be set for custom roles"),
+				http.StatusBadRequest,
+			)
+		}
+
+		return nil
+	case types.RoleCustom:
+		switch policyUID {
+		case "":
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy_uid is required for custom roles"),
+				http.StatusBadRequest,
+			)
+		case string(types.RoleAdmin), string(types.RoleDeveloper), string(types.RoleViewer):
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("use the %s role kind instead of a custom role", policyUID),
+				http.StatusBadRequest,
+			)
+		}
+
+		_, reqErr := GetAPIPolicyFromUID(policyRepo, projectID, policyUID)
+
+		return reqErr
+	default:
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("unsupported role kind %s", kind),
+			http.StatusBadRequest,
+		)
+	}
+}
@@ -51,6 +51,26 @@ func (p *ProjectScopedMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if project.TwoFactorRequired {
+		user, _ := r.Context().Value(types.UserScope).(*models.User)
+		verified, _ := r.Context().Value(types.TwoFactorVerifiedCtxKey).(bool)
+
+		ok, err := UserSatisfiesTwoFactorRequirement(p.config.Repo, user, verified)
+		if err != nil {
+			apierrors.HandleAPIError(p.config.Logger, p.config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+			return
+		}
+
+		if !ok {
+			apierrors.HandleAPIError(p.config.Logger, p.config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("project %d requires two-factor authentication: enroll at /api/users/current/2fa/enroll, then log in again with your second factor", projID),
+				http.StatusForbidden,
+			), true)
+
+			return
+		}
+	}
+
 	ctx := NewProjectContext(r.Context(), project)
 	r = r.Clone(ctx)
 	p.next.ServeHTTP(w, r)
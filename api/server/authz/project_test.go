@@ -1,7 +1,9 @@
 package authz_test
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -70,6 +72,53 @@ func TestProjectMiddlewareFailedRead(t *testing.T) {
 	apitest.AssertResponseInternalServerError(t, rr)
 }
 
+func TestProjectMiddlewareTwoFactorRequired(t *testing.T) {
+	config, handler, next := loadProjectHandlers(t)
+
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name:              "test-project",
+		TwoFactorRequired: true,
+	}, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = config.Repo.TwoFactor().CreateUserTwoFactor(&models.UserTwoFactor{
+		UserID:  user.ID,
+		Enabled: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRequest := func(twoFactorVerified bool) (*http.Request, *httptest.ResponseRecorder) {
+		req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbGet), "/api/projects/1", nil)
+		req = apitest.WithAuthenticatedUser(t, req, user)
+		req = req.WithContext(context.WithValue(req.Context(), types.TwoFactorVerifiedCtxKey, twoFactorVerified))
+		req = apitest.WithRequestScopes(t, req, map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbGet,
+				Resource: types.NameOrUInt{
+					UInt: proj.ID,
+				},
+			},
+		})
+
+		return req, rr
+	}
+
+	// an enrolled user whose session did not complete the second factor is rejected
+	req, rr := newRequest(false)
+	handler.ServeHTTP(rr, req)
+	assert.False(t, next.WasCalled, "next handler should not have been called")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+
+	req, rr = newRequest(true)
+	handler.ServeHTTP(rr, req)
+	assert.True(t, next.WasCalled, "next handler should have been called")
+}
+
 func loadProjectHandlers(
 	t *testing.T,
 	failingRepoMethods ...string,
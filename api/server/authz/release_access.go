@@ -0,0 +1,59 @@
+package authz
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ReleaseAccessChecker checks whether the requester may act on releases of a cluster. Handlers which act on many
+// releases at once, such as bulk operations, are only scoped to the cluster by the policy middleware, so they must
+// check the namespace and release of each release against the policy of the requester.
+type ReleaseAccessChecker struct {
+	policyDocs []*types.PolicyDocument
+	projectID  uint
+	clusterID  uint
+}
+
+// NewReleaseAccessChecker loads the policy of the requester in the project
+func NewReleaseAccessChecker(config *config.Config, r *http.Request, projectID, clusterID uint) (*ReleaseAccessChecker, apierrors.RequestError) {
+	policyDocs, reqErr := loadRequesterPolicyDocuments(config, r, projectID)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	return &ReleaseAccessChecker{
+		policyDocs: policyDocs,
+		projectID:  projectID,
+		clusterID:  clusterID,
+	}, nil
+}
+
+// HasAccess returns true if the policy of the requester allows the verb on the release in the namespace
+func (c *ReleaseAccessChecker) HasAccess(verb types.APIVerb, namespace, name string) bool {
+	if c.policyDocs == nil {
+		return false
+	}
+
+	return policy.HasScopeAccess(c.policyDocs, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb:     verb,
+			Resource: types.NameOrUInt{UInt: c.projectID},
+		},
+		types.ClusterScope: {
+			Verb:     verb,
+			Resource: types.NameOrUInt{UInt: c.clusterID},
+		},
+		types.NamespaceScope: {
+			Verb:     verb,
+			Resource: types.NameOrUInt{Name: namespace},
+		},
+		types.ReleaseScope: {
+			Verb:     verb,
+			Resource: types.NameOrUInt{Name: name},
+		},
+	})
+}
@@ -0,0 +1,70 @@
+package authz_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseAccessChecker(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+
+	project, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+	require.NoError(t, err)
+
+	// the deployer can update releases in the staging namespace, and only read releases in production
+	policyBytes, err := json.Marshal([]*types.PolicyDocument{
+		{
+			Scope: types.ProjectScope,
+			Verbs: types.ReadWriteVerbGroup(),
+			Children: map[types.PermissionScope]*types.PolicyDocument{
+				types.ClusterScope: {
+					Scope: types.ClusterScope,
+					Verbs: types.ReadWriteVerbGroup(),
+					Children: map[types.PermissionScope]*types.PolicyDocument{
+						types.NamespaceScope: {
+							Scope:     types.NamespaceScope,
+							Resources: []types.NameOrUInt{{Name: "staging"}},
+							Verbs:     types.ReadWriteVerbGroup(),
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = config.Repo.Policy().CreatePolicy(&models.Policy{
+		UniqueID:    "staging-deployer",
+		ProjectID:   project.ID,
+		Name:        "staging-deployer",
+		PolicyBytes: policyBytes,
+	})
+	require.NoError(t, err)
+
+	_, err = config.Repo.Project().CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    user.ID,
+			ProjectID: project.ID,
+			Kind:      types.RoleCustom,
+			PolicyUID: "staging-deployer",
+		},
+	})
+	require.NoError(t, err)
+
+	req, _ := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/1/releases/bulk", nil)
+	req = apitest.WithAuthenticatedUser(t, req, user)
+
+	checker, reqErr := authz.NewReleaseAccessChecker(config, req, project.ID, 1)
+	require.Nil(t, reqErr)
+
+	assert.True(t, checker.HasAccess(types.APIVerbUpdate, "staging", "web"))
+	assert.False(t, checker.HasAccess(types.APIVerbUpdate, "production", "web"))
+}
@@ -0,0 +1,53 @@
+package authz
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CanRevealSecrets returns true if the requester may read secret values in plain text, such as when exporting
+// an environment group. This requires write access to project settings, which only admins have by default.
+func CanRevealSecrets(config *config.Config, r *http.Request, projectID uint) (bool, apierrors.RequestError) {
+	policyDocs, reqErr := loadRequesterPolicyDocuments(config, r, projectID)
+	if reqErr != nil || policyDocs == nil {
+		return false, reqErr
+	}
+
+	return policy.HasScopeAccess(policyDocs, map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb:     types.APIVerbGet,
+			Resource: types.NameOrUInt{UInt: projectID},
+		},
+		types.SettingsScope: {
+			Verb: types.APIVerbUpdate,
+		},
+	}), nil
+}
+
+// loadRequesterPolicyDocuments loads the policy of the api token or user which made the request in the project.
+// It returns nil if the request was not made by a token or a user.
+func loadRequesterPolicyDocuments(config *config.Config, r *http.Request, projectID uint) ([]*types.PolicyDocument, apierrors.RequestError) {
+	policyLoaderOpts := &policy.PolicyLoaderOpts{
+		ProjectID: projectID,
+	}
+
+	if apiToken, ok := r.Context().Value("api_token").(*models.APIToken); ok && apiToken != nil {
+		policyLoaderOpts.ProjectToken = apiToken
+	} else {
+		user, _ := r.Context().Value(types.UserScope).(*models.User)
+		if user == nil {
+			return nil, nil
+		}
+
+		policyLoaderOpts.UserID = user.ID
+	}
+
+	policyDocLoader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.Policy())
+
+	return policyDocLoader.LoadPolicyDocuments(policyLoaderOpts)
+}
@@ -0,0 +1,29 @@
+package authz
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// UserSatisfiesTwoFactorRequirement returns whether the user may access a project which requires
+// two-factor authentication: the user must be enrolled, and must have provided a second factor when
+// logging in, which is recorded in the session as verified. API tokens are not subject to the requirement.
+func UserSatisfiesTwoFactorRequirement(repo repository.Repository, user *models.User, verified bool) (bool, error) {
+	if user == nil || user.ID == 0 {
+		return true, nil
+	}
+
+	twoFactor, err := repo.TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return twoFactor.Enabled && verified, nil
+}
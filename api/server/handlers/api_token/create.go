@@ -3,6 +3,7 @@ package api_token
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/porter-dev/porter/api/server/authz/policy"
@@ -87,6 +88,7 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		PolicyName:      apiPolicy.Name,
 		Name:            req.Name,
 		SecretKey:       hashedToken,
+		AllowedCIDRs:    strings.Join(req.AllowedCIDRs, ","),
 	}
 
 	apiToken, err = p.Repo().APIToken().CreateAPIToken(apiToken)
@@ -0,0 +1,110 @@
+package app_sleep
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CreateScheduleHandler creates a schedule which puts the apps of a deployment target to sleep outside of working hours
+type CreateScheduleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCreateScheduleHandler returns a new CreateScheduleHandler
+func NewCreateScheduleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateScheduleHandler {
+	return &CreateScheduleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreateScheduleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-app-sleep-schedule")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.CreateAppSleepScheduleRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "app-name", Value: request.AppName},
+	)
+
+	schedule, err := porter_app.ParseSleepSchedule(request.Days, request.WakeTime, request.SleepTime, request.Timezone)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid sleep schedule")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	deploymentTarget, err := c.Repo().DeploymentTarget().DeploymentTarget(cluster.ProjectID, request.DeploymentTargetID)
+	if err != nil || deploymentTarget == nil || uint(deploymentTarget.ClusterID) != cluster.ID {
+		err = telemetry.Error(ctx, span, err, "deployment target not found in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	if request.AppName != "" {
+		porterApp, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, request.AppName)
+		if err != nil || porterApp == nil || porterApp.ID == 0 {
+			err = telemetry.Error(ctx, span, err, "porter app not found in cluster")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+	}
+
+	existing, err := c.Repo().AppSleep().ListSchedulesByClusterID(cluster.ProjectID, cluster.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing sleep schedules")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	for _, other := range existing {
+		if other.DeploymentTargetID == request.DeploymentTargetID && other.AppName == request.AppName {
+			err = telemetry.Error(ctx, span, nil, "a sleep schedule already exists for this app and deployment target")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+			return
+		}
+	}
+
+	sleepSchedule := &models.AppSleepSchedule{
+		ProjectID:          cluster.ProjectID,
+		ClusterID:          cluster.ID,
+		DeploymentTargetID: request.DeploymentTargetID,
+		AppName:            request.AppName,
+		Days:               strings.Join(schedule.DayList(), ","),
+		WakeTime:           request.WakeTime,
+		SleepTime:          request.SleepTime,
+		Timezone:           request.Timezone,
+	}
+
+	sleepSchedule, err = c.Repo().AppSleep().CreateSchedule(sleepSchedule)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error creating sleep schedule")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, sleepSchedule.ToAppSleepScheduleType())
+}
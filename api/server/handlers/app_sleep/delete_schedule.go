@@ -0,0 +1,66 @@
+package app_sleep
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// DeleteScheduleHandler deletes a sleep schedule. Apps which are asleep stay asleep until they are woken up manually.
+type DeleteScheduleHandler struct {
+	handlers.PorterHandler
+}
+
+// NewDeleteScheduleHandler returns a new DeleteScheduleHandler
+func NewDeleteScheduleHandler(
+	config *config.Config,
+) *DeleteScheduleHandler {
+	return &DeleteScheduleHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (c *DeleteScheduleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-app-sleep-schedule")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	scheduleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamAppSleepScheduleID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing schedule id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "schedule-id", Value: scheduleID})
+
+	schedule, err := c.Repo().AppSleep().ReadSchedule(cluster.ProjectID, cluster.ID, scheduleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "sleep schedule not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading sleep schedule")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if _, err := c.Repo().AppSleep().DeleteSchedule(schedule); err != nil {
+		err = telemetry.Error(ctx, span, err, "error deleting sleep schedule")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
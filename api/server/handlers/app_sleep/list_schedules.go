@@ -0,0 +1,50 @@
+package app_sleep
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListSchedulesHandler lists the sleep schedules in a cluster
+type ListSchedulesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListSchedulesHandler returns a new ListSchedulesHandler
+func NewListSchedulesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListSchedulesHandler {
+	return &ListSchedulesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListSchedulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-app-sleep-schedules")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	schedules, err := c.Repo().AppSleep().ListSchedulesByClusterID(cluster.ProjectID, cluster.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing sleep schedules")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListAppSleepSchedulesResponse, 0, len(schedules))
+
+	for _, schedule := range schedules {
+		res = append(res, schedule.ToAppSleepScheduleType())
+	}
+
+	c.WriteResult(w, r, res)
+}
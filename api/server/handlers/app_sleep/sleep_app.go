@@ -0,0 +1,140 @@
+package app_sleep
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// SleepAppHandler puts an app to sleep or wakes it up. The app stays in the requested state until the
+// next transition of the sleep schedule which applies to it.
+type SleepAppHandler struct {
+	handlers.PorterHandlerReadWriter
+
+	asleep bool
+}
+
+// NewSleepAppHandler returns a SleepAppHandler which puts apps to sleep
+func NewSleepAppHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SleepAppHandler {
+	return &SleepAppHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		asleep:                  true,
+	}
+}
+
+// NewWakeAppHandler returns a SleepAppHandler which wakes apps up
+func NewWakeAppHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *SleepAppHandler {
+	return &SleepAppHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		asleep:                  false,
+	}
+}
+
+func (c *SleepAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-sleep-app")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &types.SleepAppRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "app-name", Value: appName},
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "asleep", Value: c.asleep},
+	)
+
+	deploymentTarget, err := c.Repo().DeploymentTarget().DeploymentTarget(cluster.ProjectID, request.DeploymentTargetID)
+	if err != nil || deploymentTarget == nil || uint(deploymentTarget.ClusterID) != cluster.ID {
+		err = telemetry.Error(ctx, span, err, "deployment target not found in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	porterApp, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil || porterApp == nil || porterApp.ID == 0 {
+		err = telemetry.Error(ctx, span, err, "porter app not found in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	schedules, err := c.Repo().AppSleep().ListSchedulesByClusterID(cluster.ProjectID, cluster.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing sleep schedules")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// a manual change holds until the schedule next wakes up the app or puts it to sleep
+	var overrideUntil *time.Time
+
+	if schedule := porter_app.SleepScheduleForApp(schedules, request.DeploymentTargetID, appName); schedule != nil {
+		parsed, err := porter_app.ParseSleepScheduleModel(schedule)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error parsing sleep schedule")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		next := parsed.NextTransition(time.Now())
+		overrideUntil = &next
+	}
+
+	input := porter_app.SleepAppInput{
+		ProjectID:          cluster.ProjectID,
+		ClusterID:          cluster.ID,
+		AppName:            appName,
+		DeploymentTargetID: request.DeploymentTargetID,
+		Reason:             porter_app.SleepReasonManual,
+		OverrideUntil:      overrideUntil,
+		CCPClient:          c.Config().ClusterControlPlaneClient,
+		Repo:               c.Repo(),
+	}
+
+	var state *models.AppSleepState
+
+	if c.asleep {
+		state, err = porter_app.SleepApp(ctx, input)
+	} else {
+		state, err = porter_app.WakeApp(ctx, input)
+	}
+
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error changing sleep state of app")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, state.ToAppSleepStateType())
+}
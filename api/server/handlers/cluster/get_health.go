@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/health"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetClusterHealthHandler returns a health report for a cluster
+type GetClusterHealthHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+
+	// policies are the OPA policies run as part of the report, if configured. They are loaded once, when the
+	// handler is created, since internal/opa cannot be referenced by the server config.
+	policies *opa.KubernetesPolicies
+}
+
+// NewGetClusterHealthHandler returns a new GetClusterHealthHandler
+func NewGetClusterHealthHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetClusterHealthHandler {
+	var policies *opa.KubernetesPolicies
+
+	if config.ServerConf != nil && config.ServerConf.OPAConfigFileDir != "" {
+		var err error
+
+		policies, err = opa.LoadPolicies(config.ServerConf.OPAConfigFileDir)
+		if err != nil && config.Logger != nil {
+			config.Logger.Error().Err(err).Msg("could not load opa policies for cluster health reports")
+		}
+	}
+
+	return &GetClusterHealthHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+		policies:              policies,
+	}
+}
+
+func (c *GetClusterHealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-cluster-health")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+	)
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error getting kubernetes agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	inp := health.ReportInput{
+		Clientset: agent.Clientset,
+	}
+
+	if c.policies != nil {
+		dynamicClient, err := c.GetDynamicClient(r, cluster)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error getting dynamic client")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		inp.PolicyRunner = opa.NewRunner(c.policies, cluster, agent, dynamicClient, c.Config().DBConf.HelmSQLConnectionString)
+	}
+
+	res := health.GetReport(ctx, inp)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "healthy", Value: res.Healthy})
+
+	c.WriteResult(w, r, res)
+}
@@ -16,7 +16,9 @@ import (
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -37,7 +39,10 @@ func NewNotifyNewIncidentHandler(
 }
 
 func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-notify-new-incident")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
 	request := &types.Incident{}
 
@@ -46,6 +51,11 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	webhookInts, err := c.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(cluster.ProjectID)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
 
 	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
 
@@ -89,6 +99,29 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		}))
 	}
 
+	if len(webhookInts) > 0 {
+		notifiers = append(notifiers, webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			Config:    notifConf,
+		}, webhookInts...))
+	}
+
+	pagingNotifiers, pagingErrs, err := getPagingIncidentNotifiers(c.Repo(), cluster)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.NotifyIncidentResponse{}
+
+	for _, pagingErr := range pagingErrs {
+		pagingErr = telemetry.Error(ctx, span, pagingErr, "error creating paging incident notifier")
+		res.PagingErrors = append(res.PagingErrors, pagingErr.Error())
+	}
+
+	notifiers = append(notifiers, pagingNotifiers...)
+
 	multi := notifier.NewMultiIncidentNotifier(
 		notifConf,
 		notifiers...,
@@ -121,7 +154,19 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
+
+		// the other integrations have been notified, but the incident was not paged through any provider
+		if len(pagingErrs) > 0 && len(pagingNotifiers) == 0 {
+			err := telemetry.Error(ctx, span, errors.Join(pagingErrs...), "no paging integration could be notified")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("no paging integration could be notified: %w", err),
+				http.StatusInternalServerError,
+			))
+			return
+		}
 	}
+
+	c.WriteResult(w, r, res)
 }
 
 func getUsersByProjectID(repo repository.Repository, projectID uint) ([]*models.User, error) {
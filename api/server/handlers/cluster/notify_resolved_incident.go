@@ -16,6 +16,8 @@ import (
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/telemetry"
 	"gorm.io/gorm"
 )
 
@@ -36,7 +38,10 @@ func NewNotifyResolvedIncidentHandler(
 }
 
 func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-notify-resolved-incident")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
 
 	request := &types.Incident{}
 
@@ -45,6 +50,11 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	webhookInts, err := c.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(cluster.ProjectID)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
 
 	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
 
@@ -88,37 +98,62 @@ func (c *NotifyResolvedIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http
 		}))
 	}
 
+	if len(webhookInts) > 0 {
+		notifiers = append(notifiers, webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+			ProjectID: cluster.ProjectID,
+			ClusterID: cluster.ID,
+			Config:    notifConf,
+		}, webhookInts...))
+	}
+
 	multi := notifier.NewMultiIncidentNotifier(
 		notifConf,
 		notifiers...,
 	)
 
-	if !cluster.NotificationsDisabled {
-		url := fmt.Sprintf(
-			"%s/applications/%s/%s/%s?project_id=%d",
+	url := fmt.Sprintf(
+		"%s/applications/%s/%s/%s?project_id=%d",
+		c.Config().ServerConf.ServerURL,
+		cluster.Name,
+		request.ReleaseNamespace,
+		request.ReleaseName,
+		cluster.ProjectID,
+	)
+
+	if strings.ToLower(string(request.InvolvedObjectKind)) == "job" {
+		url = fmt.Sprintf(
+			"%s/jobs/%s/%s/%s?project_id=%d&job=%s",
 			c.Config().ServerConf.ServerURL,
 			cluster.Name,
 			request.ReleaseNamespace,
 			request.ReleaseName,
 			cluster.ProjectID,
+			request.InvolvedObjectName,
 		)
+	}
 
-		if strings.ToLower(string(request.InvolvedObjectKind)) == "job" {
-			url = fmt.Sprintf(
-				"%s/jobs/%s/%s/%s?project_id=%d&job=%s",
-				c.Config().ServerConf.ServerURL,
-				cluster.Name,
-				request.ReleaseNamespace,
-				request.ReleaseName,
-				cluster.ProjectID,
-				request.InvolvedObjectName,
-			)
-		}
+	// paging alerts are resolved through the integrations which opened them, even if notifications have
+	// been disabled since
+	pagingErrs, err := resolvePagingIncidentAlerts(c.Repo(), cluster, request, url)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.NotifyIncidentResponse{}
 
+	for _, pagingErr := range pagingErrs {
+		pagingErr = telemetry.Error(ctx, span, pagingErr, "error creating paging incident notifier")
+		res.PagingErrors = append(res.PagingErrors, pagingErr.Error())
+	}
+
+	if !cluster.NotificationsDisabled {
 		err := multi.NotifyResolved(request, url)
 		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
 	}
+
+	c.WriteResult(w, r, res)
 }
@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/opsgenie"
+	"github.com/porter-dev/porter/internal/notifier/pagerduty"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// pagingIncidentNotifier opens alerts for new incidents through a paging integration, and records each alert
+// so that it is resolved through the same integration when the incident is resolved
+type pagingIncidentNotifier struct {
+	repo      repository.Repository
+	clusterID uint
+	pagingInt *ints.PagingIntegration
+	rules     []types.IncidentRoutingRule
+	next      notifier.IncidentNotifier
+}
+
+func (p *pagingIncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	if !notifier.MatchesIncidentRoutingRules(p.rules, incident) {
+		return nil
+	}
+
+	if err := p.next.NotifyNew(incident, url); err != nil {
+		return err
+	}
+
+	_, err := p.repo.PagingIntegration().CreatePagingIncidentAlert(&ints.PagingIncidentAlert{
+		PagingIntegrationID: p.pagingInt.ID,
+		ClusterID:           p.clusterID,
+		IncidentID:          incident.ID,
+	})
+
+	return err
+}
+
+func (p *pagingIncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	return p.next.NotifyResolved(incident, url)
+}
+
+// newProviderIncidentNotifier returns the incident notifier for the provider of a paging integration. The API
+// URL is derived from the region of the integration, since the key of the integration is sent to it.
+func newProviderIncidentNotifier(
+	pagingInt *ints.PagingIntegration,
+	clusterID uint,
+	rules []types.IncidentRoutingRule,
+) (notifier.IncidentNotifier, error) {
+	switch pagingInt.Provider {
+	case types.PagingProviderPagerDuty:
+		return pagerduty.NewIncidentNotifier(&pagerduty.IncidentNotifierOpts{
+			RoutingKey: string(pagingInt.Key),
+			APIURL:     pagerduty.EventsAPIURLForRegion(pagingInt.Region),
+			ClusterID:  clusterID,
+			Rules:      rules,
+		}), nil
+	case types.PagingProviderOpsgenie:
+		return opsgenie.NewIncidentNotifier(&opsgenie.IncidentNotifierOpts{
+			APIKey:    string(pagingInt.Key),
+			APIURL:    opsgenie.APIURLForRegion(pagingInt.Region),
+			ClusterID: clusterID,
+			Rules:     rules,
+		}), nil
+	}
+
+	return nil, fmt.Errorf("unsupported paging provider %s", pagingInt.Provider)
+}
+
+// getPagingIncidentNotifiers returns an incident notifier for each paging integration in the cluster's project,
+// along with an error for each integration for which a notifier could not be created
+func getPagingIncidentNotifiers(
+	repo repository.Repository,
+	cluster *models.Cluster,
+) ([]notifier.IncidentNotifier, []error, error) {
+	pagingInts, err := repo.PagingIntegration().ListPagingIntegrationsByProjectID(cluster.ProjectID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res := make([]notifier.IncidentNotifier, 0)
+	skipped := make([]error, 0)
+
+	for _, pagingInt := range pagingInts {
+		rules, err := pagingInt.GetRules()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		next, err := newProviderIncidentNotifier(pagingInt, cluster.ID, rules)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("paging integration %s: %w", pagingInt.Name, err))
+			continue
+		}
+
+		res = append(res, &pagingIncidentNotifier{
+			repo:      repo,
+			clusterID: cluster.ID,
+			pagingInt: pagingInt,
+			rules:     rules,
+			next:      next,
+		})
+	}
+
+	return res, skipped, nil
+}
+
+// resolvePagingIncidentAlerts resolves the alerts which were opened for an incident through the integrations
+// which opened them. Routing rules and notification settings are not evaluated again, since an alert which
+// was opened must be resolved even if they changed in the meantime. An error is returned for each integration
+// for which a notifier could not be created, since its alerts cannot be resolved.
+func resolvePagingIncidentAlerts(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	incident *types.Incident,
+	url string,
+) ([]error, error) {
+	alerts, err := repo.PagingIntegration().ListPagingIncidentAlerts(cluster.ID, incident.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	skipped := make([]error, 0)
+
+	if len(alerts) == 0 {
+		return skipped, nil
+	}
+
+	resolved := make(map[uint]bool)
+
+	for _, alert := range alerts {
+		if resolved[alert.PagingIntegrationID] {
+			continue
+		}
+
+		resolved[alert.PagingIntegrationID] = true
+
+		pagingInt, err := repo.PagingIntegration().ReadPagingIntegration(cluster.ProjectID, alert.PagingIntegrationID)
+		if err != nil {
+			// the integration was deleted after the alert was opened, so the alert cannot be resolved
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		next, err := newProviderIncidentNotifier(pagingInt, cluster.ID, nil)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("paging integration %s: %w", pagingInt.Name, err))
+			continue
+		}
+
+		if err := next.NotifyResolved(incident, url); err != nil {
+			return nil, err
+		}
+	}
+
+	return skipped, repo.PagingIntegration().DeletePagingIncidentAlerts(cluster.ID, incident.ID)
+}
@@ -0,0 +1,95 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePagingIntegrationRepository struct {
+	repository.PagingIntegrationRepository
+
+	pagingInts []*ints.PagingIntegration
+}
+
+func (f *fakePagingIntegrationRepository) ListPagingIntegrationsByProjectID(projectID uint) ([]*ints.PagingIntegration, error) {
+	return f.pagingInts, nil
+}
+
+type fakePagingRepository struct {
+	repository.Repository
+
+	pagingIntegration *fakePagingIntegrationRepository
+}
+
+func (f *fakePagingRepository) PagingIntegration() repository.PagingIntegrationRepository {
+	return f.pagingIntegration
+}
+
+func TestGetPagingIncidentNotifiers(t *testing.T) {
+	tests := []struct {
+		name          string
+		providers     []types.PagingProvider
+		wantNotifiers int
+		wantErrs      []string
+	}{
+		{
+			name:      "no integrations",
+			providers: []types.PagingProvider{},
+		},
+		{
+			name:          "supported providers",
+			providers:     []types.PagingProvider{types.PagingProviderPagerDuty, types.PagingProviderOpsgenie},
+			wantNotifiers: 2,
+		},
+		{
+			name:          "unsupported provider is reported",
+			providers:     []types.PagingProvider{types.PagingProviderPagerDuty, "unknown"},
+			wantNotifiers: 1,
+			wantErrs:      []string{"paging integration integration-1: unsupported paging provider unknown"},
+		},
+		{
+			name:      "no provider can be notified",
+			providers: []types.PagingProvider{"unknown"},
+			wantErrs:  []string{"paging integration integration-0: unsupported paging provider unknown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pagingInts := make([]*ints.PagingIntegration, 0)
+			for i, provider := range tt.providers {
+				pagingInts = append(pagingInts, &ints.PagingIntegration{
+					Name:     fmt.Sprintf("integration-%d", i),
+					Provider: provider,
+				})
+			}
+
+			repo := &fakePagingRepository{
+				pagingIntegration: &fakePagingIntegrationRepository{pagingInts: pagingInts},
+			}
+
+			notifiers, errs, err := getPagingIncidentNotifiers(repo, &models.Cluster{ProjectID: 1})
+			require.NoError(t, err)
+
+			assert.Len(t, notifiers, tt.wantNotifiers)
+
+			gotErrs := make([]string, 0)
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+
+			if tt.wantErrs == nil {
+				tt.wantErrs = []string{}
+			}
+
+			assert.Equal(t, tt.wantErrs, gotErrs)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package cluster
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// defaultRecommenderCheckHistoryLimit is the number of results returned if the request does not set a limit
+const defaultRecommenderCheckHistoryLimit = 100
+
+// ListRecommenderCheckHistoryHandler lists the most recent results of a single recommender check
+type ListRecommenderCheckHistoryHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewListRecommenderCheckHistoryHandler returns a new ListRecommenderCheckHistoryHandler
+func NewListRecommenderCheckHistoryHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListRecommenderCheckHistoryHandler {
+	return &ListRecommenderCheckHistoryHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListRecommenderCheckHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-recommender-check-history")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.ListMonitorTestRunResultsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	limit := request.Limit
+	if limit == 0 {
+		limit = defaultRecommenderCheckHistoryLimit
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "object-id", Value: request.ObjectID},
+		telemetry.AttributeKV{Key: "limit", Value: limit},
+	)
+
+	results, err := c.Repo().MonitorTestResult().ListMonitorTestRunResultsByObjectID(cluster.ProjectID, cluster.ID, request.ObjectID, limit)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing recommender check history")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListMonitorTestRunResultsResponse, 0, len(results))
+
+	for _, result := range results {
+		res = append(res, result.ToMonitorTestRunResultType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,71 @@
+package cluster
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/recommender"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// defaultRecommenderTrendDays is the number of days of recommender history returned if the request does not set days
+const defaultRecommenderTrendDays = 30
+
+// GetRecommenderTrendHandler returns the pass and fail counts of each recommender run in a cluster, and the
+// history of each check
+type GetRecommenderTrendHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewGetRecommenderTrendHandler returns a new GetRecommenderTrendHandler
+func NewGetRecommenderTrendHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GetRecommenderTrendHandler {
+	return &GetRecommenderTrendHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *GetRecommenderTrendHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-recommender-trend")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.GetRecommenderTrendRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	days := request.Days
+	if days == 0 {
+		days = defaultRecommenderTrendDays
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "days", Value: days},
+	)
+
+	since := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+
+	results, err := c.Repo().MonitorTestResult().ListMonitorTestRunResults(cluster.ProjectID, cluster.ID, since)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing recommender run results")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, recommender.Trend(results))
+}
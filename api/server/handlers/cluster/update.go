@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/authz"
@@ -68,6 +69,24 @@ func (c *ClusterUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		cluster.PreviewEnvsEnabled = *request.PreviewEnvsEnabled
 	}
 
+	if request.EnvGroupVersionRetention != nil {
+		cluster.EnvGroupVersionRetention = *request.EnvGroupVersionRetention
+	}
+
+	if request.RecommenderAlertSeverity != nil {
+		severity := *request.RecommenderAlertSeverity
+
+		if severity != "" && !types.IsValidMonitorTestSeverity(severity) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("recommender alert severity must be one of critical, high or low"),
+				http.StatusBadRequest,
+			))
+			return
+		}
+
+		cluster.RecommenderAlertSeverity = string(severity)
+	}
+
 	if request.Name != "" && cluster.Name != request.Name {
 		cluster.Name = request.Name
 	}
@@ -0,0 +1,170 @@
+package cluster
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"github.com/stefanmcshane/helm/pkg/storage"
+)
+
+// UpdateHelmStorageHandler moves the Helm releases of a cluster between release secrets and the sql storage
+// configured on the server, and switches the storage which releases of the cluster are read from
+type UpdateHelmStorageHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewUpdateHelmStorageHandler returns a new UpdateHelmStorageHandler
+func NewUpdateHelmStorageHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateHelmStorageHandler {
+	return &UpdateHelmStorageHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// namespaceStorages are the storages which releases of a namespace are moved between
+type namespaceStorages struct {
+	namespace string
+	src       *storage.Storage
+	dst       *storage.Storage
+}
+
+func (c *UpdateHelmStorageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-helm-storage")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.UpdateClusterHelmStorageRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "current-storage", Value: cluster.HelmStorage},
+		telemetry.AttributeKV{Key: "storage", Value: request.Storage},
+	)
+
+	sqlConnectionString := c.Config().DBConf.HelmSQLConnectionString
+
+	res := &types.UpdateClusterHelmStorageResponse{
+		Storage: request.Storage,
+	}
+
+	if sqlConnectionString == "" {
+		if request.Storage == helm.ClusterStorageSQL {
+			err := telemetry.Error(ctx, span, nil, "sql storage is not configured on this server")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		// without sql storage, there are no releases to move back to secrets
+		if err := c.setHelmStorage(cluster, request.Storage); err != nil {
+			err = telemetry.Error(ctx, span, err, "error updating cluster helm storage")
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error getting k8s agent")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	namespaces, err := agent.ListNamespaces()
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing namespaces")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	storages := make([]namespaceStorages, 0, len(namespaces.Items))
+
+	for _, ns := range namespaces.Items {
+		secretStorage, err := helm.StorageMap[helm.ClusterStorageSecret](c.Config().Logger, agent.Clientset.CoreV1(), ns.Name)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error getting secret storage")
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		sqlStorage, err := helm.NewClusterSQLStorageDriver(c.Config().Logger, sqlConnectionString, cluster.ID, ns.Name)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error getting sql storage")
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if request.Storage == helm.ClusterStorageSQL {
+			storages = append(storages, namespaceStorages{ns.Name, secretStorage, sqlStorage})
+		} else {
+			storages = append(storages, namespaceStorages{ns.Name, sqlStorage, secretStorage})
+		}
+	}
+
+	// revisions are copied before the cluster is switched to the new storage, and only deleted from the
+	// old storage afterwards, so that releases can still be read if the migration fails part way. Requesting
+	// the current storage again moves any revisions which were left in the other storage.
+	for _, stg := range storages {
+		written, err := helm.CopyReleases(stg.src, stg.dst)
+		res.MigratedRevisions += written
+
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, fmt.Sprintf("error copying releases in namespace %s", stg.namespace))
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	if err := c.setHelmStorage(cluster, request.Storage); err != nil {
+		err = telemetry.Error(ctx, span, err, "error updating cluster helm storage")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// revisions created in the old storage while releases were being copied are moved as well
+	for _, stg := range storages {
+		written, err := helm.MigrateReleases(stg.src, stg.dst)
+		res.MigratedRevisions += written
+
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, fmt.Sprintf("error moving releases in namespace %s", stg.namespace))
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "migrated-revisions", Value: res.MigratedRevisions})
+
+	c.WriteResult(w, r, res)
+}
+
+func (c *UpdateHelmStorageHandler) setHelmStorage(cluster *models.Cluster, helmStorage string) error {
+	if cluster.HelmStorage == helmStorage {
+		return nil
+	}
+
+	cluster.HelmStorage = helmStorage
+
+	_, err := c.Repo().Cluster().UpdateCluster(cluster, c.Config().LaunchDarklyClient)
+
+	return err
+}
@@ -0,0 +1,133 @@
+package deployment_target
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CreateDeploymentTargetHandler handles POST requests to the /deployment-targets endpoint
+type CreateDeploymentTargetHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewCreateDeploymentTargetHandler returns a new CreateDeploymentTargetHandler
+func NewCreateDeploymentTargetHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateDeploymentTargetHandler {
+	return &CreateDeploymentTargetHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ServeHTTP creates a named deployment target for a namespace on any cluster in the project
+func (c *CreateDeploymentTargetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-deployment-target")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateDeploymentTargetRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: request.ClusterID},
+		telemetry.AttributeKV{Key: "name", Value: request.Name},
+		telemetry.AttributeKV{Key: "namespace", Value: request.Namespace},
+	)
+
+	cluster, err := c.Repo().Cluster().ReadCluster(project.ID, request.ClusterID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "cluster not found in project")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		err := telemetry.Error(ctx, span, err, "error reading cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	_, err = c.Repo().DeploymentTarget().DeploymentTargetByName(project.ID, request.Name)
+	if err == nil {
+		err := telemetry.Error(ctx, span, nil, "a deployment target with this name already exists")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+		return
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		err := telemetry.Error(ctx, span, err, "error reading deployment target by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// the namespace of the default target is its selector, so this also rejects targeting the default namespace
+	_, err = c.Repo().DeploymentTarget().DeploymentTargetBySelector(project.ID, cluster.ID, request.Namespace)
+	if err == nil {
+		err := telemetry.Error(ctx, span, nil, "a deployment target for this namespace already exists in the cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+		return
+	}
+
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		err := telemetry.Error(ctx, span, err, "error reading deployment target by namespace")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "unable to connect to kubernetes cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	_, err = agent.CreateNamespace(request.Namespace, nil)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error creating namespace for deployment target")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	deploymentTarget, err := c.Repo().DeploymentTarget().CreateDeploymentTarget(&models.DeploymentTarget{
+		ProjectID:    int(project.ID),
+		ClusterID:    int(cluster.ID),
+		Name:         request.Name,
+		Selector:     request.Namespace,
+		SelectorType: models.DeploymentTargetSelectorType_Namespace,
+	})
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error creating deployment target")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res, err := deploymentTarget.ToDeploymentTargetType()
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error converting deployment target")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,114 @@
+package deployment_target_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/deployment_target"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCreateDeploymentTargetConflict(t *testing.T) {
+	tests := []struct {
+		name      string
+		request   *types.CreateDeploymentTargetRequest
+		wantError string
+	}{
+		{
+			name: "name already used in project",
+			request: &types.CreateDeploymentTargetRequest{
+				Name:      "staging",
+				ClusterID: 1,
+				Namespace: "staging-2",
+			},
+			wantError: "a deployment target with this name already exists",
+		},
+		{
+			name: "namespace already targeted in cluster",
+			request: &types.CreateDeploymentTargetRequest{
+				Name:      "staging-2",
+				ClusterID: 1,
+				Namespace: "staging",
+			},
+			wantError: "a deployment target for this namespace already exists in the cluster",
+		},
+		{
+			name: "namespace of default target",
+			request: &types.CreateDeploymentTargetRequest{
+				Name:      "staging-2",
+				ClusterID: 1,
+				Namespace: "default",
+			},
+			wantError: "a deployment target for this namespace already exists in the cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := apitest.LoadConfig(t)
+			user := apitest.CreateTestUser(t, config, true)
+			proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+				Name: "test-project",
+			}, user)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cluster, err := config.Repo.Cluster().CreateCluster(&models.Cluster{
+				ProjectID: proj.ID,
+				Name:      "test-cluster",
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, target := range []*models.DeploymentTarget{
+				{
+					ProjectID:    int(proj.ID),
+					ClusterID:    int(cluster.ID),
+					Selector:     "default",
+					SelectorType: "default",
+				},
+				{
+					ProjectID:    int(proj.ID),
+					ClusterID:    int(cluster.ID),
+					Name:         "staging",
+					Selector:     "staging",
+					SelectorType: models.DeploymentTargetSelectorType_Namespace,
+				},
+			} {
+				if _, err := config.Repo.DeploymentTarget().CreateDeploymentTarget(target); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/targets", tt.request)
+
+			req = apitest.WithAuthenticatedUser(t, req, user)
+			req = apitest.WithProject(t, req, proj)
+
+			handler := deployment_target.NewCreateDeploymentTargetHandler(
+				config,
+				shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+				shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+			)
+
+			handler.ServeHTTP(rr, req)
+
+			apitest.AssertResponseError(t, rr, http.StatusConflict, &types.ExternalError{Error: tt.wantError})
+
+			targets, err := config.Repo.DeploymentTarget().List(proj.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(targets) != 2 {
+				t.Errorf("expected 2 deployment targets, got %d", len(targets))
+			}
+		})
+	}
+}
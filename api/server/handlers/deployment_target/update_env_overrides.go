@@ -0,0 +1,105 @@
+package deployment_target
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// UpdateEnvOverridesHandler handles requests to the /deployment-targets/{deployment_target_id}/env-overrides endpoint
+type UpdateEnvOverridesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateEnvOverridesHandler returns a new UpdateEnvOverridesHandler
+func NewUpdateEnvOverridesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateEnvOverridesHandler {
+	return &UpdateEnvOverridesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP replaces the env overrides of a deployment target. The overrides are layered on top of the
+// app environment group the next time an app is applied to the target.
+func (c *UpdateEnvOverridesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-update-deployment-target-env-overrides")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	deploymentTarget, reqErr := readDeploymentTarget(ctx, r, c.Repo(), project.ID)
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateDeploymentTargetEnvOverridesRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if err := deploymentTarget.SetEnvOverrides(request.DeploymentTargetEnvOverrides); err != nil {
+		err := telemetry.Error(ctx, span, err, "error setting env overrides")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	deploymentTarget, err := c.Repo().DeploymentTarget().UpdateDeploymentTarget(deploymentTarget)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error updating deployment target")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res, err := deploymentTarget.ToDeploymentTargetType()
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error converting deployment target")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// readDeploymentTarget reads the deployment target referenced by the url
+func readDeploymentTarget(ctx context.Context, r *http.Request, repo repository.Repository, projectID uint) (*models.DeploymentTarget, apierrors.RequestError) {
+	ctx, span := telemetry.NewSpan(ctx, "read-deployment-target")
+	defer span.End()
+
+	deploymentTargetID, reqErr := requestutils.GetURLParamString(r, types.URLParamDeploymentTargetID)
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "deployment-target-id", Value: deploymentTargetID})
+
+	deploymentTarget, err := repo.DeploymentTarget().DeploymentTarget(projectID, deploymentTargetID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err := telemetry.Error(ctx, span, err, "deployment target not found in project")
+			return nil, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound)
+		}
+
+		err := telemetry.Error(ctx, span, err, "error reading deployment target")
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	return deploymentTarget, nil
+}
@@ -36,10 +36,18 @@ type UpdateEnvironmentGroupRequest struct {
 	Name string `json:"name"`
 
 	// Variables are values which are not sensitive. All values must be a string due to a kubernetes limitation.
+	// A value of the form ${vault://kv/app#DB_PASSWORD} or ${awssm://prod/db#password} is a reference to a secret in
+	// an external secret manager, and is stored as a secret variable resolved from that manager. Values without the
+	// ${...} wrapper, such as vault://kv/app#DB_PASSWORD, are stored as they are.
 	Variables map[string]string `json:"variables"`
 
 	// SecretVariables are sensitive values. All values must be a string due to a kubernetes limitation.
+	// External secret references use the same ${...} syntax as Variables.
 	SecretVariables map[string]string `json:"secret_variables"`
+
+	// Merge adds the provided variables to those of the latest version of the env group instead of replacing them,
+	// so that many variables can be updated in a single new version
+	Merge bool `json:"merge"`
 }
 type UpdateEnvironmentGroupResponse struct {
 	// Name of the env group to create or update
@@ -75,19 +83,43 @@ func (c *UpdateEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http
 		return
 	}
 
+	variables := make(map[string]string)
 	secrets := make(map[string][]byte)
+
+	if request.Merge {
+		latestEnvGroup, err := environment_groups.LatestBaseEnvironmentGroup(ctx, agent, request.Name)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "unable to get latest environment group")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		for k, v := range latestEnvGroup.Variables {
+			variables[k] = v
+		}
+		for k, v := range latestEnvGroup.SecretVariables {
+			secrets[k] = v
+		}
+	}
+
+	// a key can only be either a variable or a secret, so the most recently provided type wins
+	for k, v := range request.Variables {
+		delete(secrets, k)
+		variables[k] = v
+	}
 	for k, v := range request.SecretVariables {
+		delete(variables, k)
 		secrets[k] = []byte(v)
 	}
 
 	envGroup := environment_groups.EnvironmentGroup{
 		Name:            request.Name,
-		Variables:       request.Variables,
+		Variables:       variables,
 		SecretVariables: secrets,
 		CreatedAtUTC:    time.Now().UTC(),
 	}
 
-	err = environment_groups.CreateOrUpdateBaseEnvironmentGroup(ctx, agent, envGroup)
+	err = environment_groups.CreateOrUpdateBaseEnvironmentGroup(ctx, agent, cluster.ProjectID, envGroup)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "unable to create or update environment group")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
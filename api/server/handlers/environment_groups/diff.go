@@ -0,0 +1,134 @@
+package environment_groups
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// DiffEnvironmentGroupVersionsHandler handles GET requests comparing two versions of an environment group
+type DiffEnvironmentGroupVersionsHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewDiffEnvironmentGroupVersionsHandler returns a new DiffEnvironmentGroupVersionsHandler
+func NewDiffEnvironmentGroupVersionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiffEnvironmentGroupVersionsHandler {
+	return &DiffEnvironmentGroupVersionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// DiffEnvironmentGroupVersionsRequest is the request object for the /environment-groups/{name}/diff endpoint
+type DiffEnvironmentGroupVersionsRequest struct {
+	// From is the older version to compare. Defaults to the version before To
+	From int `schema:"from"`
+	// To is the newer version to compare. Defaults to the latest version
+	To int `schema:"to"`
+}
+
+// DiffEnvironmentGroupVersionsResponse is the response object for the /environment-groups/{name}/diff endpoint
+type DiffEnvironmentGroupVersionsResponse struct {
+	Name        string                             `json:"name"`
+	FromVersion int                                `json:"from_version"`
+	ToVersion   int                                `json:"to_version"`
+	Changes     []environmentgroups.VariableChange `json:"changes"`
+}
+
+// ServeHTTP compares two versions of an environment group. Only keys are reported for secret variables
+func (c *DiffEnvironmentGroupVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-diff-env-group-versions")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing environment group name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &DiffEnvironmentGroupVersionsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	versions, err := environmentgroups.EnvironmentGroupVersions(ctx, agent, name)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to list environment group versions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if len(versions) == 0 {
+		err = telemetry.Error(ctx, span, nil, "environment group not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	toVersion := request.To
+	if toVersion == 0 {
+		toVersion = versions[0].Version
+	}
+	fromVersion := request.From
+	if fromVersion == 0 {
+		fromVersion = toVersion - 1
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: name},
+		telemetry.AttributeKV{Key: "from-version", Value: fromVersion},
+		telemetry.AttributeKV{Key: "to-version", Value: toVersion},
+	)
+
+	var from, to environmentgroups.EnvironmentGroup
+	var foundFrom, foundTo bool
+	for _, version := range versions {
+		if version.Version == fromVersion {
+			from, foundFrom = version, true
+		}
+		if version.Version == toVersion {
+			to, foundTo = version, true
+		}
+	}
+
+	if !foundTo {
+		err = telemetry.Error(ctx, span, nil, "to version not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+	// the first version of an environment group is compared against an empty environment group
+	if !foundFrom && fromVersion != 0 {
+		err = telemetry.Error(ctx, span, nil, "from version not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	c.WriteResult(w, r, DiffEnvironmentGroupVersionsResponse{
+		Name:        name,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     environmentgroups.DiffEnvironmentGroups(from, to),
+	})
+}
@@ -0,0 +1,141 @@
+package environment_groups
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ExportEnvironmentGroupHandler handles GET requests to export all variables of an environment group
+type ExportEnvironmentGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewExportEnvironmentGroupHandler returns a new ExportEnvironmentGroupHandler
+func NewExportEnvironmentGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ExportEnvironmentGroupHandler {
+	return &ExportEnvironmentGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// ExportEnvironmentGroupRequest is the request object for the /environment-groups/{name}/export endpoint
+type ExportEnvironmentGroupRequest struct {
+	// Version is the version to export. Defaults to the latest version
+	Version int `schema:"version"`
+	// RevealSecrets returns secret values in plain text instead of masking them. Only project admins may reveal secrets
+	RevealSecrets bool `schema:"reveal_secrets"`
+}
+
+// ExportEnvironmentGroupResponse is the response object for the /environment-groups/{name}/export endpoint
+type ExportEnvironmentGroupResponse struct {
+	Name string `json:"name"`
+	EnvironmentGroupVersion
+	// SecretsRevealed is true if the values of secret variables are in plain text
+	SecretsRevealed bool `json:"secrets_revealed"`
+}
+
+// ServeHTTP exports the variables of a single version of an environment group. Secret values are masked unless the requester is
+// allowed to reveal them
+func (c *ExportEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-export-env-group")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing environment group name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &ExportEnvironmentGroupRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: name},
+		telemetry.AttributeKV{Key: "version", Value: request.Version},
+		telemetry.AttributeKV{Key: "reveal-secrets", Value: request.RevealSecrets},
+	)
+
+	if request.RevealSecrets {
+		canReveal, reqErr := authz.CanRevealSecrets(c.Config(), r, project.ID)
+		if reqErr != nil {
+			err := telemetry.Error(ctx, span, reqErr, "unable to check permission to reveal secrets")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		if !canReveal {
+			err := telemetry.Error(ctx, span, nil, "only project admins can reveal secret values")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	versions, err := environmentgroups.EnvironmentGroupVersions(ctx, agent, name)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to list environment group versions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if len(versions) == 0 {
+		err = telemetry.Error(ctx, span, nil, "environment group not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	envGroup := versions[0]
+	if request.Version != 0 {
+		var found bool
+		for _, version := range versions {
+			if version.Version == request.Version {
+				envGroup, found = version, true
+				break
+			}
+		}
+		if !found {
+			err = telemetry.Error(ctx, span, nil, "environment group version not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+	}
+
+	res := ExportEnvironmentGroupResponse{
+		Name:                    name,
+		EnvironmentGroupVersion: toEnvironmentGroupVersion(envGroup),
+		SecretsRevealed:         request.RevealSecrets,
+	}
+
+	if request.RevealSecrets {
+		for k, v := range envGroup.SecretVariables {
+			res.SecretVariables[k] = string(v)
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,124 @@
+package environment_groups
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RollbackEnvironmentGroupHandler handles POST requests to roll an environment group back to a previous version
+type RollbackEnvironmentGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewRollbackEnvironmentGroupHandler returns a new RollbackEnvironmentGroupHandler
+func NewRollbackEnvironmentGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *RollbackEnvironmentGroupHandler {
+	return &RollbackEnvironmentGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// RollbackEnvironmentGroupRequest is the request object for the /environment-groups/{name}/rollback endpoint
+type RollbackEnvironmentGroupRequest struct {
+	// Version is the version to roll back to
+	Version int `json:"version" form:"required"`
+}
+
+// RollbackEnvironmentGroupResponse is the response object for the /environment-groups/{name}/rollback endpoint
+type RollbackEnvironmentGroupResponse struct {
+	// Version is the new version created with the variables of the requested version
+	Version EnvironmentGroupVersion `json:"version"`
+	// LinkedApplications are the applications which the new version was synced to
+	LinkedApplications []string `json:"linked_applications,omitempty"`
+}
+
+// ServeHTTP creates a new version of an environment group from a previous version and syncs it to all linked applications
+func (c *RollbackEnvironmentGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-rollback-env-group")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing environment group name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	request := &RollbackEnvironmentGroupRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: name},
+		telemetry.AttributeKV{Key: "rollback-version", Value: request.Version},
+	)
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	existing, err := environmentgroups.EnvironmentGroupInTargetNamespace(ctx, agent, environmentgroups.EnvironmentGroupInTargetNamespaceInput{
+		Name:      name,
+		Version:   request.Version,
+		Namespace: environmentgroups.Namespace_EnvironmentGroups,
+	})
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to get environment group version")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if existing.Name == "" {
+		err = telemetry.Error(ctx, span, nil, "environment group version not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	newVersion, err := environmentgroups.RollbackEnvironmentGroup(ctx, agent, cluster.ProjectID, name, request.Version)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to roll back environment group")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	applications, err := environmentgroups.SyncLinkedApplications(ctx, agent, cluster.ProjectID, name)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to sync rolled back environment group to linked applications")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := RollbackEnvironmentGroupResponse{
+		Version: toEnvironmentGroupVersion(newVersion),
+	}
+
+	linkedApplications := make(map[string]bool)
+	for _, app := range applications {
+		if !linkedApplications[app.Name] {
+			linkedApplications[app.Name] = true
+			res.LinkedApplications = append(res.LinkedApplications, app.Name)
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
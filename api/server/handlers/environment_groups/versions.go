@@ -0,0 +1,112 @@
+package environment_groups
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// maskedSecretValue replaces the values of secret variables in environment group history responses
+const maskedSecretValue = "********"
+
+// ListEnvironmentGroupVersionsHandler handles GET requests for the version history of an environment group
+type ListEnvironmentGroupVersionsHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewListEnvironmentGroupVersionsHandler returns a new ListEnvironmentGroupVersionsHandler
+func NewListEnvironmentGroupVersionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListEnvironmentGroupVersionsHandler {
+	return &ListEnvironmentGroupVersionsHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+// EnvironmentGroupVersion is a single version of an environment group. Secret values are always masked
+type EnvironmentGroupVersion struct {
+	Version         int               `json:"version"`
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]string `json:"secret_variables"`
+	CreatedAtUTC    time.Time         `json:"created_at"`
+}
+
+// ListEnvironmentGroupVersionsResponse is the response object for the /environment-groups/{name}/versions endpoint
+type ListEnvironmentGroupVersionsResponse struct {
+	Name string `json:"name"`
+	// Versions are ordered from newest to oldest
+	Versions []EnvironmentGroupVersion `json:"versions"`
+}
+
+// ServeHTTP lists all versions of an environment group
+func (c *ListEnvironmentGroupVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-env-group-versions")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing environment group name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: name})
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to connect to cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusServiceUnavailable))
+		return
+	}
+
+	versions, err := environmentgroups.EnvironmentGroupVersions(ctx, agent, name)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to list environment group versions")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+	if len(versions) == 0 {
+		err = telemetry.Error(ctx, span, nil, "environment group not found")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	res := ListEnvironmentGroupVersionsResponse{
+		Name:     name,
+		Versions: make([]EnvironmentGroupVersion, 0, len(versions)),
+	}
+
+	for _, version := range versions {
+		res.Versions = append(res.Versions, toEnvironmentGroupVersion(version))
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+func toEnvironmentGroupVersion(envGroup environmentgroups.EnvironmentGroup) EnvironmentGroupVersion {
+	secrets := make(map[string]string)
+	for k := range envGroup.SecretVariables {
+		secrets[k] = maskedSecretValue
+	}
+
+	return EnvironmentGroupVersion{
+		Version:         envGroup.Version,
+		Variables:       envGroup.Variables,
+		SecretVariables: secrets,
+		CreatedAtUTC:    envGroup.CreatedAtUTC,
+	}
+}
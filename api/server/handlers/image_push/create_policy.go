@@ -0,0 +1,101 @@
+package image_push
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/imagepush"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// CreatePolicyHandler creates a policy which upgrades an app or a release when a matching image is pushed
+type CreatePolicyHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCreatePolicyHandler returns a new CreatePolicyHandler
+func NewCreatePolicyHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreatePolicyHandler {
+	return &CreatePolicyHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreatePolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-image-push-policy")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.CreateImagePushPolicyRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "policy-type", Value: string(request.Type)},
+		telemetry.AttributeKV{Key: "app-name", Value: request.AppName},
+		telemetry.AttributeKV{Key: "release-name", Value: request.ReleaseName},
+	)
+
+	if err := imagepush.ValidatePolicy(request.Type, request.Pattern); err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid policy pattern")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if request.AppName != "" {
+		porterApp, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, request.AppName)
+		if err != nil || porterApp == nil || porterApp.ID == 0 {
+			err = telemetry.Error(ctx, span, err, "porter app not found in cluster")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		deploymentTarget, err := c.Repo().DeploymentTarget().DeploymentTarget(cluster.ProjectID, request.DeploymentTargetID)
+		if err != nil || deploymentTarget == nil || uint(deploymentTarget.ClusterID) != cluster.ID {
+			err = telemetry.Error(ctx, span, err, "deployment target not found in cluster")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+	} else {
+		if _, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.Namespace); err != nil {
+			err = telemetry.Error(ctx, span, err, "release not found in cluster")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+	}
+
+	policy := &models.ImagePushPolicy{
+		ProjectID:          cluster.ProjectID,
+		ClusterID:          cluster.ID,
+		AppName:            request.AppName,
+		DeploymentTargetID: request.DeploymentTargetID,
+		ReleaseName:        request.ReleaseName,
+		Namespace:          request.Namespace,
+		ImageRepository:    imagepush.NormalizeRepository(request.ImageRepository),
+		Type:               string(request.Type),
+		Pattern:            request.Pattern,
+	}
+
+	policy, err := c.Repo().ImagePush().CreatePolicy(policy)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error creating image push policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, policy.ToImagePushPolicyType())
+}
@@ -0,0 +1,66 @@
+package image_push
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// DeletePolicyHandler deletes an image push policy, so that pushes no longer upgrade its app or release
+type DeletePolicyHandler struct {
+	handlers.PorterHandler
+}
+
+// NewDeletePolicyHandler returns a new DeletePolicyHandler
+func NewDeletePolicyHandler(
+	config *config.Config,
+) *DeletePolicyHandler {
+	return &DeletePolicyHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (c *DeletePolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-image-push-policy")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	policyID, reqErr := requestutils.GetURLParamUint(r, types.URLParamImagePushPolicyID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing policy id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "policy-id", Value: policyID})
+
+	policy, err := c.Repo().ImagePush().ReadPolicy(cluster.ProjectID, cluster.ID, policyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "image push policy not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading image push policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if _, err := c.Repo().ImagePush().DeletePolicy(policy); err != nil {
+		err = telemetry.Error(ctx, span, err, "error deleting image push policy")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,63 @@
+package image_push
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// GetWebhookHandler returns the urls that registries should send push events to for a project. No urls are
+// returned until the token in the urls is generated by RotateWebhookHandler.
+type GetWebhookHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewGetWebhookHandler returns a new GetWebhookHandler
+func NewGetWebhookHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetWebhookHandler {
+	return &GetWebhookHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *GetWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-get-image-push-webhook")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "has-token", Value: project.ImagePushToken != ""},
+	)
+
+	c.WriteResult(w, r, webhookResponse(c.Config().ServerConf.ServerURL, project))
+}
+
+// webhookResponse returns the webhook urls and secret of a project, or an empty response if its token has not
+// been generated yet
+func webhookResponse(serverURL string, project *models.Project) *types.ImagePushWebhookResponse {
+	res := &types.ImagePushWebhookResponse{
+		URLs: make(map[types.ImagePushSource]string, len(types.ImagePushSources)),
+	}
+
+	if project.ImagePushToken == "" {
+		return res
+	}
+
+	for _, source := range types.ImagePushSources {
+		res.URLs[source] = fmt.Sprintf("%s/api/webhooks/image-push/%s/%s", serverURL, project.ImagePushToken, source)
+	}
+
+	res.Secret = project.ImagePushSecret
+
+	return res
+}
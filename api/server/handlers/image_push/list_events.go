@@ -0,0 +1,66 @@
+package image_push
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// maxListedEvents is the number of most recent automatic upgrades returned by ListEventsHandler
+const maxListedEvents = 100
+
+// ListEventsHandler lists the automatic upgrades triggered by image pushes in a project
+type ListEventsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewListEventsHandler returns a new ListEventsHandler
+func NewListEventsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListEventsHandler {
+	return &ListEventsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-image-push-events")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListImagePushEventsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "policy-id", Value: request.PolicyID},
+	)
+
+	events, err := c.Repo().ImagePush().ListEventsByProjectID(project.ID, request.PolicyID, maxListedEvents)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing image push events")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListImagePushEventsResponse, 0, len(events))
+
+	for _, event := range events {
+		res = append(res, event.ToImagePushEventType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,50 @@
+package image_push
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListPoliciesHandler lists the image push policies in a cluster
+type ListPoliciesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListPoliciesHandler returns a new ListPoliciesHandler
+func NewListPoliciesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListPoliciesHandler {
+	return &ListPoliciesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListPoliciesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-image-push-policies")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	policies, err := c.Repo().ImagePush().ListPoliciesByClusterID(cluster.ProjectID, cluster.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing image push policies")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListImagePushPoliciesResponse, 0, len(policies))
+
+	for _, policy := range policies {
+		res = append(res, policy.ToImagePushPolicyType())
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,280 @@
+package image_push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/imagepush"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// maxPushEventSize is the largest push event that is read from a registry
+const maxPushEventSize = 1 << 20
+
+// ReceiveWebhookHandler receives push events from registries, and upgrades the apps and releases whose image
+// push policies match the pushed tags. Events are authenticated by the token in the url, and the signatures of
+// events from registries which sign them are verified with the webhook secret of the project.
+type ReceiveWebhookHandler struct {
+	handlers.PorterHandlerWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewReceiveWebhookHandler returns a new ReceiveWebhookHandler
+func NewReceiveWebhookHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ReceiveWebhookHandler {
+	return &ReceiveWebhookHandler{
+		PorterHandlerWriter:   handlers.NewDefaultPorterHandler(config, nil, writer),
+		KubernetesAgentGetter: authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ReceiveWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-receive-image-push-webhook")
+	defer span.End()
+
+	token, _ := requestutils.GetURLParamString(r, types.URLParamToken)
+	source, _ := requestutils.GetURLParamString(r, types.URLParamImagePushSource)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "source", Value: source})
+
+	project, err := c.Repo().Project().ReadProjectByImagePushToken(token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "project not found with given image push token")
+			// throw forbidden error, since we don't want a way to verify if tokens exist
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading project by image push token")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPushEventSize))
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error reading push event")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	err = imagepush.VerifySignature(types.ImagePushSource(source), project.ImagePushSecret, r.Header, body)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error verifying push event signature")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+		return
+	}
+
+	pushes, err := imagepush.ParsePushes(types.ImagePushSource(source), body)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error parsing push event")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "pushes", Value: len(pushes)})
+
+	res := &types.ImagePushWebhookResult{
+		Events: []*types.ImagePushEvent{},
+	}
+
+	for _, push := range pushes {
+		policies, err := c.Repo().ImagePush().ListPoliciesByRepository(project.ID, push.Repository)
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error listing image push policies")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		for _, policy := range policies {
+			matched, err := imagepush.Match(policy, push)
+			if err != nil {
+				// a policy with an invalid pattern should not prevent other policies from being applied
+				_ = telemetry.Error(ctx, span, err, "error matching image push policy")
+				continue
+			}
+
+			if !matched {
+				continue
+			}
+
+			event, err := c.upgrade(ctx, r, policy, types.ImagePushSource(source), push)
+			if err != nil {
+				err = telemetry.Error(ctx, span, err, "error recording image push event")
+				c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+				return
+			}
+
+			res.Events = append(res.Events, event.ToImagePushEventType())
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// upgrade deploys the pushed image to the app or release of a policy, and records the outcome as an event. An
+// error is only returned if the outcome cannot be recorded.
+func (c *ReceiveWebhookHandler) upgrade(
+	ctx context.Context,
+	r *http.Request,
+	policy *models.ImagePushPolicy,
+	source types.ImagePushSource,
+	push imagepush.Push,
+) (*models.ImagePushEvent, error) {
+	ctx, span := telemetry.NewSpan(ctx, "image-push-upgrade")
+	defer span.End()
+
+	tag := imagepush.DeployTag(policy, push)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "policy-id", Value: policy.ID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: policy.ClusterID},
+		telemetry.AttributeKV{Key: "app-name", Value: policy.AppName},
+		telemetry.AttributeKV{Key: "release-name", Value: policy.ReleaseName},
+		telemetry.AttributeKV{Key: "tag", Value: tag},
+	)
+
+	event := &models.ImagePushEvent{
+		ProjectID:       policy.ProjectID,
+		PolicyID:        policy.ID,
+		Source:          string(source),
+		ImageRepository: push.Repository,
+		Tag:             push.Tag,
+		Digest:          push.Digest,
+		Status:          string(types.ImagePushEventStatusDeployed),
+	}
+
+	cluster, err := c.Repo().Cluster().ReadCluster(policy.ProjectID, policy.ClusterID)
+	if err == nil {
+		if policy.AppName != "" {
+			err = c.upgradeApp(ctx, cluster, policy, tag)
+		} else {
+			err = c.upgradeRelease(ctx, r, cluster, policy, tag)
+		}
+	}
+
+	if err != nil {
+		_ = telemetry.Error(ctx, span, err, "error upgrading from image push")
+
+		event.Status = string(types.ImagePushEventStatusFailed)
+		event.Message = err.Error()
+	} else {
+		policy.LastTag = push.Tag
+		policy.LastDigest = push.Digest
+
+		if _, err := c.Repo().ImagePush().UpdatePolicy(policy); err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error updating image push policy")
+		}
+	}
+
+	return c.Repo().ImagePush().CreateEvent(event)
+}
+
+// upgradeApp applies the current revision of a porter app with the image tag replaced
+func (c *ReceiveWebhookHandler) upgradeApp(ctx context.Context, cluster *models.Cluster, policy *models.ImagePushPolicy, tag string) error {
+	porterApp, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, policy.AppName)
+	if err != nil {
+		return fmt.Errorf("error reading app %s: %w", policy.AppName, err)
+	}
+
+	if porterApp == nil || porterApp.ID == 0 {
+		return fmt.Errorf("app %s not found in cluster", policy.AppName)
+	}
+
+	currentResp, err := c.Config().ClusterControlPlaneClient.CurrentAppRevision(ctx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:          int64(cluster.ProjectID),
+		AppId:              int64(porterApp.ID),
+		DeploymentTargetId: policy.DeploymentTargetID,
+	}))
+	if err != nil {
+		return fmt.Errorf("error getting current revision of app %s: %w", policy.AppName, err)
+	}
+
+	if currentResp == nil || currentResp.Msg == nil || currentResp.Msg.AppRevision == nil || currentResp.Msg.AppRevision.App == nil {
+		return fmt.Errorf("app %s has no current revision", policy.AppName)
+	}
+
+	app := currentResp.Msg.AppRevision.App
+	if app.Image == nil {
+		return fmt.Errorf("app %s does not deploy from an image", policy.AppName)
+	}
+
+	app.Image.Tag = tag
+
+	applyResp, err := c.Config().ClusterControlPlaneClient.ApplyPorterApp(ctx, connect.NewRequest(&porterv1.ApplyPorterAppRequest{
+		ProjectId:          int64(cluster.ProjectID),
+		DeploymentTargetId: policy.DeploymentTargetID,
+		App:                app,
+	}))
+	if err != nil {
+		return fmt.Errorf("error applying app %s: %w", policy.AppName, err)
+	}
+
+	if applyResp == nil || applyResp.Msg == nil {
+		return fmt.Errorf("empty response applying app %s", policy.AppName)
+	}
+
+	if applyResp.Msg.CliAction == porterv1.EnumCLIAction_ENUM_CLI_ACTION_BUILD {
+		return fmt.Errorf("app %s must be built by its build pipeline before it can be deployed", policy.AppName)
+	}
+
+	return nil
+}
+
+// upgradeRelease upgrades a helm release with the image tag replaced, in the same way as the release deploy webhook
+func (c *ReceiveWebhookHandler) upgradeRelease(ctx context.Context, r *http.Request, cluster *models.Cluster, policy *models.ImagePushPolicy, tag string) error {
+	helmAgent, err := c.GetHelmAgent(ctx, r, cluster, policy.Namespace)
+	if err != nil {
+		return fmt.Errorf("error getting helm agent: %w", err)
+	}
+
+	rel, err := helmAgent.GetRelease(ctx, policy.ReleaseName, 0, true)
+	if err != nil {
+		return fmt.Errorf("error getting release %s: %w", policy.ReleaseName, err)
+	}
+
+	image, ok := rel.Config["image"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("release %s does not set an image", policy.ReleaseName)
+	}
+
+	image["tag"] = tag
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+	if err != nil {
+		return fmt.Errorf("error listing registries: %w", err)
+	}
+
+	conf := &helm.UpgradeReleaseConfig{
+		Name:       policy.ReleaseName,
+		Cluster:    cluster,
+		Repo:       c.Repo(),
+		Registries: registries,
+		Values:     rel.Config,
+	}
+
+	if _, err := helmAgent.UpgradeReleaseByValues(ctx, conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection, false); err != nil {
+		return fmt.Errorf("error upgrading release %s: %w", policy.ReleaseName, err)
+	}
+
+	return nil
+}
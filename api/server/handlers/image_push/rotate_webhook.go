@@ -0,0 +1,64 @@
+package image_push
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// RotateWebhookHandler generates a new token and secret for the image push webhook of a project, so that the
+// previous urls stop working, and returns the new urls
+type RotateWebhookHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewRotateWebhookHandler returns a new RotateWebhookHandler
+func NewRotateWebhookHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RotateWebhookHandler {
+	return &RotateWebhookHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *RotateWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-rotate-image-push-webhook")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	token, err := encryption.GenerateRandomBytes(16)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error generating image push token")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	secret, err := encryption.GenerateRandomBytes(32)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error generating image push secret")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	project.ImagePushToken = token
+	project.ImagePushSecret = secret
+
+	if _, err := c.Repo().Project().UpdateProject(project); err != nil {
+		err = telemetry.Error(ctx, span, err, "error saving image push token")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, webhookResponse(c.Config().ServerConf.ServerURL, project))
+}
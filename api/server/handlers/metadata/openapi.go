@@ -0,0 +1,33 @@
+package metadata
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/openapi"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+)
+
+// OpenAPIDocumentGetHandler serves the OpenAPI document describing the API
+type OpenAPIDocumentGetHandler struct {
+	handlers.PorterHandlerWriter
+
+	document *openapi.Document
+}
+
+// NewOpenAPIDocumentGetHandler returns a new OpenAPIDocumentGetHandler serving the given document
+func NewOpenAPIDocumentGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+	document *openapi.Document,
+) *OpenAPIDocumentGetHandler {
+	return &OpenAPIDocumentGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+		document:            document,
+	}
+}
+
+func (v *OpenAPIDocumentGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	v.WriteResult(w, r, v.document)
+}
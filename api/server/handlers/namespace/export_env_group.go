@@ -0,0 +1,129 @@
+package namespace
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// maskedSecretValue replaces the values of secret variables in exported env groups
+const maskedSecretValue = "********"
+
+type ExportEnvGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewExportEnvGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ExportEnvGroupHandler {
+	return &ExportEnvGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ExportEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-export-env-group")
+	defer span.End()
+
+	request := &types.ExportEnvGroupRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	namespace, _ := ctx.Value(types.NamespaceScope).(string)
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "env-group-name", Value: request.Name},
+		telemetry.AttributeKV{Key: "namespace", Value: namespace},
+		telemetry.AttributeKV{Key: "reveal-secrets", Value: request.RevealSecrets},
+	)
+
+	if request.RevealSecrets {
+		canReveal, reqErr := authz.CanRevealSecrets(c.Config(), r, project.ID)
+		if reqErr != nil {
+			err := telemetry.Error(ctx, span, reqErr, "unable to check permission to reveal secrets")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+		if !canReveal {
+			err := telemetry.Error(ctx, span, nil, "only project admins can reveal secret values")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden))
+			return
+		}
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envGroup, err := envgroup.GetEnvGroup(agent, request.Name, namespace, request.Version)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("env group not found"),
+				http.StatusNotFound),
+			)
+			return
+		}
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ExportEnvGroupResponse{
+		Name:            envGroup.Name,
+		Version:         envGroup.Version,
+		Variables:       make(map[string]string),
+		SecretVariables: make(map[string]string),
+		SecretsRevealed: request.RevealSecrets,
+	}
+
+	// all secret variables of a version are normally stored in the same secret, so each secret is only read once
+	secretData := make(map[string]map[string][]byte)
+
+	for key, val := range envGroup.Variables {
+		// secret variables are stored in the configmap as PORTERSECRET_<secret name>, with the value stored in the linked secret
+		if !strings.HasPrefix(val, "PORTERSECRET_") {
+			res.Variables[key] = val
+			continue
+		}
+
+		if !request.RevealSecrets {
+			res.SecretVariables[key] = maskedSecretValue
+			continue
+		}
+
+		secretName := strings.TrimPrefix(val, "PORTERSECRET_")
+		if _, ok := secretData[secretName]; !ok {
+			secret, err := agent.GetSecret(secretName, namespace)
+			if err != nil {
+				err = telemetry.Error(ctx, span, err, "unable to get secret for env group")
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			secretData[secretName] = secret.Data
+		}
+
+		res.SecretVariables[key] = string(secretData[secretName][key])
+	}
+
+	c.WriteResult(w, r, res)
+}
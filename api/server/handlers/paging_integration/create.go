@@ -0,0 +1,71 @@
+package paging_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type PagingIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewPagingIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PagingIntegrationCreateHandler {
+	return &PagingIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *PagingIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreatePagingIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if reqErr := validateRoutingRules(request.Rules); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	pagingInt := &integrations.PagingIntegration{
+		ProjectID: project.ID,
+		UserID:    user.ID,
+		Name:      request.Name,
+		Provider:  request.Provider,
+		Region:    request.Region,
+		Key:       []byte(request.Key),
+	}
+
+	if err := pagingInt.SetRules(request.Rules); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	pagingInt, err := p.Repo().PagingIntegration().CreatePagingIntegration(pagingInt)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := pagingInt.ToPagingIntegrationType()
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,49 @@
+package paging_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type PagingIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewPagingIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *PagingIntegrationListHandler {
+	return &PagingIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *PagingIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	pagingInts, err := p.Repo().PagingIntegration().ListPagingIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListPagingIntegrationsResponse, 0)
+
+	for _, pagingInt := range pagingInts {
+		pagingIntType, err := pagingInt.ToPagingIntegrationType()
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res = append(res, pagingIntType)
+	}
+
+	p.WriteResult(w, r, res)
+}
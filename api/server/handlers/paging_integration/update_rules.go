@@ -0,0 +1,102 @@
+package paging_integration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type PagingIntegrationUpdateRulesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewPagingIntegrationUpdateRulesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *PagingIntegrationUpdateRulesHandler {
+	return &PagingIntegrationUpdateRulesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *PagingIntegrationUpdateRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	integrationID, reqErr := requestutils.GetURLParamUint(r, types.URLParamPagingIntegrationID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdatePagingIntegrationRulesRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if reqErr := validateRoutingRules(request.Rules); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	pagingInt, err := p.Repo().PagingIntegration().ReadPagingIntegration(project.ID, integrationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("paging integration with id %d not found in project", integrationID),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := pagingInt.SetRules(request.Rules); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	pagingInt, err = p.Repo().PagingIntegration().UpdatePagingIntegration(pagingInt)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := pagingInt.ToPagingIntegrationType()
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
+
+// validateRoutingRules checks that the glob patterns in each rule are well-formed
+func validateRoutingRules(rules []types.IncidentRoutingRule) apierrors.RequestError {
+	for _, rule := range rules {
+		for _, pattern := range []string{rule.ReleaseName, rule.ReleaseNamespace} {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("invalid pattern %s in routing rule: %w", pattern, err),
+					http.StatusBadRequest,
+				)
+			}
+		}
+	}
+
+	return nil
+}
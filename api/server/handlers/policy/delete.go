@@ -0,0 +1,88 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// errPolicyInUse is returned when a policy is deleted while custom roles still reference it
+var errPolicyInUse = errors.New("policy is used by custom roles")
+
+// PolicyDeleteHandler deletes a project policy. Policies which are referenced by custom roles cannot be deleted,
+// since those roles would no longer grant any access.
+type PolicyDeleteHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewPolicyDeleteHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *PolicyDeleteHandler {
+	return &PolicyDeleteHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *PolicyDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	policyID, reqErr := requestutils.GetURLParamString(r, types.URLParamPolicyID)
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// the roles are checked in the same transaction as the delete, so that a role cannot be assigned the policy
+	// in between
+	err := p.Repo().Transaction(func(tx repository.Repository) error {
+		policy, err := tx.Policy().ReadPolicy(proj.ID, policyID)
+		if err != nil {
+			return err
+		}
+
+		roles, err := tx.Project().ListProjectRoles(proj.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			if role.Kind == types.RoleCustom && role.PolicyUID == policy.UniqueID {
+				return errPolicyInUse
+			}
+		}
+
+		_, err = tx.Policy().DeletePolicy(policy)
+		return err
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy with id %s not found in project", policyID),
+				http.StatusNotFound,
+			))
+		case errors.Is(err, errPolicyInUse):
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy with id %s is used by custom roles, which must be changed before it can be deleted", policyID),
+				http.StatusConflict,
+			))
+		default:
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		}
+
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
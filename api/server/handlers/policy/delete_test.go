@@ -0,0 +1,106 @@
+package policy_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/policy"
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestPolicyDelete(t *testing.T) {
+	tests := []struct {
+		name       string
+		policyID   string
+		customRole bool
+		wantStatus int
+		wantError  string
+	}{
+		{
+			name:       "deletes unused policy",
+			policyID:   "test-policy",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "rejects policy used by custom role",
+			policyID:   "test-policy",
+			customRole: true,
+			wantStatus: http.StatusConflict,
+			wantError:  "policy with id test-policy is used by custom roles, which must be changed before it can be deleted",
+		},
+		{
+			name:       "missing policy",
+			policyID:   "missing",
+			wantStatus: http.StatusNotFound,
+			wantError:  "policy with id missing not found in project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := apitest.LoadConfig(t)
+			user := apitest.CreateTestUser(t, config, true)
+			proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+				Name: "test-project",
+			}, user)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = config.Repo.Policy().CreatePolicy(&models.Policy{
+				UniqueID:  "test-policy",
+				ProjectID: proj.ID,
+				Name:      "test-policy",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.customRole {
+				_, err = config.Repo.Project().CreateProjectRole(proj, &models.Role{
+					Role: types.Role{
+						UserID:    user.ID + 1,
+						ProjectID: proj.ID,
+						Kind:      types.RoleCustom,
+						PolicyUID: "test-policy",
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/policy/"+tt.policyID, nil)
+
+			req = apitest.WithAuthenticatedUser(t, req, user)
+			req = apitest.WithProject(t, req, proj)
+			req = apitest.WithURLParams(t, req, map[string]string{
+				string(types.URLParamPolicyID): tt.policyID,
+			})
+
+			handler := policy.NewPolicyDeleteHandler(
+				config,
+				shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+			)
+
+			handler.ServeHTTP(rr, req)
+
+			if tt.wantError != "" {
+				apitest.AssertResponseError(t, rr, tt.wantStatus, &types.ExternalError{Error: tt.wantError})
+				return
+			}
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rr.Code)
+			}
+
+			if _, err := config.Repo.Policy().ReadPolicy(proj.ID, "test-policy"); err == nil {
+				t.Errorf("expected policy to be deleted")
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"connectrpc.com/connect"
+	"github.com/google/uuid"
 
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 
@@ -18,6 +19,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -49,12 +51,17 @@ type ApplyPorterAppRequest struct {
 	DeploymentTargetId string `json:"deployment_target_id"`
 	AppRevisionID      string `json:"app_revision_id"`
 	ForceBuild         bool   `json:"force_build"`
+
+	types.PolicyOverrideRequest
 }
 
 // ApplyPorterAppResponse is the response object for the /apps/apply endpoint
 type ApplyPorterAppResponse struct {
 	AppRevisionId string                 `json:"app_revision_id"`
 	CLIAction     porterv1.EnumCLIAction `json:"cli_action"`
+
+	// PolicyWarnings are the violations of warn-only policies, and of blocking policies which were overridden
+	PolicyWarnings []*types.PolicyViolation `json:"policy_warnings,omitempty"`
 }
 
 // ServeHTTP translates the request into a ApplyPorterApp request, forwards to the cluster control plane, and returns the response
@@ -64,6 +71,7 @@ func (c *ApplyPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
 	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+	user, _ := ctx.Value(types.UserScope).(*models.User)
 
 	telemetry.WithAttributes(span,
 		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
@@ -87,9 +95,46 @@ func (c *ApplyPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	var appProto *porterv1.PorterApp
 	var deploymentTargetID string
 
+	// the app which is evaluated against the enforced policies of the project. When an existing revision is
+	// applied, this is the app of the revision, since it may have been changed since it was created.
+	var admittedApp *porterv1.PorterApp
+
 	if request.AppRevisionID != "" {
 		appRevisionID = request.AppRevisionID
 		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-revision-id", Value: request.AppRevisionID})
+
+		revisionID, err := uuid.Parse(request.AppRevisionID)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error parsing app revision id")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		revision, err := porter_app.GetAppRevision(ctx, porter_app.GetAppRevisionInput{
+			ProjectID:     project.ID,
+			AppRevisionID: revisionID,
+			CCPClient:     c.Config().ClusterControlPlaneClient,
+		})
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting app revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		decoded, err := base64.StdEncoding.DecodeString(revision.B64AppProto)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error decoding app proto of revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		admittedApp = &porterv1.PorterApp{}
+		err = helpers.UnmarshalContractObject(decoded, admittedApp)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error unmarshalling app proto of revision")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
 	} else {
 		if request.Base64AppProto == "" {
 			err := telemetry.Error(ctx, span, nil, "b64 yaml is empty")
@@ -145,6 +190,58 @@ func (c *ApplyPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 			return
 		}
+
+		admittedApp = appProto
+	}
+
+	// the namespace of the deployment target is optional when an existing revision is applied, in which case
+	// policies which match a namespace are evaluated as if the app were deployed to it
+	var namespace string
+
+	if request.DeploymentTargetId != "" {
+		deploymentTargetDetailsReq := connect.NewRequest(&porterv1.DeploymentTargetDetailsRequest{
+			ProjectId:          int64(project.ID),
+			DeploymentTargetId: request.DeploymentTargetId,
+		})
+
+		deploymentTargetDetailsResp, err := c.Config().ClusterControlPlaneClient.DeploymentTargetDetails(ctx, deploymentTargetDetailsReq)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error getting deployment target details from cluster control plane client")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		if deploymentTargetDetailsResp == nil || deploymentTargetDetailsResp.Msg == nil {
+			err := telemetry.Error(ctx, span, err, "deployment target details resp is nil")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		if deploymentTargetDetailsResp.Msg.ClusterId != int64(cluster.ID) {
+			err := telemetry.Error(ctx, span, err, "deployment target details resp cluster id does not match cluster id")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		namespace = deploymentTargetDetailsResp.Msg.Namespace
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
+	}
+
+	admissionController, reqErr := release.NewAdmissionController(c.Config(), cluster, user, request.PolicyOverrideRequest)
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if err := admissionController.AdmitApp(ctx, admittedApp, namespace); err != nil {
+		if reqErr := release.AdmissionRequestError(err); reqErr != nil {
+			c.HandleAPIError(w, r, reqErr)
+			return
+		}
+
+		err := telemetry.Error(ctx, span, err, "error evaluating policies")
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
 	applyReq := connect.NewRequest(&porterv1.ApplyPorterAppRequest{
@@ -189,8 +286,9 @@ func (c *ApplyPorterAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "cli-action", Value: ccpResp.Msg.CliAction.String()})
 
 	response := &ApplyPorterAppResponse{
-		AppRevisionId: ccpResp.Msg.PorterAppRevisionId,
-		CLIAction:     ccpResp.Msg.CliAction,
+		AppRevisionId:  ccpResp.Msg.PorterAppRevisionId,
+		CLIAction:      ccpResp.Msg.CliAction,
+		PolicyWarnings: admissionController.Warnings,
 	}
 
 	c.WriteResult(w, r, response)
@@ -124,21 +124,17 @@ func (c *CreateAppHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "source-type", Value: request.SourceType})
 
-	porterAppDBEntries, err := c.Repo().PorterApp().ReadPorterAppsByProjectIDAndName(project.ID, request.Name)
+	// apps are looked up by cluster, since an app can be deployed to targets on multiple clusters in a project
+	existingApp, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, request.Name)
 	if err != nil {
-		err := telemetry.Error(ctx, span, nil, "error reading porter apps by project id and name")
+		err := telemetry.Error(ctx, span, nil, "error reading porter app by name")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
 		return
 	}
 
-	if len(porterAppDBEntries) > 1 {
-		err := telemetry.Error(ctx, span, nil, "multiple apps with same name")
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
-		return
-	}
-	if len(porterAppDBEntries) == 1 {
-		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "existing-app-id", Value: porterAppDBEntries[0].ID})
-		c.WriteResult(w, r, porterAppDBEntries[0].ToPorterAppType())
+	if existingApp.ID != 0 {
+		telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "existing-app-id", Value: existingApp.ID})
+		c.WriteResult(w, r, existingApp.ToPorterAppType())
 		return
 	}
 
@@ -0,0 +1,266 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// DiffAppRevisionsHandler handles requests to the /apps/{porter_app_name}/diff endpoint
+type DiffAppRevisionsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewDiffAppRevisionsHandler returns a new DiffAppRevisionsHandler
+func NewDiffAppRevisionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiffAppRevisionsHandler {
+	return &DiffAppRevisionsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// DiffAppRevisionsRequest is the request object for the /apps/{porter_app_name}/diff endpoint
+type DiffAppRevisionsRequest struct {
+	// DeploymentTargetID is the deployment target of the revisions
+	DeploymentTargetID string `json:"deployment_target_id"`
+	// FromRevisionNumber is the revision number to diff from. If unset, the current revision is used.
+	FromRevisionNumber uint64 `json:"from_revision_number"`
+	// ToRevisionNumber is the revision number to diff to. If unset, the current revision is used.
+	ToRevisionNumber uint64 `json:"to_revision_number"`
+	// ToBase64AppProto is an app which has not been applied yet, such as a parsed porter.yaml, to diff to.
+	// If set, ToRevisionNumber is ignored.
+	ToBase64AppProto string `json:"to_b64_app_proto"`
+}
+
+// DiffAppRevisionsResponse is the response object for the /apps/{porter_app_name}/diff endpoint
+type DiffAppRevisionsResponse struct {
+	FromRevisionNumber uint64             `json:"from_revision_number"`
+	ToRevisionNumber   uint64             `json:"to_revision_number"`
+	Diff               porter_app.AppDiff `json:"diff"`
+}
+
+// ServeHTTP returns a semantic, per-service diff between two revisions of an app, or between a revision and an app which has not been applied
+func (c *DiffAppRevisionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-diff-app-revisions")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	appName, reqErr := requestutils.GetURLParamString(r, types.URLParamPorterAppName)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, nil, "error parsing porter app name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "app-name", Value: appName})
+
+	request := &DiffAppRevisionsRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	if _, err := uuid.Parse(request.DeploymentTargetID); err != nil {
+		err := telemetry.Error(ctx, span, err, "error parsing deployment target id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: request.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "from-revision-number", Value: request.FromRevisionNumber},
+		telemetry.AttributeKV{Key: "to-revision-number", Value: request.ToRevisionNumber},
+		telemetry.AttributeKV{Key: "to-local-app", Value: request.ToBase64AppProto != ""},
+	)
+
+	app, err := c.Repo().PorterApp().ReadPorterAppByName(cluster.ID, appName)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading porter app by name")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	var toApp *porterv1.PorterApp
+	if request.ToBase64AppProto != "" {
+		toApp, err = decodeAppProto(request.ToBase64AppProto)
+		if err != nil {
+			err := telemetry.Error(ctx, span, err, "error decoding app proto")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		// an app which has not been created yet is diffed against an empty app
+		if app.ID == 0 {
+			c.WriteResult(w, r, &DiffAppRevisionsResponse{
+				Diff: porter_app.DiffApps(nil, toApp),
+			})
+			return
+		}
+	}
+
+	if app.ID == 0 {
+		err := telemetry.Error(ctx, span, nil, "app with name does not exist in cluster")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	revisions := appRevisionGetter{
+		ctx:                ctx,
+		ccpClient:          c.Config().ClusterControlPlaneClient,
+		projectID:          project.ID,
+		appID:              app.ID,
+		deploymentTargetID: request.DeploymentTargetID,
+	}
+
+	fromRevision, err := revisions.get(request.FromRevisionNumber)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting revision to diff from")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	res := &DiffAppRevisionsResponse{
+		FromRevisionNumber: fromRevision.RevisionNumber,
+	}
+
+	if toApp != nil {
+		res.Diff = porter_app.DiffApps(fromRevision.App, withDeployedFields(toApp, fromRevision.App))
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	toRevision, err := revisions.get(request.ToRevisionNumber)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting revision to diff to")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	res.ToRevisionNumber = toRevision.RevisionNumber
+	res.Diff = porter_app.DiffApps(fromRevision.App, toRevision.App)
+
+	c.WriteResult(w, r, res)
+}
+
+// appRevisionGetter fetches revisions of an app on a deployment target from the cluster control plane
+type appRevisionGetter struct {
+	ctx                context.Context
+	ccpClient          porterv1connect.ClusterControlPlaneServiceClient
+	projectID          uint
+	appID              uint
+	deploymentTargetID string
+
+	// listed caches the revisions listed from the cluster control plane
+	listed []*porterv1.AppRevision
+}
+
+// get returns the revision with the given number, or the current revision if the number is 0. If the app has not
+// been deployed to the target yet, an empty revision is returned as its current revision, so that the app is diffed
+// against an empty app.
+func (g *appRevisionGetter) get(revisionNumber uint64) (*porterv1.AppRevision, error) {
+	if revisionNumber == 0 {
+		resp, err := g.ccpClient.CurrentAppRevision(g.ctx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+			ProjectId:          int64(g.projectID),
+			AppId:              int64(g.appID),
+			DeploymentTargetId: g.deploymentTargetID,
+		}))
+		if err != nil {
+			if connect.CodeOf(err) == connect.CodeNotFound {
+				return &porterv1.AppRevision{}, nil
+			}
+
+			return nil, err
+		}
+
+		if resp == nil || resp.Msg == nil {
+			return nil, errors.New("current app revision response is nil")
+		}
+
+		if resp.Msg.AppRevision == nil {
+			return &porterv1.AppRevision{}, nil
+		}
+
+		return resp.Msg.AppRevision, nil
+	}
+
+	if g.listed == nil {
+		resp, err := g.ccpClient.ListAppRevisions(g.ctx, connect.NewRequest(&porterv1.ListAppRevisionsRequest{
+			ProjectId:          int64(g.projectID),
+			AppId:              int64(g.appID),
+			DeploymentTargetId: g.deploymentTargetID,
+		}))
+		if err != nil {
+			return nil, err
+		}
+
+		if resp == nil || resp.Msg == nil {
+			return nil, errors.New("list app revisions response is nil")
+		}
+
+		g.listed = resp.Msg.AppRevisions
+	}
+
+	for _, revision := range g.listed {
+		if revision.RevisionNumber == revisionNumber {
+			return revision, nil
+		}
+	}
+
+	return nil, fmt.Errorf("revision %d not found", revisionNumber)
+}
+
+// withDeployedFields fills in the fields of an app which has not been applied yet that are only set during apply,
+// so that they do not show up as changes. Env groups are attached and images are built during apply.
+func withDeployedFields(app *porterv1.PorterApp, deployed *porterv1.PorterApp) *porterv1.PorterApp {
+	if deployed == nil {
+		return app
+	}
+
+	if len(app.EnvGroups) == 0 {
+		app.EnvGroups = deployed.EnvGroups
+	}
+
+	if app.Build != nil && deployed.Image != nil && (app.Image == nil || app.Image.Tag == "") {
+		app.Image = deployed.Image
+	}
+
+	return app
+}
+
+func decodeAppProto(b64AppProto string) (*porterv1.PorterApp, error) {
+	decoded, err := base64.StdEncoding.DecodeString(b64AppProto)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode base64 app proto: %w", err)
+	}
+
+	app := &porterv1.PorterApp{}
+	if err := helpers.UnmarshalContractObject(decoded, app); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal app proto: %w", err)
+	}
+
+	return app, nil
+}
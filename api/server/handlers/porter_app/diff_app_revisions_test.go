@@ -0,0 +1,106 @@
+package porter_app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCurrentRevisionClient serves a fixed response to CurrentAppRevision
+type fakeCurrentRevisionClient struct {
+	porterv1connect.ClusterControlPlaneServiceClient
+
+	revision *porterv1.AppRevision
+	err      error
+}
+
+func (c *fakeCurrentRevisionClient) CurrentAppRevision(
+	ctx context.Context,
+	req *connect.Request[porterv1.CurrentAppRevisionRequest],
+) (*connect.Response[porterv1.CurrentAppRevisionResponse], error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return connect.NewResponse(&porterv1.CurrentAppRevisionResponse{AppRevision: c.revision}), nil
+}
+
+func TestAppRevisionGetterCurrentRevision(t *testing.T) {
+	toApp := &porterv1.PorterApp{
+		Name: "test-app",
+		Services: map[string]*porterv1.Service{
+			"web": {Run: "node index.js", Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		client       *fakeCurrentRevisionClient
+		wantErr      bool
+		wantRevision uint64
+		wantStatus   porter_app.DiffStatus
+	}{
+		{
+			name:       "no revision on target",
+			client:     &fakeCurrentRevisionClient{err: connect.NewError(connect.CodeNotFound, errors.New("no revisions found"))},
+			wantStatus: porter_app.DiffStatus_Added,
+		},
+		{
+			name:       "empty current revision",
+			client:     &fakeCurrentRevisionClient{},
+			wantStatus: porter_app.DiffStatus_Added,
+		},
+		{
+			name: "current revision",
+			client: &fakeCurrentRevisionClient{revision: &porterv1.AppRevision{
+				RevisionNumber: 3,
+				App: &porterv1.PorterApp{
+					Name: "test-app",
+					Services: map[string]*porterv1.Service{
+						"web": {Run: "node server.js", Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+					},
+				},
+			}},
+			wantRevision: 3,
+			wantStatus:   porter_app.DiffStatus_Modified,
+		},
+		{
+			name:    "control plane error",
+			client:  &fakeCurrentRevisionClient{err: connect.NewError(connect.CodeUnavailable, errors.New("unavailable"))},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revisions := appRevisionGetter{
+				ctx:                context.Background(),
+				ccpClient:          tt.client,
+				projectID:          1,
+				appID:              1,
+				deploymentTargetID: "6c5f1a8e-5d3f-4d0a-9a57-7c0e7b9d6a11",
+			}
+
+			revision, err := revisions.get(0)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantRevision, revision.RevisionNumber)
+
+			diff := porter_app.DiffApps(revision.App, withDeployedFields(toApp, revision.App))
+			require.Len(t, diff.Services, 1)
+			assert.Equal(t, "web", diff.Services[0].Name)
+			assert.Equal(t, tt.wantStatus, diff.Services[0].Status)
+		})
+	}
+}
@@ -201,7 +201,7 @@ func parse(ctx context.Context, conf ParseConf) (*chart.Chart, map[string]interf
 		Release:  parsed.Release,
 	}
 
-	values, err := buildUmbrellaChartValues(ctx, application, synced_env, conf.ImageInfo, conf.ExistingHelmValues, conf.SubdomainCreateOpts, conf.InjectLauncherToStartCommand, conf.ShouldValidateHelmValues, conf.UserUpdate, conf.Namespace, conf.AddCustomNodeSelector, conf.RemoveDeletedServices)
+	values, err := buildUmbrellaChartValues(ctx, application, synced_env, conf.ImageInfo, conf.ExistingHelmValues, conf.SubdomainCreateOpts, conf.InjectLauncherToStartCommand, conf.ShouldValidateHelmValues, conf.UserUpdate, conf.Namespace, conf.AddCustomNodeSelector, conf.RemoveDeletedServices, conf.ProjectID)
 	if err != nil {
 		err = telemetry.Error(ctx, span, err, "error building values")
 		return nil, nil, nil, err
@@ -241,6 +241,7 @@ func buildUmbrellaChartValues(
 	namespace string,
 	addCustomNodeSelector bool,
 	removeDeletedValues bool,
+	projectID uint,
 ) (map[string]interface{}, error) {
 	values := make(map[string]interface{})
 
@@ -288,7 +289,7 @@ func buildUmbrellaChartValues(
 			return nil, fmt.Errorf("error validating service \"%s\": %s", name, validateErr)
 		}
 
-		err := syncEnvironmentGroupToNamespaceIfLabelsExist(ctx, opts.k8sAgent, service, namespace)
+		err := syncEnvironmentGroupToNamespaceIfLabelsExist(ctx, opts.k8sAgent, projectID, service, namespace)
 		if err != nil {
 			return nil, fmt.Errorf("error syncing environment group to namespace: %w", err)
 		}
@@ -345,7 +346,7 @@ func buildUmbrellaChartValues(
 }
 
 // syncEnvironmentGroupToNamespaceIfLabelsExist will sync the latest version of the environment group to the target namespace if the service has the appropriate label.
-func syncEnvironmentGroupToNamespaceIfLabelsExist(ctx context.Context, agent *kubernetes.Agent, service *Service, targetNamespace string) error {
+func syncEnvironmentGroupToNamespaceIfLabelsExist(ctx context.Context, agent *kubernetes.Agent, projectID uint, service *Service, targetNamespace string) error {
 	var linkedGroupNames string
 
 	// patchwork because we are not consistent with the type of labels
@@ -364,6 +365,7 @@ func syncEnvironmentGroupToNamespaceIfLabelsExist(ctx context.Context, agent *ku
 		inp := environment_groups.SyncLatestVersionToNamespaceInput{
 			BaseEnvironmentGroupName: linkedGroupName,
 			TargetNamespace:          targetNamespace,
+			ProjectID:                projectID,
 		}
 
 		syncedEnvironment, err := environment_groups.SyncLatestVersionToNamespace(ctx, agent, inp)
@@ -128,6 +128,24 @@ func (c *UpdateAppEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	namespace := deploymentTargetDetailsResp.Msg.Namespace
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "namespace", Value: namespace})
 
+	deploymentTarget, err := c.Repo().DeploymentTarget().DeploymentTarget(project.ID, request.DeploymentTargetID)
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error reading deployment target")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	envOverrides, err := deploymentTarget.GetEnvOverrides()
+	if err != nil {
+		err := telemetry.Error(ctx, span, err, "error getting deployment target env overrides")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// env overrides for the deployment target take precedence over the values in the request
+	request.Variables = layerEnvOverrides(request.Variables, envOverrides.Variables)
+	request.Secrets = layerEnvOverrides(request.Secrets, envOverrides.Secrets)
+
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "hard-update", Value: request.HardUpdate})
 
 	envGroupName, err := porter_app.AppEnvGroupName(ctx, appName, request.DeploymentTargetID, cluster.ID, c.Repo().PorterApp())
@@ -216,7 +234,7 @@ func (c *UpdateAppEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		CreatedAtUTC:    time.Now().UTC(),
 	}
 
-	err = environment_groups.CreateOrUpdateBaseEnvironmentGroup(ctx, agent, envGroup)
+	err = environment_groups.CreateOrUpdateBaseEnvironmentGroup(ctx, agent, project.ID, envGroup)
 	if err != nil {
 		err := telemetry.Error(ctx, span, err, "unable to create or update base environment group")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
@@ -226,6 +244,7 @@ func (c *UpdateAppEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	inp := environment_groups.SyncLatestVersionToNamespaceInput{
 		BaseEnvironmentGroupName: envGroupName,
 		TargetNamespace:          namespace,
+		ProjectID:                project.ID,
 	}
 
 	syncedEnvironment, err := environment_groups.SyncLatestVersionToNamespace(ctx, agent, inp)
@@ -257,3 +276,21 @@ func (c *UpdateAppEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 
 	c.WriteResult(w, r, res)
 }
+
+func layerEnvOverrides(base map[string]string, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+
+	layered := make(map[string]string)
+
+	for key, value := range base {
+		layered[key] = value
+	}
+
+	for key, value := range overrides {
+		layered[key] = value
+	}
+
+	return layered
+}
@@ -46,6 +46,20 @@ func (p *RoleDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// a removed collaborator should immediately lose access, so their sessions and the api
+	// tokens they created for the project are revoked. Sessions are not scoped to a project,
+	// so the collaborator must log in again to use their other projects.
+	if err := revokeCollaboratorSessions(p.Config(), request.UserID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := revokeCollaboratorAPITokens(p.Config(), proj.ID, request.UserID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
 	res := &types.DeleteRoleResponse{
@@ -0,0 +1,120 @@
+package project
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	environmentgroups "github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/projectexport"
+)
+
+// ExportProjectHandler writes every project-scoped model of a project into an archive, with the
+// credentials of the project encrypted under a passphrase
+type ExportProjectHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewExportProjectHandler returns a new ExportProjectHandler
+func NewExportProjectHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ExportProjectHandler {
+	return &ExportProjectHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ExportProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ExportProjectRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	archive, err := projectexport.Export(c.Repo(), proj, projectexport.ExportOptions{
+		Passphrase: request.Passphrase,
+		ListEnvGroups: func(cluster *models.Cluster) ([]*projectexport.EnvGroupMetadata, error) {
+			return c.listEnvGroups(r, cluster)
+		},
+	})
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	data, err := archive.Encode()
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, &types.ExportProjectResponse{
+		Archive: data,
+	})
+}
+
+// listEnvGroups returns the names of the variables in the latest version of each environment group in a cluster
+func (c *ExportProjectHandler) listEnvGroups(r *http.Request, cluster *models.Cluster) ([]*projectexport.EnvGroupMetadata, error) {
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := environmentgroups.ListEnvironmentGroups(r.Context(), agent, environmentgroups.WithNamespace(environmentgroups.Namespace_EnvironmentGroups))
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]environmentgroups.EnvironmentGroup)
+
+	for _, version := range versions {
+		if version.Name == "" {
+			continue
+		}
+
+		if current, ok := latest[version.Name]; !ok || version.Version > current.Version {
+			latest[version.Name] = version
+		}
+	}
+
+	res := make([]*projectexport.EnvGroupMetadata, 0, len(latest))
+
+	for _, envGroup := range latest {
+		metadata := &projectexport.EnvGroupMetadata{
+			Name:                envGroup.Name,
+			LatestVersion:       envGroup.Version,
+			VariableNames:       make([]string, 0, len(envGroup.Variables)),
+			SecretVariableNames: make([]string, 0, len(envGroup.SecretVariables)),
+		}
+
+		for key := range envGroup.Variables {
+			metadata.VariableNames = append(metadata.VariableNames, key)
+		}
+
+		for key := range envGroup.SecretVariables {
+			metadata.SecretVariableNames = append(metadata.SecretVariableNames, key)
+		}
+
+		sort.Strings(metadata.VariableNames)
+		sort.Strings(metadata.SecretVariableNames)
+
+		res = append(res, metadata)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/projectexport"
+)
+
+// ImportProjectHandler creates the models of a project export archive in a project, or validates
+// the archive if the request is a dry run
+type ImportProjectHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewImportProjectHandler returns a new ImportProjectHandler
+func NewImportProjectHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ImportProjectHandler {
+	return &ImportProjectHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ImportProjectHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ImportProjectRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	archive, err := projectexport.Decode(request.Archive)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	resp, err := projectexport.Import(c.Repo(), proj, archive, projectexport.ImportOptions{
+		Passphrase:         request.Passphrase,
+		DryRun:             request.DryRun,
+		UserID:             user.ID,
+		LaunchDarklyClient: c.Config().LaunchDarklyClient,
+	})
+	if err != nil {
+		if errors.Is(err, projectexport.ErrInvalidPassphrase) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !resp.DryRun && len(resp.Errors) != 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("archive cannot be imported: %s", strings.Join(resp.Errors, "; ")),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	c.WriteResult(w, r, resp)
+}
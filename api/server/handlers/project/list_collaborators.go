@@ -55,6 +55,7 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		res = append(res, &types.Collaborator{
 			ID:        roleMap[user.ID].ID,
 			Kind:      string(roleMap[user.ID].Kind),
+			PolicyUID: roleMap[user.ID].PolicyUID,
 			UserID:    roleMap[user.ID].UserID,
 			Email:     user.Email,
 			ProjectID: roleMap[user.ID].ProjectID,
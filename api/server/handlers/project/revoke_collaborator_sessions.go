@@ -0,0 +1,98 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RevokeCollaboratorSessionsHandler force-logs out a collaborator of the project. Sessions are not scoped to a
+// project, so the collaborator is logged out of every project they belong to.
+type RevokeCollaboratorSessionsHandler struct {
+	handlers.PorterHandlerReader
+}
+
+// NewRevokeCollaboratorSessionsHandler returns a new RevokeCollaboratorSessionsHandler
+func NewRevokeCollaboratorSessionsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *RevokeCollaboratorSessionsHandler {
+	return &RevokeCollaboratorSessionsHandler{
+		PorterHandlerReader: handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+	}
+}
+
+func (p *RevokeCollaboratorSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.RevokeCollaboratorSessionsRequest{}
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// admins can only log out users who are collaborators in their project
+	if _, err := p.Repo().Project().ReadProjectRole(proj.ID, request.UserID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(
+			fmt.Errorf("user %d is not a collaborator in project %d", request.UserID, proj.ID),
+		))
+		return
+	}
+
+	if err := revokeCollaboratorSessions(p.Config(), request.UserID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// revokeCollaboratorSessions deletes every session of the user, across all projects, and rejects the user
+// tokens, such as those of the CLI, which were issued to the user until now
+func revokeCollaboratorSessions(config *config.Config, userID uint) error {
+	if err := config.Repo.Session().DeleteSessionsByUserID(userID, ""); err != nil {
+		return fmt.Errorf("error revoking sessions for user %d: %w", userID, err)
+	}
+
+	user, err := config.Repo.User().ReadUser(userID)
+	if err != nil {
+		return fmt.Errorf("error reading user %d: %w", userID, err)
+	}
+
+	now := time.Now()
+	user.SessionsRevokedAt = &now
+
+	if _, err := config.Repo.User().UpdateUser(user); err != nil {
+		return fmt.Errorf("error revoking tokens for user %d: %w", userID, err)
+	}
+
+	return nil
+}
+
+// revokeCollaboratorAPITokens revokes the api tokens that the user created in the project
+func revokeCollaboratorAPITokens(config *config.Config, projectID, userID uint) error {
+	tokens, err := config.Repo.APIToken().ListAPITokensByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing api tokens for project %d: %w", projectID, err)
+	}
+
+	for _, token := range tokens {
+		if token.CreatedByUserID != userID || token.Revoked {
+			continue
+		}
+
+		token.Revoked = true
+
+		if _, err := config.Repo.APIToken().UpdateAPIToken(token); err != nil {
+			return fmt.Errorf("error revoking api token %s: %w", token.UniqueID, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+// UpdateQuietHoursHandler sets the hours during which deployment notifications of a project are held
+type UpdateQuietHoursHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateQuietHoursHandler returns a new UpdateQuietHoursHandler
+func NewUpdateQuietHoursHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateQuietHoursHandler {
+	return &UpdateQuietHoursHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateQuietHoursHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateProjectQuietHoursRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// an empty request removes the quiet hours of the project
+	if request.QuietHours == nil {
+		proj.QuietHoursStart = ""
+		proj.QuietHoursEnd = ""
+		proj.QuietHoursTimezone = ""
+	} else {
+		quietHours := request.QuietHours
+
+		if _, err := notifier.ParseQuietHours(quietHours.Start, quietHours.End, quietHours.Timezone); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("invalid quiet hours: %w", err),
+				http.StatusBadRequest,
+			))
+			return
+		}
+
+		proj.QuietHoursStart = quietHours.Start
+		proj.QuietHoursEnd = quietHours.End
+		proj.QuietHoursTimezone = quietHours.Timezone
+	}
+
+	project, err := c.Repo().Project().UpdateProject(proj)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, project.ToProjectType(c.Config().LaunchDarklyClient))
+}
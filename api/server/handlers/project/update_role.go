@@ -3,6 +3,7 @@ package project
 import (
 	"net/http"
 
+	"github.com/porter-dev/porter/api/server/authz/policy"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -42,7 +43,13 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if reqErr := policy.ValidateRoleAssignment(p.Repo().Policy(), proj.ID, types.RoleKind(request.Kind), request.PolicyUID); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	role.Kind = types.RoleKind(request.Kind)
+	role.PolicyUID = request.PolicyUID
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
 
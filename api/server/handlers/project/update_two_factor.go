@@ -0,0 +1,69 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// UpdateTwoFactorHandler sets whether a project requires two-factor authentication
+type UpdateTwoFactorHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUpdateTwoFactorHandler returns a new UpdateTwoFactorHandler
+func NewUpdateTwoFactorHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateTwoFactorHandler {
+	return &UpdateTwoFactorHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *UpdateTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateProjectTwoFactorRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// prevent admins from locking themselves out of the project
+	if request.Required {
+		verified, _ := r.Context().Value(types.TwoFactorVerifiedCtxKey).(bool)
+
+		ok, err := authz.UserSatisfiesTwoFactorRequirement(c.Repo(), user, verified)
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if !ok {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("you must enable two-factor authentication and log in with your second factor before requiring it for the project"),
+				http.StatusBadRequest,
+			))
+			return
+		}
+	}
+
+	proj.TwoFactorRequired = request.Required
+
+	project, err := c.Repo().Project().UpdateProject(proj)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, project.ToProjectType(c.Config().LaunchDarklyClient))
+}
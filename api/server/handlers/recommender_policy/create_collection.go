@@ -0,0 +1,118 @@
+package recommender_policy
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// CreateCollectionHandler uploads a project's Rego policy collection, replacing the collection with the same name
+type CreateCollectionHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewCreateCollectionHandler returns a new CreateCollectionHandler
+func NewCreateCollectionHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateCollectionHandler {
+	return &CreateCollectionHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreateCollectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-create-recommender-policy-collection")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateRecommenderPolicyCollectionRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "name", Value: request.Name},
+		telemetry.AttributeKV{Key: "kind", Value: string(request.Kind)},
+		telemetry.AttributeKV{Key: "policy-count", Value: len(request.Policies)},
+	)
+
+	// compiling the policies here means that the recommender never runs a collection which does not compile
+	if _, err := opa.NewCustomPolicyCollection(request).Compile(ctx); err != nil {
+		err = telemetry.Error(ctx, span, err, "invalid policy collection")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	match, err := json.Marshal(request.Match)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error encoding match parameters")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	policies, err := json.Marshal(request.Policies)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error encoding policies")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	collection, err := c.Repo().RecommenderPolicy().ReadCollectionByName(project.ID, request.Name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		err = telemetry.Error(ctx, span, err, "error reading policy collection")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	exists := collection != nil
+
+	if !exists {
+		collection = &models.RecommenderPolicyCollection{
+			ProjectID: project.ID,
+			Name:      request.Name,
+		}
+	}
+
+	collection.Kind = string(request.Kind)
+	collection.Match = match
+	collection.MustExist = request.MustExist
+	collection.OverrideSeverity = string(request.OverrideSeverity)
+	collection.Policies = policies
+
+	if exists {
+		collection, err = c.Repo().RecommenderPolicy().UpdateCollection(collection)
+	} else {
+		collection, err = c.Repo().RecommenderPolicy().CreateCollection(collection)
+	}
+
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error saving policy collection")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res, err := collection.ToRecommenderPolicyCollectionType()
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error decoding policy collection")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	c.WriteResult(w, r, res)
+}
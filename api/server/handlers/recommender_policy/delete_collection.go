@@ -0,0 +1,69 @@
+package recommender_policy
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+// DeleteCollectionHandler deletes a Rego policy collection, so that the recommender no longer runs it
+type DeleteCollectionHandler struct {
+	handlers.PorterHandler
+}
+
+// NewDeleteCollectionHandler returns a new DeleteCollectionHandler
+func NewDeleteCollectionHandler(
+	config *config.Config,
+) *DeleteCollectionHandler {
+	return &DeleteCollectionHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (c *DeleteCollectionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-delete-recommender-policy-collection")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	collectionID, reqErr := requestutils.GetURLParamUint(r, types.URLParamRecommenderPolicyCollectionID)
+	if reqErr != nil {
+		err := telemetry.Error(ctx, span, reqErr, "error parsing policy collection id")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: project.ID},
+		telemetry.AttributeKV{Key: "collection-id", Value: collectionID},
+	)
+
+	collection, err := c.Repo().RecommenderPolicy().ReadCollection(project.ID, collectionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			err = telemetry.Error(ctx, span, err, "policy collection not found")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+			return
+		}
+
+		err = telemetry.Error(ctx, span, err, "error reading policy collection")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	if _, err := c.Repo().RecommenderPolicy().DeleteCollection(collection); err != nil {
+		err = telemetry.Error(ctx, span, err, "error deleting policy collection")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
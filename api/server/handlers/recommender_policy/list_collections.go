@@ -0,0 +1,59 @@
+package recommender_policy
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListCollectionsHandler lists the Rego policy collections of a project
+type ListCollectionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListCollectionsHandler returns a new ListCollectionsHandler
+func NewListCollectionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListCollectionsHandler {
+	return &ListCollectionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListCollectionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-recommender-policy-collections")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	collections, err := c.Repo().RecommenderPolicy().ListCollectionsByProjectID(project.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing policy collections")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListRecommenderPolicyCollectionsResponse, 0, len(collections))
+
+	for _, collection := range collections {
+		collectionType, err := collection.ToRecommenderPolicyCollectionType()
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error decoding policy collection")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		res = append(res, collectionType)
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,59 @@
+package recommender_policy
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// ListOverrideEventsHandler lists the deploys of a project which overrode blocking policies
+type ListOverrideEventsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListOverrideEventsHandler returns a new ListOverrideEventsHandler
+func NewListOverrideEventsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListOverrideEventsHandler {
+	return &ListOverrideEventsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListOverrideEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-list-policy-override-events")
+	defer span.End()
+
+	project, _ := ctx.Value(types.ProjectScope).(*models.Project)
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "project-id", Value: project.ID})
+
+	events, err := c.Repo().RecommenderPolicy().ListOverrideEventsByProjectID(project.ID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing policy override events")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	res := make(types.ListPolicyOverrideEventsResponse, 0, len(events))
+
+	for _, event := range events {
+		eventType, err := event.ToPolicyOverrideEventType()
+		if err != nil {
+			err = telemetry.Error(ctx, span, err, "error decoding policy override event")
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+			return
+		}
+
+		res = append(res, eventType)
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,51 @@
+package release
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/admission"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// NewAdmissionController returns the controller which evaluates the enforced policies of the project against
+// a release before it is deployed. The user may be nil for deploys which are not made by a user.
+func NewAdmissionController(
+	config *config.Config,
+	cluster *models.Cluster,
+	user *models.User,
+	override types.PolicyOverrideRequest,
+) (*admission.Controller, apierrors.RequestError) {
+	controller, err := admission.NewController(admission.ControllerInput{
+		Repo:     config.Repo,
+		Cluster:  cluster,
+		User:     user,
+		Override: override,
+	})
+
+	switch {
+	case err == nil:
+		return controller, nil
+	case errors.Is(err, admission.ErrOverrideReasonRequired):
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	case errors.Is(err, admission.ErrOverrideForbidden):
+		return nil, apierrors.NewErrPassThroughToClient(err, http.StatusForbidden)
+	default:
+		return nil, apierrors.NewErrInternal(err)
+	}
+}
+
+// AdmissionRequestError returns the request error for a release which was blocked by policies, or nil if
+// the error is not an admission error
+func AdmissionRequestError(err error) apierrors.RequestError {
+	var admissionErr *admission.Error
+
+	if errors.As(err, &admissionErr) {
+		return apierrors.NewErrPolicyViolation(admissionErr, admissionErr.Violations)
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package release
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/admission"
+	"github.com/porter-dev/porter/internal/bulkrelease"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"github.com/stefanmcshane/helm/pkg/storage/driver"
+)
+
+// BulkOperationHandler applies an operation to every release in a cluster with a tag, such as restarting
+// or scaling all releases tagged "backend"
+type BulkOperationHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+// NewBulkOperationHandler returns a new BulkOperationHandler
+func NewBulkOperationHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *BulkOperationHandler {
+	return &BulkOperationHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *BulkOperationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, span := telemetry.NewSpan(r.Context(), "serve-bulk-release-operation")
+	defer span.End()
+
+	cluster, _ := ctx.Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.BulkReleaseOperationRequest{}
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		err := telemetry.Error(ctx, span, nil, "error decoding request")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: cluster.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: cluster.ID},
+		telemetry.AttributeKV{Key: "tag", Value: request.Tag},
+		telemetry.AttributeKV{Key: "action", Value: string(request.Action)},
+		telemetry.AttributeKV{Key: "dry-run", Value: request.DryRun},
+	)
+
+	if _, err := c.Repo().Tag().ReadTagByNameAndProjectId(request.Tag, cluster.ProjectID); err != nil {
+		err = telemetry.Error(ctx, span, err, "tag not found in project")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusNotFound))
+		return
+	}
+
+	releases, err := c.Repo().Release().ListReleasesByTag(cluster.ID, request.Tag)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing releases with tag")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "release-count", Value: len(releases)})
+
+	registries, err := c.Repo().Registry().ListRegistriesByProjectID(cluster.ProjectID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error listing registries")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "error getting kubernetes agent")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
+
+	// the endpoint is only scoped to the cluster, so access to the namespace and name of each release is
+	// checked against the policy of the requester before it is changed
+	access, reqErr := authz.NewReleaseAccessChecker(c.Config(), r, cluster.ProjectID, cluster.ID)
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// bulk operations cannot override blocking policies, so releases which violate them fail
+	admissionController, reqErr := NewAdmissionController(c.Config(), cluster, nil, types.PolicyOverrideRequest{})
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	op := &bulkOperation{
+		handler:      c,
+		r:            r,
+		cluster:      cluster,
+		registries:   registries,
+		agent:        agent,
+		request:      request,
+		access:       access,
+		admission:    admissionController,
+		helmAgents:   make(map[string]*helm.Agent),
+		helmAgentsMu: &sync.Mutex{},
+	}
+
+	res := &types.BulkReleaseOperationResponse{
+		Tag:     request.Tag,
+		Action:  request.Action,
+		DryRun:  request.DryRun,
+		Results: bulkrelease.Run(ctx, releases, request.Concurrency, op.apply),
+	}
+
+	c.WriteResult(w, r, res)
+}
+
+// bulkOperation holds the clients that are shared by the releases of a bulk operation
+type bulkOperation struct {
+	handler    *BulkOperationHandler
+	r          *http.Request
+	cluster    *models.Cluster
+	registries []*models.Registry
+	agent      *kubernetes.Agent
+	request    *types.BulkReleaseOperationRequest
+	access     *authz.ReleaseAccessChecker
+	admission  *admission.Controller
+
+	// helmAgents caches a helm agent for each namespace
+	helmAgents   map[string]*helm.Agent
+	helmAgentsMu *sync.Mutex
+}
+
+func (o *bulkOperation) helmAgent(ctx context.Context, namespace string) (*helm.Agent, error) {
+	o.helmAgentsMu.Lock()
+	defer o.helmAgentsMu.Unlock()
+
+	if helmAgent, ok := o.helmAgents[namespace]; ok {
+		return helmAgent, nil
+	}
+
+	helmAgent, err := o.handler.GetHelmAgent(ctx, o.r, o.cluster, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	o.helmAgents[namespace] = helmAgent
+
+	return helmAgent, nil
+}
+
+func (o *bulkOperation) apply(ctx context.Context, release *models.Release) (types.BulkReleaseResultStatus, string, error) {
+	if !o.access.HasAccess(types.APIVerbUpdate, release.Namespace, release.Name) {
+		return types.BulkReleaseResultForbidden, "insufficient permissions to update release", nil
+	}
+
+	helmAgent, err := o.helmAgent(ctx, release.Namespace)
+	if err != nil {
+		return "", "", fmt.Errorf("error getting helm agent: %w", err)
+	}
+
+	rel, err := helmAgent.GetRelease(ctx, release.Name, 0, false)
+	if err != nil {
+		// the release has likely been deleted from the cluster but not yet from the Porter database
+		if errors.Is(err, driver.ErrReleaseNotFound) {
+			return types.BulkReleaseResultSkipped, "release not found in cluster", nil
+		}
+
+		return "", "", fmt.Errorf("error reading release: %w", err)
+	}
+
+	if o.request.Action == types.BulkReleaseActionRestart {
+		controllers := bulkrelease.RestartableControllers(rel.Manifest, rel.Namespace)
+		if len(controllers) == 0 {
+			return types.BulkReleaseResultSkipped, "release has no deployments, stateful sets or daemon sets", nil
+		}
+
+		if o.request.DryRun {
+			return types.BulkReleaseResultPlanned, fmt.Sprintf("would restart %d controllers", len(controllers)), nil
+		}
+
+		if err := bulkrelease.RestartControllers(ctx, o.agent, controllers); err != nil {
+			return "", "", err
+		}
+
+		return types.BulkReleaseResultSucceeded, fmt.Sprintf("restarted %d controllers", len(controllers)), nil
+	}
+
+	values := rel.Config
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	var (
+		changed bool
+		message string
+		onApply func() error
+	)
+
+	switch o.request.Action {
+	case types.BulkReleaseActionUpdateImage:
+		changed, err = bulkrelease.SetImageTag(values, o.request.ImageTag)
+		message = fmt.Sprintf("image tag %s", o.request.ImageTag)
+
+		// jobs are not run when their image is updated, in the same way as batch image updates
+		if changed && rel.Chart != nil && rel.Chart.Name() == "job" {
+			values["paused"] = true
+		}
+	case types.BulkReleaseActionScale:
+		changed, err = bulkrelease.SetReplicas(values, *o.request.Replicas)
+		message = fmt.Sprintf("%d replicas", *o.request.Replicas)
+	case types.BulkReleaseActionAttachEnvGroup:
+		cm, _, cmErr := o.agent.GetLatestVersionedConfigMap(o.request.EnvGroupName, release.Namespace)
+		if cmErr != nil {
+			if errors.Is(cmErr, kubernetes.IsNotFoundError) {
+				return "", "", fmt.Errorf("env group %s not found in namespace %s", o.request.EnvGroupName, release.Namespace)
+			}
+
+			return "", "", fmt.Errorf("error reading env group: %w", cmErr)
+		}
+
+		envGroup, egErr := envgroup.ToEnvGroup(cm)
+		if egErr != nil {
+			return "", "", fmt.Errorf("error reading env group: %w", egErr)
+		}
+
+		changed, err = bulkrelease.AttachEnvGroup(values, bulkrelease.SyncedEnvGroup{
+			Name:      envGroup.Name,
+			Version:   envGroup.Version,
+			Variables: envGroup.Variables,
+		})
+		message = fmt.Sprintf("env group %s version %d attached", envGroup.Name, envGroup.Version)
+
+		onApply = func() error {
+			_, err := o.agent.AddApplicationToVersionedConfigMap(cm, release.Name)
+			return err
+		}
+	case types.BulkReleaseActionDetachEnvGroup:
+		changed, err = bulkrelease.DetachEnvGroup(values, o.request.EnvGroupName)
+		message = fmt.Sprintf("env group %s detached", o.request.EnvGroupName)
+
+		onApply = func() error {
+			cm, _, err := o.agent.GetLatestVersionedConfigMap(o.request.EnvGroupName, release.Namespace)
+			if err != nil {
+				// the env group may have been deleted, in which case it no longer lists the release
+				if errors.Is(err, kubernetes.IsNotFoundError) {
+					return nil
+				}
+
+				return err
+			}
+
+			_, err = o.agent.RemoveApplicationFromVersionedConfigMap(cm, release.Name)
+			return err
+		}
+	default:
+		return "", "", fmt.Errorf("unsupported action %s", o.request.Action)
+	}
+
+	if err != nil {
+		return types.BulkReleaseResultSkipped, err.Error(), nil
+	}
+
+	if !changed {
+		return types.BulkReleaseResultSkipped, fmt.Sprintf("release already has %s", message), nil
+	}
+
+	if o.request.DryRun {
+		return types.BulkReleaseResultPlanned, fmt.Sprintf("would set %s", message), nil
+	}
+
+	// each release is admitted by its own controller, since releases are upgraded concurrently
+	admissionController := o.admission.Clone()
+
+	conf := &helm.UpgradeReleaseConfig{
+		Name:       release.Name,
+		Cluster:    o.cluster,
+		Repo:       o.handler.Repo(),
+		Registries: o.registries,
+		Values:     values,
+		Admit:      admissionController.AdmissionFunc(),
+	}
+
+	_, err = helmAgent.UpgradeReleaseByValues(ctx, conf, o.handler.Config().DOConf, o.handler.Config().ServerConf.DisablePullSecretsInjection, false)
+	if err != nil {
+		return "", "", fmt.Errorf("error upgrading release: %w", err)
+	}
+
+	if onApply != nil {
+		if err := onApply(); err != nil {
+			return "", "", fmt.Errorf("release was upgraded, but the env group could not be updated: %w", err)
+		}
+	}
+
+	if len(admissionController.Warnings) > 0 {
+		message = fmt.Sprintf("%s (%d policy warnings)", message, len(admissionController.Warnings))
+	}
+
+	return types.BulkReleaseResultSucceeded, message, nil
+}
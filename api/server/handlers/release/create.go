@@ -151,7 +151,20 @@ func (c *CreateReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		Registries: registries,
 	}
 
+	admissionController, reqErr := NewAdmissionController(c.Config(), cluster, user, request.PolicyOverrideRequest)
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	conf.Admit = admissionController.AdmissionFunc()
+
 	helmRelease, err := helmAgent.InstallChart(ctx, conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection)
+	if reqErr := AdmissionRequestError(err); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
 			telemetry.Error(ctx, span, err, "error installing a new chart"),
@@ -255,6 +268,10 @@ func (c *CreateReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	))
 
 	w.WriteHeader(http.StatusCreated)
+
+	c.WriteResult(w, r, &types.DeployReleaseResponse{
+		PolicyWarnings: admissionController.Warnings,
+	})
 }
 
 func CreateAppReleaseFromHelmRelease(
@@ -92,7 +92,20 @@ func (c *CreateAddonHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Registries: registries,
 	}
 
+	admissionController, reqErr := NewAdmissionController(c.Config(), cluster, user, request.PolicyOverrideRequest)
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	conf.Admit = admissionController.AdmissionFunc()
+
 	helmRelease, err := helmAgent.InstallChart(context.Background(), conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection)
+	if reqErr := AdmissionRequestError(err); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
 			fmt.Errorf("error installing a new chart: %s", err.Error()),
@@ -115,6 +128,10 @@ func (c *CreateAddonHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			FlowID: operationID,
 		},
 	))
+
+	c.WriteResult(w, r, &types.DeployReleaseResponse{
+		PolicyWarnings: admissionController.Warnings,
+	})
 }
 
 type LoadAddonChartOpts struct {
@@ -34,7 +34,8 @@ func (c *GetComponentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	objects := grapher.ParseObjs(yamlArr, helmRelease.Namespace)
 
 	parsed := grapher.ParsedObjs{
-		Objects: objects,
+		Objects:   objects,
+		SpecRules: c.Config().GrapherSpecRules,
 	}
 
 	parsed.GetControlRel()
@@ -2,7 +2,9 @@ package release
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"sort"
 
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -12,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/stefanmcshane/helm/pkg/release"
 )
 
 type GetReleaseHistoryHandler struct {
@@ -47,5 +50,38 @@ func (c *GetReleaseHistoryHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// revisions which have been pruned from the cluster are listed from the revision archive, but the
+	// history of the cluster is still returned if the archive cannot be read
+	if c.Config().RevisionArchive != nil {
+		archived, err := c.Config().RevisionArchive.ListRevisions(r.Context(), cluster.ProjectID, cluster.ID, helmAgent.Namespace(), name)
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(fmt.Errorf("error listing archived revisions: %w", err)))
+		} else {
+			history = mergeArchivedRevisions(history, archived)
+		}
+	}
+
 	c.WriteResult(w, r, history)
 }
+
+// mergeArchivedRevisions adds the archived revisions which are no longer in the cluster to a release history,
+// sorted by revision
+func mergeArchivedRevisions(history []*release.Release, archived []*release.Release) []*release.Release {
+	inCluster := make(map[int]bool, len(history))
+
+	for _, rel := range history {
+		inCluster[rel.Version] = true
+	}
+
+	for _, rel := range archived {
+		if !inCluster[rel.Version] {
+			history = append(history, rel)
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Version < history[j].Version
+	})
+
+	return history
+}
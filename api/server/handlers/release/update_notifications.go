@@ -1,6 +1,7 @@
 package release
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -46,6 +47,15 @@ func (c *UpdateNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		}
 
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, err := models.ParseNotifLimit(request.Payload.NotifLimit); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid notif_limit: %w", err),
+			http.StatusBadRequest,
+		))
+		return
 	}
 
 	// either create a new notification config or update the current one
@@ -53,6 +63,9 @@ func (c *UpdateNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		Enabled: request.Payload.Enabled,
 		Success: request.Payload.Success,
 		Failure: request.Payload.Failure,
+
+		NotifLimit: request.Payload.NotifLimit,
+		Digest:     request.Payload.Digest,
 	}
 
 	if release.NotificationConfig == 0 {
@@ -68,6 +81,12 @@ func (c *UpdateNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		release, err = c.Repo().Release().UpdateRelease(release)
 	} else {
 		newConfig.ID = release.NotificationConfig
+
+		// keep the time of the last notification, so that changing the config does not reset the limit
+		if prevConfig, err := c.Repo().NotificationConfig().ReadNotificationConfig(release.NotificationConfig); err == nil {
+			newConfig.LastNotifiedTime = prevConfig.LastNotifiedTime
+		}
+
 		newConfig, err = c.Repo().NotificationConfig().UpdateNotificationConfig(newConfig)
 	}
 
@@ -2,6 +2,7 @@ package release
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -12,8 +13,11 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/archive"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/stefanmcshane/helm/pkg/release"
+	"github.com/stefanmcshane/helm/pkg/storage/driver"
 )
 
 type RollbackReleaseHandler struct {
@@ -49,6 +53,23 @@ func (c *RollbackReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// a revision which was pruned from the cluster is restored from the revision archive before rolling back to it
+	if c.Config().RevisionArchive != nil {
+		err = restoreArchivedRevision(r.Context(), c.Config().RevisionArchive, cluster, helmAgent, helmRelease, request.Revision)
+
+		if errors.Is(err, archive.ErrObjectNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("revision %d of release %s was not found in the cluster or the revision archive", request.Revision, helmRelease.Name),
+				http.StatusBadRequest,
+			))
+
+			return
+		} else if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error restoring archived revision: %w", err)))
+			return
+		}
+	}
+
 	err = helmAgent.RollbackRelease(context.Background(), helmRelease.Name, request.Revision)
 
 	if err != nil {
@@ -109,6 +130,29 @@ func (c *RollbackReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	}
 }
 
+// restoreArchivedRevision restores a revision of a release from the revision archive if it is no longer
+// in the cluster, returning archive.ErrObjectNotFound if it was never archived
+func restoreArchivedRevision(
+	ctx context.Context,
+	revisionArchive *archive.Archive,
+	cluster *models.Cluster,
+	helmAgent *helm.Agent,
+	helmRelease *release.Release,
+	revision int,
+) error {
+	_, err := helmAgent.GetRelease(ctx, helmRelease.Name, revision, false)
+	if err == nil || !errors.Is(err, driver.ErrReleaseNotFound) {
+		return err
+	}
+
+	archived, err := revisionArchive.ReadRevision(ctx, cluster.ProjectID, cluster.ID, helmRelease.Namespace, helmRelease.Name, revision)
+	if err != nil {
+		return err
+	}
+
+	return helmAgent.RestoreReleaseRevision(ctx, archived)
+}
+
 func UpdateReleaseRepo(config *config.Config, release *models.Release, helmRelease *release.Release) error {
 	repository := helmRelease.Config["image"].(map[string]interface{})["repository"]
 	repoStr, ok := repository.(string)
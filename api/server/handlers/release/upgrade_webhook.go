@@ -14,8 +14,11 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/digest"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"github.com/porter-dev/porter/internal/telemetry"
 	"gorm.io/gorm"
 )
@@ -152,8 +155,15 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
+	webhookInts, err := c.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(release.ProjectID)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to list webhook integrations for upgrade webhook")
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		return
+	}
 
 	var notifConf *types.NotificationConfig
+	var notifModel *models.NotificationConfig
 	notifConf = nil
 	if release.NotificationConfig != 0 {
 		conf, err := c.Repo().NotificationConfig().ReadNotificationConfig(release.NotificationConfig)
@@ -164,9 +174,22 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 
 		notifConf = conf.ToNotificationConfigType()
+		notifModel = conf
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	multiNotifier := notifier.NewMultiNotifier(
+		slack.NewDeploymentNotifier(notifConf, slackInts...).WithDeduplicator(digest.NewDeduplicator(c.Repo())),
+		webhook.NewDeploymentNotifier(notifConf, webhookInts...),
+	)
+
+	// hold notifications in digest mode or during quiet hours. The release has already been upgraded, so
+	// notifications are sent without being held if the throttled notifier cannot be created.
+	deplNotifier, err := digest.NewReleaseNotifier(c.Repo(), cluster, notifModel, multiNotifier)
+	if err != nil {
+		err = telemetry.Error(ctx, span, err, "unable to create deployment notifier for upgrade webhook")
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusInternalServerError))
+		deplNotifier = multiNotifier
+	}
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   release.ProjectID,
@@ -184,14 +207,35 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		),
 	}
 
+	// deploys by webhook are not made by a user, so they cannot override blocking policies
+	admissionController, reqErr := NewAdmissionController(c.Config(), cluster, nil, types.PolicyOverrideRequest{})
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	conf.Admit = admissionController.AdmissionFunc()
+
 	rel, err = helmAgent.UpgradeReleaseByValues(ctx, conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection, false)
 	if err != nil {
+		admissionReqErr := AdmissionRequestError(err)
+
 		notifyOpts.Status = notifier.StatusHelmFailed
 		notifyOpts.Info = err.Error()
 
+		if admissionReqErr != nil {
+			notifyOpts.Status = notifier.StatusPolicyBlocked
+		}
+
 		if !cluster.NotificationsDisabled {
 			deplNotifier.Notify(notifyOpts)
 		}
+
+		if admissionReqErr != nil {
+			c.HandleAPIError(w, r, admissionReqErr)
+			return
+		}
+
 		err = telemetry.Error(ctx, span, err, "unable to upgrade release for upgrade webhook")
 		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
 		return
@@ -218,7 +262,9 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		),
 	}))
 
-	c.WriteResult(w, r, nil)
+	c.WriteResult(w, r, &types.DeployReleaseResponse{
+		PolicyWarnings: admissionController.Warnings,
+	})
 
 	err = postUpgrade(c.Config(), cluster.ProjectID, cluster.ID, rel)
 	if err != nil {
@@ -54,6 +54,9 @@ func (c *CLILoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the CLI inherits the second factor of the session it was logged in from
+	jwt.TwoFactorVerified, _ = r.Context().Value(types.TwoFactorVerifiedCtxKey).(bool)
+
 	encoded, err := jwt.EncodeToken(c.Config().TokenConf)
 	if err != nil {
 		err = fmt.Errorf("CLI token encoding failed: %s", err.Error())
@@ -11,7 +11,6 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/google/go-github/v41/github"
-	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -75,13 +74,18 @@ func (p *UserOAuthGithubCallbackHandler) ServeHTTP(w http.ResponseWriter, r *htt
 
 	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
 
-	// save the user as authenticated in the session
-	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
+	// save the user as authenticated in the session, unless a second factor is required first
+	twoFactorRequired, redirect, err := startLogin(w, r, p.Config(), user)
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
+	if twoFactorRequired {
+		http.Redirect(w, r, twoFactorLoginPath, http.StatusFound)
+		return
+	}
+
 	// non-fatal send email verification
 	if !user.EmailVerified {
 		err = startEmailVerification(p.Config(), w, r, user)
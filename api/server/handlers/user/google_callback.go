@@ -11,7 +11,6 @@ import (
 	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 
-	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -78,13 +77,18 @@ func (p *UserOAuthGoogleCallbackHandler) ServeHTTP(w http.ResponseWriter, r *htt
 
 	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
 
-	// save the user as authenticated in the session
-	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
+	// save the user as authenticated in the session, unless a second factor is required first
+	twoFactorRequired, redirect, err := startLogin(w, r, p.Config(), user)
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
+	if twoFactorRequired {
+		http.Redirect(w, r, twoFactorLoginPath, http.StatusFound)
+		return
+	}
+
 	// non-fatal send email verification
 	if !user.EmailVerified {
 		err = startEmailVerification(p.Config(), w, r, user)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -61,19 +60,28 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// save the user as authenticated in the session
-	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), storedUser)
+	// save the user as authenticated in the session, unless a second factor is required first
+	twoFactorRequired, redirect, err := startLogin(w, r, u.Config(), storedUser)
 	if err != nil {
 		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
+	if twoFactorRequired {
+		u.WriteResult(w, r, &types.LoginUserResponse{
+			TwoFactorRequired: true,
+		})
+		return
+	}
+
 	if redirect != "" {
 		http.Redirect(w, r, redirect, http.StatusFound)
 		return
 	}
 
-	u.WriteResult(w, r, storedUser.ToUserType())
+	u.WriteResult(w, r, &types.LoginUserResponse{
+		User: *storedUser.ToUserType(),
+	})
 }
 
 // checkUserRestrictions checks login restrictions specified by environment variables on the
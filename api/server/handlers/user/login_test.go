@@ -35,12 +35,14 @@ func TestLoginUserSuccessful(t *testing.T) {
 	handler.ServeHTTP(rr, req)
 
 	expUser := &types.LoginUserResponse{
-		ID:            1,
-		FirstName:     "Mister",
-		LastName:      "Porter",
-		CompanyName:   "Porter Technologies, Inc.",
-		Email:         "mrp@porter.run",
-		EmailVerified: true,
+		User: types.User{
+			ID:            1,
+			FirstName:     "Mister",
+			LastName:      "Porter",
+			CompanyName:   "Porter Technologies, Inc.",
+			Email:         "mrp@porter.run",
+			EmailVerified: true,
+		},
 	}
 
 	gotUser := &types.LoginUserResponse{}
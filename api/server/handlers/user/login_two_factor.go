@@ -0,0 +1,115 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+// maxTwoFactorAttempts is the number of codes which can be tried against a single login
+const maxTwoFactorAttempts = 5
+
+// twoFactorLoginPath is where browser logins through an external identity provider are sent when a
+// second factor is required, so that the dashboard can prompt for a code
+const twoFactorLoginPath = "/login?two_factor=true"
+
+// startLogin is called once a user has proven their identity with a password or an external identity
+// provider. If the user has enrolled in two-factor authentication, the session is only authenticated once
+// the second factor is provided to POST /api/login/2fa, and twoFactorRequired is returned. Otherwise, the
+// session is authenticated and the stored redirect uri is returned.
+func startLogin(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	user *models.User,
+) (twoFactorRequired bool, redirect string, err error) {
+	twoFactor, err := config.Repo.TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, "", err
+	}
+
+	if err == nil && twoFactor.Enabled {
+		return true, "", authn.SaveUserPendingTwoFactor(w, r, config, user)
+	}
+
+	redirect, err = authn.SaveUserAuthenticated(w, r, config, user, false)
+
+	return false, redirect, err
+}
+
+// UserLoginTwoFactorHandler completes a password login which was challenged for a second factor
+type UserLoginTwoFactorHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUserLoginTwoFactorHandler returns a new UserLoginTwoFactorHandler
+func NewUserLoginTwoFactorHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserLoginTwoFactorHandler {
+	return &UserLoginTwoFactorHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *UserLoginTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.LoginTwoFactorRequest{}
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	userID, err := authn.GetPendingTwoFactorUserID(w, r, u.Config(), maxTwoFactorAttempts)
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusUnauthorized))
+		return
+	}
+
+	user, err := u.Repo().User().ReadUser(userID)
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	twoFactor, err := u.Repo().TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	ok, err := verifyTwoFactorCode(u.Config(), twoFactor, request.Code)
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !ok {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("invalid two-factor code"), http.StatusUnauthorized))
+		return
+	}
+
+	// save the user as authenticated in the session
+	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), user, true)
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	u.WriteResult(w, r, &types.LoginUserResponse{
+		User: *user.ToUserType(),
+	})
+}
@@ -0,0 +1,294 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+// errOIDCLogin is returned for login failures which can be shown to the user
+type errOIDCLogin struct {
+	msg string
+}
+
+func (e *errOIDCLogin) Error() string {
+	return e.msg
+}
+
+// errOIDCLinkRequired is returned when the identity matches an existing account by email, but the
+// account is protected by a password or a second factor. The identity is only linked once the user
+// has logged in to that account and confirmed the link.
+type errOIDCLinkRequired struct{}
+
+func (e *errOIDCLinkRequired) Error() string {
+	return "An account with this email already exists. Log in to it, then confirm linking your identity provider account from your settings."
+}
+
+// UserOAuthOIDCCallbackHandler completes a login through the configured OIDC provider
+type UserOAuthOIDCCallbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewUserOAuthOIDCCallbackHandler returns a new UserOAuthOIDCCallbackHandler
+func NewUserOAuthOIDCCallbackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCCallbackHandler {
+	return &UserOAuthOIDCCallbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	if p.Config().OIDCConf == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("oidc login is not enabled"), http.StatusNotFound))
+		return
+	}
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, ok := session.Values["state"]; !ok {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("oidc state not found in session")))
+		return
+	}
+
+	if r.URL.Query().Get("state") != session.Values["state"] {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("oidc state does not match")))
+		return
+	}
+
+	if errParam := r.URL.Query().Get("error"); errParam != "" {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(errParam), http.StatusFound)
+		return
+	}
+
+	token, err := p.Config().OIDCConf.Exchange(ctx, r.URL.Query().Get("code"))
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	if !token.Valid() {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("invalid token")))
+		return
+	}
+
+	// the nonce is single use, and is removed when the session is next saved
+	nonce, _ := session.Values["oidc_nonce"].(string)
+	delete(session.Values, "oidc_nonce")
+
+	idToken, err := p.Config().OIDCConf.VerifyIDToken(ctx, token, nonce)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	info, err := p.Config().OIDCConf.GetUserInfo(ctx, token)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	// the userinfo response is only trusted for the user identified by the verified id token
+	if info.Sub != idToken.Sub {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("oidc userinfo subject does not match id token")))
+		return
+	}
+
+	user, err := upsertOIDCUser(ctx, p.Config(), info)
+
+	var linkErr *errOIDCLinkRequired
+	if err != nil && errors.As(err, &linkErr) {
+		if err := savePendingOIDCLink(w, r, session, p.Config().OIDCConf.Provider.Issuer, info); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(linkErr.Error()), http.StatusFound)
+		return
+	}
+
+	var loginErr *errOIDCLogin
+	if err != nil && errors.As(err, &loginErr) {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(loginErr.Error()), http.StatusFound)
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
+
+	// save the user as authenticated in the session, unless a second factor is required first
+	twoFactorRequired, redirect, err := startLogin(w, r, p.Config(), user)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if twoFactorRequired {
+		http.Redirect(w, r, twoFactorLoginPath, http.StatusFound)
+		return
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard", http.StatusFound)
+}
+
+func upsertOIDCUser(ctx context.Context, config *config.Config, info *oauth.OIDCUserInfo) (*models.User, error) {
+	// only verified emails are trusted, since the email is used to match invites and roles
+	if !info.EmailVerified {
+		return nil, &errOIDCLogin{msg: "Email address has not been verified by the identity provider."}
+	}
+
+	if !oidcEmailDomainAllowed(config.ServerConf.OIDCAllowedDomains, info.Email) {
+		return nil, &errOIDCLogin{msg: "Email is not in an allowed domain."}
+	}
+
+	if err := checkUserRestrictions(config.ServerConf, info.Email); err != nil {
+		return nil, &errOIDCLogin{msg: err.Error()}
+	}
+
+	issuer := config.OIDCConf.Provider.Issuer
+
+	user, err := config.Repo.User().ReadUserByOIDCSubject(issuer, info.Sub)
+	if err == nil {
+		return user, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error reading user by oidc subject: %w", err)
+	}
+
+	// if a user with that email already exists, the identity is only linked automatically if the account
+	// has no other credentials. Accounts with a password or a second factor must confirm the link after
+	// logging in, so that control of an email address at the identity provider is not enough to take over
+	// an account.
+	user, err = config.Repo.User().ReadUserByEmail(info.Email)
+	if err == nil {
+		if user.OIDCSubject != "" {
+			return nil, &errOIDCLogin{msg: "This account is already linked to another identity."}
+		}
+
+		protected, err := userHasCredentials(config, user)
+		if err != nil {
+			return nil, err
+		}
+
+		if protected {
+			return nil, &errOIDCLinkRequired{}
+		}
+
+		return linkOIDCIdentity(config, user, issuer, info.Sub)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error reading user by email: %w", err)
+	}
+
+	user, err = config.Repo.User().CreateUser(&models.User{
+		Email:         info.Email,
+		EmailVerified: true,
+		FirstName:     info.GivenName,
+		LastName:      info.FamilyName,
+		OIDCIssuer:    issuer,
+		OIDCSubject:   info.Sub,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+
+	if err := addUserToDefaultProject(config, user); err != nil {
+		return nil, err
+	}
+
+	if err := addOIDCUserToAutoJoinProject(config, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// oidcEmailDomainAllowed checks the email against a comma-separated list of allowed domains.
+// An empty list allows all domains.
+func oidcEmailDomainAllowed(allowedDomains string, email string) bool {
+	if allowedDomains == "" {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at == -1 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+
+	for _, allowed := range strings.Split(allowedDomains, ",") {
+		if strings.ToLower(strings.TrimSpace(allowed)) == domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addOIDCUserToAutoJoinProject adds a newly created OIDC user to the configured auto-join
+// project with the configured default role
+func addOIDCUserToAutoJoinProject(config *config.Config, user *models.User) error {
+	projectID := config.ServerConf.OIDCAutoJoinProjectID
+	if projectID == 0 {
+		return nil
+	}
+
+	project, err := config.Repo.Project().ReadProject(projectID)
+	if err != nil {
+		return fmt.Errorf("error reading oidc auto-join project: %w", err)
+	}
+
+	kind := types.RoleKind(config.ServerConf.OIDCAutoJoinRole)
+
+	switch kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+	default:
+		kind = types.RoleDeveloper
+	}
+
+	// the user may already be a member through the default project
+	if _, err := config.Repo.Project().ReadProjectRole(project.ID, user.ID); err == nil {
+		return nil
+	}
+
+	_, err = config.Repo.Project().CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    user.ID,
+			ProjectID: project.ID,
+			Kind:      kind,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error adding user to oidc auto-join project: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpsertOIDCUserLinking(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	config.OIDCConf = &oauth.OIDCConf{
+		Provider: &oauth.OIDCProviderMetadata{Issuer: "https://idp.example.com"},
+	}
+
+	// accounts with a password are not linked by email alone
+	passwordUser := apitest.CreateTestUser(t, config, true)
+
+	_, err := upsertOIDCUser(context.Background(), config, &oauth.OIDCUserInfo{
+		Sub:           "subject-1",
+		Email:         passwordUser.Email,
+		EmailVerified: true,
+	})
+	var linkErr *errOIDCLinkRequired
+	assert.ErrorAs(t, err, &linkErr)
+
+	passwordUser, err = config.Repo.User().ReadUser(passwordUser.ID)
+	require.NoError(t, err)
+	assert.Empty(t, passwordUser.OIDCSubject)
+
+	// unverified emails are never trusted
+	_, err = upsertOIDCUser(context.Background(), config, &oauth.OIDCUserInfo{
+		Sub:   "subject-1",
+		Email: passwordUser.Email,
+	})
+	var loginErr *errOIDCLogin
+	assert.ErrorAs(t, err, &loginErr)
+
+	// accounts without other credentials are linked
+	passwordlessUser, err := config.Repo.User().CreateUser(&models.User{
+		Email:         "sso@porter.run",
+		EmailVerified: true,
+	})
+	require.NoError(t, err)
+
+	linked, err := upsertOIDCUser(context.Background(), config, &oauth.OIDCUserInfo{
+		Sub:           "subject-2",
+		Email:         passwordlessUser.Email,
+		EmailVerified: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, passwordlessUser.ID, linked.ID)
+	assert.Equal(t, "subject-2", linked.OIDCSubject)
+
+	// an account linked to one identity is not linked to another with the same email
+	_, err = upsertOIDCUser(context.Background(), config, &oauth.OIDCUserInfo{
+		Sub:           "subject-3",
+		Email:         passwordlessUser.Email,
+		EmailVerified: true,
+	})
+	assert.ErrorAs(t, err, &loginErr)
+}
@@ -0,0 +1,167 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+// oidcLinkTTL is how long an identity waits to be linked to an existing account after the OIDC login
+const oidcLinkTTL = 10 * time.Minute
+
+// userHasCredentials returns whether the user can log in with a password or has a second factor
+func userHasCredentials(config *config.Config, user *models.User) (bool, error) {
+	if user.Password != "" {
+		return true, nil
+	}
+
+	twoFactor, err := config.Repo.TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("error reading two-factor enrollment: %w", err)
+	}
+
+	return twoFactor.Enabled, nil
+}
+
+// linkOIDCIdentity sets the OIDC identity used to log in as the user
+func linkOIDCIdentity(config *config.Config, user *models.User, issuer, subject string) (*models.User, error) {
+	user.OIDCIssuer = issuer
+	user.OIDCSubject = subject
+	user.EmailVerified = true
+
+	return config.Repo.User().UpdateUser(user)
+}
+
+// savePendingOIDCLink stores a verified identity in the session until the user logs in to the account
+// with the same email and confirms the link
+func savePendingOIDCLink(
+	w http.ResponseWriter,
+	r *http.Request,
+	session *sessions.Session,
+	issuer string,
+	info *oauth.OIDCUserInfo,
+) error {
+	session.Values["oidc_link_issuer"] = issuer
+	session.Values["oidc_link_subject"] = info.Sub
+	session.Values["oidc_link_email"] = info.Email
+	session.Values["oidc_link_expiry"] = time.Now().Add(oidcLinkTTL).Unix()
+
+	return session.Save(r, w)
+}
+
+func clearPendingOIDCLink(session *sessions.Session) {
+	delete(session.Values, "oidc_link_issuer")
+	delete(session.Values, "oidc_link_subject")
+	delete(session.Values, "oidc_link_email")
+	delete(session.Values, "oidc_link_expiry")
+}
+
+// UserOIDCLinkHandler links the identity from an OIDC login which matched an existing account by email
+// to the current user, once the user has logged in to that account
+type UserOIDCLinkHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewUserOIDCLinkHandler returns a new UserOIDCLinkHandler
+func NewUserOIDCLinkHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *UserOIDCLinkHandler {
+	return &UserOIDCLinkHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (h *UserOIDCLinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if h.Config().OIDCConf == nil {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("oidc login is not enabled"), http.StatusNotFound))
+		return
+	}
+
+	session, err := h.Config().Store.Get(r, h.Config().ServerConf.CookieName)
+	if err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	issuer, _ := session.Values["oidc_link_issuer"].(string)
+	subject, _ := session.Values["oidc_link_subject"].(string)
+	email, _ := session.Values["oidc_link_email"].(string)
+	expiry, _ := session.Values["oidc_link_expiry"].(int64)
+
+	if subject == "" || issuer != h.Config().OIDCConf.Provider.Issuer || time.Now().Unix() > expiry {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("no identity is waiting to be linked, please log in with your identity provider again"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	if !strings.EqualFold(email, user.Email) {
+		h.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("identity email does not match the current user")))
+		return
+	}
+
+	// an account protected by a second factor can only be linked from a session which provided it
+	hasTwoFactor := false
+
+	if twoFactor, err := h.Repo().TwoFactor().ReadUserTwoFactor(user.ID); err == nil {
+		hasTwoFactor = twoFactor.Enabled
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if verified, _ := r.Context().Value(types.TwoFactorVerifiedCtxKey).(bool); hasTwoFactor && !verified {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("log in with your second factor before linking an identity provider"),
+			http.StatusForbidden,
+		))
+		return
+	}
+
+	if _, err := h.Repo().User().ReadUserByOIDCSubject(issuer, subject); err == nil {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("this identity is already linked to an account"),
+			http.StatusConflict,
+		))
+		return
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	user, err = linkOIDCIdentity(h.Config(), user, issuer, subject)
+	if err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	clearPendingOIDCLink(session)
+
+	if err := session.Save(r, w); err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	h.WriteResult(w, r, user.ToUserType())
+}
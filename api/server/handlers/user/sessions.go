@@ -0,0 +1,151 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ListSessionsHandler lists the active sessions of the current user
+type ListSessionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewListSessionsHandler returns a new ListSessionsHandler
+func NewListSessionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListSessionsHandler {
+	return &ListSessionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (h *ListSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	sessions, err := h.Repo().Session().ListSessionsByUserID(user.ID)
+	if err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	currentKey := currentSessionKey(h.Config(), r)
+
+	res := make(types.ListSessionsResponse, 0, len(sessions))
+
+	for _, session := range sessions {
+		sessionType := session.ToSessionType()
+		sessionType.Current = currentKey != "" && session.Key == currentKey
+
+		res = append(res, sessionType)
+	}
+
+	h.WriteResult(w, r, res)
+}
+
+// RevokeSessionHandler revokes a single session of the current user
+type RevokeSessionHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewRevokeSessionHandler returns a new RevokeSessionHandler
+func NewRevokeSessionHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RevokeSessionHandler {
+	return &RevokeSessionHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (h *RevokeSessionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	sessionID, reqErr := requestutils.GetURLParamUint(r, types.URLParamSessionID)
+	if reqErr != nil {
+		h.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if err := h.Repo().Session().DeleteUserSession(user.ID, sessionID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			h.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("session %d not found", sessionID)))
+			return
+		}
+
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// RevokeAllSessionsHandler revokes all sessions of the current user except for the session
+// which made the request. Use POST /api/logout to end the current session. User tokens, such as
+// those of the CLI, are revoked as well, including the token which made the request, since they
+// cannot be told apart.
+type RevokeAllSessionsHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewRevokeAllSessionsHandler returns a new RevokeAllSessionsHandler
+func NewRevokeAllSessionsHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *RevokeAllSessionsHandler {
+	return &RevokeAllSessionsHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (h *RevokeAllSessionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if err := h.Repo().Session().DeleteSessionsByUserID(user.ID, currentSessionKey(h.Config(), r)); err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := revokeUserTokens(h.Config(), user); err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// revokeUserTokens rejects all user tokens which were issued to the user until now. User tokens are not
+// stored, so they are rejected by their issue time when they are used.
+func revokeUserTokens(config *config.Config, user *models.User) error {
+	now := time.Now()
+	user.SessionsRevokedAt = &now
+
+	if _, err := config.Repo.User().UpdateUser(user); err != nil {
+		return fmt.Errorf("error revoking tokens for user %d: %w", user.ID, err)
+	}
+
+	return nil
+}
+
+// currentSessionKey returns the key of the cookie session which made the request, or an empty
+// string if the request was authenticated with a token
+func currentSessionKey(config *config.Config, r *http.Request) string {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+	if err != nil || session.IsNew {
+		return ""
+	}
+
+	return session.ID
+}
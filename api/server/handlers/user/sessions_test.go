@@ -0,0 +1,44 @@
+package user_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeAllSessionsRevokesUserTokens(t *testing.T) {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbDelete),
+		"/api/users/current/sessions",
+		nil,
+	)
+
+	config := apitest.LoadConfig(t)
+	authUser := apitest.CreateTestUser(t, config, true)
+	req = apitest.WithAuthenticatedUser(t, req, authUser)
+
+	handler := user.NewRevokeAllSessionsHandler(
+		config,
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	before := time.Now()
+
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode, "status code should be 200")
+
+	gotUser, err := config.Repo.User().ReadUser(authUser.ID)
+	require.NoError(t, err)
+
+	require.NotNil(t, gotUser.SessionsRevokedAt, "sessions revoked at should be set")
+	assert.False(t, gotUser.SessionsRevokedAt.Before(before), "sessions revoked at should be the time of the request")
+}
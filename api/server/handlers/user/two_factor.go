@@ -0,0 +1,327 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const (
+	twoFactorIssuer        = "Porter"
+	twoFactorRecoveryCodes = 10
+)
+
+// GetTwoFactorStatusHandler returns the two-factor enrollment of the current user
+type GetTwoFactorStatusHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewGetTwoFactorStatusHandler returns a new GetTwoFactorStatusHandler
+func NewGetTwoFactorStatusHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *GetTwoFactorStatusHandler {
+	return &GetTwoFactorStatusHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (h *GetTwoFactorStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	res := &types.GetTwoFactorStatusResponse{}
+
+	twoFactor, err := h.Repo().TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err == nil && twoFactor.Enabled {
+		hashes, err := twoFactor.GetRecoveryCodeHashes()
+		if err != nil {
+			h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res.Enabled = true
+		res.RecoveryCodesRemaining = len(hashes)
+	}
+
+	h.WriteResult(w, r, res)
+}
+
+// EnrollTwoFactorHandler generates a new TOTP secret for the current user. The secret is not
+// used for logins until it has been confirmed with ConfirmTwoFactorHandler.
+type EnrollTwoFactorHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+// NewEnrollTwoFactorHandler returns a new EnrollTwoFactorHandler
+func NewEnrollTwoFactorHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *EnrollTwoFactorHandler {
+	return &EnrollTwoFactorHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (h *EnrollTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	// users without a password log in through an external identity provider, which is
+	// responsible for their second factor
+	if user.Password == "" {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is only available for password accounts"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	twoFactor, err := h.Repo().TwoFactor().ReadUserTwoFactor(user.ID)
+
+	switch {
+	case err == nil && twoFactor.Enabled:
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is already enabled"),
+			http.StatusConflict,
+		))
+		return
+	case err == nil:
+		twoFactor.Secret = []byte(secret)
+		twoFactor.LastUsedStep = 0
+
+		_, err = h.Repo().TwoFactor().UpdateUserTwoFactor(twoFactor)
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		_, err = h.Repo().TwoFactor().CreateUserTwoFactor(&models.UserTwoFactor{
+			UserID: user.ID,
+			Secret: []byte(secret),
+		})
+	}
+
+	if err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	h.WriteResult(w, r, &types.EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(twoFactorIssuer, user.Email, secret),
+	})
+}
+
+// ConfirmTwoFactorHandler enables two-factor authentication once the user has proven that their
+// authenticator app generates valid codes, and returns a fresh set of recovery codes
+type ConfirmTwoFactorHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+// NewConfirmTwoFactorHandler returns a new ConfirmTwoFactorHandler
+func NewConfirmTwoFactorHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ConfirmTwoFactorHandler {
+	return &ConfirmTwoFactorHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (h *ConfirmTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.TwoFactorCodeRequest{}
+	if ok := h.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	twoFactor, err := h.Repo().TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("two-factor enrollment has not been started"),
+				http.StatusBadRequest,
+			))
+			return
+		}
+
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if twoFactor.Enabled {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is already enabled"),
+			http.StatusConflict,
+		))
+		return
+	}
+
+	step, ok := totp.Validate(string(twoFactor.Secret), request.Code, time.Now())
+	if !ok {
+		h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("invalid code"), http.StatusUnauthorized))
+		return
+	}
+
+	codes, err := totp.GenerateRecoveryCodes(twoFactorRecoveryCodes)
+	if err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	hashes := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		hash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		hashes = append(hashes, string(hash))
+	}
+
+	if err := twoFactor.SetRecoveryCodeHashes(hashes); err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	twoFactor.Enabled = true
+	twoFactor.LastUsedStep = step
+
+	if _, err := h.Repo().TwoFactor().UpdateUserTwoFactor(twoFactor); err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the user has just provided a valid code, so the current session does not need to log in again
+	if err := authn.SaveUserTwoFactorVerified(w, r, h.Config()); err != nil {
+		h.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+
+	h.WriteResult(w, r, &types.ConfirmTwoFactorResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// DisableTwoFactorHandler removes the second factor of the current user
+type DisableTwoFactorHandler struct {
+	handlers.PorterHandlerReader
+}
+
+// NewDisableTwoFactorHandler returns a new DisableTwoFactorHandler
+func NewDisableTwoFactorHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *DisableTwoFactorHandler {
+	return &DisableTwoFactorHandler{
+		PorterHandlerReader: handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+	}
+}
+
+func (h *DisableTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.TwoFactorCodeRequest{}
+	if ok := h.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	twoFactor, err := h.Repo().TwoFactor().ReadUserTwoFactor(user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// a pending enrollment can be discarded without a code
+	if twoFactor.Enabled {
+		ok, err := verifyTwoFactorCode(h.Config(), twoFactor, request.Code)
+		if err != nil {
+			h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if !ok {
+			h.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("invalid code"), http.StatusUnauthorized))
+			return
+		}
+	}
+
+	if err := h.Repo().TwoFactor().DeleteUserTwoFactor(twoFactor); err != nil {
+		h.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifyTwoFactorCode checks the code against the user's TOTP secret and unused recovery codes.
+// Accepted codes are recorded so that they cannot be used again.
+func verifyTwoFactorCode(config *config.Config, twoFactor *models.UserTwoFactor, code string) (bool, error) {
+	if step, ok := totp.Validate(string(twoFactor.Secret), code, time.Now()); ok {
+		if step <= twoFactor.LastUsedStep {
+			return false, nil
+		}
+
+		twoFactor.LastUsedStep = step
+
+		if _, err := config.Repo.TwoFactor().UpdateUserTwoFactor(twoFactor); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	hashes, err := twoFactor.GetRecoveryCodeHashes()
+	if err != nil {
+		return false, err
+	}
+
+	normalized := totp.NormalizeRecoveryCode(code)
+
+	for i, hash := range hashes {
+		if bcrypt.CompareHashAndPassword([]byte(hash), []byte(normalized)) != nil {
+			continue
+		}
+
+		remaining := append(hashes[:i:i], hashes[i+1:]...)
+
+		if err := twoFactor.SetRecoveryCodeHashes(remaining); err != nil {
+			return false, err
+		}
+
+		if _, err := config.Repo.TwoFactor().UpdateUserTwoFactor(twoFactor); err != nil {
+			return false, err
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -18,7 +18,9 @@ import (
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/digest"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"github.com/stefanmcshane/helm/pkg/release"
 )
 
@@ -139,17 +141,36 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	admissionController, reqErr := baseReleaseHandler.NewAdmissionController(c.Config(), cluster, user, request.PolicyOverrideRequest)
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	conf.Admit = admissionController.AdmissionFunc()
+
 	newHelmRelease, upgradeErr := helmAgent.UpgradeReleaseByValues(context.Background(), conf, c.Config().DOConf, c.Config().ServerConf.DisablePullSecretsInjection, false)
 
+	if reqErr := baseReleaseHandler.AdmissionRequestError(upgradeErr); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	if upgradeErr == nil && newHelmRelease != nil {
 		helmRelease = newHelmRelease
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	// the release has already been upgraded, so a failure to list webhooks is reported without failing the request
+	webhookInts, err := c.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(cluster.ProjectID)
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(fmt.Errorf("error listing webhook integrations: %w", err)))
+	}
 
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
 	var notifConf *types.NotificationConfig
+	var notifModel *models.NotificationConfig
 	notifConf = nil
 	if rel != nil && rel.NotificationConfig != 0 {
 		conf, err := c.Repo().NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
@@ -159,9 +180,21 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 
 		notifConf = conf.ToNotificationConfigType()
+		notifModel = conf
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	multiNotifier := notifier.NewMultiNotifier(
+		slack.NewDeploymentNotifier(notifConf, slackInts...).WithDeduplicator(digest.NewDeduplicator(c.Repo())),
+		webhook.NewDeploymentNotifier(notifConf, webhookInts...),
+	)
+
+	// hold notifications in digest mode or during quiet hours. The release has already been upgraded, so
+	// notifications are sent without being held if the throttled notifier cannot be created.
+	deplNotifier, err := digest.NewReleaseNotifier(c.Repo(), cluster, notifModel, multiNotifier)
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(fmt.Errorf("error creating deployment notifier: %w", err)))
+		deplNotifier = multiNotifier
+	}
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   cluster.ProjectID,
@@ -249,4 +282,8 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			}
 		}
 	}
+
+	c.WriteResult(w, r, &types.DeployReleaseResponse{
+		PolicyWarnings: admissionController.Warnings,
+	})
 }
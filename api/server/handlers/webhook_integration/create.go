@@ -0,0 +1,79 @@
+package webhook_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type WebhookIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWebhookIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WebhookIntegrationCreateHandler {
+	return &WebhookIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WebhookIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateWebhookIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	parsedURL, err := url.Parse(request.URL)
+	if err != nil || (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("webhook url must be a valid http or https url"),
+			http.StatusBadRequest,
+		))
+		return
+	}
+
+	signingSecret := request.SigningSecret
+
+	if signingSecret == "" {
+		signingSecret, err = encryption.GenerateRandomBytes(32)
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	webhookInt := &integrations.WebhookIntegration{
+		ProjectID:     project.ID,
+		UserID:        user.ID,
+		Name:          request.Name,
+		URL:           []byte(request.URL),
+		SigningSecret: []byte(signingSecret),
+	}
+
+	webhookInt, err = p.Repo().WebhookIntegration().CreateWebhookIntegration(webhookInt)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, &types.CreateWebhookIntegrationResponse{
+		WebhookIntegration: webhookInt.ToWebhookIntegrationType(),
+		SigningSecret:      signingSecret,
+	})
+}
@@ -0,0 +1,43 @@
+package webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type WebhookIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewWebhookIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *WebhookIntegrationListHandler {
+	return &WebhookIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *WebhookIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInts, err := p.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWebhookIntegrationsResponse, 0)
+
+	for _, webhookInt := range webhookInts {
+		res = append(res, webhookInt.ToWebhookIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
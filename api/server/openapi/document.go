@@ -0,0 +1,94 @@
+package openapi
+
+import "github.com/porter-dev/porter/api/types"
+
+// Version is the version of the OpenAPI specification that generated documents conform to
+const Version = "3.0.3"
+
+// Document is the root object of an OpenAPI document. Only the parts of the specification used to describe
+// the Porter API are implemented
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info contains metadata about the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem contains the operations available on a single path, keyed by lowercase HTTP method
+type PathItem map[string]*Operation
+
+// Operation describes a single API endpoint
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+
+	// Verb is the verb checked against project policies when authorizing requests to the endpoint
+	Verb types.APIVerb `json:"x-porter-verb,omitempty"`
+	// Scopes are the resources which are loaded and authorized before the request is handled
+	Scopes []types.PermissionScope `json:"x-porter-scopes,omitempty"`
+	// Websocket is true if the endpoint upgrades the connection to a websocket
+	Websocket bool `json:"x-porter-websocket,omitempty"`
+}
+
+// Parameter is a single path or query parameter of an operation
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of a request
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response describes a single response of an operation
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes the content of a request or response body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a subset of the OpenAPI schema object, which describes a JSON value
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// Components holds the schemas and security schemes referenced from operations
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme describes a way of authenticating requests
+type SecurityScheme struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	Scheme      string `json:"scheme,omitempty"`
+	In          string `json:"in,omitempty"`
+	Name        string `json:"name,omitempty"`
+}
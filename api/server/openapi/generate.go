@@ -0,0 +1,411 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/types"
+)
+
+const (
+	securitySchemeBearer  = "bearerAuth"
+	securitySchemeSession = "sessionCookie"
+
+	contentTypeJSON = "application/json"
+)
+
+var pathParamRegex = regexp.MustCompile(`\{([^}:]+)(:[^}]*)?\}`)
+
+// integerURLParams are the path parameters which are parsed as integers by the scoped middleware
+var integerURLParams = map[string]bool{
+	string(types.URLParamProjectID):         true,
+	string(types.URLParamClusterID):         true,
+	string(types.URLParamRegistryID):        true,
+	string(types.URLParamHelmRepoID):        true,
+	string(types.URLParamGitInstallationID): true,
+	string(types.URLParamInfraID):           true,
+	string(types.URLParamInviteID):          true,
+}
+
+// Endpoint is an endpoint registered on the API router
+type Endpoint struct {
+	Handler  http.Handler
+	Metadata *types.APIRequestMetadata
+}
+
+// NewDocumentInput contains everything required to generate an OpenAPI document for the API
+type NewDocumentInput struct {
+	Info Info
+	// Router is walked to find the full path of each endpoint, since endpoints only know their path relative to the
+	// router they are mounted on
+	Router chi.Routes
+	// Endpoints are all documented endpoints. Routes on the router which are not endpoints, such as static files, are ignored
+	Endpoints []Endpoint
+	// CookieName is the name of the session cookie used to authenticate dashboard requests
+	CookieName string
+}
+
+type documentedOperation struct {
+	method   string
+	path     string
+	endpoint Endpoint
+}
+
+// NewDocument generates an OpenAPI document describing every endpoint registered on the router. Request and response
+// schemas are generated from the Request and Response types in the metadata of each endpoint
+func NewDocument(inp NewDocumentInput) (*Document, error) {
+	if inp.Router == nil {
+		return nil, fmt.Errorf("router is required to generate an OpenAPI document")
+	}
+
+	var operations []documentedOperation
+
+	err := chi.Walk(inp.Router, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		endpoint, ok := findEndpoint(inp.Endpoints, method, route, handler)
+		if !ok {
+			return nil
+		}
+
+		operations = append(operations, documentedOperation{
+			method:   strings.ToLower(method),
+			path:     route,
+			endpoint: endpoint,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking router: %w", err)
+	}
+
+	// sorting makes the generated document, including the names chosen for colliding components, independent of registration order
+	sort.Slice(operations, func(i, j int) bool {
+		if operations[i].path != operations[j].path {
+			return operations[i].path < operations[j].path
+		}
+		return operations[i].method < operations[j].method
+	})
+
+	schemas := newSchemaGenerator()
+	errorSchema := schemas.schemaFor(reflect.TypeOf(types.ExternalError{}))
+
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    inp.Info,
+		Paths:   make(map[string]PathItem),
+		Components: Components{
+			Schemas: schemas.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				securitySchemeBearer: {
+					Type:        "http",
+					Scheme:      "bearer",
+					Description: "A project API token or a CLI token",
+				},
+				securitySchemeSession: {
+					Type:        "apiKey",
+					In:          "cookie",
+					Name:        inp.CookieName,
+					Description: "The session cookie set when logging in to the dashboard",
+				},
+			},
+		},
+	}
+
+	operationIDs := operationIDs(operations)
+
+	for i, op := range operations {
+		openAPIPath, params := pathParameters(op.path)
+
+		operation := &Operation{
+			OperationID: operationIDs[i],
+			Tags:        []string{handlerPackage(op.endpoint.Handler)},
+			Parameters:  params,
+			Responses: map[string]*Response{
+				"default": {
+					Description: "Error",
+					Content:     map[string]*MediaType{contentTypeJSON: {Schema: errorSchema}},
+				},
+			},
+			Verb:      op.endpoint.Metadata.Verb,
+			Scopes:    op.endpoint.Metadata.Scopes,
+			Websocket: op.endpoint.Metadata.IsWebsocket,
+		}
+
+		if hasScope(op.endpoint.Metadata.Scopes, types.UserScope) {
+			operation.Security = []map[string][]string{
+				{securitySchemeBearer: {}},
+				{securitySchemeSession: {}},
+			}
+		}
+
+		if request := op.endpoint.Metadata.Request; request != nil {
+			requestType := reflect.TypeOf(request)
+
+			operation.Parameters = append(operation.Parameters, queryParameters(schemas, requestType)...)
+
+			if op.method != "get" && hasJSONFields(requestType) {
+				operation.RequestBody = &RequestBody{
+					Required: true,
+					Content:  map[string]*MediaType{contentTypeJSON: {Schema: schemas.schemaFor(requestType)}},
+				}
+			}
+		}
+
+		successResponse := &Response{Description: "Success"}
+		if response := op.endpoint.Metadata.Response; response != nil {
+			successResponse.Content = map[string]*MediaType{
+				contentTypeJSON: {Schema: schemas.schemaFor(reflect.TypeOf(response))},
+			}
+		}
+		operation.Responses[successStatus(op.endpoint.Metadata)] = successResponse
+
+		if doc.Paths[openAPIPath] == nil {
+			doc.Paths[openAPIPath] = make(PathItem)
+		}
+		doc.Paths[openAPIPath][op.method] = operation
+	}
+
+	return doc, nil
+}
+
+// findEndpoint returns the endpoint serving a route found while walking the router. Handlers are compared by identity,
+// since the same handler type can be registered for several routes
+func findEndpoint(endpoints []Endpoint, method string, route string, handler http.Handler) (Endpoint, bool) {
+	if handler == nil || !reflect.TypeOf(handler).Comparable() {
+		return Endpoint{}, false
+	}
+
+	var matches []Endpoint
+	for _, endpoint := range endpoints {
+		if endpoint.Metadata == nil || !strings.EqualFold(string(endpoint.Metadata.Method), method) {
+			continue
+		}
+		if reflect.TypeOf(endpoint.Handler) != reflect.TypeOf(handler) || endpoint.Handler != handler {
+			continue
+		}
+
+		matches = append(matches, endpoint)
+	}
+
+	if len(matches) == 0 {
+		return Endpoint{}, false
+	}
+
+	// the same handler instance may be registered on several paths, in which case the relative paths tell them apart
+	for _, match := range matches {
+		if match.Metadata.Path != nil && strings.HasSuffix(route, match.Metadata.Path.RelativePath) {
+			return match, true
+		}
+	}
+
+	return matches[0], true
+}
+
+// pathParameters converts a chi route pattern to an OpenAPI path, and returns the parameters in the path
+func pathParameters(route string) (string, []*Parameter) {
+	// a trailing wildcard matches the rest of the path
+	if strings.HasSuffix(route, "/*") {
+		route = strings.TrimSuffix(route, "*") + "{wildcard}"
+	}
+
+	var params []*Parameter
+
+	openAPIPath := pathParamRegex.ReplaceAllStringFunc(route, func(match string) string {
+		name := pathParamRegex.FindStringSubmatch(match)[1]
+
+		schema := &Schema{Type: "string"}
+		if integerURLParams[name] {
+			schema = &Schema{Type: "integer", Format: "int64"}
+		}
+
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   schema,
+		})
+
+		return "{" + name + "}"
+	})
+
+	return openAPIPath, params
+}
+
+// queryParameters returns a parameter for each field of the request type with a schema tag, which the request decoder
+// reads from the query string
+func queryParameters(schemas *schemaGenerator, t reflect.Type) []*Parameter {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var params []*Parameter
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag, ok := field.Tag.Lookup("schema")
+		if !ok {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if field.Anonymous && fieldType.Kind() == reflect.Struct {
+				params = append(params, queryParameters(schemas, fieldType)...)
+			}
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: hasOption(opts, "required") || hasOption(field.Tag.Get("form"), "required"),
+			Schema:   schemas.schemaFor(field.Type),
+		})
+	}
+
+	return params
+}
+
+// hasJSONFields returns true if any field of the request type is read from a JSON body
+func hasJSONFields(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return true
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if name, _, skip := jsonFieldName(field); !skip && name != "" {
+			return true
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && fieldType.Kind() == reflect.Struct && hasJSONFields(fieldType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func successStatus(metadata *types.APIRequestMetadata) string {
+	if metadata.IsWebsocket {
+		return "101"
+	}
+
+	return "200"
+}
+
+// operationIDs names each operation after its handler type. Handlers which serve several operations are named after
+// the method and path of each operation instead, so that every operation ID is unique
+func operationIDs(operations []documentedOperation) []string {
+	handlerNames := make([]string, len(operations))
+	counts := make(map[string]int)
+
+	for i, op := range operations {
+		handlerNames[i] = handlerName(op.endpoint.Handler)
+		counts[handlerNames[i]]++
+	}
+
+	ids := make([]string, len(operations))
+	for i, op := range operations {
+		if handlerNames[i] != "" && counts[handlerNames[i]] == 1 {
+			ids[i] = lowerFirst(handlerNames[i])
+			continue
+		}
+
+		ids[i] = op.method + pathIdentifier(op.path)
+	}
+
+	return ids
+}
+
+func handlerName(handler http.Handler) string {
+	t := reflect.TypeOf(handler)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return strings.TrimSuffix(t.Name(), "Handler")
+}
+
+func handlerPackage(handler http.Handler) string {
+	t := reflect.TypeOf(handler)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return path.Base(t.PkgPath())
+}
+
+// pathIdentifier converts a path such as /api/projects/{project_id}/clusters to ApiProjectsByProjectIDClusters
+func pathIdentifier(route string) string {
+	var sb strings.Builder
+
+	for _, segment := range strings.Split(route, "/") {
+		if segment == "" {
+			continue
+		}
+
+		if segment == "*" {
+			sb.WriteString("ByWildcard")
+			continue
+		}
+
+		if match := pathParamRegex.FindStringSubmatch(segment); match != nil {
+			sb.WriteString("By")
+			segment = match[1]
+		}
+
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool { return r == '-' || r == '_' || r == '.' || r == '*' }) {
+			if word == "id" {
+				sb.WriteString("ID")
+				continue
+			}
+			sb.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+
+	return sb.String()
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
+
+func hasScope(scopes []types.PermissionScope, scope types.PermissionScope) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,199 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testHandler struct {
+	name string
+}
+
+func (h *testHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {}
+
+type GetWidgetHandler struct{ testHandler }
+
+type UpdateWidgetHandler struct{ testHandler }
+
+type testWidgetMeta struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testWidget struct {
+	testWidgetMeta
+	Name     string            `json:"name" form:"required"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Children []*testWidget     `json:"children"`
+	Data     []byte            `json:"data"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+type testGetWidgetRequest struct {
+	Version uint   `schema:"version"`
+	Filter  string `schema:"filter,required"`
+}
+
+func newTestRouter(t *testing.T) (chi.Router, []Endpoint) {
+	t.Helper()
+
+	r := chi.NewRouter()
+	var endpoints []Endpoint
+
+	register := func(r chi.Router, handler http.Handler, metadata *types.APIRequestMetadata) {
+		r.Method(string(metadata.Method), metadata.Path.RelativePath, handler)
+		endpoints = append(endpoints, Endpoint{Handler: handler, Metadata: metadata})
+	}
+
+	r.Route("/api", func(r chi.Router) {
+		r.Route("/projects/{project_id}", func(r chi.Router) {
+			register(r, &GetWidgetHandler{}, &types.APIRequestMetadata{
+				Verb:     types.APIVerbGet,
+				Method:   types.HTTPVerbGet,
+				Path:     &types.Path{RelativePath: "/widgets/{name}"},
+				Scopes:   []types.PermissionScope{types.UserScope, types.ProjectScope},
+				Request:  &testGetWidgetRequest{},
+				Response: &testWidget{},
+			})
+
+			// the same handler type registered on two paths
+			register(r, &UpdateWidgetHandler{}, &types.APIRequestMetadata{
+				Verb:    types.APIVerbUpdate,
+				Method:  types.HTTPVerbPost,
+				Path:    &types.Path{RelativePath: "/widgets/{name}"},
+				Scopes:  []types.PermissionScope{types.UserScope, types.ProjectScope},
+				Request: &testWidget{},
+			})
+			register(r, &UpdateWidgetHandler{}, &types.APIRequestMetadata{
+				Verb:    types.APIVerbUpdate,
+				Method:  types.HTTPVerbPost,
+				Path:    &types.Path{RelativePath: "/gadgets/{name}"},
+				Scopes:  []types.PermissionScope{types.UserScope, types.ProjectScope},
+				Request: &testWidget{},
+			})
+			register(r, &UpdateWidgetHandler{}, &types.APIRequestMetadata{
+				Verb:    types.APIVerbUpdate,
+				Method:  types.HTTPVerbPost,
+				Path:    &types.Path{RelativePath: "/gadgets/*"},
+				Scopes:  []types.PermissionScope{types.UserScope, types.ProjectScope},
+				Request: &testWidget{},
+			})
+		})
+	})
+
+	// routes which are not endpoints are not documented
+	r.Get("/*", func(w http.ResponseWriter, r *http.Request) {})
+
+	return r, endpoints
+}
+
+func TestNewDocument(t *testing.T) {
+	r, endpoints := newTestRouter(t)
+
+	doc, err := NewDocument(NewDocumentInput{
+		Info:       Info{Title: "Test API", Version: "test"},
+		Router:     r,
+		Endpoints:  endpoints,
+		CookieName: "porter",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 3)
+
+	get := doc.Paths["/api/projects/{project_id}/widgets/{name}"]["get"]
+	require.NotNil(t, get)
+	assert.Equal(t, "getWidget", get.OperationID)
+	assert.Equal(t, []string{"openapi"}, get.Tags)
+	assert.Equal(t, types.APIVerbGet, get.Verb)
+	assert.Len(t, get.Security, 2)
+	assert.Nil(t, get.RequestBody)
+
+	require.Len(t, get.Parameters, 4)
+	assert.Equal(t, &Parameter{Name: "project_id", In: "path", Required: true, Schema: &Schema{Type: "integer", Format: "int64"}}, get.Parameters[0])
+	assert.Equal(t, &Parameter{Name: "name", In: "path", Required: true, Schema: &Schema{Type: "string"}}, get.Parameters[1])
+	assert.Equal(t, &Parameter{Name: "version", In: "query", Schema: &Schema{Type: "integer", Format: "int64"}}, get.Parameters[2])
+	assert.Equal(t, &Parameter{Name: "filter", In: "query", Required: true, Schema: &Schema{Type: "string"}}, get.Parameters[3])
+
+	assert.Equal(t, "#/components/schemas/openapi.testWidget", get.Responses["200"].Content[contentTypeJSON].Schema.Ref)
+	assert.Equal(t, "#/components/schemas/ExternalError", get.Responses["default"].Content[contentTypeJSON].Schema.Ref)
+
+	// handlers serving several operations are named after the method and path
+	post := doc.Paths["/api/projects/{project_id}/widgets/{name}"]["post"]
+	require.NotNil(t, post)
+	assert.Equal(t, "postApiProjectsByProjectIDWidgetsByName", post.OperationID)
+	require.NotNil(t, post.RequestBody)
+	assert.Equal(t, "#/components/schemas/openapi.testWidget", post.RequestBody.Content[contentTypeJSON].Schema.Ref)
+
+	gadgets := doc.Paths["/api/projects/{project_id}/gadgets/{name}"]["post"]
+	require.NotNil(t, gadgets)
+	assert.Equal(t, "postApiProjectsByProjectIDGadgetsByName", gadgets.OperationID)
+
+	// wildcard routes are named apart from the route they are nested under
+	wildcard := doc.Paths["/api/projects/{project_id}/gadgets/{wildcard}"]["post"]
+	require.NotNil(t, wildcard)
+	assert.Equal(t, "postApiProjectsByProjectIDGadgetsByWildcard", wildcard.OperationID)
+}
+
+func TestNewDocumentSchemas(t *testing.T) {
+	r, endpoints := newTestRouter(t)
+
+	doc, err := NewDocument(NewDocumentInput{Router: r, Endpoints: endpoints})
+	require.NoError(t, err)
+
+	widget := doc.Components.Schemas["openapi.testWidget"]
+	require.NotNil(t, widget)
+
+	assert.Equal(t, "object", widget.Type)
+	assert.Equal(t, []string{"name"}, widget.Required)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, widget.Properties["created_at"], "fields of embedded structs are promoted")
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, widget.Properties["labels"])
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/openapi.testWidget"}}, widget.Properties["children"], "recursive types are referenced")
+	assert.Equal(t, &Schema{Type: "string", Format: "byte"}, widget.Properties["data"])
+	assert.NotContains(t, widget.Properties, "Ignored")
+	assert.NotContains(t, widget.Properties, "internal")
+	assert.Len(t, widget.Properties, 5)
+
+	// the document must be valid JSON with every reference resolvable
+	bytes, err := json.Marshal(doc)
+	require.NoError(t, err)
+	assert.Contains(t, string(bytes), `"x-porter-scopes":["user","project"]`)
+
+	for _, item := range doc.Paths {
+		for _, op := range item {
+			for _, response := range op.Responses {
+				for _, media := range response.Content {
+					assertRefResolves(t, doc, media.Schema)
+				}
+			}
+			if op.RequestBody != nil {
+				for _, media := range op.RequestBody.Content {
+					assertRefResolves(t, doc, media.Schema)
+				}
+			}
+		}
+	}
+}
+
+func assertRefResolves(t *testing.T, doc *Document, schema *Schema) {
+	t.Helper()
+
+	if schema == nil || schema.Ref == "" {
+		return
+	}
+
+	name := schema.Ref[len("#/components/schemas/"):]
+	assert.Contains(t, doc.Components.Schemas, name)
+}
+
+func TestNewDocumentRequiresRouter(t *testing.T) {
+	_, err := NewDocument(NewDocumentInput{})
+	assert.Error(t, err)
+}
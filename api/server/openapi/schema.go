@@ -0,0 +1,196 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+	invalidComponentNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+)
+
+// schemaGenerator builds schemas for Go types by reflection, following the rules of encoding/json. Named struct
+// types are added to the components of the document and referenced, so that each type is only described once
+type schemaGenerator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaGenerator() *schemaGenerator {
+	return &schemaGenerator{
+		schemas: make(map[string]*Schema),
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+func (g *schemaGenerator) schemaFor(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case implements(t, jsonMarshalerType):
+		// the JSON representation of custom marshalers is unknown
+		return &Schema{}
+	case implements(t, textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice:
+		// encoding/json writes byte slices as base64 strings
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Array:
+		return &Schema{Type: "array", Items: g.schemaFor(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaFor(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	default:
+		// interfaces can hold any value
+		return &Schema{}
+	}
+}
+
+// component adds the schema for a named struct type to the components, if it has not been added already, and returns its name
+func (g *schemaGenerator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+
+	name := g.componentName(t)
+	g.names[t] = name
+
+	// the schema is registered before its fields are generated so that recursive types reference it instead of looping
+	schema := &Schema{}
+	g.schemas[name] = schema
+	*schema = *g.structSchema(t)
+
+	return name
+}
+
+// componentName names types in the api/types package by their type name, and other types by their package and type name.
+// If two types would get the same name, the full package path is used for the second one
+func (g *schemaGenerator) componentName(t reflect.Type) string {
+	name := t.Name()
+	if !strings.HasSuffix(t.PkgPath(), "/api/types") {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	name = invalidComponentNameChars.ReplaceAllString(name, "_")
+
+	if _, taken := g.schemas[name]; taken {
+		name = invalidComponentNameChars.ReplaceAllString(strings.ReplaceAll(t.PkgPath(), "/", ".")+"."+t.Name(), "_")
+	}
+
+	return name
+}
+
+func (g *schemaGenerator) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+
+	g.addFields(schema, t)
+
+	return schema
+}
+
+// addFields adds the JSON fields of a struct to the schema. Fields of embedded structs without a JSON name are promoted,
+// like encoding/json does
+func (g *schemaGenerator) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		name, opts, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			g.addFields(schema, fieldType)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldSchema := g.schemaFor(field.Type)
+		if hasOption(opts, "string") {
+			fieldSchema = &Schema{Type: "string"}
+		}
+
+		schema.Properties[name] = fieldSchema
+
+		if hasOption(field.Tag.Get("form"), "required") && !hasOption(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
+
+// jsonFieldName returns the name and options from the json tag of a struct field, and whether the field is skipped by encoding/json
+func jsonFieldName(field reflect.StructField) (string, string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", "", true
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+
+	return name, opts, false
+}
+
+func hasOption(opts string, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+func implements(t reflect.Type, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/credentials"
 	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
 	"github.com/porter-dev/porter/api/server/handlers/healthcheck"
+	"github.com/porter-dev/porter/api/server/handlers/image_push"
 	"github.com/porter-dev/porter/api/server/handlers/metadata"
 	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/handlers/user"
@@ -182,6 +183,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/users",
 			},
+			Request: &types.CreateUserRequest{},
 		},
 	)
 
@@ -206,6 +208,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/login",
 			},
+			Request: &types.LoginUserRequest{},
 		},
 	)
 
@@ -221,6 +224,31 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// POST /api/login/2fa -> user.NewUserLoginTwoFactorHandler
+	loginTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/login/2fa",
+			},
+			Request: &types.LoginTwoFactorRequest{},
+		},
+	)
+
+	loginTwoFactorHandler := user.NewUserLoginTwoFactorHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: loginTwoFactorEndpoint,
+		Handler:  loginTwoFactorHandler,
+		Router:   r,
+	})
+
 	// POST /api/cli/login/exchange -> user.NewCLILoginExchangeHandler
 	cliLoginExchangeEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -230,6 +258,8 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/cli/login/exchange",
 			},
+			Request:  &types.CLILoginExchangeRequest{},
+			Response: &types.CLILoginExchangeResponse{},
 		},
 	)
 
@@ -254,6 +284,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/password/reset/initiate",
 			},
+			Request: &types.InitiateResetUserPasswordRequest{},
 		},
 	)
 
@@ -278,6 +309,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/password/reset/verify",
 			},
+			Request: &types.VerifyResetUserPasswordRequest{},
 		},
 	)
 
@@ -302,6 +334,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/password/reset/finalize",
 			},
+			Request: &types.FinalizeResetUserPasswordRequest{},
 		},
 	)
 
@@ -326,7 +359,8 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/webhooks/deploy/{token}",
 			},
-			Scopes: []types.PermissionScope{},
+			Scopes:  []types.PermissionScope{},
+			Request: &types.WebhookRequest{},
 		},
 	)
 
@@ -342,6 +376,31 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// POST /api/webhooks/image-push/{token}/{source} -> image_push.NewReceiveWebhookHandler
+	imagePushWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("/webhooks/image-push/{%s}/{%s}", types.URLParamToken, types.URLParamImagePushSource),
+			},
+			Scopes:   []types.PermissionScope{},
+			Response: &types.ImagePushWebhookResult{},
+		},
+	)
+
+	imagePushWebhookHandler := image_push.NewReceiveWebhookHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: imagePushWebhookEndpoint,
+		Handler:  imagePushWebhookHandler,
+		Router:   r,
+	})
+
 	//  GET /api/integrations/github-app/install
 	githubAppInstallEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -490,6 +549,56 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/oauth/login/oidc
+	oidcLoginStartEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/login/oidc",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginStartHandler := user.NewUserOAuthOIDCHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginStartEndpoint,
+		Handler:  oidcLoginStartHandler,
+		Router:   r,
+	})
+
+	// GET /api/oauth/oidc/callback
+	oidcLoginCallbackEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/oidc/callback",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginCallbackHandler := user.NewUserOAuthOIDCCallbackHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginCallbackEndpoint,
+		Handler:  oidcLoginCallbackHandler,
+		Router:   r,
+	})
+
 	// GET /api/internal/credentials
 	getCredentialsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -8,6 +8,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/database"
 	"github.com/porter-dev/porter/api/server/handlers/environment"
 	"github.com/porter-dev/porter/api/server/handlers/environment_groups"
+	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/router"
@@ -306,6 +307,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Response: &types.ListEnvironmentsResponse{},
 			},
 		)
 
@@ -364,6 +366,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request: &types.ToggleNewCommentRequest{},
 			},
 		)
 
@@ -394,6 +397,8 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request:  &types.ValidatePorterYAMLRequest{},
+				Response: &types.ValidatePorterYAMLResponse{},
 			},
 		)
 
@@ -424,6 +429,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request: &types.CreateDeploymentRequest{},
 			},
 		)
 
@@ -454,6 +460,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request: &types.ListDeploymentRequest{},
 			},
 		)
 
@@ -484,6 +491,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request: &types.UpdateDeploymentByClusterRequest{},
 			},
 		)
 
@@ -514,6 +522,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request: &types.UpdateDeploymentStatusByClusterRequest{},
 			},
 		)
 
@@ -696,6 +705,7 @@ func getClusterRoutes(
 					types.ClusterScope,
 					types.PreviewEnvironmentScope,
 				},
+				Request: &types.PullRequest{},
 			},
 		)
 
@@ -725,6 +735,7 @@ func getClusterRoutes(
 					types.ProjectScope,
 					types.ClusterScope,
 				},
+				Request: &types.UpdateClusterRequest{},
 			},
 		)
 
@@ -740,6 +751,37 @@ func getClusterRoutes(
 			Router:   r,
 		})
 
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/helm_storage -> cluster.NewUpdateHelmStorageHandler
+		updateHelmStorageEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbUpdate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: relPath + "/helm_storage",
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+				},
+				Request:  &types.UpdateClusterHelmStorageRequest{},
+				Response: &types.UpdateClusterHelmStorageResponse{},
+			},
+		)
+
+		updateHelmStorageHandler := cluster.NewUpdateHelmStorageHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: updateHelmStorageEndpoint,
+			Handler:  updateHelmStorageHandler,
+			Router:   r,
+		})
+
 		// DELETE /api/projects/{project_id}/clusters/{cluster_id}/deployments/{deployment_id} ->
 		// environment.NewDeleteDeploymentHandler
 		deleteDeploymentEndpoint := factory.NewAPIEndpoint(
@@ -901,6 +943,8 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &types.CreateNamespaceRequest{},
+			Response: &types.NamespaceResponse{},
 		},
 	)
 
@@ -959,6 +1003,7 @@ func getClusterRoutes(
 					types.ProjectScope,
 					types.ClusterScope,
 				},
+				Response: &types.GetTemporaryKubeconfigResponse{},
 			},
 		)
 
@@ -1078,6 +1123,32 @@ func getClusterRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/health -> cluster.NewGetClusterHealthHandler
+	getClusterHealthEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/health",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Response: &types.GetClusterHealthResponse{},
+		},
+	)
+
+	getClusterHealthHandler := cluster.NewGetClusterHealthHandler(config, factory.GetResultWriter())
+
+	routes = append(routes, &router.Route{
+		Endpoint: getClusterHealthEndpoint,
+		Handler:  getClusterHealthHandler,
+		Router:   r,
+	})
+
 	// POST /api/projects/{project_id}/clusters/{cluster_id}/agent/upgrade -> cluster.NewInstallAgentHandler
 	upgradeAgentEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -1149,6 +1220,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.GetPodMetricsRequest{},
 		},
 	)
 
@@ -1179,6 +1251,7 @@ func getClusterRoutes(
 				types.ClusterScope,
 			},
 			IsWebsocket: true,
+			Request:     &types.StreamHelmReleaseRequest{},
 		},
 	)
 
@@ -1208,6 +1281,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &cluster.ClusterStatusResponse{},
 		},
 	)
 
@@ -1242,6 +1316,7 @@ func getClusterRoutes(
 				types.ClusterScope,
 			},
 			IsWebsocket: true,
+			Request:     &types.StreamStatusRequest{},
 		},
 	)
 
@@ -1271,6 +1346,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.GetPodsRequest{},
 		},
 	)
 
@@ -1300,6 +1376,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.ListIncidentsRequest{},
 		},
 	)
 
@@ -1358,6 +1435,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.ListIncidentEventsRequest{},
 		},
 	)
 
@@ -1387,6 +1465,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.GetLogRequest{},
 		},
 	)
 
@@ -1416,6 +1495,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.GetPodValuesRequest{},
 		},
 	)
 
@@ -1445,6 +1525,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.GetPodValuesRequest{},
 		},
 	)
 
@@ -1503,6 +1584,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.ListJobEventsRequest{},
 		},
 	)
 
@@ -1561,6 +1643,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.Incident{},
 		},
 	)
 
@@ -1590,6 +1673,7 @@ func getClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.Incident{},
 		},
 	)
 
@@ -1692,5 +1776,219 @@ func getClusterRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/environment-groups/{name}/versions -> environment_groups.NewListEnvironmentGroupVersionsHandler
+	listEnvironmentGroupVersionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/environment-groups/{%s}/versions", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Response: &environment_groups.ListEnvironmentGroupVersionsResponse{},
+		},
+	)
+
+	listEnvironmentGroupVersionsHandler := environment_groups.NewListEnvironmentGroupVersionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listEnvironmentGroupVersionsEndpoint,
+		Handler:  listEnvironmentGroupVersionsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/environment-groups/{name}/diff -> environment_groups.NewDiffEnvironmentGroupVersionsHandler
+	diffEnvironmentGroupVersionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/environment-groups/{%s}/diff", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request: &environment_groups.DiffEnvironmentGroupVersionsRequest{},
+		},
+	)
+
+	diffEnvironmentGroupVersionsHandler := environment_groups.NewDiffEnvironmentGroupVersionsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: diffEnvironmentGroupVersionsEndpoint,
+		Handler:  diffEnvironmentGroupVersionsHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/environment-groups/{name}/export -> environment_groups.NewExportEnvironmentGroupHandler
+	exportEnvironmentGroupEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/environment-groups/{%s}/export", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &environment_groups.ExportEnvironmentGroupRequest{},
+			Response: &environment_groups.ExportEnvironmentGroupResponse{},
+		},
+	)
+
+	exportEnvironmentGroupHandler := environment_groups.NewExportEnvironmentGroupHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: exportEnvironmentGroupEndpoint,
+		Handler:  exportEnvironmentGroupHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/clusters/{cluster_id}/environment-groups/{name}/rollback -> environment_groups.NewRollbackEnvironmentGroupHandler
+	rollbackEnvironmentGroupEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/environment-groups/{%s}/rollback", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &environment_groups.RollbackEnvironmentGroupRequest{},
+			Response: &environment_groups.RollbackEnvironmentGroupResponse{},
+		},
+	)
+
+	rollbackEnvironmentGroupHandler := environment_groups.NewRollbackEnvironmentGroupHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: rollbackEnvironmentGroupEndpoint,
+		Handler:  rollbackEnvironmentGroupHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/recommender/trend -> cluster.NewGetRecommenderTrendHandler
+	getRecommenderTrendEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/recommender/trend",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &types.GetRecommenderTrendRequest{},
+			Response: &types.GetRecommenderTrendResponse{},
+		},
+	)
+
+	getRecommenderTrendHandler := cluster.NewGetRecommenderTrendHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getRecommenderTrendEndpoint,
+		Handler:  getRecommenderTrendHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/recommender/history -> cluster.NewListRecommenderCheckHistoryHandler
+	listRecommenderCheckHistoryEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/recommender/history",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &types.ListMonitorTestRunResultsRequest{},
+			Response: &types.ListMonitorTestRunResultsResponse{},
+		},
+	)
+
+	listRecommenderCheckHistoryHandler := cluster.NewListRecommenderCheckHistoryHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listRecommenderCheckHistoryEndpoint,
+		Handler:  listRecommenderCheckHistoryHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/clusters/{cluster_id}/releases/bulk -> release.NewBulkOperationHandler
+	bulkReleaseOperationEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/releases/bulk",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &types.BulkReleaseOperationRequest{},
+			Response: &types.BulkReleaseOperationResponse{},
+		},
+	)
+
+	bulkReleaseOperationHandler := release.NewBulkOperationHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: bulkReleaseOperationEndpoint,
+		Handler:  bulkReleaseOperationHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
@@ -67,6 +67,7 @@ func getClusterIntegrationRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &types.GetAWSClusterInfoResponse{},
 		},
 	)
 
@@ -182,6 +182,7 @@ func getHelmRepoRoutes(
 				types.ProjectScope,
 				types.HelmRepoScope,
 			},
+			Response: &types.GetTemplateResponse{},
 		},
 	)
 
@@ -0,0 +1,283 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/server/handlers/image_push"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/router"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// NewImagePushScopedRegisterer registers the project-scoped image push routes
+func NewImagePushScopedRegisterer(children ...*router.Registerer) *router.Registerer {
+	return &router.Registerer{
+		GetRoutes: GetImagePushScopedRoutes,
+		Children:  children,
+	}
+}
+
+// GetImagePushScopedRoutes returns the project-scoped image push routes
+func GetImagePushScopedRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+	children ...*router.Registerer,
+) []*router.Route {
+	routes, projPath := getImagePushRoutes(r, config, basePath, factory)
+
+	if len(children) > 0 {
+		r.Route(projPath.RelativePath, func(r chi.Router) {
+			for _, child := range children {
+				childRoutes := child.GetRoutes(r, config, basePath, factory, child.Children...)
+
+				routes = append(routes, childRoutes...)
+			}
+		})
+	}
+
+	return routes
+}
+
+func getImagePushRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+) ([]*router.Route, *types.Path) {
+	relPath := "/image_push"
+
+	newPath := &types.Path{
+		Parent:       basePath,
+		RelativePath: relPath,
+	}
+
+	routes := make([]*router.Route, 0)
+
+	// GET /api/projects/{project_id}/image_push/webhook -> image_push.NewGetWebhookHandler
+	getWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/webhook",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Response: &types.ImagePushWebhookResponse{},
+		},
+	)
+
+	getWebhookHandler := image_push.NewGetWebhookHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getWebhookEndpoint,
+		Handler:  getWebhookHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/image_push/webhook/rotate -> image_push.NewRotateWebhookHandler
+	rotateWebhookEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/webhook/rotate",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Response: &types.ImagePushWebhookResponse{},
+		},
+	)
+
+	rotateWebhookHandler := image_push.NewRotateWebhookHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: rotateWebhookEndpoint,
+		Handler:  rotateWebhookHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/image_push/events -> image_push.NewListEventsHandler
+	listEventsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/events",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+			Request:  &types.ListImagePushEventsRequest{},
+			Response: &types.ListImagePushEventsResponse{},
+		},
+	)
+
+	listEventsHandler := image_push.NewListEventsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listEventsEndpoint,
+		Handler:  listEventsHandler,
+		Router:   r,
+	})
+
+	return routes, newPath
+}
+
+// NewImagePushPolicyScopedRegisterer registers the cluster-scoped image push policy routes
+func NewImagePushPolicyScopedRegisterer(children ...*router.Registerer) *router.Registerer {
+	return &router.Registerer{
+		GetRoutes: GetImagePushPolicyScopedRoutes,
+		Children:  children,
+	}
+}
+
+// GetImagePushPolicyScopedRoutes returns the cluster-scoped image push policy routes
+func GetImagePushPolicyScopedRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+	children ...*router.Registerer,
+) []*router.Route {
+	routes, policyPath := getImagePushPolicyRoutes(r, config, basePath, factory)
+
+	if len(children) > 0 {
+		r.Route(policyPath.RelativePath, func(r chi.Router) {
+			for _, child := range children {
+				childRoutes := child.GetRoutes(r, config, basePath, factory, child.Children...)
+
+				routes = append(routes, childRoutes...)
+			}
+		})
+	}
+
+	return routes
+}
+
+func getImagePushPolicyRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+) ([]*router.Route, *types.Path) {
+	relPath := "/image_push/policies"
+
+	newPath := &types.Path{
+		Parent:       basePath,
+		RelativePath: relPath,
+	}
+
+	routes := make([]*router.Route, 0)
+
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/image_push/policies -> image_push.NewListPoliciesHandler
+	listPoliciesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Response: &types.ListImagePushPoliciesResponse{},
+		},
+	)
+
+	listPoliciesHandler := image_push.NewListPoliciesHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listPoliciesEndpoint,
+		Handler:  listPoliciesHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/clusters/{cluster_id}/image_push/policies -> image_push.NewCreatePolicyHandler
+	createPolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &types.CreateImagePushPolicyRequest{},
+			Response: &types.ImagePushPolicy{},
+		},
+	)
+
+	createPolicyHandler := image_push.NewCreatePolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createPolicyEndpoint,
+		Handler:  createPolicyHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/clusters/{cluster_id}/image_push/policies/{image_push_policy_id} -> image_push.NewDeletePolicyHandler
+	deletePolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}", relPath, types.URLParamImagePushPolicyID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+		},
+	)
+
+	deletePolicyHandler := image_push.NewDeletePolicyHandler(config)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deletePolicyEndpoint,
+		Handler:  deletePolicyHandler,
+		Router:   r,
+	})
+
+	return routes, newPath
+}
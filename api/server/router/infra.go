@@ -155,6 +155,7 @@ func getInfraRoutes(
 				types.ProjectScope,
 				types.InfraScope,
 			},
+			Request: &types.RetryInfraRequest{},
 		},
 	)
 
@@ -184,6 +185,7 @@ func getInfraRoutes(
 				types.ProjectScope,
 				types.InfraScope,
 			},
+			Request: &types.DeleteInfraRequest{},
 		},
 	)
 
@@ -387,6 +389,7 @@ func getInfraRoutes(
 				types.ProjectScope,
 				types.InfraScope,
 			},
+			Request: &types.DeleteInfraRequest{},
 		},
 	)
 
@@ -416,6 +419,7 @@ func getInfraRoutes(
 				types.ProjectScope,
 				types.InfraScope,
 			},
+			Request: &types.UpdateDatabaseStatusRequest{},
 		},
 	)
 
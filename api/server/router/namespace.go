@@ -101,6 +101,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CloneEnvGroupRequest{},
 		},
 	)
 
@@ -131,6 +132,8 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request:  &types.GetEnvGroupRequest{},
+			Response: &types.GetEnvGroupResponse{},
 		},
 	)
 
@@ -146,6 +149,38 @@ func getNamespaceRoutes(
 		Router:   r,
 	})
 
+	// GET /api/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/envgroup/export -> namespace.NewExportEnvGroupHandler
+	exportEnvGroupEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/envgroup/export",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+			},
+			Request:  &types.ExportEnvGroupRequest{},
+			Response: &types.ExportEnvGroupResponse{},
+		},
+	)
+
+	exportEnvGroupHandler := namespace.NewExportEnvGroupHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: exportEnvGroupEndpoint,
+		Handler:  exportEnvGroupHandler,
+		Router:   r,
+	})
+
 	// GET /api/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/envgroup/all_versions -> namespace.NewGetEnvGroupAllVersionsHandler
 	getEnvGroupAllVersionsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -161,6 +196,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.GetEnvGroupAllRequest{},
 		},
 	)
 
@@ -191,6 +227,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CreateEnvGroupRequest{},
 		},
 	)
 
@@ -221,6 +258,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CreateEnvGroupRequest{},
 		},
 	)
 
@@ -250,6 +288,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.AddEnvGroupApplicationRequest{},
 		},
 	)
 
@@ -280,6 +319,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.AddEnvGroupApplicationRequest{},
 		},
 	)
 
@@ -310,6 +350,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.DeleteEnvGroupRequest{},
 		},
 	)
 
@@ -340,6 +381,7 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.UpdateConfigMapRequest{},
 		},
 	)
 
@@ -369,6 +411,7 @@ func getNamespaceRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.DeleteCRDRequest{},
 		},
 	)
 
@@ -399,6 +442,8 @@ func getNamespaceRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request:  &types.ListReleasesRequest{},
+			Response: &types.ListReleasesResponse{},
 		},
 	)
 
@@ -434,6 +479,7 @@ func getNamespaceRoutes(
 				types.NamespaceScope,
 			},
 			IsWebsocket: true,
+			Request:     &types.GetPodLogsRequest{},
 		},
 	)
 
@@ -468,6 +514,7 @@ func getNamespaceRoutes(
 				types.NamespaceScope,
 			},
 			IsWebsocket: true,
+			Request:     &types.GetLogRequest{},
 		},
 	)
 
@@ -502,6 +549,7 @@ func getNamespaceRoutes(
 				types.NamespaceScope,
 			},
 			IsWebsocket: true,
+			Request:     &types.StreamJobRunsRequest{},
 		},
 	)
 
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/server/openapi"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/router"
+)
+
+// newOpenAPIDocument generates the OpenAPI document for all routes registered on the API router. Since the document is
+// generated from the same route registrations that serve requests, it always matches the running server
+func newOpenAPIDocument(config *config.Config, r chi.Routes, routes []*router.Route) (*openapi.Document, error) {
+	endpoints := make([]openapi.Endpoint, 0, len(routes))
+	for _, route := range routes {
+		endpoints = append(endpoints, openapi.Endpoint{
+			Handler:  route.Handler,
+			Metadata: route.Endpoint.Metadata,
+		})
+	}
+
+	version := "dev"
+	if config.Metadata != nil && config.Metadata.Version != "" {
+		version = config.Metadata.Version
+	}
+
+	return openapi.NewDocument(openapi.NewDocumentInput{
+		Info: openapi.Info{
+			Title:   "Porter API",
+			Version: version,
+		},
+		Router:     r,
+		Endpoints:  endpoints,
+		CookieName: config.ServerConf.CookieName,
+	})
+}
@@ -0,0 +1,75 @@
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/server/openapi"
+	"github.com/porter-dev/porter/api/server/router"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestOpenAPIDocumentCoversAllRoutes checks that every route registered under /api is described by the served
+// OpenAPI document, and that the document describes no route which is not registered
+func TestOpenAPIDocumentCoversAllRoutes(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	r := router.NewAPIRouter(config)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	doc := &openapi.Document{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+
+	registered := make(map[string]bool)
+	err := chi.Walk(r, func(method string, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		if !strings.HasPrefix(route, "/api/") || route == "/api/openapi.json" {
+			return nil
+		}
+
+		path := route
+		if strings.HasSuffix(path, "/*") {
+			path = strings.TrimSuffix(path, "*") + "{wildcard}"
+		}
+		registered[strings.ToLower(method)+" "+path] = true
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	documented := make(map[string]bool)
+	operationIDs := make(map[string]string)
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			key := method + " " + path
+			documented[key] = true
+
+			if other, ok := operationIDs[op.OperationID]; ok {
+				t.Errorf("operation id %s is used by both %s and %s", op.OperationID, other, key)
+			}
+			operationIDs[op.OperationID] = key
+
+			for _, param := range op.Parameters {
+				if param.In == "path" {
+					assert.Contains(t, path, "{"+param.Name+"}", "path parameter %s of %s", param.Name, key)
+				}
+			}
+		}
+	}
+
+	for key := range registered {
+		assert.True(t, documented[key], "route %s is registered but not in the OpenAPI document", key)
+	}
+	for key := range documented {
+		assert.True(t, registered[key], "route %s is in the OpenAPI document but not registered", key)
+	}
+}
@@ -238,6 +238,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.CreatePorterAppRequest{},
 		},
 	)
 
@@ -267,6 +268,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.RollbackPorterAppRequest{},
 		},
 	)
 
@@ -296,6 +298,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &types.CreateSecretAndOpenGHPRRequest{},
+			Response: &types.CreateSecretAndOpenGHPRResponse{},
 		},
 	)
 
@@ -410,6 +414,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.PorterAppAnalyticsRequest{},
 		},
 	)
 
@@ -468,6 +473,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.RunPorterAppCommandRequest{},
 		},
 	)
 
@@ -527,6 +533,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request: &types.CreatePorterAppRequest{},
 		},
 	)
 
@@ -585,6 +592,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.ParsePorterYAMLToProtoRequest{},
+			Response: &porter_app.ParsePorterYAMLToProtoResponse{},
 		},
 	)
 
@@ -614,6 +623,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.ValidatePorterAppRequest{},
+			Response: &porter_app.ValidatePorterAppResponse{},
 		},
 	)
 
@@ -643,6 +654,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.CreateAppRequest{},
+			Response: &types.PorterApp{},
 		},
 	)
 
@@ -672,6 +685,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.ApplyPorterAppRequest{},
+			Response: &porter_app.ApplyPorterAppResponse{},
 		},
 	)
 
@@ -701,6 +716,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &porter_app.DefaultDeploymentTargetResponse{},
 		},
 	)
 
@@ -774,6 +790,37 @@ func getPorterAppRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/clusters/{cluster_id}/apps/{porter_app_name}/diff -> porter_app.NewDiffAppRevisionsHandler
+	diffAppRevisionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("/apps/{%s}/diff", types.URLParamPorterAppName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+			Request:  &porter_app.DiffAppRevisionsRequest{},
+			Response: &porter_app.DiffAppRevisionsResponse{},
+		},
+	)
+
+	diffAppRevisionsHandler := porter_app.NewDiffAppRevisionsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: diffAppRevisionsEndpoint,
+		Handler:  diffAppRevisionsHandler,
+		Router:   r,
+	})
+
 	// GET /api/projects/{project_id}/clusters/{cluster_id}/apps/revisions -> porter_app.NewCurrentAppRevisionHandler
 	latestAppRevisionsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -817,6 +864,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.CreateSubdomainRequest{},
+			Response: &porter_app.CreateSubdomainResponse{},
 		},
 	)
 
@@ -846,6 +895,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &porter_app.PredeployStatusResponse{},
 		},
 	)
 
@@ -963,6 +1013,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.UpdateAppRevisionStatusRequest{},
+			Response: &porter_app.UpdateAppRevisionStatusResponse{},
 		},
 	)
 
@@ -992,6 +1044,7 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &porter_app.GetBuildEnvResponse{},
 		},
 	)
 
@@ -1021,6 +1074,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.UpdateAppEnvironmentRequest{},
+			Response: &porter_app.UpdateAppEnvironmentResponse{},
 		},
 	)
 
@@ -1050,6 +1105,8 @@ func getPorterAppRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &porter_app.GetAppEnvRequest{},
+			Response: &porter_app.GetAppEnvResponse{},
 		},
 	)
 
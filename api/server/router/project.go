@@ -242,6 +242,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.ProjectInviteAdminRequest{},
 		},
 	)
 
@@ -270,6 +271,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Response: &types.GetProjectUsageResponse{},
 		},
 	)
 
@@ -324,6 +326,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Response: &types.GetProjectBillingResponse{},
 		},
 	)
 
@@ -483,6 +486,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.UpdateRoleRequest{},
+			Response: &types.UpdateRoleResponse{},
 		},
 	)
 
@@ -511,6 +516,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.DeleteRoleRequest{},
+			Response: &types.DeleteRoleResponse{},
 		},
 	)
 
@@ -566,6 +573,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.CreateRegistryRequest{},
 		},
 	)
 
@@ -594,6 +602,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.GetRegistryECRTokenRequest{},
+			Response: &types.GetRegistryTokenResponse{},
 		},
 	)
 
@@ -622,6 +632,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.GetRegistryDOCRTokenRequest{},
+			Response: &types.GetRegistryTokenResponse{},
 		},
 	)
 
@@ -650,6 +662,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.GetRegistryGCRTokenRequest{},
+			Response: &types.GetRegistryTokenResponse{},
 		},
 	)
 
@@ -678,6 +692,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.GetRegistryGCRTokenRequest{},
+			Response: &types.GetRegistryTokenResponse{},
 		},
 	)
 
@@ -706,6 +722,8 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.GetRegistryACRTokenRequest{},
+			Response: &types.GetRegistryTokenResponse{},
 		},
 	)
 
@@ -734,6 +752,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Response: &types.GetRegistryTokenResponse{},
 		},
 	)
 
@@ -762,6 +781,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.CreateInfraRequest{},
 		},
 	)
 
@@ -1019,6 +1039,7 @@ func getProjectRoutes(
 				types.ProjectScope,
 				types.SettingsScope,
 			},
+			Request: &types.CreatePolicy{},
 		},
 	)
 
@@ -1092,6 +1113,34 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	//  DELETE /api/projects/{project_id}/policy/{policy_id} -> policy.NewPolicyDeleteHandler
+	policyDeleteEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/policy/{%s}", relPath, types.URLParamPolicyID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	policyDeleteHandler := policy.NewPolicyDeleteHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: policyDeleteEndpoint,
+		Handler:  policyDeleteHandler,
+		Router:   r,
+	})
+
 	//  POST /api/projects/{project_id}/api_token -> api_token.NewAPITokenCreateHandler
 	apiTokenCreateEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -1106,6 +1155,7 @@ func getProjectRoutes(
 				types.ProjectScope,
 				types.SettingsScope,
 			},
+			Request: &types.CreateAPIToken{},
 		},
 	)
 
@@ -1208,6 +1258,36 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/api_token/{api_token_id}/rotate -> api_token.NewAPITokenRotateHandler
+	apiTokenRotateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/api_token/{%s}/rotate", relPath, types.URLParamTokenID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request: &types.RotateAPITokenRequest{},
+		},
+	)
+
+	apiTokenRotateHandler := api_token.NewAPITokenRotateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: apiTokenRotateEndpoint,
+		Handler:  apiTokenRotateHandler,
+		Router:   r,
+	})
+
 	//  POST /api/projects/{project_id}/helmrepos -> helmrepo.NewHelmRepoCreateHandler
 	hrCreateEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -1221,6 +1301,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.CreateUpdateHelmRepoRequest{},
 		},
 	)
 
@@ -1303,6 +1384,7 @@ func getProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.CreateTagRequest{},
 		},
 	)
 
@@ -1429,5 +1511,157 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/settings/2fa -> project.NewUpdateTwoFactorHandler
+	updateTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/settings/2fa",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request: &types.UpdateProjectTwoFactorRequest{},
+		},
+	)
+
+	updateTwoFactorHandler := project.NewUpdateTwoFactorHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateTwoFactorEndpoint,
+		Handler:  updateTwoFactorHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/settings/quiet-hours -> project.NewUpdateQuietHoursHandler
+	updateQuietHoursEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/settings/quiet-hours",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request:  &types.UpdateProjectQuietHoursRequest{},
+			Response: &types.Project{},
+		},
+	)
+
+	updateQuietHoursHandler := project.NewUpdateQuietHoursHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateQuietHoursEndpoint,
+		Handler:  updateQuietHoursHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/export -> project.NewExportProjectHandler
+	exportEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/export",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request:  &types.ExportProjectRequest{},
+			Response: &types.ExportProjectResponse{},
+		},
+	)
+
+	exportHandler := project.NewExportProjectHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: exportEndpoint,
+		Handler:  exportHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/import -> project.NewImportProjectHandler
+	importEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/import",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request:  &types.ImportProjectRequest{},
+			Response: &types.ImportProjectResponse{},
+		},
+	)
+
+	importHandler := project.NewImportProjectHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: importEndpoint,
+		Handler:  importHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/roles/sessions -> project.NewRevokeCollaboratorSessionsHandler
+	revokeCollaboratorSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/roles/sessions",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request: &types.RevokeCollaboratorSessionsRequest{},
+		},
+	)
+
+	revokeCollaboratorSessionsHandler := project.NewRevokeCollaboratorSessionsHandler(
+		config,
+		factory.GetDecoderValidator(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: revokeCollaboratorSessionsEndpoint,
+		Handler:  revokeCollaboratorSessionsHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
@@ -122,6 +122,8 @@ func getProjectIntegrationRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.CreateBasicRequest{},
+			Response: &types.CreateBasicResponse{},
 		},
 	)
 
@@ -150,6 +152,8 @@ func getProjectIntegrationRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.CreateAWSRequest{},
+			Response: &types.CreateAWSResponse{},
 		},
 	)
 
@@ -205,6 +209,8 @@ func getProjectIntegrationRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.OverwriteAWSRequest{},
+			Response: &types.OverwriteAWSResponse{},
 		},
 	)
 
@@ -316,6 +322,8 @@ func getProjectIntegrationRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.CreateGCPRequest{},
+			Response: &types.CreateGCPResponse{},
 		},
 	)
 
@@ -371,6 +379,8 @@ func getProjectIntegrationRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.CreateAzureRequest{},
+			Response: &types.CreateAzureResponse{},
 		},
 	)
 
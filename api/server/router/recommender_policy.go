@@ -0,0 +1,173 @@
+package router
+
+import (
+	"fmt"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/porter-dev/porter/api/server/handlers/recommender_policy"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/router"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// NewRecommenderPolicyScopedRegisterer registers the project-scoped recommender policy routes
+func NewRecommenderPolicyScopedRegisterer(children ...*router.Registerer) *router.Registerer {
+	return &router.Registerer{
+		GetRoutes: GetRecommenderPolicyScopedRoutes,
+		Children:  children,
+	}
+}
+
+// GetRecommenderPolicyScopedRoutes returns the project-scoped recommender policy routes
+func GetRecommenderPolicyScopedRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+	children ...*router.Registerer,
+) []*router.Route {
+	routes, policyPath := getRecommenderPolicyRoutes(r, config, basePath, factory)
+
+	if len(children) > 0 {
+		r.Route(policyPath.RelativePath, func(r chi.Router) {
+			for _, child := range children {
+				childRoutes := child.GetRoutes(r, config, basePath, factory, child.Children...)
+
+				routes = append(routes, childRoutes...)
+			}
+		})
+	}
+
+	return routes
+}
+
+func getRecommenderPolicyRoutes(
+	r chi.Router,
+	config *config.Config,
+	basePath *types.Path,
+	factory shared.APIEndpointFactory,
+) ([]*router.Route, *types.Path) {
+	relPath := "/recommender/policies"
+
+	newPath := &types.Path{
+		Parent:       basePath,
+		RelativePath: relPath,
+	}
+
+	routes := make([]*router.Route, 0)
+
+	// GET /api/projects/{project_id}/recommender/policies -> recommender_policy.NewListCollectionsHandler
+	listCollectionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+			Response: &types.ListRecommenderPolicyCollectionsResponse{},
+		},
+	)
+
+	listCollectionsHandler := recommender_policy.NewListCollectionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listCollectionsEndpoint,
+		Handler:  listCollectionsHandler,
+		Router:   r,
+	})
+
+	// POST /api/projects/{project_id}/recommender/policies -> recommender_policy.NewCreateCollectionHandler
+	createCollectionEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath,
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+			Request:  &types.CreateRecommenderPolicyCollectionRequest{},
+			Response: &types.RecommenderPolicyCollection{},
+		},
+	)
+
+	createCollectionHandler := recommender_policy.NewCreateCollectionHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createCollectionEndpoint,
+		Handler:  createCollectionHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/projects/{project_id}/recommender/policies/{recommender_policy_collection_id} -> recommender_policy.NewDeleteCollectionHandler
+	deleteCollectionEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}", relPath, types.URLParamRecommenderPolicyCollectionID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	deleteCollectionHandler := recommender_policy.NewDeleteCollectionHandler(config)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteCollectionEndpoint,
+		Handler:  deleteCollectionHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/recommender/policies/overrides -> recommender_policy.NewListOverrideEventsHandler
+	listOverrideEventsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/overrides",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+			},
+			Response: &types.ListPolicyOverrideEventsResponse{},
+		},
+	)
+
+	listOverrideEventsHandler := recommender_policy.NewListOverrideEventsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listOverrideEventsEndpoint,
+		Handler:  listOverrideEventsHandler,
+		Router:   r,
+	})
+
+	return routes, newPath
+}
@@ -97,6 +97,7 @@ func getRegistryRoutes(
 				types.ProjectScope,
 				types.RegistryScope,
 			},
+			Request: &types.UpdateRegistryRequest{},
 		},
 	)
 
@@ -214,6 +215,7 @@ func getRegistryRoutes(
 				types.ProjectScope,
 				types.RegistryScope,
 			},
+			Request: &types.CreateRegistryRepositoryRequest{},
 		},
 	)
 
@@ -69,6 +69,7 @@ func getReleaseRoutes(
 				types.NamespaceScope,
 				types.ReleaseScope,
 			},
+			Response: &types.Release{},
 		},
 	)
 
@@ -100,6 +101,7 @@ func getReleaseRoutes(
 				types.ReleaseScope,
 			},
 			IsWebsocket: true,
+			Request:     &types.StreamCRDRequest{},
 		},
 	)
 
@@ -250,6 +252,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.UpdateNotificationConfigRequest{},
 		},
 	)
 
@@ -280,6 +283,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Response: &types.GetNotificationConfigResponse{},
 		},
 	)
 
@@ -309,6 +313,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.UpdateBuildConfigRequest{},
 		},
 	)
 
@@ -428,6 +433,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.UpdateReleaseStepsRequest{},
 		},
 	)
 
@@ -458,6 +464,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CreateReleaseRequest{},
 		},
 	)
 
@@ -488,6 +495,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CreateAddonRequest{},
 		},
 	)
 
@@ -518,6 +526,7 @@ func getReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.GetGHATemplateRequest{},
 		},
 	)
 
@@ -799,6 +808,7 @@ func getReleaseRoutes(
 				types.NamespaceScope,
 				types.ReleaseScope,
 			},
+			Request: &types.PatchUpdateReleaseTags{},
 		},
 	)
 
@@ -830,6 +840,7 @@ func getReleaseRoutes(
 				types.NamespaceScope,
 				types.ReleaseScope,
 			},
+			Request: &types.UpdateCanonicalNameRequest{},
 		},
 	)
 
@@ -861,6 +872,7 @@ func getReleaseRoutes(
 				types.NamespaceScope,
 				types.ReleaseScope,
 			},
+			Request: &types.UpdateGitActionConfigRequest{},
 		},
 	)
 
@@ -11,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers/metadata"
 	"github.com/porter-dev/porter/api/server/router/middleware"
 	v1 "github.com/porter-dev/porter/api/server/router/v1"
 	"github.com/porter-dev/porter/api/server/shared"
@@ -32,7 +33,9 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	namespaceRegisterer := NewNamespaceScopedRegisterer(releaseRegisterer)
 	clusterIntegrationRegisterer := NewClusterIntegrationScopedRegisterer()
 	stackRegisterer := NewPorterAppScopedRegisterer()
-	clusterRegisterer := NewClusterScopedRegisterer(namespaceRegisterer, clusterIntegrationRegisterer, stackRegisterer)
+	imagePushPolicyRegisterer := NewImagePushPolicyScopedRegisterer()
+	appSleepRegisterer := NewAppSleepScopedRegisterer()
+	clusterRegisterer := NewClusterScopedRegisterer(namespaceRegisterer, clusterIntegrationRegisterer, stackRegisterer, imagePushPolicyRegisterer, appSleepRegisterer)
 	infraRegisterer := NewInfraScopedRegisterer()
 	gitInstallationRegisterer := NewGitInstallationScopedRegisterer()
 	registryRegisterer := NewRegistryScopedRegisterer()
@@ -41,6 +44,11 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	projectIntegrationRegisterer := NewProjectIntegrationScopedRegisterer()
 	projectOAuthRegisterer := NewProjectOAuthScopedRegisterer()
 	slackIntegrationRegisterer := NewSlackIntegrationScopedRegisterer()
+	webhookIntegrationRegisterer := NewWebhookIntegrationScopedRegisterer()
+	pagingIntegrationRegisterer := NewPagingIntegrationScopedRegisterer()
+	deploymentTargetRegisterer := NewDeploymentTargetScopedRegisterer()
+	imagePushRegisterer := NewImagePushScopedRegisterer()
+	recommenderPolicyRegisterer := NewRecommenderPolicyScopedRegisterer()
 	projRegisterer := NewProjectScopedRegisterer(
 		clusterRegisterer,
 		registryRegisterer,
@@ -51,6 +59,11 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		projectIntegrationRegisterer,
 		projectOAuthRegisterer,
 		slackIntegrationRegisterer,
+		webhookIntegrationRegisterer,
+		pagingIntegrationRegisterer,
+		deploymentTargetRegisterer,
+		imagePushRegisterer,
+		recommenderPolicyRegisterer,
 	)
 	statusRegisterer := NewStatusScopedRegisterer()
 
@@ -61,7 +74,10 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		r.Mount("/debug", chiMiddleware.Profiler())
 	}
 
-	r.Route("/api", func(r chi.Router) {
+	// documentedRoutes are all routes described by the OpenAPI document served at /api/openapi.json
+	var documentedRoutes []*router.Route
+
+	apiRouter := r.Route("/api", func(r chi.Router) {
 		r.Use(
 			otelchi.Middleware("porter-server-middleware", otelchi.WithRequestMethodInSpanName(true), otelchi.WithChiRoutes(r), otelchi.WithFilter(func(r *http.Request) bool {
 				if strings.HasSuffix(r.URL.Path, "/livez") || strings.HasSuffix(r.URL.Path, "/readyz") {
@@ -113,6 +129,8 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		}
 
 		registerRoutes(config, allRoutes)
+
+		documentedRoutes = append(documentedRoutes, allRoutes...)
 	})
 
 	r.Route("/api/v1", func(r chi.Router) {
@@ -157,8 +175,23 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		allRoutes = append(allRoutes, v1Routes...)
 
 		registerRoutes(config, allRoutes)
+
+		documentedRoutes = append(documentedRoutes, allRoutes...)
 	})
 
+	// the document is generated once all routes are registered, since it needs the full path of each route
+	openAPIDocument, err := newOpenAPIDocument(config, r, documentedRoutes)
+	if err != nil {
+		config.Logger.Error().Err(err).Msg("error generating OpenAPI document")
+	} else {
+		// GET /api/openapi.json -> metadata.NewOpenAPIDocumentGetHandler
+		apiRouter.Method(
+			string(types.HTTPVerbGet),
+			"/openapi.json",
+			metadata.NewOpenAPIDocumentGetHandler(config, endpointFactory.GetResultWriter(), openAPIDocument),
+		)
+	}
+
 	staticFilePath := config.ServerConf.StaticFilePath
 	fs := http.FileServer(http.Dir(staticFilePath))
 
@@ -58,7 +58,8 @@ func getUserRoutes(
 				Parent:       basePath,
 				RelativePath: "/welcome",
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:  []types.PermissionScope{types.UserScope},
+			Request: &types.WelcomeWebhookRequest{},
 		},
 	)
 
@@ -194,6 +195,203 @@ func getUserRoutes(
 		Router:   r,
 	})
 
+	// GET /api/users/current/2fa -> user.NewGetTwoFactorStatusHandler
+	getTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa",
+			},
+			Scopes:   []types.PermissionScope{types.UserScope},
+			Response: &types.GetTwoFactorStatusResponse{},
+		},
+	)
+
+	getTwoFactorHandler := user.NewGetTwoFactorStatusHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getTwoFactorEndpoint,
+		Handler:  getTwoFactorHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/2fa/enroll -> user.NewEnrollTwoFactorHandler
+	enrollTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/enroll",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	enrollTwoFactorHandler := user.NewEnrollTwoFactorHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: enrollTwoFactorEndpoint,
+		Handler:  enrollTwoFactorHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/2fa/confirm -> user.NewConfirmTwoFactorHandler
+	confirmTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/confirm",
+			},
+			Scopes:  []types.PermissionScope{types.UserScope},
+			Request: &types.TwoFactorCodeRequest{},
+		},
+	)
+
+	confirmTwoFactorHandler := user.NewConfirmTwoFactorHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: confirmTwoFactorEndpoint,
+		Handler:  confirmTwoFactorHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/2fa/disable -> user.NewDisableTwoFactorHandler
+	disableTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/disable",
+			},
+			Scopes:  []types.PermissionScope{types.UserScope},
+			Request: &types.TwoFactorCodeRequest{},
+		},
+	)
+
+	disableTwoFactorHandler := user.NewDisableTwoFactorHandler(
+		config,
+		factory.GetDecoderValidator(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: disableTwoFactorEndpoint,
+		Handler:  disableTwoFactorHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/oidc/link -> user.NewUserOIDCLinkHandler
+	oidcLinkEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/oidc/link",
+			},
+			Scopes:   []types.PermissionScope{types.UserScope},
+			Response: &types.User{},
+		},
+	)
+
+	oidcLinkHandler := user.NewUserOIDCLinkHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLinkEndpoint,
+		Handler:  oidcLinkHandler,
+		Router:   r,
+	})
+
+	// GET /api/users/current/sessions -> user.NewListSessionsHandler
+	listSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/sessions",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	listSessionsHandler := user.NewListSessionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listSessionsEndpoint,
+		Handler:  listSessionsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/users/current/sessions -> user.NewRevokeAllSessionsHandler
+	revokeAllSessionsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/sessions",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	revokeAllSessionsHandler := user.NewRevokeAllSessionsHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: revokeAllSessionsEndpoint,
+		Handler:  revokeAllSessionsHandler,
+		Router:   r,
+	})
+
+	// DELETE /api/users/current/sessions/{session_id} -> user.NewRevokeSessionHandler
+	revokeSessionEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("/users/current/sessions/{%s}", types.URLParamSessionID),
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	revokeSessionHandler := user.NewRevokeSessionHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: revokeSessionEndpoint,
+		Handler:  revokeSessionHandler,
+		Router:   r,
+	})
+
 	// POST /api/projects -> project.NewProjectCreateHandler
 	createEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -203,7 +401,8 @@ func getUserRoutes(
 				Parent:       basePath,
 				RelativePath: "/projects",
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:  []types.PermissionScope{types.UserScope},
+			Request: &types.CreateProjectRequest{},
 		},
 	)
 
@@ -298,7 +497,8 @@ func getUserRoutes(
 				Parent:       basePath,
 				RelativePath: "/templates",
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:  []types.PermissionScope{types.UserScope},
+			Request: &types.ListTemplatesRequest{},
 		},
 	)
 
@@ -327,7 +527,9 @@ func getUserRoutes(
 					types.URLParamTemplateVersion,
 				),
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:   []types.PermissionScope{types.UserScope},
+			Request:  &types.GetTemplateRequest{},
+			Response: &types.GetTemplateResponse{},
 		},
 	)
 
@@ -356,7 +558,8 @@ func getUserRoutes(
 					types.URLParamTemplateVersion,
 				),
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:  []types.PermissionScope{types.UserScope},
+			Request: &types.GetTemplateUpgradeNotesRequest{},
 		},
 	)
 
@@ -431,7 +634,8 @@ func getUserRoutes(
 				Parent:       basePath,
 				RelativePath: "/integrations/github-app/accounts",
 			},
-			Scopes: []types.PermissionScope{types.UserScope},
+			Scopes:   []types.PermissionScope{types.UserScope},
+			Response: &types.GetGithubAppAccountsResponse{},
 		},
 	)
 
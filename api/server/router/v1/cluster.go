@@ -112,6 +112,8 @@ func getV1ClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Request:  &types.CreateNamespaceRequest{},
+			Response: &types.NamespaceResponse{},
 		},
 	)
 
@@ -165,6 +167,7 @@ func getV1ClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &types.NamespaceResponse{},
 		},
 	)
 
@@ -214,6 +217,7 @@ func getV1ClusterRoutes(
 				types.ProjectScope,
 				types.ClusterScope,
 			},
+			Response: &types.ListNamespacesResponse{},
 		},
 	)
 
@@ -125,6 +125,7 @@ func getV1ProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.ListTemplatesRequest{},
 		},
 	)
 
@@ -186,6 +187,8 @@ func getV1ProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request:  &types.GetTemplateRequest{},
+			Response: &types.GetTemplateResponse{},
 		},
 	)
 
@@ -251,6 +254,7 @@ func getV1ProjectRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.GetTemplateUpgradeNotesRequest{},
 		},
 	)
 
@@ -112,6 +112,7 @@ func getV1RegistryRoutes(
 				types.UserScope,
 				types.ProjectScope,
 			},
+			Request: &types.CreateRegistryRequest{},
 		},
 	)
 
@@ -308,6 +309,7 @@ func getV1RegistryRoutes(
 				types.ProjectScope,
 				types.RegistryScope,
 			},
+			Request: &types.CreateRegistryRepositoryRequest{},
 		},
 	)
 
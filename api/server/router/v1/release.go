@@ -140,6 +140,7 @@ func getV1ReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CreateReleaseRequest{},
 		},
 	)
 
@@ -196,6 +197,7 @@ func getV1ReleaseRoutes(
 				types.NamespaceScope,
 				types.ReleaseScope,
 			},
+			Response: &types.Release{},
 		},
 	)
 
@@ -243,6 +245,8 @@ func getV1ReleaseRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request:  &types.ListReleasesRequest{},
+			Response: &types.ListReleasesResponse{},
 		},
 	)
 
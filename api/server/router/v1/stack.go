@@ -210,6 +210,7 @@ func getV1StackRoutes(
 				types.ClusterScope,
 				types.NamespaceScope,
 			},
+			Request: &types.CreateStackRequest{},
 		},
 	)
 
@@ -533,6 +534,7 @@ func getV1StackRoutes(
 				types.NamespaceScope,
 				types.StackScope,
 			},
+			Request: &types.StackRollbackRequest{},
 		},
 	)
 
@@ -641,6 +643,7 @@ func getV1StackRoutes(
 				types.NamespaceScope,
 				types.StackScope,
 			},
+			Request: &types.CreateStackAppResourceRequest{},
 		},
 	)
 
@@ -752,6 +755,7 @@ func getV1StackRoutes(
 				types.NamespaceScope,
 				types.StackScope,
 			},
+			Request: &types.CreateStackEnvGroupRequest{},
 		},
 	)
 
@@ -861,6 +865,7 @@ func getV1StackRoutes(
 				types.NamespaceScope,
 				types.StackScope,
 			},
+			Request: &types.UpdateStackRequest{},
 		},
 	)
 
@@ -117,6 +117,33 @@ func (e *ErrNotFound) GetStatusCode() int {
 	return http.StatusNotFound
 }
 
+// errors that denote that a deploy was blocked by policies, which are passed to the client together
+// with the violations
+type ErrPolicyViolation struct {
+	err        error
+	violations []*types.PolicyViolation
+}
+
+func NewErrPolicyViolation(err error, violations []*types.PolicyViolation) RequestError {
+	return &ErrPolicyViolation{err, violations}
+}
+
+func (e *ErrPolicyViolation) Error() string {
+	return e.err.Error()
+}
+
+func (e *ErrPolicyViolation) InternalError() string {
+	return e.err.Error()
+}
+
+func (e *ErrPolicyViolation) ExternalError() string {
+	return e.err.Error()
+}
+
+func (e *ErrPolicyViolation) GetStatusCode() int {
+	return http.StatusForbidden
+}
+
 type ErrorOpts struct {
 	Code uint
 }
@@ -164,6 +191,11 @@ func HandleAPIError(
 			resp.Code = opts[0].Code
 		}
 
+		if policyErr, ok := err.(*ErrPolicyViolation); ok {
+			resp.Code = types.ErrCodePolicyViolation
+			resp.PolicyViolations = policyErr.violations
+		}
+
 		// write the status code
 		w.WriteHeader(err.GetStatusCode())
 
@@ -5,11 +5,14 @@ import (
 	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
 	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/features"
+	"github.com/porter-dev/porter/internal/helm/archive"
+	"github.com/porter-dev/porter/internal/helm/grapher"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/dns"
 	"github.com/porter-dev/porter/internal/nats"
@@ -70,6 +73,9 @@ type Config struct {
 	// GoogleConf is the configuration for a Google OAuth client
 	GoogleConf *oauth2.Config
 
+	// OIDCConf is the configuration for a generic OIDC single sign-on provider
+	OIDCConf *oauth.OIDCConf
+
 	// LaunchDarklyClient is the client for the LaunchDarkly feature flag service
 	LaunchDarklyClient *features.Client
 
@@ -110,6 +116,16 @@ type Config struct {
 	// NATS contains the required config for connecting to a NATS cluster for streaming
 	NATS nats.NATS
 
+	// GrapherSpecRules are relationships between custom resources drawn in release resource graphs
+	GrapherSpecRules []grapher.SpecRule
+
+	// RevisionArchive stores Helm release revisions pruned by the helm-revisions-count-tracker worker, if configured
+	RevisionArchive *archive.Archive
+
+	// TrustedProxies are the load balancers and reverse proxies whose X-Forwarded-For header is used to
+	// determine the address of the client
+	TrustedProxies requestutils.TrustedProxies
+
 	// EnableCAPIProvisioner enables CAPI Provisioner, which requires config for ClusterControlPlaneClient and NATS, if set to true
 	EnableCAPIProvisioner bool
 
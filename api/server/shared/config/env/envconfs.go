@@ -29,6 +29,10 @@ type ServerConf struct {
 	IsTesting            bool          `env:"IS_TESTING,default=false"`
 	AppRootDomain        string        `env:"APP_ROOT_DOMAIN,default=porter.run"`
 
+	// TrustedProxyCIDRs are the address ranges of load balancers in front of the server. The client address is only
+	// read from X-Forwarded-For when a request is received from one of these ranges
+	TrustedProxyCIDRs []string `env:"TRUSTED_PROXY_CIDRS"`
+
 	DefaultApplicationHelmRepoURL string `env:"HELM_APP_REPO_URL,default=https://charts.dev.getporter.dev"`
 	DefaultAddonHelmRepoURL       string `env:"HELM_ADD_ON_REPO_URL,default=https://chart-addons.dev.getporter.dev"`
 
@@ -55,6 +59,48 @@ type ServerConf struct {
 	GoogleClientSecret     string `env:"GOOGLE_CLIENT_SECRET"`
 	GoogleRestrictedDomain string `env:"GOOGLE_RESTRICTED_DOMAIN"`
 
+	// OIDCDiscoveryURL is the issuer or discovery document URL of a generic OIDC provider
+	// (e.g. Okta or Keycloak) to use for single sign-on
+	OIDCDiscoveryURL string `env:"OIDC_DISCOVERY_URL"`
+	OIDCClientID     string `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `env:"OIDC_CLIENT_SECRET"`
+	OIDCProviderName string `env:"OIDC_PROVIDER_NAME,default=SSO"`
+	// OIDCAllowedDomains is a comma-separated list of email domains which may log in via OIDC
+	OIDCAllowedDomains string `env:"OIDC_ALLOWED_DOMAINS"`
+	// OIDCAutoJoinProjectID is a project that verified OIDC users are added to on first login
+	OIDCAutoJoinProjectID uint   `env:"OIDC_AUTO_JOIN_PROJECT_ID"`
+	OIDCAutoJoinRole      string `env:"OIDC_AUTO_JOIN_ROLE,default=developer"`
+
+	// ExternalSecretsVaultAddress and ExternalSecretsVaultToken configure the Vault server used to resolve ${vault://...} references
+	// in environment groups. References are only resolved under porter/projects/<project_id>, and the token should be restricted
+	// by policy to that path
+	ExternalSecretsVaultAddress string `env:"EXTERNAL_SECRETS_VAULT_ADDR"`
+	ExternalSecretsVaultToken   string `env:"EXTERNAL_SECRETS_VAULT_TOKEN"`
+	// ExternalSecretsAWSRegion configures the AWS Secrets Manager region used to resolve ${awssm://...} references in environment groups.
+	// Credentials are read from the default AWS credential chain, and should be restricted to secrets named porter/projects/*
+	ExternalSecretsAWSRegion string `env:"EXTERNAL_SECRETS_AWS_REGION"`
+
+	// RevisionArchiveBackend is the object storage (s3, gcs or local) which the helm-revisions-count-tracker worker
+	// archives pruned Helm revisions to. When set, archived revisions are listed in release histories and can be
+	// rolled back to. The options below must match those of the worker.
+	RevisionArchiveBackend       string `env:"REVISION_ARCHIVE_BACKEND"`
+	RevisionArchiveEncryptionKey string `env:"S3_ENCRYPTION_KEY"`
+	RevisionArchiveAWSRegion     string `env:"AWS_REGION"`
+	RevisionArchiveAWSAccessKey  string `env:"AWS_ACCESS_KEY_ID"`
+	RevisionArchiveAWSSecretKey  string `env:"AWS_SECRET_ACCESS_KEY"`
+	RevisionArchiveS3BucketName  string `env:"S3_BUCKET_NAME"`
+	RevisionArchiveGCSBucketName string `env:"GCS_BUCKET_NAME"`
+	RevisionArchiveGCSCreds      string `env:"GCS_CREDENTIALS"`
+	RevisionArchiveDir           string `env:"REVISION_ARCHIVE_DIR"`
+
+	// GrapherSpecRulesPath is the path to a yaml file of rules declaring relationships between custom resources, which
+	// are drawn in the release resource graph in addition to the built-in relationships
+	GrapherSpecRulesPath string `env:"GRAPHER_SPEC_RULES_PATH"`
+
+	// OPAConfigFileDir is the directory containing the OPA policy config file, whose certificate policies are run
+	// as part of cluster health reports
+	OPAConfigFileDir string `env:"OPA_CONFIG_FILE_DIR"`
+
 	// FeatureFlagClient controls which client to use (database or launch_darkly)
 	FeatureFlagClient  string `env:"FEATURE_FLAG_CLIENT,default=launch_darkly"`
 	LaunchDarklySDKKey string `env:"LAUNCHDARKLY_SDK_KEY"`
@@ -171,6 +217,10 @@ type DBConf struct {
 	VaultPrefix    string `env:"VAULT_PREFIX,default=production"`
 	VaultAPIKey    string `env:"VAULT_API_KEY"`
 	VaultServerURL string `env:"VAULT_SERVER_URL"`
+
+	// HelmSQLConnectionString is the Postgres database holding the Helm releases of clusters which use the sql
+	// storage driver. The releases of each cluster are kept in a separate schema.
+	HelmSQLConnectionString string `env:"HELM_DRIVER_SQL_CONNECTION_STRING"`
 }
 
 // RedisConf is the redis config required for the provisioner container
@@ -1,6 +1,7 @@
 package loader
 
 import (
+	"context"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -16,6 +17,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/server/shared/config/envloader"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/ee/integrations/vault"
 	"github.com/porter-dev/porter/internal/adapter"
@@ -24,9 +26,12 @@ import (
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/features"
+	"github.com/porter-dev/porter/internal/helm/archive"
+	"github.com/porter-dev/porter/internal/helm/grapher"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/cloudflare"
 	"github.com/porter-dev/porter/internal/integrations/dns"
+	"github.com/porter-dev/porter/internal/integrations/externalsecrets"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
@@ -112,6 +117,11 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 	res.Repo = gorm.NewRepository(InstanceDB, &key, instanceCredentialBackend)
 	res.Logger.Info().Msg("Created new gorm repository")
 
+	res.TrustedProxies, err = requestutils.ParseTrustedProxies(envConf.ServerConf.TrustedProxyCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse trusted proxy ranges: %w", err)
+	}
+
 	res.Logger.Info().Msg("Creating new session store")
 	// create the session store
 	res.Store, err = sessionstore.NewStore(
@@ -119,6 +129,9 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 			SessionRepository: res.Repo.Session(),
 			CookieSecrets:     envConf.ServerConf.CookieSecrets,
 			Insecure:          envConf.ServerConf.CookieInsecure,
+			ClientIP: func(r *http.Request) string {
+				return requestutils.GetRequestIP(r, res.TrustedProxies)
+			},
 		},
 	)
 
@@ -186,6 +199,68 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		res.Logger.Info().Msg(" Google client")
 	}
 
+	if res.Metadata.OIDCLogin {
+		res.Logger.Info().Msg("Creating OIDC client")
+		provider, err := oauth.DiscoverOIDCProvider(context.Background(), sc.OIDCDiscoveryURL)
+		if err != nil {
+			return nil, fmt.Errorf("could not discover oidc provider: %w", err)
+		}
+
+		res.OIDCConf = oauth.NewOIDCClient(&oauth.Config{
+			ClientID:     sc.OIDCClientID,
+			ClientSecret: sc.OIDCClientSecret,
+			Scopes:       []string{"openid", "profile", "email"},
+			BaseURL:      sc.ServerURL,
+		}, provider)
+		res.Logger.Info().Msg("Created OIDC client")
+	}
+
+	if sc.ExternalSecretsVaultAddress != "" && sc.ExternalSecretsVaultToken != "" {
+		externalsecrets.RegisterProvider(externalsecrets.NewVaultProvider(sc.ExternalSecretsVaultAddress, sc.ExternalSecretsVaultToken))
+		res.Logger.Info().Msg("Registered Vault external secrets provider")
+	}
+
+	if sc.ExternalSecretsAWSRegion != "" {
+		awsProvider, err := externalsecrets.NewAWSSecretsManagerProvider(sc.ExternalSecretsAWSRegion)
+		if err != nil {
+			return nil, fmt.Errorf("could not create aws secrets manager provider: %w", err)
+		}
+
+		externalsecrets.RegisterProvider(awsProvider)
+		res.Logger.Info().Msg("Registered AWS Secrets Manager external secrets provider")
+	}
+
+	if sc.GrapherSpecRulesPath != "" {
+		rulesBytes, err := os.ReadFile(sc.GrapherSpecRulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not read grapher spec rules: %w", err)
+		}
+
+		res.GrapherSpecRules, err = grapher.ParseSpecRules(rulesBytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse grapher spec rules: %w", err)
+		}
+		res.Logger.Info().Msgf("Loaded %d grapher spec rules", len(res.GrapherSpecRules))
+	}
+
+	if sc.RevisionArchiveBackend != "" {
+		res.RevisionArchive, err = archive.New(context.Background(), &archive.Options{
+			Backend:            sc.RevisionArchiveBackend,
+			EncryptionKey:      sc.RevisionArchiveEncryptionKey,
+			AWSRegion:          sc.RevisionArchiveAWSRegion,
+			AWSAccessKeyID:     sc.RevisionArchiveAWSAccessKey,
+			AWSSecretAccessKey: sc.RevisionArchiveAWSSecretKey,
+			S3BucketName:       sc.RevisionArchiveS3BucketName,
+			GCSBucketName:      sc.RevisionArchiveGCSBucketName,
+			GCSCredentials:     sc.RevisionArchiveGCSCreds,
+			LocalDir:           sc.RevisionArchiveDir,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not create revision archive: %w", err)
+		}
+		res.Logger.Info().Msgf("Created %s revision archive", sc.RevisionArchiveBackend)
+	}
+
 	// TODO: remove this as part of POR-1055
 	if sc.GithubClientID != "" && sc.GithubClientSecret != "" {
 		res.Logger.Info().Msg("Creating Github client")
@@ -0,0 +1,96 @@
+package requestutils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// TrustedProxies is a set of address ranges of load balancers and reverse proxies in front of the server. Only
+// these proxies are trusted to report the address of the client in the `X-Forwarded-For` header.
+type TrustedProxies []*net.IPNet
+
+// ParseTrustedProxies parses a list of CIDR ranges, such as 10.0.0.0/8. A single address is treated as a range
+// containing only that address.
+func ParseTrustedProxies(cidrs []string) (TrustedProxies, error) {
+	res := make(TrustedProxies, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if cidr == "" {
+			continue
+		}
+
+		if !strings.Contains(cidr, "/") {
+			if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %s: %w", cidr, err)
+		}
+
+		res = append(res, ipNet)
+	}
+
+	return res, nil
+}
+
+// contains returns true if the address belongs to a trusted proxy
+func (t TrustedProxies) contains(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range t {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetRequestIP returns the IP address of the client that made the request. The host portion of the
+// request's remote address is used unless the request was received from a trusted proxy, in which
+// case the `X-Forwarded-For` header is read from right to left, skipping addresses of trusted proxies,
+// and the first untrusted address is returned. Addresses further to the left were set by the client
+// and are never used, since they can be spoofed.
+func GetRequestIP(r *http.Request, trustedProxies TrustedProxies) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+
+	if !trustedProxies.contains(ip) {
+		return ip
+	}
+
+	forwardedFor := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwardedFor[i])
+		if hop == "" {
+			continue
+		}
+
+		if net.ParseIP(hop) == nil {
+			// a malformed entry cannot have been appended by a trusted proxy, so nothing to its left can be trusted
+			return ip
+		}
+
+		ip = hop
+
+		if !trustedProxies.contains(hop) {
+			return hop
+		}
+	}
+
+	return ip
+}
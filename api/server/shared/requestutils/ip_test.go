@@ -0,0 +1,108 @@
+package requestutils_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type getRequestIPTest struct {
+	description    string
+	remoteAddr     string
+	headers        map[string]string
+	trustedProxies []string
+	expIP          string
+}
+
+var getRequestIPTests = []getRequestIPTest{
+	{
+		description: "should use the remote address when no headers are set",
+		remoteAddr:  "10.0.0.1:54321",
+		expIP:       "10.0.0.1",
+	},
+	{
+		description: "should ignore a spoofed X-Forwarded-For when no proxies are trusted",
+		remoteAddr:  "198.51.100.4:54321",
+		headers: map[string]string{
+			"X-Forwarded-For": "203.0.113.7",
+		},
+		expIP: "198.51.100.4",
+	},
+	{
+		description: "should ignore a spoofed X-Real-IP",
+		remoteAddr:  "198.51.100.4:54321",
+		headers: map[string]string{
+			"X-Real-IP": "203.0.113.8",
+		},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "198.51.100.4",
+	},
+	{
+		description: "should ignore X-Forwarded-For from an untrusted remote address",
+		remoteAddr:  "198.51.100.4:54321",
+		headers: map[string]string{
+			"X-Forwarded-For": "203.0.113.7",
+		},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "198.51.100.4",
+	},
+	{
+		description: "should use the address appended by a trusted proxy",
+		remoteAddr:  "10.0.0.1:54321",
+		headers: map[string]string{
+			"X-Forwarded-For": "203.0.113.7",
+		},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "203.0.113.7",
+	},
+	{
+		description: "should ignore addresses prepended by the client to X-Forwarded-For",
+		remoteAddr:  "10.0.0.1:54321",
+		headers: map[string]string{
+			"X-Forwarded-For": "192.0.2.1, 203.0.113.7, 10.0.0.2",
+		},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "203.0.113.7",
+	},
+	{
+		description: "should not read past a malformed X-Forwarded-For entry",
+		remoteAddr:  "10.0.0.1:54321",
+		headers: map[string]string{
+			"X-Forwarded-For": "192.0.2.1, not-an-ip",
+		},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "10.0.0.1",
+	},
+	{
+		description: "should return the remote address as-is if it has no port",
+		remoteAddr:  "10.0.0.1",
+		expIP:       "10.0.0.1",
+	},
+}
+
+func TestGetRequestIP(t *testing.T) {
+	for _, test := range getRequestIPTests {
+		r := httptest.NewRequest("GET", "/api", nil)
+		r.RemoteAddr = test.remoteAddr
+
+		for key, val := range test.headers {
+			r.Header.Set(key, val)
+		}
+
+		trustedProxies, err := requestutils.ParseTrustedProxies(test.trustedProxies)
+		assert.NoError(t, err, test.description)
+
+		assert.Equal(t, test.expIP, requestutils.GetRequestIP(r, trustedProxies), test.description)
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	trustedProxies, err := requestutils.ParseTrustedProxies([]string{"10.0.0.0/8", " 192.0.2.1 ", "2001:db8::1"})
+	assert.NoError(t, err)
+	assert.Len(t, trustedProxies, 3)
+
+	_, err = requestutils.ParseTrustedProxies([]string{"not-a-cidr"})
+	assert.Error(t, err)
+}
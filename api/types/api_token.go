@@ -12,6 +12,15 @@ type APITokenMeta struct {
 	PolicyName string `json:"policy_name"`
 	PolicyUID  string `json:"policy_uid"`
 	Name       string `json:"name"`
+	Revoked    bool   `json:"revoked"`
+
+	LastUsedAt   *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP   string     `json:"last_used_ip,omitempty"`
+	AllowedCIDRs []string   `json:"allowed_cidrs"`
+
+	// PreviousSecretExpiry is set after a rotation, and is the time at which the
+	// previous secret stops being accepted
+	PreviousSecretExpiry *time.Time `json:"previous_secret_expiry,omitempty"`
 }
 
 type APIToken struct {
@@ -22,7 +31,14 @@ type APIToken struct {
 }
 
 type CreateAPIToken struct {
-	PolicyUID string    `json:"policy_uid" form:"required"`
-	ExpiresAt time.Time `json:"expires_at"`
-	Name      string    `json:"name" form:"required"`
+	PolicyUID    string    `json:"policy_uid" form:"required"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Name         string    `json:"name" form:"required"`
+	AllowedCIDRs []string  `json:"allowed_cidrs" form:"omitempty,dive,cidr"`
+}
+
+type RotateAPITokenRequest struct {
+	// GracePeriodSeconds is the amount of time the previous secret remains valid after
+	// rotation. If not set, it defaults to 24 hours.
+	GracePeriodSeconds uint `json:"grace_period_seconds" form:"omitempty,max=604800"`
 }
@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+const (
+	URLParamAppSleepScheduleID URLParam = "app_sleep_schedule_id"
+)
+
+// AppSleepSchedule scales the web and worker services of apps in a deployment target to zero outside of
+// the hours during which they are awake
+type AppSleepSchedule struct {
+	ID                 uint   `json:"id"`
+	ProjectID          uint   `json:"project_id"`
+	ClusterID          uint   `json:"cluster_id"`
+	DeploymentTargetID string `json:"deployment_target_id"`
+
+	// AppName limits the schedule to a single app. If empty, the schedule applies to every app in the
+	// deployment target which does not have a schedule of its own.
+	AppName string `json:"app_name,omitempty"`
+
+	// Days are the days of the week on which the apps are woken up, such as ["mon", "tue"]
+	Days []string `json:"days"`
+	// WakeTime and SleepTime are times of day in the 24-hour HH:MM format, such as "08:00" and "20:00".
+	// If SleepTime is before WakeTime, the apps sleep on the following day.
+	WakeTime  string `json:"wake_time"`
+	SleepTime string `json:"sleep_time"`
+	// Timezone is an IANA timezone, such as "Europe/Berlin"
+	Timezone string `json:"timezone"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateAppSleepScheduleRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id" form:"required,uuid"`
+	AppName            string `json:"app_name"`
+
+	// Days accepts the names of the days of the week, as well as "weekdays", "weekends" and "daily"
+	Days      []string `json:"days" form:"required,min=1"`
+	WakeTime  string   `json:"wake_time" form:"required"`
+	SleepTime string   `json:"sleep_time" form:"required"`
+	Timezone  string   `json:"timezone" form:"required"`
+}
+
+type ListAppSleepSchedulesResponse []*AppSleepSchedule
+
+// SleepAppRequest puts an app to sleep, or wakes it up. The app stays in the requested state until the
+// next transition of its schedule.
+type SleepAppRequest struct {
+	DeploymentTargetID string `json:"deployment_target_id" form:"required,uuid"`
+}
+
+// AppSleepState is the sleep state of an app in a deployment target
+type AppSleepState struct {
+	AppName            string `json:"app_name"`
+	DeploymentTargetID string `json:"deployment_target_id"`
+	Asleep             bool   `json:"asleep"`
+
+	// OverrideUntil is set if the app was put to sleep or woken up manually, until its schedule takes over again
+	OverrideUntil *time.Time `json:"override_until,omitempty"`
+}
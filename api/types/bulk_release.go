@@ -0,0 +1,65 @@
+package types
+
+// BulkReleaseAction is an operation which is applied to every release with a tag
+type BulkReleaseAction string
+
+const (
+	// BulkReleaseActionRestart restarts the pods of the deployments, stateful sets and daemon sets of each release
+	BulkReleaseActionRestart BulkReleaseAction = "restart"
+	// BulkReleaseActionUpdateImage upgrades each release to a new image tag
+	BulkReleaseActionUpdateImage BulkReleaseAction = "update_image"
+	// BulkReleaseActionAttachEnvGroup syncs an env group to each release
+	BulkReleaseActionAttachEnvGroup BulkReleaseAction = "attach_env_group"
+	// BulkReleaseActionDetachEnvGroup stops syncing an env group to each release
+	BulkReleaseActionDetachEnvGroup BulkReleaseAction = "detach_env_group"
+	// BulkReleaseActionScale sets the replica count of each release
+	BulkReleaseActionScale BulkReleaseAction = "scale"
+)
+
+// BulkReleaseResultStatus is the outcome of a bulk operation for a single release
+type BulkReleaseResultStatus string
+
+const (
+	BulkReleaseResultSucceeded BulkReleaseResultStatus = "succeeded"
+	BulkReleaseResultFailed    BulkReleaseResultStatus = "failed"
+	// BulkReleaseResultSkipped is returned for releases which the operation does not apply to, such as
+	// releases which already have the requested image tag
+	BulkReleaseResultSkipped BulkReleaseResultStatus = "skipped"
+	// BulkReleaseResultPlanned is returned for releases which would be changed by a dry run
+	BulkReleaseResultPlanned BulkReleaseResultStatus = "planned"
+	// BulkReleaseResultForbidden is returned for releases which the requester is not allowed to update
+	BulkReleaseResultForbidden BulkReleaseResultStatus = "forbidden"
+)
+
+type BulkReleaseOperationRequest struct {
+	// Tag selects the releases in the cluster which the operation is applied to
+	Tag    string            `json:"tag" form:"required"`
+	Action BulkReleaseAction `json:"action" form:"required,oneof=restart update_image attach_env_group detach_env_group scale"`
+
+	// ImageTag is the image tag that releases are upgraded to by update_image
+	ImageTag string `json:"image_tag" form:"required_if=Action update_image"`
+	// EnvGroupName is the env group that is attached or detached, which must exist in the namespace of each release
+	EnvGroupName string `json:"env_group_name" form:"required_if=Action attach_env_group,required_if=Action detach_env_group"`
+	// Replicas is the replica count that releases are scaled to
+	Replicas *int `json:"replicas" form:"required_if=Action scale,omitempty,min=0"`
+
+	// Concurrency is the number of releases which are changed at the same time. It defaults to 5.
+	Concurrency int `json:"concurrency" form:"omitempty,min=1,max=20"`
+	// DryRun returns the releases which would be changed, without changing them
+	DryRun bool `json:"dry_run"`
+}
+
+// BulkReleaseResult is the outcome of a bulk operation for a single release
+type BulkReleaseResult struct {
+	Name      string                  `json:"name"`
+	Namespace string                  `json:"namespace"`
+	Status    BulkReleaseResultStatus `json:"status"`
+	Message   string                  `json:"message,omitempty"`
+}
+
+type BulkReleaseOperationResponse struct {
+	Tag     string               `json:"tag"`
+	Action  BulkReleaseAction    `json:"action"`
+	DryRun  bool                 `json:"dry_run"`
+	Results []*BulkReleaseResult `json:"results"`
+}
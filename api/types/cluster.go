@@ -42,6 +42,13 @@ type Cluster struct {
 	// Whether preview environments is enabled on this cluster
 	PreviewEnvsEnabled bool `json:"preview_envs_enabled"`
 
+	// The number of most recent environment group versions to keep, or 0 to keep all versions
+	EnvGroupVersionRetention uint `json:"env_group_version_retention"`
+
+	// The lowest severity of recommender checks which send a notification when they start failing, or empty
+	// if recommender notifications are disabled
+	RecommenderAlertSeverity MonitorTestSeverity `json:"recommender_alert_severity,omitempty"`
+
 	// Cluster provisioning status if managed by Porter
 	Status ClusterStatus `json:"status"`
 
@@ -306,14 +313,77 @@ type UpdateClusterRequest struct {
 	AgentIntegrationEnabled *bool `json:"agent_integration_enabled"`
 
 	PreviewEnvsEnabled *bool `json:"preview_envs_enabled"`
+
+	EnvGroupVersionRetention *uint `json:"env_group_version_retention"`
+
+	// RecommenderAlertSeverity is one of "critical", "high" or "low", or empty to disable recommender notifications
+	RecommenderAlertSeverity *MonitorTestSeverity `json:"recommender_alert_severity"`
 }
 
 type RenameClusterRequest struct {
 	Name string `json:"name"`
 }
 
+// UpdateClusterHelmStorageRequest moves the Helm releases of a cluster to another storage driver
+type UpdateClusterHelmStorageRequest struct {
+	// Storage is the storage driver to move the releases to
+	Storage string `json:"storage" form:"required,oneof=secret sql"`
+}
+
+// UpdateClusterHelmStorageResponse is the result of moving the Helm releases of a cluster
+type UpdateClusterHelmStorageResponse struct {
+	// Storage is the storage driver the releases of the cluster are read from
+	Storage string `json:"storage"`
+
+	// MigratedRevisions is the number of release revisions which were written to the storage
+	MigratedRevisions int `json:"migrated_revisions"`
+}
+
 type ListClusterResponse []*Cluster
 
 type CreateClusterCandidateResponse []*ClusterCandidate
 
 type ListClusterCandidateResponse []*ClusterCandidate
+
+// ClusterHealthSeverity is the severity of a finding in a cluster health report
+type ClusterHealthSeverity string
+
+const (
+	// ClusterHealthSeverityInfo findings do not need action
+	ClusterHealthSeverityInfo ClusterHealthSeverity = "info"
+	// ClusterHealthSeverityWarning findings should be fixed, but do not affect running workloads yet
+	ClusterHealthSeverityWarning ClusterHealthSeverity = "warning"
+	// ClusterHealthSeverityCritical findings are affecting, or are about to affect, running workloads
+	ClusterHealthSeverityCritical ClusterHealthSeverity = "critical"
+)
+
+// ClusterHealthFinding is a single problem found by a cluster health check
+type ClusterHealthFinding struct {
+	Severity ClusterHealthSeverity `json:"severity"`
+
+	// Object identifies the object the finding is about, such as node/ip-10-0-1-1
+	Object string `json:"object,omitempty"`
+
+	Message string `json:"message"`
+
+	// Remediation is a hint on how to resolve the finding
+	Remediation string `json:"remediation,omitempty"`
+}
+
+// ClusterHealthCheck is the result of a single check in a cluster health report
+type ClusterHealthCheck struct {
+	Name string `json:"name"`
+
+	// Error is set if the check could not be run, in which case there are no findings
+	Error string `json:"error,omitempty"`
+
+	Findings []ClusterHealthFinding `json:"findings"`
+}
+
+// GetClusterHealthResponse is a cluster health report
+type GetClusterHealthResponse struct {
+	// Healthy is true if every check ran and found no warnings or critical problems
+	Healthy bool `json:"healthy"`
+
+	Checks []ClusterHealthCheck `json:"checks"`
+}
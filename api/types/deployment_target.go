@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// URLParamDeploymentTargetID is the url param for the id of a deployment target
+const URLParamDeploymentTargetID URLParam = "deployment_target_id"
+
+// DeploymentTarget is a named target that porter apps can be deployed to, selecting a namespace on a cluster
+type DeploymentTarget struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ProjectID uint      `json:"project_id"`
+	ClusterID uint      `json:"cluster_id"`
+	Namespace string    `json:"namespace"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// EnvOverrideKeys are the keys of the variables and secrets which are overridden for this target
+	EnvOverrideKeys []string `json:"env_override_keys"`
+}
+
+// ListDeploymentTargetsResponse is the response object for the /deployment-targets endpoint
+type ListDeploymentTargetsResponse []*DeploymentTarget
+
+// CreateDeploymentTargetRequest is the request object for the /deployment-targets endpoint
+type CreateDeploymentTargetRequest struct {
+	Name      string `json:"name" form:"required,dns1123"`
+	ClusterID uint   `json:"cluster_id" form:"required"`
+	Namespace string `json:"namespace" form:"required,dns1123"`
+}
+
+// DeploymentTargetEnvOverrides are the variables and secrets which are layered on top of the
+// base environment group of every app deployed to a deployment target
+type DeploymentTargetEnvOverrides struct {
+	Variables map[string]string `json:"variables"`
+	Secrets   map[string]string `json:"secrets"`
+}
+
+// UpdateDeploymentTargetEnvOverridesRequest is the request object for the /deployment-targets/{deployment_target_id}/env-overrides endpoint
+type UpdateDeploymentTargetEnvOverridesRequest struct {
+	DeploymentTargetEnvOverrides
+}
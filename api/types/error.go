@@ -1,7 +1,8 @@
 package types
 
 const (
-	ErrCodeUnavailable uint = 601
+	ErrCodeUnavailable     uint = 601
+	ErrCodePolicyViolation uint = 602
 )
 
 type ExternalError struct {
@@ -9,4 +10,7 @@ type ExternalError struct {
 	Code uint `json:"code,omitempty"`
 
 	Error string `json:"error"`
+
+	// PolicyViolations are set when a deploy is blocked by policies, with the code ErrCodePolicyViolation
+	PolicyViolations []*PolicyViolation `json:"policy_violations,omitempty"`
 }
@@ -0,0 +1,134 @@
+package types
+
+import "time"
+
+const (
+	URLParamImagePushPolicyID URLParam = "image_push_policy_id"
+	URLParamImagePushSource   URLParam = "source"
+)
+
+// ImagePushSource is a registry that sends push events to porter
+type ImagePushSource string
+
+const (
+	// ImagePushSourceECR is an Amazon EventBridge "ECR Image Action" event
+	ImagePushSourceECR ImagePushSource = "ecr"
+	// ImagePushSourceDockerHub is a Docker Hub repository webhook
+	ImagePushSourceDockerHub ImagePushSource = "dockerhub"
+	// ImagePushSourceGHCR is a GitHub "package" webhook for a container package
+	ImagePushSourceGHCR ImagePushSource = "ghcr"
+	// ImagePushSourceHarbor is a Harbor project webhook
+	ImagePushSourceHarbor ImagePushSource = "harbor"
+	// ImagePushSourceDistribution is a notification from a registry implementing the distribution notification spec
+	ImagePushSourceDistribution ImagePushSource = "distribution"
+)
+
+// ImagePushSources are all of the registries that can send push events
+var ImagePushSources = []ImagePushSource{
+	ImagePushSourceECR,
+	ImagePushSourceDockerHub,
+	ImagePushSourceGHCR,
+	ImagePushSourceHarbor,
+	ImagePushSourceDistribution,
+}
+
+// ImagePushPolicyType determines how a pushed tag is matched against a policy
+type ImagePushPolicyType string
+
+const (
+	// ImagePushPolicySemver matches tags which are semantic versions within the range in the pattern, such as ">=1.2.0, <2"
+	ImagePushPolicySemver ImagePushPolicyType = "semver"
+	// ImagePushPolicyRegex matches tags which fully match the regular expression in the pattern
+	ImagePushPolicyRegex ImagePushPolicyType = "regex"
+	// ImagePushPolicyDigest matches every new digest pushed to the tag in the pattern, such as "latest"
+	ImagePushPolicyDigest ImagePushPolicyType = "digest"
+)
+
+// ImagePushEventStatus is the outcome of an automatic upgrade
+type ImagePushEventStatus string
+
+const (
+	ImagePushEventStatusDeployed ImagePushEventStatus = "deployed"
+	ImagePushEventStatusFailed   ImagePushEventStatus = "failed"
+)
+
+// ImagePushPolicy upgrades an app or a release when a matching image is pushed
+type ImagePushPolicy struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+	ClusterID uint `json:"cluster_id"`
+
+	// AppName is the name of the porter app which is upgraded. Either AppName or ReleaseName is set.
+	AppName string `json:"app_name,omitempty"`
+	// DeploymentTargetID is the deployment target of the app which is upgraded
+	DeploymentTargetID string `json:"deployment_target_id,omitempty"`
+
+	// ReleaseName is the name of the helm release which is upgraded
+	ReleaseName string `json:"release_name,omitempty"`
+	// Namespace is the namespace of the helm release which is upgraded
+	Namespace string `json:"namespace,omitempty"`
+
+	// ImageRepository is the repository that pushes are matched against, such as ghcr.io/porter-dev/porter
+	ImageRepository string              `json:"image_repository"`
+	Type            ImagePushPolicyType `json:"type"`
+	Pattern         string              `json:"pattern"`
+
+	// LastTag is the tag that was last deployed by the policy
+	LastTag string `json:"last_tag,omitempty"`
+	// LastDigest is the digest that was last deployed by the policy
+	LastDigest string `json:"last_digest,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateImagePushPolicyRequest struct {
+	AppName            string `json:"app_name" form:"required_without=ReleaseName,excluded_with=ReleaseName"`
+	DeploymentTargetID string `json:"deployment_target_id" form:"required_with=AppName"`
+
+	ReleaseName string `json:"release_name"`
+	Namespace   string `json:"namespace" form:"required_with=ReleaseName"`
+
+	ImageRepository string              `json:"image_repository" form:"required"`
+	Type            ImagePushPolicyType `json:"type" form:"required,oneof=semver regex digest"`
+	Pattern         string              `json:"pattern" form:"required"`
+}
+
+type ListImagePushPoliciesResponse []*ImagePushPolicy
+
+// ImagePushEvent records an automatic upgrade triggered by an image push
+type ImagePushEvent struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+	PolicyID  uint `json:"policy_id"`
+
+	Source          ImagePushSource      `json:"source"`
+	ImageRepository string               `json:"image_repository"`
+	Tag             string               `json:"tag"`
+	Digest          string               `json:"digest,omitempty"`
+	Status          ImagePushEventStatus `json:"status"`
+	Message         string               `json:"message,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListImagePushEventsRequest struct {
+	PolicyID uint `schema:"policy_id"`
+}
+
+type ListImagePushEventsResponse []*ImagePushEvent
+
+// ImagePushWebhookResponse contains the urls that registries should send push events to
+type ImagePushWebhookResponse struct {
+	// URLs maps each registry to the url that its push events should be sent to. It is empty until the webhook
+	// token is first rotated.
+	URLs map[ImagePushSource]string `json:"urls"`
+
+	// Secret is the webhook secret which GHCR events must be signed with. Events from other registries are only
+	// authenticated by the token in their url, since those registries do not sign their events.
+	Secret string `json:"secret,omitempty"`
+}
+
+// ImagePushWebhookResult is returned to the registry after a push event is processed
+type ImagePushWebhookResult struct {
+	Events []*ImagePushEvent `json:"events"`
+}
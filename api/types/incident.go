@@ -77,6 +77,12 @@ type Incident struct {
 	Detail string   `json:"detail" form:"required"`
 }
 
+// NotifyIncidentResponse is the response to notifying the integrations of a project about a new or resolved incident
+type NotifyIncidentResponse struct {
+	// PagingErrors are the errors of paging integrations which could not be notified
+	PagingErrors []string `json:"paging_errors,omitempty"`
+}
+
 type IncidentEvent struct {
 	ID           string     `json:"id" form:"required"`
 	LastSeen     *time.Time `json:"last_seen" form:"required"`
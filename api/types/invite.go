@@ -11,6 +11,8 @@ type Invite struct {
 	Email    string `json:"email"`
 	Accepted bool   `json:"accepted"`
 	Kind     string `json:"kind"`
+
+	PolicyUID string `json:"policy_uid,omitempty"`
 }
 
 type GetInviteResponse Invite
@@ -18,6 +20,9 @@ type GetInviteResponse Invite
 type CreateInviteRequest struct {
 	Email string `json:"email,required"`
 	Kind  string `json:"kind,required"`
+
+	// PolicyUID is the project policy to assign when Kind is "custom"
+	PolicyUID string `json:"policy_uid"`
 }
 
 type CreateInviteResponse struct {
@@ -28,4 +33,7 @@ type ListInvitesResponse []*Invite
 
 type UpdateInviteRoleRequest struct {
 	Kind string `json:"kind,required"`
+
+	// PolicyUID is the project policy to assign when Kind is "custom"
+	PolicyUID string `json:"policy_uid"`
 }
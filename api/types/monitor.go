@@ -33,3 +33,94 @@ type MonitorTestResult struct {
 
 	Severity MonitorTestSeverity `json:"severity"`
 }
+
+// IsValidMonitorTestSeverity returns true if severity is one of the severities of the recommender checks
+func IsValidMonitorTestSeverity(severity MonitorTestSeverity) bool {
+	switch severity {
+	case MonitorTestSeverityCritical, MonitorTestSeverityHigh, MonitorTestSeverityLow:
+		return true
+	default:
+		return false
+	}
+}
+
+// MonitorTestRunResult is the result of a single check in a single run of the recommender
+type MonitorTestRunResult struct {
+	ObjectID string `json:"object_id"`
+	Category string `json:"category"`
+
+	RecommenderRunID string            `json:"recommender_run_id"`
+	Result           MonitorTestStatus `json:"result"`
+	TestedAt         time.Time         `json:"tested_at"`
+
+	Title    string              `json:"title"`
+	Severity MonitorTestSeverity `json:"severity"`
+}
+
+type ListMonitorTestRunResultsRequest struct {
+	// ObjectID is the check to list the results of
+	ObjectID string `schema:"object_id" form:"required"`
+
+	// Limit is the number of most recent results to return. It defaults to 100.
+	Limit int `schema:"limit" form:"omitempty,min=1,max=1000"`
+}
+
+type ListMonitorTestRunResultsResponse []*MonitorTestRunResult
+
+type GetRecommenderTrendRequest struct {
+	// Days is the number of days of history to return. It defaults to 30.
+	Days int `schema:"days" form:"omitempty,min=1,max=90"`
+}
+
+// RecommenderTrendPoint summarizes a single run of the recommender
+type RecommenderTrendPoint struct {
+	RecommenderRunID string    `json:"recommender_run_id"`
+	TestedAt         time.Time `json:"tested_at"`
+
+	Passed int `json:"passed"`
+	Failed int `json:"failed"`
+
+	// FailedBySeverity is the number of failed checks of each severity
+	FailedBySeverity map[MonitorTestSeverity]int `json:"failed_by_severity"`
+}
+
+// RecommenderCheckTrend summarizes the history of a single check
+type RecommenderCheckTrend struct {
+	ObjectID string              `json:"object_id"`
+	Category string              `json:"category"`
+	Title    string              `json:"title"`
+	Severity MonitorTestSeverity `json:"severity"`
+
+	LastRunResult MonitorTestStatus `json:"last_run_result"`
+
+	Runs     int `json:"runs"`
+	Failures int `json:"failures"`
+
+	// Transitions is the number of times the check changed between passing and failing
+	Transitions int `json:"transitions"`
+}
+
+type GetRecommenderTrendResponse struct {
+	// Runs contains a point for each run of the recommender, oldest first
+	Runs []*RecommenderTrendPoint `json:"runs"`
+
+	// Checks contains the trend of each check which ran in the period, with the checks which failed the most first
+	Checks []*RecommenderCheckTrend `json:"checks"`
+}
+
+// RecommenderRegression is a recommender check which started failing
+type RecommenderRegression struct {
+	ObjectID string              `json:"object_id"`
+	Category string              `json:"category"`
+	Title    string              `json:"title"`
+	Message  string              `json:"message"`
+	Severity MonitorTestSeverity `json:"severity"`
+}
+
+// RecommenderRegressions are the checks in a cluster which started failing in a single run of the recommender
+type RecommenderRegressions struct {
+	ProjectID   uint                     `json:"project_id"`
+	ClusterID   uint                     `json:"cluster_id"`
+	ClusterName string                   `json:"cluster_name"`
+	Regressions []*RecommenderRegression `json:"regressions"`
+}
@@ -244,6 +244,26 @@ type GetEnvGroupResponse struct {
 	StackID string `json:"stack_id,omitempty"`
 }
 
+// ExportEnvGroupRequest represents the query parameters to export an env group
+type ExportEnvGroupRequest struct {
+	Name    string `schema:"name,required"`
+	Version uint   `schema:"version"`
+
+	// RevealSecrets returns secret values in plain text instead of masking them. Only project admins may reveal secrets
+	RevealSecrets bool `schema:"reveal_secrets"`
+}
+
+// ExportEnvGroupResponse contains all variables of an env group, with secret variables separated from the others
+type ExportEnvGroupResponse struct {
+	Name            string            `json:"name"`
+	Version         uint              `json:"version"`
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]string `json:"secret_variables"`
+
+	// SecretsRevealed is true if the values of secret variables are in plain text
+	SecretsRevealed bool `json:"secrets_revealed"`
+}
+
 // V1EnvGroupReleaseRequest represents the request body to add or remove a release in an env group
 //
 // swagger:model
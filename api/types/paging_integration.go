@@ -0,0 +1,71 @@
+package types
+
+const (
+	URLParamPagingIntegrationID URLParam = "paging_integration_id"
+)
+
+// PagingProvider is an external incident management service which incidents can be
+// routed to
+type PagingProvider string
+
+const (
+	PagingProviderPagerDuty PagingProvider = "pagerduty"
+	PagingProviderOpsgenie  PagingProvider = "opsgenie"
+)
+
+// PagingRegion is the region of the account with the paging provider, which determines the API that
+// incidents are sent to
+type PagingRegion string
+
+const (
+	PagingRegionUS PagingRegion = "us"
+	PagingRegionEU PagingRegion = "eu"
+)
+
+// IncidentRoutingRule determines which incidents are routed to a paging integration. An
+// incident is routed if it matches any of the integration's rules, or if the integration
+// has no rules.
+type IncidentRoutingRule struct {
+	// ReleaseName is a glob pattern matched against the name of the release, where an
+	// empty pattern matches every release
+	ReleaseName string `json:"release_name"`
+
+	// ReleaseNamespace is a glob pattern matched against the namespace of the release,
+	// where an empty pattern matches every namespace
+	ReleaseNamespace string `json:"release_namespace"`
+
+	// Severities is the list of incident severities to route, where an empty list
+	// matches every severity
+	Severities []SeverityType `json:"severities" form:"omitempty,dive,oneof=critical normal"`
+}
+
+type PagingIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	Name     string         `json:"name"`
+	Provider PagingProvider `json:"provider"`
+	Region   PagingRegion   `json:"region"`
+
+	Rules []IncidentRoutingRule `json:"rules"`
+}
+
+type ListPagingIntegrationsResponse []*PagingIntegration
+
+type CreatePagingIntegrationRequest struct {
+	Name     string         `json:"name" form:"required,max=255"`
+	Provider PagingProvider `json:"provider" form:"required,oneof=pagerduty opsgenie"`
+
+	// Key is the PagerDuty Events v2 routing key, or the Opsgenie API key
+	Key string `json:"key" form:"required"`
+
+	// Region is the region of the account with the provider, which is "us" if it is not set
+	Region PagingRegion `json:"region" form:"omitempty,oneof=us eu"`
+
+	Rules []IncidentRoutingRule `json:"rules" form:"omitempty,dive"`
+}
+
+type UpdatePagingIntegrationRulesRequest struct {
+	Rules []IncidentRoutingRule `json:"rules" form:"dive"`
+}
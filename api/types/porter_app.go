@@ -111,6 +111,10 @@ const (
 	PorterAppEventType_PreDeploy PorterAppEventType = "PRE_DEPLOY"
 	// PorterAppEventType_AppEvent represents a Porter Stack App Event which occurred whilst the application was running, such as an OutOfMemory (OOM) error
 	PorterAppEventType_AppEvent PorterAppEventType = "APP_EVENT"
+	// PorterAppEventType_Sleep represents the web and worker services of a Porter Stack being scaled to zero, either by a sleep schedule or manually
+	PorterAppEventType_Sleep PorterAppEventType = "SLEEP"
+	// PorterAppEventType_Wake represents the web and worker services of a Porter Stack being scaled back up after it was put to sleep
+	PorterAppEventType_Wake PorterAppEventType = "WAKE"
 )
 
 // PorterAppEventStatus is an alias for a string that represents a Porter Stack Event Status
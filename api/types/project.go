@@ -40,6 +40,27 @@ type Project struct {
 	FullAddOns             bool    `json:"full_add_ons"`
 	EnableReprovision      bool    `json:"enable_reprovision"`
 	ValidateApplyV2        bool    `json:"validate_apply_v2"`
+	TwoFactorRequired      bool    `json:"two_factor_required"`
+
+	QuietHours *ProjectQuietHours `json:"quiet_hours,omitempty"`
+}
+
+// ProjectQuietHours is a daily window during which deployment notifications are held, and sent as a
+// single digest once the window ends
+type ProjectQuietHours struct {
+	// Start and End are the local times of day that quiet hours start and end, such as "22:00" and "07:30".
+	// Quiet hours span midnight if End is before Start.
+	Start string `json:"start" form:"required"`
+	End   string `json:"end" form:"required"`
+
+	// Timezone is the IANA timezone of Start and End, such as "America/New_York"
+	Timezone string `json:"timezone" form:"required"`
+}
+
+// UpdateProjectQuietHoursRequest sets the quiet hours of a project. Quiet hours are disabled if
+// QuietHours is not set.
+type UpdateProjectQuietHoursRequest struct {
+	QuietHours *ProjectQuietHours `json:"quiet_hours"`
 }
 
 type FeatureFlags struct {
@@ -91,6 +112,7 @@ type ListProjectRolesResponse []RoleKind
 type Collaborator struct {
 	ID        uint   `json:"id"`
 	Kind      string `json:"kind"`
+	PolicyUID string `json:"policy_uid,omitempty"`
 	UserID    uint   `json:"user_id"`
 	Email     string `json:"email"`
 	ProjectID uint   `json:"project_id"`
@@ -101,6 +123,9 @@ type ListCollaboratorsResponse []*Collaborator
 type UpdateRoleRequest struct {
 	UserID uint   `json:"user_id,required"`
 	Kind   string `json:"kind,required"`
+
+	// PolicyUID is the project policy to assign when Kind is "custom"
+	PolicyUID string `json:"policy_uid"`
 }
 
 type UpdateRoleResponse struct {
@@ -169,6 +194,49 @@ type UpdateOnboardingStepRequest struct {
 }
 
 // UpdateProjectNameRequest takes in a name to rename projects
+// UpdateProjectTwoFactorRequest sets whether collaborators must use two-factor authentication
+type UpdateProjectTwoFactorRequest struct {
+	Required bool `json:"required"`
+}
+
 type UpdateProjectNameRequest struct {
 	Name string `json:"name" form:"required"`
 }
+
+// ExportProjectRequest is the request to export a project into an archive
+type ExportProjectRequest struct {
+	// Passphrase encrypts the credentials of the project in the archive. It is required to import the archive.
+	Passphrase string `json:"passphrase" form:"required,min=12"`
+}
+
+// ExportProjectResponse holds a gzipped archive of all project-scoped models
+type ExportProjectResponse struct {
+	Archive []byte `json:"archive"`
+}
+
+// ImportProjectRequest is the request to import an archive written by a project export into a project
+type ImportProjectRequest struct {
+	Archive    []byte `json:"archive" form:"required"`
+	Passphrase string `json:"passphrase" form:"required"`
+
+	// DryRun validates the archive without creating any models
+	DryRun bool `json:"dry_run"`
+}
+
+// ImportProjectResponse describes the models created by an import. In a dry run, Counts holds the number of
+// models which would be created and IDMappings is empty.
+type ImportProjectResponse struct {
+	DryRun bool `json:"dry_run"`
+
+	// Counts is the number of imported models, keyed by kind
+	Counts map[string]int `json:"counts"`
+
+	// IDMappings maps the id of each model in the exported project to its id in this project, keyed by kind
+	IDMappings map[string]map[string]string `json:"id_mappings"`
+
+	// Warnings are parts of the archive which could not be restored as they were exported
+	Warnings []string `json:"warnings"`
+
+	// Errors prevent the archive from being imported
+	Errors []string `json:"errors"`
+}
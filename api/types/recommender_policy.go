@@ -0,0 +1,135 @@
+package types
+
+import "time"
+
+const (
+	URLParamRecommenderPolicyCollectionID URLParam = "recommender_policy_collection_id"
+)
+
+// RecommenderPolicyKind is the kind of object that the policies of a collection are evaluated against
+type RecommenderPolicyKind string
+
+const (
+	RecommenderPolicyKindHelmRelease RecommenderPolicyKind = "helm_release"
+	RecommenderPolicyKindPod         RecommenderPolicyKind = "pod"
+	RecommenderPolicyKindCRDList     RecommenderPolicyKind = "crd_list"
+	RecommenderPolicyKindDaemonset   RecommenderPolicyKind = "daemonset"
+)
+
+// RecommenderPolicyMatch selects the objects that the policies of a collection are evaluated against. It has the
+// same fields as the match parameters of the built-in policy collections.
+type RecommenderPolicyMatch struct {
+	// KubernetesService limits the collection to clusters of a service, such as "eks"
+	KubernetesService string `json:"kubernetes_service,omitempty"`
+
+	// Name, Namespace and ChartName select helm releases
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	ChartName string `json:"chart_name,omitempty"`
+
+	// Labels select pods and daemonsets
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Group, Version and Resource select the objects of a crd_list collection
+	Group    string `json:"group,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Resource string `json:"resource,omitempty"`
+}
+
+// RecommenderPolicy is a single Rego policy
+type RecommenderPolicy struct {
+	// Name is the package of the Rego module, such as "porter.custom.memory_limits"
+	Name string `json:"name" form:"required"`
+
+	// Module is the Rego source of the policy. The result of the package must have the same fields as
+	// the built-in policies, such as ALLOW, POLICY_ID, POLICY_SEVERITY, POLICY_TITLE and FAILURE_MESSAGE.
+	Module string `json:"module" form:"required"`
+
+	// Enforcement is whether the policy also gates deploys. Policies without an enforcement are only run by the
+	// recommender.
+	Enforcement RecommenderPolicyEnforcement `json:"enforcement,omitempty" form:"omitempty,oneof=warn block"`
+}
+
+// RecommenderPolicyEnforcement is how a policy is enforced when a release is installed or upgraded
+type RecommenderPolicyEnforcement string
+
+const (
+	// RecommenderPolicyEnforcementWarn deploys the release, and returns the violations of the policy as warnings
+	RecommenderPolicyEnforcementWarn RecommenderPolicyEnforcement = "warn"
+
+	// RecommenderPolicyEnforcementBlock stops the deploy of a release which violates the policy, unless a
+	// project admin overrides it
+	RecommenderPolicyEnforcementBlock RecommenderPolicyEnforcement = "block"
+)
+
+// RecommenderPolicyCollection is a set of Rego policies defined by a project, which the recommender runs
+// alongside the built-in policies
+type RecommenderPolicyCollection struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+
+	// Name is the name of the collection. Results of the collection have the category "custom-<name>".
+	Name string `json:"name"`
+
+	Kind             RecommenderPolicyKind  `json:"kind"`
+	Match            RecommenderPolicyMatch `json:"match"`
+	MustExist        bool                   `json:"must_exist"`
+	OverrideSeverity MonitorTestSeverity    `json:"override_severity,omitempty"`
+
+	Policies []RecommenderPolicy `json:"policies"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateRecommenderPolicyCollectionRequest creates a policy collection, or replaces the collection with the same name
+type CreateRecommenderPolicyCollectionRequest struct {
+	Name             string                 `json:"name" form:"required,max=63"`
+	Kind             RecommenderPolicyKind  `json:"kind" form:"required,oneof=helm_release pod crd_list daemonset"`
+	Match            RecommenderPolicyMatch `json:"match"`
+	MustExist        bool                   `json:"must_exist"`
+	OverrideSeverity MonitorTestSeverity    `json:"override_severity" form:"omitempty,oneof=critical high low"`
+
+	Policies []RecommenderPolicy `json:"policies" form:"required,min=1,dive"`
+}
+
+type ListRecommenderPolicyCollectionsResponse []*RecommenderPolicyCollection
+
+// PolicyViolation is a failed policy found when the rendered manifests of a release are evaluated before a deploy
+type PolicyViolation struct {
+	// Collection is the name of the policy collection of the policy
+	Collection string `json:"collection"`
+
+	PolicyID    string                       `json:"policy_id"`
+	PolicyTitle string                       `json:"policy_title"`
+	Severity    string                       `json:"severity"`
+	Enforcement RecommenderPolicyEnforcement `json:"enforcement"`
+
+	// ObjectID is the object which violates the policy, such as "deployment/default/web"
+	ObjectID string `json:"object_id"`
+	Message  string `json:"message"`
+}
+
+// PolicyOverrideRequest lets a project admin deploy a release which violates blocking policies. The override
+// is recorded as a policy override event.
+type PolicyOverrideRequest struct {
+	OverridePolicies bool   `json:"override_policies,omitempty"`
+	OverrideReason   string `json:"override_reason,omitempty"`
+}
+
+// PolicyOverrideEvent records a deploy which violated blocking policies and was overridden by a project admin
+type PolicyOverrideEvent struct {
+	ID        uint   `json:"id"`
+	ProjectID uint   `json:"project_id"`
+	ClusterID uint   `json:"cluster_id"`
+	UserID    uint   `json:"user_id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Reason    string `json:"reason"`
+
+	Violations []*PolicyViolation `json:"violations"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type ListPolicyOverrideEventsResponse []*PolicyOverrideEvent
@@ -51,6 +51,10 @@ type UpdateNotificationConfigRequest struct {
 		Enabled bool `json:"enabled"`
 		Success bool `json:"success"`
 		Failure bool `json:"failure"`
+
+		// NotifLimit is the minimum time between notifications for the release, such as "30m", "2h" or "1d"
+		NotifLimit string `json:"notif_limit"`
+		Digest     bool   `json:"digest"`
 	} `json:"payload"`
 }
 
@@ -72,6 +76,8 @@ type CreateReleaseBaseRequest struct {
 	// The name of this release
 	// required: true
 	Name string `json:"name" form:"required,dns1123"`
+
+	PolicyOverrideRequest
 }
 
 // swagger:model
@@ -117,6 +123,8 @@ type V1UpgradeReleaseRequest struct {
 	// (optional) if set, the backend will validate that the user was upgrading from the revision specified by
 	// LatestRevision, and there hasn't been an upgrade in the meantime.
 	LatestRevision uint `json:"latest_revision"`
+
+	PolicyOverrideRequest
 }
 
 type UpgradeReleaseRequest struct {
@@ -129,6 +137,14 @@ type UpgradeReleaseRequest struct {
 
 	// Required to ignore dependecies when we read releases for umbrella charts, because their subcharts aren't Porter charts https://github.com/helm/helm/issues/9214
 	IgnoreDependencies bool
+
+	PolicyOverrideRequest
+}
+
+// DeployReleaseResponse contains the violations of warn-only policies, and of blocking policies which were
+// overridden, found when a release was evaluated before it was installed or upgraded
+type DeployReleaseResponse struct {
+	PolicyWarnings []*PolicyViolation `json:"policy_warnings,omitempty"`
 }
 
 type UpdateImageBatchRequest struct {
@@ -186,7 +202,13 @@ type NotificationConfig struct {
 	Success bool `json:"success"`
 	Failure bool `json:"failure"`
 
+	// NotifLimit is the minimum time between notifications for the release. Notifications within the limit
+	// are summarized in a digest once the limit has passed.
 	NotifLimit string `json:"notif_limit"`
+
+	// Digest batches every notification into a single summary per NotifLimit, instead of sending the first
+	// notification in each window immediately
+	Digest bool `json:"digest"`
 }
 
 type GetNotificationConfigResponse struct {
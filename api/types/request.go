@@ -78,10 +78,22 @@ type APIRequestMetadata struct {
 
 	// The usage metric that the request should check for, if CheckUsage
 	UsageMetric UsageMetric
+
+	// An instance of the type the handler decodes requests into, used to document the endpoint in the
+	// OpenAPI document. Fields with a schema tag are documented as query parameters, and fields with a
+	// json tag as the request body
+	Request interface{}
+
+	// An instance of the type the handler writes in successful responses, used to document the endpoint
+	// in the OpenAPI document
+	Response interface{}
 }
 
 const RequestScopeCtxKey = "requestscopes"
 
+// TwoFactorVerifiedCtxKey is set by authentication to whether the user provided a second factor when logging in
+const TwoFactorVerifiedCtxKey = "two_factor_verified"
+
 type RequestAction struct {
 	Verb     APIVerb
 	Resource NameOrUInt
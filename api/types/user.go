@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type User struct {
 	ID            uint   `json:"id"`
 	Email         string `json:"email"`
@@ -27,7 +29,19 @@ type LoginUserRequest struct {
 	Password string `json:"password" form:"required,max=255"`
 }
 
-type LoginUserResponse User
+// LoginUserResponse is the response to a password login. If TwoFactorRequired is set, the
+// session is not authenticated until the second factor is provided to POST /api/login/2fa.
+type LoginUserResponse struct {
+	User
+
+	TwoFactorRequired bool `json:"two_factor_required,omitempty"`
+}
+
+// LoginTwoFactorRequest completes a login which was challenged for a second factor. The code
+// may either be a TOTP code or an unused recovery code.
+type LoginTwoFactorRequest struct {
+	Code string `json:"code" form:"required,max=32"`
+}
 
 type CLILoginUserRequest struct {
 	Redirect string `schema:"redirect" form:"required"`
@@ -82,3 +96,51 @@ type UpdateUserInfoRequest struct {
 	LastName    string `json:"last_name" form:"required,max=255"`
 	CompanyName string `json:"company_name" form:"required,max=255"`
 }
+
+// GetTwoFactorStatusResponse describes the two-factor enrollment of the current user
+type GetTwoFactorStatusResponse struct {
+	Enabled                bool `json:"enabled"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+}
+
+// EnrollTwoFactorResponse contains the TOTP secret for a new enrollment. The provisioning URI
+// can be rendered as a QR code for authenticator apps.
+type EnrollTwoFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// TwoFactorCodeRequest is used to confirm or disable two-factor authentication
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" form:"required,max=32"`
+}
+
+// ConfirmTwoFactorResponse contains the single-use recovery codes, which are only shown once
+type ConfirmTwoFactorResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// URLParamSessionID is the URL parameter for a session id
+const URLParamSessionID URLParam = "session_id"
+
+// Session is an active cookie session for a user
+type Session struct {
+	ID         uint       `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	IPAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+
+	// Current is set for the session which made the request
+	Current bool `json:"current"`
+}
+
+// ListSessionsResponse is the response for GET /api/users/current/sessions
+type ListSessionsResponse []*Session
+
+// RevokeCollaboratorSessionsRequest revokes all sessions of a project collaborator. Sessions are not scoped
+// to a project, so the collaborator is logged out of every project.
+type RevokeCollaboratorSessionsRequest struct {
+	UserID uint `schema:"user_id,required"`
+}
@@ -0,0 +1,35 @@
+package types
+
+const (
+	URLParamWebhookIntegrationID URLParam = "webhook_integration_id"
+)
+
+type WebhookIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the webhook
+	Name string `json:"name"`
+
+	// The host that payloads are sent to
+	Host string `json:"host"`
+}
+
+type ListWebhookIntegrationsResponse []*WebhookIntegration
+
+type CreateWebhookIntegrationRequest struct {
+	Name string `json:"name" form:"required,max=255"`
+	URL  string `json:"url" form:"required,url"`
+
+	// SigningSecret is used to sign each payload. If it is not set, a secret is generated
+	// and returned in the response.
+	SigningSecret string `json:"signing_secret"`
+}
+
+type CreateWebhookIntegrationResponse struct {
+	*WebhookIntegration
+
+	// SigningSecret is only returned when the integration is created
+	SigningSecret string `json:"signing_secret"`
+}
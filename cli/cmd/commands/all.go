@@ -43,9 +43,12 @@ func RegisterCommands() (*cobra.Command, error) {
 	rootCmd.AddCommand(registerCommand_PortForward(cliConf))
 	rootCmd.AddCommand(registerCommand_Project(cliConf))
 	rootCmd.AddCommand(registerCommand_Registry(cliConf))
+	rootCmd.AddCommand(registerCommand_Release(cliConf))
 	rootCmd.AddCommand(registerCommand_Run(cliConf))
 	rootCmd.AddCommand(registerCommand_Server(cliConf))
 	rootCmd.AddCommand(registerCommand_Stack(cliConf))
+	rootCmd.AddCommand(registerCommand_Target(cliConf))
+	rootCmd.AddCommand(registerCommand_Token(cliConf))
 	rootCmd.AddCommand(registerCommand_Update(cliConf))
 	rootCmd.AddCommand(registerCommand_Version(cliConf))
 	return rootCmd, nil
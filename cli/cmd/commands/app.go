@@ -14,6 +14,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/porter-dev/porter/cli/cmd/utils"
+	v2 "github.com/porter-dev/porter/cli/cmd/v2"
 	"github.com/spf13/cobra"
 	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
@@ -38,6 +39,13 @@ var (
 	appExistingPod   bool
 	appInteractive   bool
 	appContainerName string
+	appPromoteFrom   string
+	appPromoteTo     string
+	appDiffFrom      uint64
+	appDiffTo        uint64
+	appDiffTarget    string
+	appDiffFile      string
+	appSleepTarget   string
 	appTag           string
 	appCpuMilli      int
 	appMemoryMi      int
@@ -100,9 +108,206 @@ func registerCommand_App(cliConf config.CLIConfig) *cobra.Command {
 	)
 	appCmd.AddCommand(appUpdateTagCmd)
 
+	// appPromoteCmd represents the "porter app promote" subcommand
+	appPromoteCmd := &cobra.Command{
+		Use:   "promote [application]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Promotes the current revision of an application from one deployment target to another.",
+		Long: fmt.Sprintf(`
+%s
+
+Promotes the current revision of an application from one deployment target to another, reusing
+the image which was built for the source target. Env overrides configured on the destination
+target are layered on top of the application's environment. If --from or --to is not set, the
+default deployment target of the current cluster is used. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter app promote\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter app promote my-app --from staging --to prod-us"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, appPromote)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	appPromoteCmd.PersistentFlags().StringVar(&appPromoteFrom, "from", "", "the name of the deployment target to promote from")
+	appPromoteCmd.PersistentFlags().StringVar(&appPromoteTo, "to", "", "the name of the deployment target to promote to")
+	appCmd.AddCommand(appPromoteCmd)
+
+	// appDiffCmd represents the "porter app diff" subcommand
+	appDiffCmd := &cobra.Command{
+		Use:   "diff [application]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Shows the changes between two revisions of an application.",
+		Long: fmt.Sprintf(`
+%s
+
+Shows the changes to the image, resources, env group versions, domains and autoscaling of each
+service between two revisions of an application. If --from or --to is not set, the current
+revision is used. For example:
+
+  %s
+
+To show the changes between a local porter.yaml and the current revision, pass the file:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter app diff\":"),
+			color.GreenString("porter app diff my-app --from 3 --to 5"),
+			color.GreenString("porter app diff my-app -f porter.yaml"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, appDiff)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	appDiffCmd.PersistentFlags().Uint64Var(&appDiffFrom, "from", 0, "the revision number to diff from")
+	appDiffCmd.PersistentFlags().Uint64Var(&appDiffTo, "to", 0, "the revision number to diff to")
+	appDiffCmd.PersistentFlags().StringVar(&appDiffTarget, "target", "", "the name of the deployment target of the revisions")
+	appDiffCmd.PersistentFlags().StringVarP(&appDiffFile, "file", "f", "", "path to a porter.yaml to diff against the current revision")
+	appCmd.AddCommand(appDiffCmd)
+
+	// appSleepCmd represents the "porter app sleep" subcommand
+	appSleepCmd := &cobra.Command{
+		Use:   "sleep [application]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Scales the web and worker services of an application to zero.",
+		Long: fmt.Sprintf(`
+%s
+
+Scales the web and worker services of an application to zero and disables their autoscaling.
+The instance counts and autoscaling settings are restored when the application is woken up with
+"porter app wake". If a sleep schedule applies to the application, the application stays asleep
+until the schedule next wakes it up. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter app sleep\":"),
+			color.GreenString("porter app sleep my-app --target staging"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, appSleep)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	appSleepCmd.PersistentFlags().StringVar(&appSleepTarget, "target", "", "the name of the deployment target of the application")
+	appCmd.AddCommand(appSleepCmd)
+
+	// appWakeCmd represents the "porter app wake" subcommand
+	appWakeCmd := &cobra.Command{
+		Use:   "wake [application]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Restores the scale of an application which was put to sleep.",
+		Long: fmt.Sprintf(`
+%s
+
+Restores the instance counts and autoscaling settings that the web and worker services of an
+application had before it was put to sleep. If a sleep schedule applies to the application, the
+application stays awake until the schedule next puts it to sleep. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter app wake\":"),
+			color.GreenString("porter app wake my-app --target staging"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, appWake)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	appWakeCmd.PersistentFlags().StringVar(&appSleepTarget, "target", "", "the name of the deployment target of the application")
+	appCmd.AddCommand(appWakeCmd)
+
 	return appCmd
 }
 
+func appSleep(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	project, err := client.GetProject(ctx, cliConfig.Project)
+	if err != nil {
+		return fmt.Errorf("could not retrieve project from Porter API. Please contact support@porter.run")
+	}
+
+	if !project.ValidateApplyV2 {
+		return fmt.Errorf("putting applications to sleep is not supported for this project")
+	}
+
+	return v2.Sleep(ctx, cliConfig, client, v2.SleepInput{
+		AppName:    args[0],
+		TargetName: appSleepTarget,
+	})
+}
+
+func appWake(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	project, err := client.GetProject(ctx, cliConfig.Project)
+	if err != nil {
+		return fmt.Errorf("could not retrieve project from Porter API. Please contact support@porter.run")
+	}
+
+	if !project.ValidateApplyV2 {
+		return fmt.Errorf("waking applications is not supported for this project")
+	}
+
+	return v2.Sleep(ctx, cliConfig, client, v2.SleepInput{
+		AppName:    args[0],
+		TargetName: appSleepTarget,
+		Wake:       true,
+	})
+}
+
+func appDiff(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	project, err := client.GetProject(ctx, cliConfig.Project)
+	if err != nil {
+		return fmt.Errorf("could not retrieve project from Porter API. Please contact support@porter.run")
+	}
+
+	if !project.ValidateApplyV2 {
+		return fmt.Errorf("diffing revisions is not supported for this project")
+	}
+
+	if len(args) == 0 && appDiffFile == "" {
+		return fmt.Errorf("an application name or a porter.yaml must be provided")
+	}
+
+	var appName string
+	if len(args) > 0 {
+		appName = args[0]
+	}
+
+	return v2.Diff(ctx, cliConfig, client, v2.DiffInput{
+		AppName:        appName,
+		TargetName:     appDiffTarget,
+		FromRevision:   appDiffFrom,
+		ToRevision:     appDiffTo,
+		PorterYamlPath: appDiffFile,
+	})
+}
+
+func appPromote(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConfig config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	project, err := client.GetProject(ctx, cliConfig.Project)
+	if err != nil {
+		return fmt.Errorf("could not retrieve project from Porter API. Please contact support@porter.run")
+	}
+
+	if !project.ValidateApplyV2 {
+		return fmt.Errorf("promoting applications is not supported for this project")
+	}
+
+	return v2.Promote(ctx, cliConfig, client, args[0], appPromoteFrom, appPromoteTo)
+}
+
 func appRunFlags(appRunCmd *cobra.Command) {
 	appRunCmd.PersistentFlags().BoolVarP(
 		&appExistingPod,
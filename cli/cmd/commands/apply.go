@@ -39,7 +39,11 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-var porterYAML string
+var (
+	porterYAML  string
+	applyTarget string
+	applyDryRun bool
+)
 
 func registerCommand_Apply(cliConf config.CLIConfig) *cobra.Command {
 	applyCmd := &cobra.Command{
@@ -104,6 +108,18 @@ applying a configuration:
 
 	applyCmd.PersistentFlags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
 	applyCmd.MarkFlagRequired("file")
+	applyCmd.PersistentFlags().StringVar(
+		&applyTarget,
+		"target",
+		"",
+		"the name of the deployment target to apply to (defaults to the default target of the current cluster)",
+	)
+	applyCmd.PersistentFlags().BoolVar(
+		&applyDryRun,
+		"dry-run",
+		false,
+		"show the changes between the porter.yaml and the deployed app without applying them",
+	)
 
 	return applyCmd
 }
@@ -121,8 +137,16 @@ func apply(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client ap
 		appName = os.Getenv("PORTER_STACK_NAME")
 	}
 
+	if applyTarget != "" && !project.ValidateApplyV2 {
+		return fmt.Errorf("deployment targets are not supported for this project")
+	}
+
+	if applyDryRun && !project.ValidateApplyV2 {
+		return fmt.Errorf("dry runs are not supported for this project")
+	}
+
 	if project.ValidateApplyV2 {
-		err = v2.Apply(ctx, cliConfig, client, porterYAML, appName)
+		err = v2.Apply(ctx, cliConfig, client, porterYAML, appName, applyTarget, applyDryRun)
 		if err != nil {
 			return err
 		}
@@ -468,7 +492,7 @@ func (d *DeployDriver) applyAddon(ctx context.Context, resource *switchboardMode
 			return nil, fmt.Errorf("error marshalling addon config from resource %s: %w", resource.Name, err)
 		}
 
-		err = client.UpgradeRelease(
+		resp, err := client.UpgradeRelease(
 			ctx,
 			d.target.Project,
 			d.target.Cluster,
@@ -482,6 +506,8 @@ func (d *DeployDriver) applyAddon(ctx context.Context, resource *switchboardMode
 		if err != nil {
 			return nil, fmt.Errorf("error updating addon from resource %s: %w", resource.Name, err)
 		}
+
+		cliUtils.PrintPolicyViolations(resp.PolicyWarnings)
 	}
 
 	if err = d.assignOutput(ctx, resource, client); err != nil {
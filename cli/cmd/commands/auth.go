@@ -19,6 +19,8 @@ import (
 
 var manual bool = false
 
+var sso bool = false
+
 func registerCommand_Auth(cliConf config.CLIConfig) *cobra.Command {
 	authCmd := &cobra.Command{
 		Use:   "auth",
@@ -71,6 +73,7 @@ func registerCommand_Auth(cliConf config.CLIConfig) *cobra.Command {
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(registerCmd)
 	authCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(sessionsCommand(cliConf))
 
 	loginCmd.PersistentFlags().BoolVar(
 		&manual,
@@ -79,6 +82,13 @@ func registerCommand_Auth(cliConf config.CLIConfig) *cobra.Command {
 		"whether to prompt for manual authentication (username/pw)",
 	)
 
+	loginCmd.PersistentFlags().BoolVar(
+		&sso,
+		"sso",
+		false,
+		"whether to log in through the server's configured OIDC single sign-on provider",
+	)
+
 	return authCmd
 }
 
@@ -107,7 +117,7 @@ func login(ctx context.Context, cliConf config.CLIConfig) error {
 		}
 
 		// log the user in
-		token, err := loginBrowser.Login(cliConf.Host)
+		token, err := loginBrowser.Login(cliConf.Host, sso)
 		if err != nil {
 			return err
 		}
@@ -211,7 +221,7 @@ func loginManual(ctx context.Context, cliConf config.CLIConfig, client api.Clien
 	if err != nil {
 		return err
 	}
-	_, err = client.Login(ctx, &types.LoginUserRequest{
+	loginResp, err := client.Login(ctx, &types.LoginUserRequest{
 		Email:    username,
 		Password: pw,
 	})
@@ -219,6 +229,20 @@ func loginManual(ctx context.Context, cliConf config.CLIConfig, client api.Clien
 		return err
 	}
 
+	if loginResp.TwoFactorRequired {
+		code, err := utils.PromptPlaintext("Two-factor code (or recovery code): ")
+		if err != nil {
+			return err
+		}
+
+		_, err = client.LoginTwoFactor(ctx, &types.LoginTwoFactorRequest{
+			Code: code,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	// set the token to empty since this is manual (cookie-based) login
 	cliConf.SetToken("")
 
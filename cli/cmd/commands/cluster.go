@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -48,6 +49,36 @@ func registerCommand_Cluster(cliConf config.CLIConfig) *cobra.Command {
 	}
 	clusterCmd.AddCommand(clusterDeleteCmd)
 
+	clusterDoctorCmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Checks the health of the current cluster",
+		Long: fmt.Sprintf(`
+%s
+
+Checks the health of the current cluster, including its nodes, version skew between the
+control plane and nodes, pending pods, DaemonSets, persistent volume usage, the Porter agent,
+the ingress load balancer, Prometheus and certificates. Each finding comes with a hint on how
+to fix it. Exits with a non-zero code if any check finds a warning or critical problem.
+
+  %s`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter cluster doctor\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter cluster doctor"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, clusterDoctor)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+	clusterDoctorCmd.Flags().StringVar(
+		&output,
+		"output",
+		"",
+		"the output format to use (\"json\")",
+	)
+	clusterCmd.AddCommand(clusterDoctorCmd)
+
 	clusterNamespaceCmd := &cobra.Command{
 		Use:     "namespace",
 		Aliases: []string{"namespaces"},
@@ -159,3 +190,81 @@ func listNamespaces(ctx context.Context, user *types.GetAuthenticatedUserRespons
 
 	return nil
 }
+
+func clusterDoctor(ctx context.Context, user *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, args []string) error {
+	report, err := client.GetClusterHealth(ctx, cliConf.Project, cliConf.Cluster)
+	if err != nil {
+		return fmt.Errorf("error getting cluster health: %w", err)
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(bytes))
+	} else {
+		printClusterHealthReport(report)
+	}
+
+	if !report.Healthy {
+		return fmt.Errorf("cluster %d is not healthy", cliConf.Cluster)
+	}
+
+	return nil
+}
+
+func printClusterHealthReport(report *types.GetClusterHealthResponse) {
+	severityColors := map[types.ClusterHealthSeverity]*color.Color{
+		types.ClusterHealthSeverityInfo:     color.New(color.FgBlue),
+		types.ClusterHealthSeverityWarning:  color.New(color.FgYellow),
+		types.ClusterHealthSeverityCritical: color.New(color.FgRed),
+	}
+
+	for _, check := range report.Checks {
+		switch {
+		case check.Error != "":
+			color.New(color.FgYellow).Printf("? %s: could not be checked: %s\n", check.Name, check.Error)
+			continue
+		case len(check.Findings) == 0:
+			color.New(color.FgGreen).Printf("✓ %s\n", check.Name)
+			continue
+		}
+
+		worst := types.ClusterHealthSeverityInfo
+		for _, finding := range check.Findings {
+			if finding.Severity == types.ClusterHealthSeverityCritical ||
+				(finding.Severity == types.ClusterHealthSeverityWarning && worst == types.ClusterHealthSeverityInfo) {
+				worst = finding.Severity
+			}
+		}
+
+		if worst == types.ClusterHealthSeverityInfo {
+			color.New(color.FgGreen).Printf("✓ %s\n", check.Name)
+		} else {
+			severityColors[worst].Printf("✗ %s\n", check.Name)
+		}
+
+		for _, finding := range check.Findings {
+			c, ok := severityColors[finding.Severity]
+			if !ok {
+				c = color.New()
+			}
+
+			if finding.Object != "" {
+				c.Printf("    [%s] %s: %s\n", finding.Severity, finding.Object, finding.Message)
+			} else {
+				c.Printf("    [%s] %s\n", finding.Severity, finding.Message)
+			}
+
+			if finding.Remediation != "" {
+				fmt.Printf("        %s\n", finding.Remediation)
+			}
+		}
+	}
+
+	if report.Healthy {
+		color.New(color.FgGreen).Println("\nThe cluster is healthy")
+	}
+}
@@ -0,0 +1,340 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/server/handlers/environment_groups"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	envGroupFile          string
+	envGroupSecretKeys    []string
+	envGroupExportFormat  string
+	envGroupRevealSecrets bool
+)
+
+// envGroupExport is the document written by `porter update env-group export` in the json and yaml formats
+type envGroupExport struct {
+	Name            string            `json:"name"`
+	Version         uint              `json:"version"`
+	Variables       map[string]string `json:"variables"`
+	SecretVariables map[string]string `json:"secret_variables"`
+}
+
+func registerCommand_EnvGroupTransfer(cliConf config.CLIConfig, updateEnvGroupCmd *cobra.Command) {
+	importEnvGroupCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Sets all variables from a .env file in an env group, creating a single new version.",
+		Long: fmt.Sprintf(`
+%s
+
+Sets all variables read from a .env file in an env group. Variables which already exist in the env group
+and are not in the file are kept. All variables are written in a single new version of the env group.
+
+Variables listed in --secret-keys are stored as secrets. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update env-group import\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter update env-group import --name my-env-group --file .env --secret-keys DB_PASSWORD,API_KEY"),
+		),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, importEnvGroup)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	importEnvGroupCmd.Flags().StringVarP(
+		&envGroupFile,
+		"file",
+		"f",
+		"",
+		"path to the .env file to import",
+	)
+
+	importEnvGroupCmd.Flags().StringSliceVar(
+		&envGroupSecretKeys,
+		"secret-keys",
+		[]string{},
+		"comma-separated list of variables in the file to store as secrets",
+	)
+
+	importEnvGroupCmd.MarkFlagRequired("file")
+
+	exportEnvGroupCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Writes the variables of an env group in the dotenv, json or yaml format.",
+		Long: fmt.Sprintf(`
+%s
+
+Writes the variables of an env group to stdout, or to the file given by --file. Secret values are masked
+unless --reveal-secrets is set, which is only allowed for project admins. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update env-group export\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter update env-group export --name my-env-group --format json"),
+		),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, exportEnvGroup)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	exportEnvGroupCmd.Flags().StringVarP(
+		&envGroupFile,
+		"file",
+		"f",
+		"",
+		"file to write the env group to (defaults to stdout)",
+	)
+
+	exportEnvGroupCmd.Flags().StringVar(
+		&envGroupExportFormat,
+		"format",
+		"dotenv",
+		"the output format (one of \"dotenv\", \"json\" or \"yaml\")",
+	)
+
+	exportEnvGroupCmd.Flags().BoolVar(
+		&envGroupRevealSecrets,
+		"reveal-secrets",
+		false,
+		"include secret values in plain text (project admins only)",
+	)
+
+	updateEnvGroupCmd.AddCommand(importEnvGroupCmd)
+	updateEnvGroupCmd.AddCommand(exportEnvGroupCmd)
+}
+
+func importEnvGroup(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, args []string) error {
+	file, err := os.Open(envGroupFile)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", envGroupFile, err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	fileVars, err := utils.ParseDotenv(file)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", envGroupFile, err)
+	}
+
+	if len(fileVars) == 0 {
+		return fmt.Errorf("no variables found in %s", envGroupFile)
+	}
+
+	variables := make(map[string]string)
+	secretVariables := make(map[string]string)
+
+	for key, value := range fileVars {
+		variables[key] = value
+	}
+
+	for _, key := range envGroupSecretKeys {
+		value, ok := variables[key]
+		if !ok {
+			return fmt.Errorf("secret key %s not found in %s", key, envGroupFile)
+		}
+
+		delete(variables, key)
+		secretVariables[key] = value
+	}
+
+	s := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
+	s.Color("cyan")
+
+	if featureFlags.ValidateApplyV2Enabled {
+		s.Suffix = fmt.Sprintf(" Importing %d variables into env group '%s'", len(fileVars), name)
+		s.Start()
+
+		// merging on the server keeps existing secrets without ever sending them to the CLI
+		_, err = client.UpdateEnvironmentGroup(ctx, cliConf.Project, cliConf.Cluster, &environment_groups.UpdateEnvironmentGroupRequest{
+			Name:            name,
+			Variables:       variables,
+			SecretVariables: secretVariables,
+			Merge:           true,
+		})
+
+		s.Stop()
+
+		if err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Printf("imported %d variables into env group '%s'\n", len(fileVars), name)
+
+		return nil
+	}
+
+	s.Suffix = fmt.Sprintf(" Fetching env group '%s' in namespace '%s'", name, namespace)
+	s.Start()
+
+	envGroupResp, err := client.GetEnvGroup(ctx, cliConf.Project, cliConf.Cluster, namespace,
+		&types.GetEnvGroupRequest{
+			Name: name, Version: version,
+		},
+	)
+
+	s.Stop()
+
+	// importing into an env group which does not exist yet creates it
+	if err != nil && !strings.Contains(err.Error(), "not found") {
+		return err
+	}
+
+	newEnvGroup := &types.CreateEnvGroupRequest{
+		Name:            name,
+		Variables:       make(map[string]string),
+		SecretVariables: secretVariables,
+	}
+
+	// existing secrets are referenced by their PORTERSECRET placeholder, which the server resolves when creating the new version
+	if err == nil {
+		for k, v := range envGroupResp.Variables {
+			newEnvGroup.Variables[k] = v
+		}
+	}
+
+	for k := range secretVariables {
+		delete(newEnvGroup.Variables, k)
+	}
+
+	for k, v := range variables {
+		newEnvGroup.Variables[k] = v
+	}
+
+	s.Suffix = fmt.Sprintf(" Importing %d variables into env group '%s' in namespace '%s'", len(fileVars), name, namespace)
+	s.Start()
+
+	_, err = client.CreateEnvGroup(
+		ctx, cliConf.Project, cliConf.Cluster, namespace, newEnvGroup,
+	)
+
+	s.Stop()
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("imported %d variables into env group '%s'\n", len(fileVars), name)
+
+	return nil
+}
+
+func exportEnvGroup(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, featureFlags config.FeatureFlags, args []string) error {
+	if envGroupExportFormat != "dotenv" && envGroupExportFormat != "json" && envGroupExportFormat != "yaml" {
+		return fmt.Errorf("invalid format %q: must be one of \"dotenv\", \"json\" or \"yaml\"", envGroupExportFormat)
+	}
+
+	var export envGroupExport
+	var secretsRevealed bool
+
+	if featureFlags.ValidateApplyV2Enabled {
+		resp, err := client.ExportEnvironmentGroup(ctx, cliConf.Project, cliConf.Cluster, name, &environment_groups.ExportEnvironmentGroupRequest{
+			Version:       int(version),
+			RevealSecrets: envGroupRevealSecrets,
+		})
+		if err != nil {
+			return err
+		}
+
+		export = envGroupExport{
+			Name:            resp.Name,
+			Version:         uint(resp.Version),
+			Variables:       resp.Variables,
+			SecretVariables: resp.SecretVariables,
+		}
+		secretsRevealed = resp.SecretsRevealed
+	} else {
+		resp, err := client.ExportEnvGroup(ctx, cliConf.Project, cliConf.Cluster, namespace, &types.ExportEnvGroupRequest{
+			Name:          name,
+			Version:       version,
+			RevealSecrets: envGroupRevealSecrets,
+		})
+		if err != nil {
+			return err
+		}
+
+		export = envGroupExport{
+			Name:            resp.Name,
+			Version:         resp.Version,
+			Variables:       resp.Variables,
+			SecretVariables: resp.SecretVariables,
+		}
+		secretsRevealed = resp.SecretsRevealed
+	}
+
+	var output []byte
+
+	switch envGroupExportFormat {
+	case "json":
+		bytes, err := json.MarshalIndent(export, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		output = append(bytes, '\n')
+	case "yaml":
+		bytes, err := yaml.Marshal(export)
+		if err != nil {
+			return err
+		}
+
+		output = bytes
+	default:
+		vars := make(map[string]string)
+		for k, v := range export.Variables {
+			vars[k] = v
+		}
+		for k, v := range export.SecretVariables {
+			vars[k] = v
+		}
+
+		output = []byte(utils.FormatDotenv(vars))
+	}
+
+	if !secretsRevealed && len(export.SecretVariables) > 0 {
+		secretKeys := make([]string, 0, len(export.SecretVariables))
+		for k := range export.SecretVariables {
+			secretKeys = append(secretKeys, k)
+		}
+		sort.Strings(secretKeys)
+
+		_, _ = color.New(color.FgYellow).Fprintf(os.Stderr, "values of secret variables %v are masked, use --reveal-secrets to include them\n", secretKeys)
+	}
+
+	if envGroupFile == "" {
+		fmt.Print(string(output))
+		return nil
+	}
+
+	// the export may contain secrets, so it is only readable by the current user
+	err := os.WriteFile(envGroupFile, output, 0o600)
+	if err != nil {
+		return fmt.Errorf("error writing %s: %w", envGroupFile, err)
+	}
+
+	color.New(color.FgGreen).Printf("exported env group '%s' to %s\n", export.Name, envGroupFile)
+
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	cliErrors "github.com/porter-dev/porter/cli/cmd/errors"
+	cliUtils "github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -79,6 +80,11 @@ func checkLoginAndRunWithConfig(cmd *cobra.Command, cliConf config.CLIConfig, ar
 			return nil
 		}
 
+		var policyErr *api.PolicyViolationError
+		if errors.As(err, &policyErr) {
+			cliUtils.PrintPolicyViolations(policyErr.Violations)
+		}
+
 		cliErrors.GetErrorHandler(cliConf).HandleError(err)
 
 		return err
@@ -6,12 +6,15 @@ import (
 	"os"
 	"os/exec"
 
+	"github.com/fatih/color"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/spf13/cobra"
 )
 
+var helmStorageTo string
+
 func registerCommand_Helm(cliConf config.CLIConfig) *cobra.Command {
 	helmCmd := &cobra.Command{
 		Use:   "helm",
@@ -24,6 +27,45 @@ func registerCommand_Helm(cliConf config.CLIConfig) *cobra.Command {
 		},
 	}
 
+	migrateStorageCmd := &cobra.Command{
+		Use:   "migrate-storage --to [sql|secret]",
+		Args:  cobra.NoArgs,
+		Short: "Moves the Helm releases of the current cluster between secret and SQL storage.",
+		Long: fmt.Sprintf(`
+%s
+
+Moves every revision of the Helm releases in the current cluster between release secrets and the
+Postgres database which the Porter server uses for Helm's SQL storage driver, keeping their revision
+numbers, and switches the storage Porter reads the releases of the cluster from. Revisions are only
+deleted from the old storage once the cluster has been switched, so the command can be run again if
+it fails. For example:
+
+  %s
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter helm migrate-storage\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter helm migrate-storage --to sql"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter helm migrate-storage --to secret"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, migrateHelmStorage)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	migrateStorageCmd.Flags().StringVar(
+		&helmStorageTo,
+		"to",
+		"",
+		"the storage to move releases to (\"sql\" or \"secret\")",
+	)
+
+	migrateStorageCmd.MarkFlagRequired("to")
+
+	helmCmd.AddCommand(migrateStorageCmd)
+
 	return helmCmd
 }
 
@@ -57,3 +99,20 @@ func runHelm(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client
 
 	return nil
 }
+
+func migrateHelmStorage(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, _ []string) error {
+	if helmStorageTo != "sql" && helmStorageTo != "secret" {
+		return fmt.Errorf("--to must be one of: sql, secret")
+	}
+
+	resp, err := client.UpdateClusterHelmStorage(ctx, cliConf.Project, cliConf.Cluster, &types.UpdateClusterHelmStorageRequest{
+		Storage: helmStorageTo,
+	})
+	if err != nil {
+		return fmt.Errorf("error migrating helm storage: %w", err)
+	}
+
+	color.New(color.FgGreen).Printf("wrote %d release revisions to %s storage\n", resp.MigratedRevisions, resp.Storage)
+
+	return nil
+}
@@ -61,6 +61,8 @@ func registerCommand_Project(cliConf config.CLIConfig) *cobra.Command {
 	}
 	projectCmd.AddCommand(listProjectCmd)
 
+	registerCommand_ProjectTransfer(cliConf, projectCmd)
+
 	return projectCmd
 }
 
@@ -0,0 +1,243 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+// projectPassphraseEnv can be set to the archive passphrase to run project export and import non-interactively
+const projectPassphraseEnv = "PORTER_PROJECT_PASSPHRASE"
+
+var (
+	projectArchiveFile  string
+	projectImportDryRun bool
+)
+
+func registerCommand_ProjectTransfer(cliConf config.CLIConfig, projectCmd *cobra.Command) {
+	exportProjectCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Writes all clusters, registries, apps and policies of the current project to an archive.",
+		Long: fmt.Sprintf(`
+%s
+
+Writes every project-scoped model of the current project to an archive file, which can be imported into
+a project on this or another Porter instance with "porter project import". The credentials in the archive
+are encrypted with a passphrase, which is prompted for unless %s is set. Exporting a project requires the
+admin role. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter project export\":"),
+			projectPassphraseEnv,
+			color.New(color.FgGreen, color.Bold).Sprintf("porter project export --file my-project.porter"),
+		),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, exportProject)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	exportProjectCmd.Flags().StringVarP(
+		&projectArchiveFile,
+		"file",
+		"f",
+		"",
+		"file to write the archive to",
+	)
+
+	exportProjectCmd.MarkFlagRequired("file")
+
+	importProjectCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Creates the models of a project export archive in the current project.",
+		Long: fmt.Sprintf(`
+%s
+
+Creates the clusters, registries, apps and policies of an archive written by "porter project export" in
+the current project. Every model gets a new id, and the ids of the exported project are printed next to
+their new ids. Use --dry-run to check the archive and the passphrase without creating anything. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter project import\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter project import --file my-project.porter --dry-run"),
+		),
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, importProject)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	importProjectCmd.Flags().StringVarP(
+		&projectArchiveFile,
+		"file",
+		"f",
+		"",
+		"archive to import",
+	)
+
+	importProjectCmd.Flags().BoolVar(
+		&projectImportDryRun,
+		"dry-run",
+		false,
+		"validate the archive without importing it",
+	)
+
+	importProjectCmd.MarkFlagRequired("file")
+
+	projectCmd.AddCommand(exportProjectCmd)
+	projectCmd.AddCommand(importProjectCmd)
+}
+
+func exportProject(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	passphrase := os.Getenv(projectPassphraseEnv)
+
+	if passphrase == "" {
+		pw, err := utils.PromptPassword("Archive passphrase: ")
+		if err != nil {
+			return err
+		}
+
+		confirmPw, err := utils.PromptPassword("Confirm archive passphrase: ")
+		if err != nil {
+			return err
+		}
+
+		if pw != confirmPw {
+			return fmt.Errorf("passphrases do not match")
+		}
+
+		passphrase = pw
+	}
+
+	resp, err := client.ExportProject(ctx, cliConf.Project, &types.ExportProjectRequest{
+		Passphrase: passphrase,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(projectArchiveFile, resp.Archive, 0o600); err != nil {
+		return fmt.Errorf("error writing %s: %w", projectArchiveFile, err)
+	}
+
+	color.New(color.FgGreen).Printf("Exported project %d to %s\n", cliConf.Project, projectArchiveFile)
+
+	return nil
+}
+
+func importProject(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	archive, err := os.ReadFile(projectArchiveFile)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", projectArchiveFile, err)
+	}
+
+	passphrase := os.Getenv(projectPassphraseEnv)
+
+	if passphrase == "" {
+		passphrase, err = utils.PromptPassword("Archive passphrase: ")
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.ImportProject(ctx, cliConf.Project, &types.ImportProjectRequest{
+		Archive:    archive,
+		Passphrase: passphrase,
+		DryRun:     projectImportDryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range resp.Warnings {
+		color.New(color.FgYellow).Printf("warning: %s\n", warning)
+	}
+
+	if resp.DryRun {
+		for _, validationErr := range resp.Errors {
+			color.New(color.FgRed).Printf("error: %s\n", validationErr)
+		}
+
+		printProjectImportCounts(resp.Counts)
+
+		if len(resp.Errors) != 0 {
+			return fmt.Errorf("archive cannot be imported into project %d", cliConf.Project)
+		}
+
+		color.New(color.FgGreen).Printf("Archive can be imported into project %d\n", cliConf.Project)
+
+		return nil
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\n", "KIND", "EXPORTED ID", "NEW ID")
+
+	kinds := make([]string, 0, len(resp.IDMappings))
+
+	for kind := range resp.IDMappings {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		mappings := resp.IDMappings[kind]
+		oldIDs := make([]string, 0, len(mappings))
+
+		for oldID := range mappings {
+			oldIDs = append(oldIDs, oldID)
+		}
+
+		sort.Strings(oldIDs)
+
+		for _, oldID := range oldIDs {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", kind, oldID, mappings[oldID])
+		}
+	}
+
+	w.Flush()
+
+	color.New(color.FgGreen).Printf("Imported archive into project %d\n", cliConf.Project)
+
+	return nil
+}
+
+func printProjectImportCounts(counts map[string]int) {
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\n", "KIND", "COUNT")
+
+	kinds := make([]string, 0, len(counts))
+
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+
+	sort.Strings(kinds)
+
+	for _, kind := range kinds {
+		fmt.Fprintf(w, "%s\t%d\n", kind, counts[kind])
+	}
+
+	w.Flush()
+}
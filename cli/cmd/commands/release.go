@@ -0,0 +1,206 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	releaseTag         string
+	releaseImageTag    string
+	releaseEnvGroup    string
+	releaseReplicas    int
+	releaseConcurrency int
+	releaseDryRun      bool
+)
+
+// releaseActions maps the actions accepted by `porter release` to the bulk actions of the API
+var releaseActions = map[string]types.BulkReleaseAction{
+	"restart":          types.BulkReleaseActionRestart,
+	"update-image":     types.BulkReleaseActionUpdateImage,
+	"attach-env-group": types.BulkReleaseActionAttachEnvGroup,
+	"detach-env-group": types.BulkReleaseActionDetachEnvGroup,
+	"scale":            types.BulkReleaseActionScale,
+}
+
+func registerCommand_Release(cliConf config.CLIConfig) *cobra.Command {
+	releaseCmd := &cobra.Command{
+		Use:     "release --tag [tag] [action]",
+		Aliases: []string{"releases"},
+		Args:    cobra.ExactArgs(1),
+		Short:   "Applies an action to every release in the current cluster with a tag.",
+		Long: fmt.Sprintf(`
+%s
+
+Applies an action to every release in the current cluster with the tag given by --tag. The
+action is one of:
+
+  restart            restarts the pods of each release
+  update-image       upgrades each release to the image tag given by --image-tag
+  attach-env-group   syncs the env group given by --env-group to each release
+  detach-env-group   stops syncing the env group given by --env-group to each release
+  scale              sets the replica count of each release to --replicas
+
+Releases are changed --concurrency at a time, and the result for each release is printed once
+all releases are done. Use --dry-run to list the releases which would be changed. For example:
+
+  %s
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter release\":"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter release --tag backend restart"),
+			color.New(color.FgGreen, color.Bold).Sprintf("porter release --tag backend scale --replicas 0 --dry-run"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, bulkReleaseOperation)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	releaseCmd.Flags().StringVar(
+		&releaseTag,
+		"tag",
+		"",
+		"the tag of the releases to apply the action to",
+	)
+
+	releaseCmd.Flags().StringVar(
+		&releaseImageTag,
+		"image-tag",
+		"",
+		"the image tag to update releases to (update-image)",
+	)
+
+	releaseCmd.Flags().StringVar(
+		&releaseEnvGroup,
+		"env-group",
+		"",
+		"the env group to attach or detach (attach-env-group, detach-env-group)",
+	)
+
+	releaseCmd.Flags().IntVar(
+		&releaseReplicas,
+		"replicas",
+		-1,
+		"the replica count to scale releases to (scale)",
+	)
+
+	releaseCmd.Flags().IntVar(
+		&releaseConcurrency,
+		"concurrency",
+		0,
+		"the number of releases to change at the same time (defaults to 5, at most 20)",
+	)
+
+	releaseCmd.Flags().BoolVar(
+		&releaseDryRun,
+		"dry-run",
+		false,
+		"list the releases which would be changed without changing them",
+	)
+
+	releaseCmd.Flags().StringVar(
+		&output,
+		"output",
+		"",
+		"the output format to use (\"json\")",
+	)
+
+	releaseCmd.MarkFlagRequired("tag")
+
+	return releaseCmd
+}
+
+func bulkReleaseOperation(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	action, ok := releaseActions[args[0]]
+	if !ok {
+		names := make([]string, 0, len(releaseActions))
+		for name := range releaseActions {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("unknown action %s, must be one of: %s", args[0], strings.Join(names, ", "))
+	}
+
+	req := &types.BulkReleaseOperationRequest{
+		Tag:          releaseTag,
+		Action:       action,
+		ImageTag:     releaseImageTag,
+		EnvGroupName: releaseEnvGroup,
+		Concurrency:  releaseConcurrency,
+		DryRun:       releaseDryRun,
+	}
+
+	if action == types.BulkReleaseActionScale {
+		if releaseReplicas < 0 {
+			return fmt.Errorf("--replicas must be set to scale releases")
+		}
+
+		req.Replicas = &releaseReplicas
+	}
+
+	resp, err := client.BulkReleaseOperation(ctx, cliConf.Project, cliConf.Cluster, req)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		bytes, err := json.MarshalIndent(resp, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error marshaling results: %w", err)
+		}
+
+		fmt.Println(string(bytes))
+
+		return nil
+	}
+
+	if len(resp.Results) == 0 {
+		fmt.Printf("no releases in the current cluster have the tag %s\n", resp.Tag)
+		return nil
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 2, '\t', 0)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "NAMESPACE", "STATUS", "MESSAGE")
+
+	failed := 0
+
+	for _, result := range resp.Results {
+		line := fmt.Sprintf("%s\t%s\t%s\t%s\n", result.Name, result.Namespace, result.Status, result.Message)
+
+		switch result.Status {
+		case types.BulkReleaseResultFailed, types.BulkReleaseResultForbidden:
+			failed++
+			color.New(color.FgRed).Fprint(w, line)
+		case types.BulkReleaseResultSucceeded:
+			color.New(color.FgGreen).Fprint(w, line)
+		default:
+			fmt.Fprint(w, line)
+		}
+	}
+
+	w.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%s failed for %d of %d releases", args[0], failed, len(resp.Results))
+	}
+
+	return nil
+}
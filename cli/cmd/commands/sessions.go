@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	revokeAllSessions  bool
+	revokeSessionsUser uint
+)
+
+func sessionsCommand(cliConf config.CLIConfig) *cobra.Command {
+	sessionsCmd := &cobra.Command{
+		Use:   "sessions",
+		Short: "Commands that manage the dashboard sessions of the current user",
+	}
+
+	sessionsListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the active dashboard sessions of the current user",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, listSessions)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	sessionsRevokeCmd := &cobra.Command{
+		Use:   "revoke [session-id]",
+		Args:  cobra.MaximumNArgs(1),
+		Short: "Revokes dashboard sessions",
+		Long: fmt.Sprintf(`
+%s
+
+Revokes a session of the current user, all other sessions of the current user with --all, or
+all sessions of a collaborator in the current project with --user. Revoking a collaborator's
+sessions requires the admin role. Sessions are not scoped to a project, so this logs the
+collaborator out of every project they belong to. Revoking all sessions of a user also logs
+the user out of the CLI on every machine, including this one when using --all. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter auth sessions revoke\":"),
+			color.GreenString("porter auth sessions revoke --user 42"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, revokeSessions)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	sessionsRevokeCmd.Flags().BoolVar(
+		&revokeAllSessions,
+		"all",
+		false,
+		"revoke all sessions of the current user except for the calling dashboard session, and log out of the CLI everywhere",
+	)
+
+	sessionsRevokeCmd.Flags().UintVar(
+		&revokeSessionsUser,
+		"user",
+		0,
+		"revoke all sessions of a collaborator in the current project",
+	)
+
+	sessionsCmd.AddCommand(sessionsListCmd)
+	sessionsCmd.AddCommand(sessionsRevokeCmd)
+
+	return sessionsCmd
+}
+
+func listSessions(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, _ config.CLIConfig, _ config.FeatureFlags, _ []string) error {
+	sessions, err := client.ListSessions(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "ID", "CREATED_AT", "LAST_SEEN_AT", "IP", "USER_AGENT")
+
+	for _, session := range sessions {
+		lastSeen := ""
+
+		if session.LastSeenAt != nil {
+			lastSeen = session.LastSeenAt.Format(time.RFC3339)
+		}
+
+		id := strconv.FormatUint(uint64(session.ID), 10)
+
+		if session.Current {
+			id += " (current)"
+		}
+
+		fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%s\n",
+			id, session.CreatedAt.Format(time.RFC3339), lastSeen, session.IPAddress, session.UserAgent,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func revokeSessions(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	switch {
+	case revokeSessionsUser != 0:
+		if err := client.RevokeCollaboratorSessions(ctx, cliConf.Project, revokeSessionsUser); err != nil {
+			return err
+		}
+
+		_, _ = color.New(color.FgGreen).Printf("Revoked all sessions of user %d\n", revokeSessionsUser)
+	case revokeAllSessions:
+		if err := client.RevokeAllSessions(ctx); err != nil {
+			return err
+		}
+
+		_, _ = color.New(color.FgGreen).Println("Revoked all other sessions. Run \"porter auth login\" to log in to the CLI again")
+	case len(args) == 1:
+		sessionID, err := strconv.ParseUint(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid session id %s: %w", args[0], err)
+		}
+
+		if err := client.RevokeSession(ctx, uint(sessionID)); err != nil {
+			return err
+		}
+
+		_, _ = color.New(color.FgGreen).Printf("Revoked session %d\n", sessionID)
+	default:
+		return fmt.Errorf("a session id, --all or --user must be specified")
+	}
+
+	return nil
+}
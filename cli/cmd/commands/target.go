@@ -0,0 +1,259 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	targetNamespace string
+	targetVars      []string
+	targetSecrets   []string
+)
+
+func registerCommand_Target(cliConf config.CLIConfig) *cobra.Command {
+	targetCmd := &cobra.Command{
+		Use:     "target",
+		Aliases: []string{"targets"},
+		Short:   "Commands that manage the deployment targets of a project",
+	}
+
+	targetCreateCmd := &cobra.Command{
+		Use:   "create [name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Creates a deployment target for a namespace on the current cluster",
+		Long: fmt.Sprintf(`
+%s
+
+Creates a named deployment target for a namespace on the current cluster. Apps can then be
+applied to the target with "porter apply --target [name]". For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter target create\":"),
+			color.GreenString("porter target create prod-eu --cluster 12 --namespace prod"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, createTarget)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	targetCreateCmd.PersistentFlags().StringVar(
+		&targetNamespace,
+		"namespace",
+		"",
+		"the namespace selected by the target (defaults to the name of the target)",
+	)
+
+	targetListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the deployment targets across all clusters in the current project",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, listTargets)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	targetSetEnvCmd := &cobra.Command{
+		Use:   "set-env [name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Replaces the env overrides of a deployment target",
+		Long: fmt.Sprintf(`
+%s
+
+Replaces the env overrides of a deployment target. Overrides are layered on top of the
+environment of every app the next time it is applied or promoted to the target. For example:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter target set-env\":"),
+			color.GreenString("porter target set-env prod-eu --var REGION=eu-west-1 --secret API_KEY=secret"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, setTargetEnv)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	targetSetEnvCmd.PersistentFlags().StringArrayVar(
+		&targetVars,
+		"var",
+		[]string{},
+		"a variable override in the form KEY=VALUE, can be specified multiple times",
+	)
+
+	targetSetEnvCmd.PersistentFlags().StringArrayVar(
+		&targetSecrets,
+		"secret",
+		[]string{},
+		"a secret override in the form KEY=VALUE, can be specified multiple times",
+	)
+
+	targetDeleteCmd := &cobra.Command{
+		Use:   "delete [name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Deletes a deployment target. The namespace of the target is not deleted.",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, deleteTarget)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	targetCmd.AddCommand(targetCreateCmd)
+	targetCmd.AddCommand(targetListCmd)
+	targetCmd.AddCommand(targetSetEnvCmd)
+	targetCmd.AddCommand(targetDeleteCmd)
+
+	return targetCmd
+}
+
+func createTarget(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	namespace := targetNamespace
+
+	if namespace == "" {
+		namespace = args[0]
+	}
+
+	resp, err := client.CreateDeploymentTarget(ctx, cliConf.Project, &types.CreateDeploymentTargetRequest{
+		Name:      args[0],
+		ClusterID: cliConf.Cluster,
+		Namespace: namespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created deployment target %s for namespace %s on cluster %d\n", resp.Name, resp.Namespace, resp.ClusterID)
+
+	return nil
+}
+
+func listTargets(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	targets, err := client.ListDeploymentTargets(ctx, cliConf.Project)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].Name < targets[j].Name
+	})
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "NAME", "CLUSTER", "NAMESPACE", "ENV_OVERRIDES", "CREATED_AT")
+
+	for _, target := range targets {
+		name := target.Name
+
+		if name == "" {
+			name = "(default)"
+		}
+
+		fmt.Fprintf(
+			w, "%s\t%d\t%s\t%s\t%s\n",
+			name, target.ClusterID, target.Namespace, strings.Join(target.EnvOverrideKeys, ","), target.CreatedAt.Format(time.RFC3339),
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func setTargetEnv(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	target, err := getDeploymentTargetByName(ctx, client, cliConf.Project, args[0])
+	if err != nil {
+		return err
+	}
+
+	variables, err := parseKeyValuePairs(targetVars)
+	if err != nil {
+		return err
+	}
+
+	secrets, err := parseKeyValuePairs(targetSecrets)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.UpdateDeploymentTargetEnvOverrides(ctx, cliConf.Project, target.ID, &types.UpdateDeploymentTargetEnvOverridesRequest{
+		DeploymentTargetEnvOverrides: types.DeploymentTargetEnvOverrides{
+			Variables: variables,
+			Secrets:   secrets,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Updated env overrides for deployment target %s: %s\n", resp.Name, strings.Join(resp.EnvOverrideKeys, ", "))
+
+	return nil
+}
+
+func deleteTarget(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	target, err := getDeploymentTargetByName(ctx, client, cliConf.Project, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := client.DeleteDeploymentTarget(ctx, cliConf.Project, target.ID); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deleted deployment target %s\n", target.Name)
+
+	return nil
+}
+
+func getDeploymentTargetByName(ctx context.Context, client api.Client, projectID uint, name string) (*types.DeploymentTarget, error) {
+	targets, err := client.ListDeploymentTargets(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range targets {
+		if target.Name == name {
+			return target, nil
+		}
+	}
+
+	return nil, fmt.Errorf("deployment target %s not found in project", name)
+}
+
+func parseKeyValuePairs(pairs []string) (map[string]string, error) {
+	res := make(map[string]string)
+
+	for _, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid value %s, must be in the form KEY=VALUE", pair)
+		}
+
+		res[key] = value
+	}
+
+	return res, nil
+}
@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokenPolicyUID    string
+	tokenExpiresIn    time.Duration
+	tokenAllowedCIDRs []string
+	tokenGracePeriod  time.Duration
+)
+
+func registerCommand_Token(cliConf config.CLIConfig) *cobra.Command {
+	tokenCmd := &cobra.Command{
+		Use:     "token",
+		Aliases: []string{"tokens"},
+		Short:   "Commands that manage API tokens for a project",
+	}
+
+	tokenCreateCmd := &cobra.Command{
+		Use:   "create [name]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Creates a new API token in the current project",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, createToken)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	tokenCreateCmd.PersistentFlags().StringVar(
+		&tokenPolicyUID,
+		"policy",
+		"developer",
+		"the uid of the policy to attach to the token",
+	)
+
+	tokenCreateCmd.PersistentFlags().DurationVar(
+		&tokenExpiresIn,
+		"expires-in",
+		0,
+		"the amount of time until the token expires (defaults to 1 year)",
+	)
+
+	tokenCreateCmd.PersistentFlags().StringSliceVar(
+		&tokenAllowedCIDRs,
+		"allowed-cidr",
+		[]string{},
+		"a CIDR block that requests using the token must originate from, can be specified multiple times",
+	)
+
+	tokenListCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lists the API tokens in the current project",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, listTokens)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	tokenRotateCmd := &cobra.Command{
+		Use:   "rotate [id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Issues a new secret for the API token with the given id",
+		Long: fmt.Sprintf(`
+%s
+
+Issues a new secret for the API token with the given id. The previous secret remains valid
+until the grace period elapses, so that clients using the token can be updated:
+
+  %s
+`,
+			color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter token rotate\":"),
+			color.GreenString("porter token rotate [id] --grace-period 1h"),
+		),
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, rotateToken)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	tokenRotateCmd.PersistentFlags().DurationVar(
+		&tokenGracePeriod,
+		"grace-period",
+		0,
+		"the amount of time the previous secret remains valid (defaults to 24 hours)",
+	)
+
+	tokenRevokeCmd := &cobra.Command{
+		Use:   "revoke [id]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Revokes the API token with the given id",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := checkLoginAndRunWithConfig(cmd, cliConf, args, revokeToken)
+			if err != nil {
+				os.Exit(1)
+			}
+		},
+	}
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRotateCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+
+	return tokenCmd
+}
+
+func createToken(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	req := &types.CreateAPIToken{
+		Name:         args[0],
+		PolicyUID:    tokenPolicyUID,
+		AllowedCIDRs: tokenAllowedCIDRs,
+	}
+
+	if tokenExpiresIn != 0 {
+		req.ExpiresAt = time.Now().Add(tokenExpiresIn)
+	}
+
+	resp, err := client.CreateAPIToken(ctx, cliConf.Project, req)
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created token %s with id %s, expiring at %s\n", resp.Name, resp.ID, resp.ExpiresAt.Format(time.RFC3339))
+	printTokenSecret(resp.Token)
+
+	return nil
+}
+
+func listTokens(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	tokens, err := client.ListAPITokens(ctx, cliConf.Project)
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "NAME", "POLICY", "EXPIRES_AT", "LAST_USED_AT", "LAST_USED_IP", "ALLOWED_CIDRS")
+
+	for _, tok := range tokens {
+		lastUsedAt := "never"
+
+		if tok.LastUsedAt != nil {
+			lastUsedAt = tok.LastUsedAt.Format(time.RFC3339)
+		}
+
+		allowedCIDRs := "any"
+
+		if len(tok.AllowedCIDRs) > 0 {
+			allowedCIDRs = strings.Join(tok.AllowedCIDRs, ",")
+		}
+
+		fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			tok.ID, tok.Name, tok.PolicyName, tok.ExpiresAt.Format(time.RFC3339), lastUsedAt, tok.LastUsedIP, allowedCIDRs,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func rotateToken(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	resp, err := client.RotateAPIToken(ctx, cliConf.Project, args[0], &types.RotateAPITokenRequest{
+		GracePeriodSeconds: uint(tokenGracePeriod.Seconds()),
+	})
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Rotated token %s with id %s\n", resp.Name, resp.ID)
+
+	if resp.PreviousSecretExpiry != nil {
+		fmt.Printf("The previous secret remains valid until %s\n", resp.PreviousSecretExpiry.Format(time.RFC3339))
+	}
+
+	printTokenSecret(resp.Token)
+
+	return nil
+}
+
+func revokeToken(ctx context.Context, _ *types.GetAuthenticatedUserResponse, client api.Client, cliConf config.CLIConfig, _ config.FeatureFlags, args []string) error {
+	userResp, err := utils.PromptPlaintext(
+		fmt.Sprintf(
+			`Are you sure you'd like to revoke the token with id %s? %s `,
+			args[0],
+			color.New(color.FgCyan).Sprintf("[y/n]"),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	if userResp := strings.ToLower(userResp); userResp == "y" || userResp == "yes" {
+		resp, err := client.RevokeAPIToken(ctx, cliConf.Project, args[0])
+		if err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Printf("Revoked token %s with id %s\n", resp.Name, resp.ID)
+	}
+
+	return nil
+}
+
+func printTokenSecret(token string) {
+	fmt.Printf("\nToken (this will not be shown again):\n\n%s\n", token)
+}
@@ -41,6 +41,8 @@ var (
 	normalEnvGroupVars      []string
 	secretEnvGroupVars      []string
 	waitForSuccessfulDeploy bool
+	overridePolicies        bool
+	overrideReason          string
 )
 
 func registerCommand_Update(cliConf config.CLIConfig) *cobra.Command {
@@ -378,6 +380,20 @@ the image that the application uses if no --values file is specified:
 		"set this to wait and be notified when a deployment is successful, otherwise time out",
 	)
 
+	updateCmd.PersistentFlags().BoolVar(
+		&overridePolicies,
+		"override-policies",
+		false,
+		"deploy even if the release violates blocking policies (project admins only, requires --override-reason)",
+	)
+
+	updateCmd.PersistentFlags().StringVar(
+		&overrideReason,
+		"override-reason",
+		"",
+		"the reason for overriding blocking policies, which is recorded with the override",
+	)
+
 	updateCmd.AddCommand(updateGetEnvCmd)
 
 	updateGetEnvCmd.PersistentFlags().StringVar(
@@ -434,6 +450,7 @@ the image that the application uses if no --values file is specified:
 
 	updateEnvGroupCmd.AddCommand(updateSetEnvGroupCmd)
 	updateEnvGroupCmd.AddCommand(updateUnsetEnvGroupCmd)
+	registerCommand_EnvGroupTransfer(cliConf, updateEnvGroupCmd)
 
 	updateCmd.AddCommand(updateBuildCmd)
 	updateCmd.AddCommand(updatePushCmd)
@@ -823,6 +840,10 @@ func updateGetAgent(ctx context.Context, client api.Client, cliConf config.CLICo
 			Method:          buildMethod,
 			AdditionalEnv:   additionalEnv,
 			UseCache:        useCache,
+			PolicyOverride: types.PolicyOverrideRequest{
+				OverridePolicies: overridePolicies,
+				OverrideReason:   overrideReason,
+			},
 		},
 		Local: source != "github",
 	})
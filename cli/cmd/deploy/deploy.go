@@ -13,6 +13,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/docker"
 	"github.com/porter-dev/porter/cli/cmd/github"
+	cliUtils "github.com/porter-dev/porter/cli/cmd/utils"
 	"github.com/porter-dev/porter/internal/templater/utils"
 	"k8s.io/client-go/util/homedir"
 )
@@ -396,16 +397,24 @@ func (d *DeployAgent) UpdateImageAndValues(ctx context.Context, overrideValues m
 		return err
 	}
 
-	return d.Client.UpgradeRelease(
+	resp, err := d.Client.UpgradeRelease(
 		ctx,
 		d.Opts.ProjectID,
 		d.Opts.ClusterID,
 		d.Release.Namespace,
 		d.Release.Name,
 		&types.UpgradeReleaseRequest{
-			Values: string(bytes),
+			Values:                string(bytes),
+			PolicyOverrideRequest: d.Opts.PolicyOverride,
 		},
 	)
+	if err != nil {
+		return err
+	}
+
+	cliUtils.PrintPolicyViolations(resp.PolicyWarnings)
+
+	return nil
 }
 
 type SyncedEnvSection struct {
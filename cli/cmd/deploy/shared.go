@@ -20,6 +20,9 @@ type SharedOpts struct {
 	AdditionalEnv   map[string]string
 	EnvGroups       []types.EnvGroupMeta
 	UseCache        bool
+
+	// PolicyOverride overrides the blocking policies of the project when the release is upgraded
+	PolicyOverride types.PolicyOverrideRequest
 }
 
 func coalesceEnvGroups(
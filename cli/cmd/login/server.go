@@ -31,8 +31,11 @@ func redirect(
 	}
 }
 
+// Login opens the browser to log the user in to the given host, and returns an API token once the
+// login has completed. If sso is set, the user is sent directly to the host's OIDC provider.
 func Login(
 	host string,
+	sso bool,
 ) (string, error) {
 	listener, err := net.Listen("tcp", ":0")
 	if err != nil {
@@ -61,7 +64,14 @@ func Login(
 		redirectHost = fmt.Sprintf("http://localhost:%d", port)
 	}
 
-	loginURL := fmt.Sprintf("%s/api/cli/login?redirect=%s", host, url.QueryEscape(redirectHost))
+	cliLoginPath := fmt.Sprintf("/api/cli/login?redirect=%s", url.QueryEscape(redirectHost))
+	loginURL := host + cliLoginPath
+
+	// the OIDC callback redirects to the session's redirect_uri after login, which
+	// continues the regular CLI login flow
+	if sso {
+		loginURL = fmt.Sprintf("%s/api/oauth/login/oidc?redirect_uri=%s", host, url.QueryEscape(cliLoginPath))
+	}
 
 	err = utils.OpenBrowser(loginURL)
 
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseDotenv reads variables in the .env format, one KEY=VALUE pair per line. Blank lines and lines starting with # are ignored,
+// as are a leading "export " and trailing comments on unquoted values. Values may be wrapped in single or double quotes; escape
+// sequences such as \n are only interpreted inside double quotes.
+func ParseDotenv(r io.Reader) (map[string]string, error) {
+	vars := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	// values such as certificates can be much longer than the default token size
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: %q is not in the form of KEY=VALUE", lineNum, line)
+		}
+
+		key = strings.TrimSpace(key)
+		if key == "" || strings.ContainsAny(key, " \t") {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNum, key)
+		}
+
+		value, err := parseDotenvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		vars[key] = value
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+func parseDotenvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch value[0] {
+	case '"':
+		end := closingQuote(value)
+		if end == -1 {
+			return "", fmt.Errorf("unterminated double quoted value")
+		}
+
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return "", fmt.Errorf("invalid double quoted value: %w", err)
+		}
+
+		return unquoted, nil
+	case '\'':
+		end := strings.Index(value[1:], "'")
+		if end == -1 {
+			return "", fmt.Errorf("unterminated single quoted value")
+		}
+
+		return value[1 : end+1], nil
+	}
+
+	if idx := strings.Index(value, " #"); idx != -1 {
+		value = value[:idx]
+	}
+
+	return strings.TrimSpace(value), nil
+}
+
+// closingQuote returns the index of the double quote which closes the value starting at value[0], skipping escaped quotes
+func closingQuote(value string) int {
+	for i := 1; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// FormatDotenv writes variables in the .env format, sorted by key. Values are double quoted when they contain characters which
+// would otherwise change their meaning when parsed.
+func FormatDotenv(vars map[string]string) string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		value := vars[key]
+		if value == "" || strings.ContainsAny(value, " \t\n\r\"'#\\") {
+			value = strconv.Quote(value)
+		}
+
+		sb.WriteString(fmt.Sprintf("%s=%s\n", key, value))
+	}
+
+	return sb.String()
+}
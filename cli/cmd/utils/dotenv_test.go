@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDotenv(t *testing.T) {
+	input := `
+# database settings
+DB_HOST=localhost
+export DB_PORT=5432
+DB_NAME=app # trailing comment
+EMPTY=
+SINGLE='value with "quotes" and \n'
+DOUBLE="line1\nline2 # not a comment"
+URL=https://example.com/path#fragment
+`
+
+	vars, err := ParseDotenv(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"DB_HOST": "localhost",
+		"DB_PORT": "5432",
+		"DB_NAME": "app",
+		"EMPTY":   "",
+		"SINGLE":  `value with "quotes" and \n`,
+		"DOUBLE":  "line1\nline2 # not a comment",
+		"URL":     "https://example.com/path#fragment",
+	}, vars)
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing equals":         "NOT_A_VARIABLE",
+		"missing key":            "=value",
+		"key with space":         "MY KEY=value",
+		"unterminated double":    `KEY="value`,
+		"unterminated single":    `KEY='value`,
+		"escaped closing double": `KEY="value\"`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseDotenv(strings.NewReader(input))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatDotenvRoundTrip(t *testing.T) {
+	vars := map[string]string{
+		"B_PLAIN":     "value",
+		"A_SPACES":    "hello world",
+		"C_MULTILINE": "line1\nline2",
+		"D_QUOTES":    `say "hi"`,
+		"E_HASH":      "a #b",
+		"F_EMPTY":     "",
+	}
+
+	formatted := FormatDotenv(vars)
+	assert.True(t, strings.HasPrefix(formatted, "A_SPACES=\"hello world\"\nB_PLAIN=value\n"))
+
+	parsed, err := ParseDotenv(strings.NewReader(formatted))
+	require.NoError(t, err)
+	assert.Equal(t, vars, parsed)
+}
@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// PrintPolicyViolations prints the policy violations found when a release was deployed. Violations of
+// blocking policies are printed in red, and violations of warn-only policies in yellow.
+func PrintPolicyViolations(violations []*types.PolicyViolation) {
+	for _, violation := range violations {
+		c := color.New(color.FgYellow)
+
+		if violation.Enforcement == types.RecommenderPolicyEnforcementBlock {
+			c = color.New(color.FgRed)
+		}
+
+		c.Fprintf(os.Stderr, "[%s] %s (%s): %s\n", violation.Enforcement, violation.PolicyTitle, violation.ObjectID, violation.Message)
+		fmt.Fprintf(os.Stderr, "    policy %s in collection %s, severity %s\n", violation.PolicyID, violation.Collection, violation.Severity)
+	}
+}
@@ -21,20 +21,27 @@ import (
 	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
 	api "github.com/porter-dev/porter/api/client"
 	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
 )
 
-// Apply implements the functionality of the `porter apply` command for validate apply v2 projects
-func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, porterYamlPath string, appName string) error {
+// Apply implements the functionality of the `porter apply` command for validate apply v2 projects.
+// If targetName is empty, the app is applied to the default deployment target of the configured cluster.
+// The changes from the porter.yaml are printed before applying; if dryRun is set, nothing is applied.
+func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, porterYamlPath string, appName string, targetName string, dryRun bool) error {
 	const forceBuild = true
 	var b64AppProto string
 
-	targetResp, err := client.DefaultDeploymentTarget(ctx, cliConf.Project, cliConf.Cluster)
+	target, err := resolveDeploymentTarget(ctx, client, cliConf, targetName)
 	if err != nil {
-		return fmt.Errorf("error calling default deployment target endpoint: %w", err)
+		return err
 	}
 
-	if targetResp.DeploymentTargetID == "" {
-		return errors.New("deployment target id is empty")
+	// named targets may be on a different cluster than the one set in the config
+	cliConf.Cluster = target.ClusterID
+	deploymentTargetID := target.ID
+
+	if dryRun && len(porterYamlPath) == 0 {
+		return errors.New("a porter.yaml must be provided for a dry run")
 	}
 
 	if len(porterYamlPath) != 0 {
@@ -56,6 +63,32 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 		}
 		b64AppProto = parseResp.B64AppProto
 
+		// override app name if provided
+		appName, err = appNameFromB64AppProto(parseResp.B64AppProto)
+		if err != nil {
+			return fmt.Errorf("error getting app name from b64 app proto: %w", err)
+		}
+
+		diffResp, err := client.DiffAppRevisions(ctx, cliConf.Project, cliConf.Cluster, appName, &porter_app.DiffAppRevisionsRequest{
+			DeploymentTargetID: deploymentTargetID,
+			ToBase64AppProto:   b64AppProto,
+		})
+		if err != nil {
+			if dryRun {
+				return fmt.Errorf("error calling diff endpoint: %w", err)
+			}
+
+			// the diff is informational, so a failure to compute it does not block the apply
+			color.New(color.FgYellow).Printf("Unable to compute changes for app \"%s\": %s\n", appName, err.Error()) // nolint:errcheck,gosec
+		} else {
+			fmt.Printf("Changes to app \"%s\":\n", appName)
+			printAppDiff(diffResp.Diff)
+		}
+
+		if dryRun {
+			return nil
+		}
+
 		// we only need to create the app if a porter yaml is provided (otherwise it must already exist)
 		createPorterAppDBEntryInp, err := createPorterAppDbEntryInputFromProtoAndEnv(parseResp.B64AppProto)
 		if err != nil {
@@ -67,13 +100,7 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 			return fmt.Errorf("error creating porter app db entry: %w", err)
 		}
 
-		// override app name if provided
-		appName, err = appNameFromB64AppProto(parseResp.B64AppProto)
-		if err != nil {
-			return fmt.Errorf("error getting app name from b64 app proto: %w", err)
-		}
-
-		envGroupResp, err := client.CreateOrUpdateAppEnvironment(ctx, cliConf.Project, cliConf.Cluster, appName, targetResp.DeploymentTargetID, parseResp.EnvVariables, parseResp.EnvSecrets)
+		envGroupResp, err := client.CreateOrUpdateAppEnvironment(ctx, cliConf.Project, cliConf.Cluster, appName, deploymentTargetID, parseResp.EnvVariables, parseResp.EnvSecrets)
 		if err != nil {
 			return fmt.Errorf("error calling create or update app environment group endpoint: %w", err)
 		}
@@ -95,7 +122,7 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 		commitSHA = commit.Sha
 	}
 
-	validateResp, err := client.ValidatePorterApp(ctx, cliConf.Project, cliConf.Cluster, appName, b64AppProto, targetResp.DeploymentTargetID, commitSHA)
+	validateResp, err := client.ValidatePorterApp(ctx, cliConf.Project, cliConf.Cluster, appName, b64AppProto, deploymentTargetID, commitSHA)
 	if err != nil {
 		return fmt.Errorf("error calling validate endpoint: %w", err)
 	}
@@ -105,11 +132,13 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 	}
 	base64AppProto := validateResp.ValidatedBase64AppProto
 
-	applyResp, err := client.ApplyPorterApp(ctx, cliConf.Project, cliConf.Cluster, base64AppProto, targetResp.DeploymentTargetID, "", forceBuild)
+	applyResp, err := client.ApplyPorterApp(ctx, cliConf.Project, cliConf.Cluster, base64AppProto, deploymentTargetID, "", forceBuild)
 	if err != nil {
 		return fmt.Errorf("error calling apply endpoint: %w", err)
 	}
 
+	utils.PrintPolicyViolations(applyResp.PolicyWarnings)
+
 	if applyResp.AppRevisionId == "" {
 		return errors.New("app revision id is empty")
 	}
@@ -117,7 +146,7 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 	if applyResp.CLIAction == porterv1.EnumCLIAction_ENUM_CLI_ACTION_BUILD {
 		color.New(color.FgGreen).Printf("Building new image...\n") // nolint:errcheck,gosec
 
-		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, targetResp.DeploymentTargetID)
+		eventID, _ := createBuildEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID)
 
 		if commitSHA == "" {
 			return errors.New("Build is required but commit SHA cannot be identified. Please set the PORTER_COMMIT_SHA environment variable or run apply in git repository with access to the git CLI.")
@@ -128,7 +157,7 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 			return fmt.Errorf("error building settings from base64 app proto: %w", err)
 		}
 
-		currentAppRevisionResp, err := client.CurrentAppRevision(ctx, cliConf.Project, cliConf.Cluster, appName, targetResp.DeploymentTargetID)
+		currentAppRevisionResp, err := client.CurrentAppRevision(ctx, cliConf.Project, cliConf.Cluster, appName, deploymentTargetID)
 		if err != nil {
 			return fmt.Errorf("error getting current app revision: %w", err)
 		}
@@ -150,7 +179,7 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 		buildSettings.CurrentImageTag = currentImageTag
 		buildSettings.ProjectID = cliConf.Project
 
-		buildEnv, err := client.GetBuildEnv(ctx, cliConf.Project, cliConf.Cluster, appName, targetResp.DeploymentTargetID)
+		buildEnv, err := client.GetBuildEnv(ctx, cliConf.Project, cliConf.Cluster, appName, deploymentTargetID)
 		if err != nil {
 			return fmt.Errorf("error getting build env: %w", err)
 		}
@@ -161,16 +190,16 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 		buildMetadata["end_time"] = time.Now().UTC()
 
 		if err != nil {
-			_ = updateExistingEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, targetResp.DeploymentTargetID, eventID, types.PorterAppEventStatus_Failed, buildMetadata)
+			_ = updateExistingEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, eventID, types.PorterAppEventStatus_Failed, buildMetadata)
 			_, _ = client.UpdateRevisionStatus(ctx, cliConf.Project, cliConf.Cluster, appName, applyResp.AppRevisionId, models.AppRevisionStatus_BuildFailed)
 			return fmt.Errorf("error building app: %w", err)
 		}
 
 		color.New(color.FgGreen).Printf("Successfully built image (tag: %s)\n", buildSettings.ImageTag) // nolint:errcheck,gosec
 
-		_ = updateExistingEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, targetResp.DeploymentTargetID, eventID, types.PorterAppEventStatus_Success, buildMetadata)
+		_ = updateExistingEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, eventID, types.PorterAppEventStatus_Success, buildMetadata)
 
-		applyResp, err = client.ApplyPorterApp(ctx, cliConf.Project, cliConf.Cluster, "", "", applyResp.AppRevisionId, !forceBuild)
+		applyResp, err = client.ApplyPorterApp(ctx, cliConf.Project, cliConf.Cluster, "", deploymentTargetID, applyResp.AppRevisionId, !forceBuild)
 		if err != nil {
 			return fmt.Errorf("apply error post-build: %w", err)
 		}
@@ -179,40 +208,9 @@ func Apply(ctx context.Context, cliConf config.CLIConfig, client api.Client, por
 	color.New(color.FgGreen).Printf("Image tag exists in repository\n") // nolint:errcheck,gosec
 
 	if applyResp.CLIAction == porterv1.EnumCLIAction_ENUM_CLI_ACTION_TRACK_PREDEPLOY {
-		color.New(color.FgGreen).Printf("Waiting for predeploy to complete...\n") // nolint:errcheck,gosec
-
-		now := time.Now().UTC()
-		eventID, _ := createPredeployEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, targetResp.DeploymentTargetID, now, applyResp.AppRevisionId)
-
-		eventStatus := types.PorterAppEventStatus_Success
-		for {
-			if time.Since(now) > checkPredeployTimeout {
-				return errors.New("timed out waiting for predeploy to complete")
-			}
-
-			predeployStatusResp, err := client.PredeployStatus(ctx, cliConf.Project, cliConf.Cluster, appName, applyResp.AppRevisionId)
-			if err != nil {
-				return fmt.Errorf("error calling predeploy status endpoint: %w", err)
-			}
-
-			if predeployStatusResp.Status == porter_app.PredeployStatus_Failed {
-				eventStatus = types.PorterAppEventStatus_Failed
-				break
-			}
-			if predeployStatusResp.Status == porter_app.PredeployStatus_Successful {
-				break
-			}
-
-			time.Sleep(checkPredeployFrequency)
-		}
-
-		metadata := make(map[string]interface{})
-		metadata["end_time"] = time.Now().UTC()
-		_ = updateExistingEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, targetResp.DeploymentTargetID, eventID, eventStatus, metadata)
-
-		applyResp, err = client.ApplyPorterApp(ctx, cliConf.Project, cliConf.Cluster, "", "", applyResp.AppRevisionId, !forceBuild)
+		applyResp, err = trackPredeploy(ctx, client, cliConf, appName, deploymentTargetID, applyResp.AppRevisionId)
 		if err != nil {
-			return fmt.Errorf("apply error post-predeploy: %w", err)
+			return err
 		}
 	}
 
@@ -230,6 +228,47 @@ const checkPredeployTimeout = 60 * time.Minute
 // checkPredeployFrequency is the frequency at which the CLI will check the status of a predeploy
 const checkPredeployFrequency = 10 * time.Second
 
+// trackPredeploy waits for the predeploy job of a revision to complete, then calls apply again to continue the rollout
+func trackPredeploy(ctx context.Context, client api.Client, cliConf config.CLIConfig, appName string, deploymentTargetID string, appRevisionID string) (*porter_app.ApplyPorterAppResponse, error) {
+	color.New(color.FgGreen).Printf("Waiting for predeploy to complete...\n") // nolint:errcheck,gosec
+
+	now := time.Now().UTC()
+	eventID, _ := createPredeployEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, now, appRevisionID)
+
+	eventStatus := types.PorterAppEventStatus_Success
+	for {
+		if time.Since(now) > checkPredeployTimeout {
+			return nil, errors.New("timed out waiting for predeploy to complete")
+		}
+
+		predeployStatusResp, err := client.PredeployStatus(ctx, cliConf.Project, cliConf.Cluster, appName, appRevisionID)
+		if err != nil {
+			return nil, fmt.Errorf("error calling predeploy status endpoint: %w", err)
+		}
+
+		if predeployStatusResp.Status == porter_app.PredeployStatus_Failed {
+			eventStatus = types.PorterAppEventStatus_Failed
+			break
+		}
+		if predeployStatusResp.Status == porter_app.PredeployStatus_Successful {
+			break
+		}
+
+		time.Sleep(checkPredeployFrequency)
+	}
+
+	metadata := make(map[string]interface{})
+	metadata["end_time"] = time.Now().UTC()
+	_ = updateExistingEvent(ctx, client, appName, cliConf.Project, cliConf.Cluster, deploymentTargetID, eventID, eventStatus, metadata)
+
+	applyResp, err := client.ApplyPorterApp(ctx, cliConf.Project, cliConf.Cluster, "", deploymentTargetID, appRevisionID, false)
+	if err != nil {
+		return nil, fmt.Errorf("apply error post-predeploy: %w", err)
+	}
+
+	return applyResp, nil
+}
+
 func appNameFromB64AppProto(base64AppProto string) (string, error) {
 	decoded, err := base64.StdEncoding.DecodeString(base64AppProto)
 	if err != nil {
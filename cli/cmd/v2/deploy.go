@@ -13,7 +13,7 @@ func UpdateFull(ctx context.Context, cliConf config.CLIConfig, client api.Client
 	// use empty string for porterYamlPath,legacy projects wont't have a v2 porter.yaml
 	var porterYamlPath string
 
-	err := Apply(ctx, cliConf, client, porterYamlPath, appName)
+	err := Apply(ctx, cliConf, client, porterYamlPath, appName, "", false)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+)
+
+// resolveDeploymentTarget returns the deployment target with the given name in the project. If the name is empty,
+// the default deployment target of the configured cluster is returned.
+func resolveDeploymentTarget(ctx context.Context, client api.Client, cliConf config.CLIConfig, targetName string) (*types.DeploymentTarget, error) {
+	if targetName == "" {
+		targetResp, err := client.DefaultDeploymentTarget(ctx, cliConf.Project, cliConf.Cluster)
+		if err != nil {
+			return nil, fmt.Errorf("error calling default deployment target endpoint: %w", err)
+		}
+
+		if targetResp.DeploymentTargetID == "" {
+			return nil, errors.New("deployment target id is empty")
+		}
+
+		return &types.DeploymentTarget{
+			ID:        targetResp.DeploymentTargetID,
+			ProjectID: cliConf.Project,
+			ClusterID: cliConf.Cluster,
+		}, nil
+	}
+
+	targets, err := client.ListDeploymentTargets(ctx, cliConf.Project)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deployment targets: %w", err)
+	}
+
+	for _, target := range targets {
+		if target.Name == targetName {
+			return target, nil
+		}
+	}
+
+	return nil, fmt.Errorf("deployment target %s not found in project", targetName)
+}
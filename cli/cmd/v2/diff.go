@@ -0,0 +1,128 @@
+package v2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/server/handlers/porter_app"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	porterAppInternal "github.com/porter-dev/porter/internal/porter_app"
+)
+
+// DiffInput is the input for Diff
+type DiffInput struct {
+	AppName    string
+	TargetName string
+	// FromRevision and ToRevision are revision numbers. Zero refers to the current revision.
+	FromRevision uint64
+	ToRevision   uint64
+	// PorterYamlPath is the path to a local porter.yaml. If set, the current revision is diffed against the porter.yaml.
+	PorterYamlPath string
+}
+
+// Diff implements the functionality of the `porter app diff` command
+func Diff(ctx context.Context, cliConf config.CLIConfig, client api.Client, inp DiffInput) error {
+	target, err := resolveDeploymentTarget(ctx, client, cliConf, inp.TargetName)
+	if err != nil {
+		return err
+	}
+
+	req := &porter_app.DiffAppRevisionsRequest{
+		DeploymentTargetID: target.ID,
+		FromRevisionNumber: inp.FromRevision,
+		ToRevisionNumber:   inp.ToRevision,
+	}
+
+	if inp.PorterYamlPath != "" {
+		porterYaml, err := os.ReadFile(filepath.Clean(inp.PorterYamlPath))
+		if err != nil {
+			return fmt.Errorf("could not read porter yaml file: %w", err)
+		}
+
+		parseResp, err := client.ParseYAML(ctx, cliConf.Project, target.ClusterID, base64.StdEncoding.EncodeToString(porterYaml), inp.AppName)
+		if err != nil {
+			return fmt.Errorf("error calling parse yaml endpoint: %w", err)
+		}
+
+		if parseResp.B64AppProto == "" {
+			return errors.New("b64 app proto is empty")
+		}
+
+		req.ToBase64AppProto = parseResp.B64AppProto
+
+		// the porter.yaml may set the app name
+		inp.AppName, err = appNameFromB64AppProto(parseResp.B64AppProto)
+		if err != nil {
+			return fmt.Errorf("error getting app name from b64 app proto: %w", err)
+		}
+	}
+
+	diffResp, err := client.DiffAppRevisions(ctx, cliConf.Project, target.ClusterID, inp.AppName, req)
+	if err != nil {
+		return fmt.Errorf("error calling diff endpoint: %w", err)
+	}
+
+	toDescription := "local porter.yaml"
+	if inp.PorterYamlPath == "" {
+		toDescription = fmt.Sprintf("revision %d", diffResp.ToRevisionNumber)
+	}
+
+	fmt.Printf("Changes from revision %d to %s of app \"%s\":\n", diffResp.FromRevisionNumber, toDescription, inp.AppName)
+	printAppDiff(diffResp.Diff)
+
+	return nil
+}
+
+// printAppDiff prints a diff in the style of a plan, with additions in green, removals in red and modifications in yellow
+func printAppDiff(diff porterAppInternal.AppDiff) {
+	if diff.IsEmpty() {
+		fmt.Println("No changes")
+		return
+	}
+
+	added := color.New(color.FgGreen)
+	removed := color.New(color.FgRed)
+	modified := color.New(color.FgYellow)
+
+	for _, change := range diff.Changes {
+		printFieldChange("", change)
+	}
+
+	services := diff.Services
+	if diff.Predeploy != nil {
+		services = append(services, *diff.Predeploy)
+	}
+
+	for _, service := range services {
+		switch service.Status {
+		case porterAppInternal.DiffStatus_Added:
+			added.Printf("+ service %s\n", service.Name) // nolint:errcheck,gosec
+		case porterAppInternal.DiffStatus_Removed:
+			removed.Printf("- service %s\n", service.Name) // nolint:errcheck,gosec
+			continue
+		default:
+			modified.Printf("~ service %s\n", service.Name) // nolint:errcheck,gosec
+		}
+
+		for _, change := range service.Changes {
+			printFieldChange("    ", change)
+		}
+	}
+}
+
+func printFieldChange(indent string, change porterAppInternal.FieldChange) {
+	switch {
+	case change.From == "":
+		color.New(color.FgGreen).Printf("%s+ %s: %s\n", indent, change.Field, change.To) // nolint:errcheck,gosec
+	case change.To == "":
+		color.New(color.FgRed).Printf("%s- %s: %s\n", indent, change.Field, change.From) // nolint:errcheck,gosec
+	default:
+		color.New(color.FgYellow).Printf("%s~ %s: %s -> %s\n", indent, change.Field, change.From, change.To) // nolint:errcheck,gosec
+	}
+}
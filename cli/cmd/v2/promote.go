@@ -0,0 +1,152 @@
+package v2
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+)
+
+// Promote deploys the current revision of an app on one deployment target to another deployment target. The image
+// built for the source target is reused, and the env overrides of the destination target are applied on top of the app env.
+func Promote(ctx context.Context, cliConf config.CLIConfig, client api.Client, appName string, fromTargetName string, toTargetName string) error {
+	if fromTargetName == toTargetName {
+		return errors.New("source and destination deployment targets must be different")
+	}
+
+	from, err := resolveDeploymentTarget(ctx, client, cliConf, fromTargetName)
+	if err != nil {
+		return err
+	}
+
+	to, err := resolveDeploymentTarget(ctx, client, cliConf, toTargetName)
+	if err != nil {
+		return err
+	}
+
+	currentAppRevisionResp, err := client.CurrentAppRevision(ctx, cliConf.Project, from.ClusterID, appName, from.ID)
+	if err != nil {
+		return fmt.Errorf("error getting current app revision: %w", err)
+	}
+
+	if currentAppRevisionResp == nil {
+		return errors.New("current app revision is nil")
+	}
+
+	revision := currentAppRevisionResp.AppRevision
+	if revision.B64AppProto == "" {
+		return errors.New("current app revision b64 app proto is empty")
+	}
+
+	b64AppProto, image, err := promotableAppProto(ctx, revision.B64AppProto, revision.Env.Name)
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Promoting app \"%s\" (tag: %s) to deployment target %s\n", appName, image.Tag, toTargetName) // nolint:errcheck,gosec
+
+	// the app may not exist yet on the cluster of the destination target
+	err = client.CreatePorterAppDBEntry(ctx, cliConf.Project, to.ClusterID, api.CreatePorterAppDBEntryInput{
+		AppName:         appName,
+		ImageRepository: image.Repository,
+		ImageTag:        image.Tag,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating porter app db entry: %w", err)
+	}
+
+	secrets := make(map[string]string)
+	for key, value := range revision.Env.SecretVariables {
+		secrets[key] = string(value)
+	}
+
+	envGroupResp, err := client.CreateOrUpdateAppEnvironment(ctx, cliConf.Project, to.ClusterID, appName, to.ID, revision.Env.Variables, secrets)
+	if err != nil {
+		return fmt.Errorf("error calling create or update app environment group endpoint: %w", err)
+	}
+
+	b64AppProto, err = updateAppEnvGroupInProto(ctx, b64AppProto, envGroupResp.EnvGroupName, envGroupResp.EnvGroupVersion)
+	if err != nil {
+		return fmt.Errorf("error updating app env group in proto: %w", err)
+	}
+
+	validateResp, err := client.ValidatePorterApp(ctx, cliConf.Project, to.ClusterID, appName, b64AppProto, to.ID, "")
+	if err != nil {
+		return fmt.Errorf("error calling validate endpoint: %w", err)
+	}
+
+	if validateResp.ValidatedBase64AppProto == "" {
+		return errors.New("validated b64 app proto is empty")
+	}
+
+	applyResp, err := client.ApplyPorterApp(ctx, cliConf.Project, to.ClusterID, validateResp.ValidatedBase64AppProto, to.ID, "", false)
+	if err != nil {
+		return fmt.Errorf("error calling apply endpoint: %w", err)
+	}
+
+	utils.PrintPolicyViolations(applyResp.PolicyWarnings)
+
+	if applyResp.AppRevisionId == "" {
+		return errors.New("app revision id is empty")
+	}
+
+	if applyResp.CLIAction == porterv1.EnumCLIAction_ENUM_CLI_ACTION_TRACK_PREDEPLOY {
+		targetConf := cliConf
+		targetConf.Cluster = to.ClusterID
+
+		applyResp, err = trackPredeploy(ctx, client, targetConf, appName, to.ID, applyResp.AppRevisionId)
+		if err != nil {
+			return err
+		}
+	}
+
+	if applyResp.CLIAction != porterv1.EnumCLIAction_ENUM_CLI_ACTION_NONE {
+		return fmt.Errorf("unexpected CLI action: %s", applyResp.CLIAction)
+	}
+
+	color.New(color.FgGreen).Printf("Successfully promoted app %s to deployment target %s in revision %s\n", appName, toTargetName, applyResp.AppRevisionId) // nolint:errcheck,gosec
+	return nil
+}
+
+// promotableAppProto removes the build settings and the app env group of the source target from an app proto,
+// so that the app is deployed from the already built image with the env group of the destination target
+func promotableAppProto(ctx context.Context, base64AppProto string, sourceEnvGroupName string) (string, *porterv1.AppImage, error) {
+	decoded, err := base64.StdEncoding.DecodeString(base64AppProto)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to decode base64 app for revision: %w", err)
+	}
+
+	app := &porterv1.PorterApp{}
+	err = helpers.UnmarshalContractObject(decoded, app)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to unmarshal app for revision: %w", err)
+	}
+
+	if app.Image == nil || app.Image.Tag == "" {
+		return "", nil, errors.New("current app revision does not have an image tag to promote")
+	}
+
+	app.Build = nil
+
+	envGroups := make([]*porterv1.EnvGroup, 0)
+	for _, envGroup := range app.EnvGroups {
+		if envGroup.Name != sourceEnvGroupName {
+			envGroups = append(envGroups, envGroup)
+		}
+	}
+	app.EnvGroups = envGroups
+
+	marshalled, err := helpers.MarshalContractObject(ctx, app)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to marshal app back to json: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(marshalled), app.Image, nil
+}
@@ -0,0 +1,55 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+)
+
+// SleepInput is the input for Sleep
+type SleepInput struct {
+	AppName    string
+	TargetName string
+	// Wake wakes the app up instead of putting it to sleep
+	Wake bool
+}
+
+// Sleep implements the functionality of the `porter app sleep` and `porter app wake` commands
+func Sleep(ctx context.Context, cliConf config.CLIConfig, client api.Client, inp SleepInput) error {
+	target, err := resolveDeploymentTarget(ctx, client, cliConf, inp.TargetName)
+	if err != nil {
+		return err
+	}
+
+	req := &types.SleepAppRequest{
+		DeploymentTargetID: target.ID,
+	}
+
+	var state *types.AppSleepState
+
+	if inp.Wake {
+		state, err = client.WakeApp(ctx, cliConf.Project, target.ClusterID, inp.AppName, req)
+	} else {
+		state, err = client.SleepApp(ctx, cliConf.Project, target.ClusterID, inp.AppName, req)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error changing sleep state of app %s: %w", inp.AppName, err)
+	}
+
+	if state.Asleep {
+		color.New(color.FgGreen).Printf("App %s is asleep\n", inp.AppName)
+	} else {
+		color.New(color.FgGreen).Printf("App %s is awake\n", inp.AppName)
+	}
+
+	if state.OverrideUntil != nil {
+		fmt.Printf("Its sleep schedule takes over again at %s\n", state.OverrideUntil.Local().Format("Mon Jan 2 15:04 MST"))
+	}
+
+	return nil
+}
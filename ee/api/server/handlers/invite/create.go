@@ -10,6 +10,7 @@ import (
 
 	"github.com/porter-dev/porter/internal/telemetry"
 
+	"github.com/porter-dev/porter/api/server/authz/policy"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -48,6 +49,15 @@ func (c *InviteCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if request.Kind == "" {
+		request.Kind = models.RoleDeveloper
+	}
+
+	if reqErr := policy.ValidateRoleAssignment(c.Repo().Policy(), project.ID, types.RoleKind(request.Kind), request.PolicyUID); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	// create invite model
 	invite, err := CreateInviteWithProject(request, project.ID)
 	if err != nil {
@@ -97,6 +107,7 @@ func CreateInviteWithProject(invite *types.CreateInviteRequest, projectID uint)
 	return &models.Invite{
 		Email:     invite.Email,
 		Kind:      invite.Kind,
+		PolicyUID: invite.PolicyUID,
 		Expiry:    &expiry,
 		ProjectID: projectID,
 		Token:     oauth.CreateRandomState(),
@@ -6,6 +6,7 @@ package invite
 import (
 	"net/http"
 
+	"github.com/porter-dev/porter/api/server/authz/policy"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
@@ -36,7 +37,13 @@ func (c *InviteUpdateRoleHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if reqErr := policy.ValidateRoleAssignment(c.Repo().Policy(), invite.ProjectID, types.RoleKind(request.Kind), request.PolicyUID); reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
 	invite.Kind = request.Kind
+	invite.PolicyUID = request.PolicyUID
 
 	if _, err := c.Repo().Invite().UpdateInvite(invite); err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -0,0 +1,218 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrOverrideReasonRequired is returned when policies are overridden without a reason
+	ErrOverrideReasonRequired = errors.New("a reason is required to override policies")
+
+	// ErrOverrideForbidden is returned when policies are overridden by a user who is not a project admin
+	ErrOverrideForbidden = errors.New("only project admins can override policies")
+)
+
+// Error is returned when a release violates blocking policies
+type Error struct {
+	// Violations are all violations of the release, including those of warn-only policies
+	Violations []*types.PolicyViolation
+}
+
+func (e *Error) Error() string {
+	blocking := make([]string, 0)
+
+	for _, violation := range e.Violations {
+		if violation.Enforcement == types.RecommenderPolicyEnforcementBlock {
+			blocking = append(blocking, fmt.Sprintf("%s (%s)", violation.PolicyTitle, violation.ObjectID))
+		}
+	}
+
+	return fmt.Sprintf("deploy blocked by %d policy violations: %s", len(blocking), strings.Join(blocking, "; "))
+}
+
+// ControllerInput is the input to NewController
+type ControllerInput struct {
+	Repo    repository.Repository
+	Cluster *models.Cluster
+
+	// User is the user deploying the release. It is nil for deploys which are not made by a user, such as
+	// those triggered by webhooks, which cannot override policies.
+	User *models.User
+
+	Override types.PolicyOverrideRequest
+}
+
+// Controller admits the deploy of a release. It records the violations of the release which did not block
+// it, so a Controller should only be used for a single deploy; use Clone for concurrent deploys.
+type Controller struct {
+	repo        repository.Repository
+	cluster     *models.Cluster
+	user        *models.User
+	override    types.PolicyOverrideRequest
+	collections []*opa.CustomPolicyCollection
+
+	// Warnings are the violations of warn-only policies, and of blocking policies which were overridden,
+	// found when the release was admitted
+	Warnings []*types.PolicyViolation
+}
+
+// NewController loads the enforced policy collections of the project of the cluster. If policies are
+// overridden, the user must be a project admin and give a reason.
+func NewController(input ControllerInput) (*Controller, error) {
+	if input.Override.OverridePolicies {
+		if strings.TrimSpace(input.Override.OverrideReason) == "" {
+			return nil, ErrOverrideReasonRequired
+		}
+
+		if input.User == nil {
+			return nil, ErrOverrideForbidden
+		}
+
+		role, err := input.Repo.Project().ReadProjectRole(input.Cluster.ProjectID, input.User.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrOverrideForbidden
+			}
+
+			return nil, fmt.Errorf("error reading project role: %w", err)
+		}
+
+		if role.Kind != types.RoleAdmin {
+			return nil, ErrOverrideForbidden
+		}
+	}
+
+	collectionModels, err := input.Repo.RecommenderPolicy().ListCollectionsByProjectID(input.Cluster.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing policy collections: %w", err)
+	}
+
+	collections := make([]*opa.CustomPolicyCollection, 0)
+
+	for _, collectionModel := range collectionModels {
+		collection, err := opa.CustomPolicyCollectionFromModel(collectionModel)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, policy := range collection.Policies {
+			if policy.Enforcement != "" {
+				collections = append(collections, collection)
+				break
+			}
+		}
+	}
+
+	return &Controller{
+		repo:        input.Repo,
+		cluster:     input.Cluster,
+		user:        input.User,
+		override:    input.Override,
+		collections: collections,
+	}, nil
+}
+
+// Clone returns a controller with the same policies and no warnings
+func (c *Controller) Clone() *Controller {
+	return &Controller{
+		repo:        c.repo,
+		cluster:     c.cluster,
+		user:        c.user,
+		override:    c.override,
+		collections: c.collections,
+	}
+}
+
+// AdmissionFunc returns the func to set on a helm install or upgrade config, or nil if the project does
+// not enforce any policies, in which case the release is not rendered before it is deployed
+func (c *Controller) AdmissionFunc() helm.AdmissionFunc {
+	if len(c.collections) == 0 {
+		return nil
+	}
+
+	return c.Admit
+}
+
+// Admit evaluates the enforced policies against a rendered release. It returns an *Error if the release
+// violates blocking policies which were not overridden, and records an event if they were overridden.
+func (c *Controller) Admit(ctx context.Context, rel *release.Release) error {
+	violations, err := opa.EvaluateAdmission(ctx, c.collections, rel, string(c.cluster.ToClusterType().Service))
+	if err != nil {
+		return fmt.Errorf("error evaluating policies: %w", err)
+	}
+
+	return c.admit(rel.Name, rel.Namespace, violations)
+}
+
+// AdmitApp evaluates the enforced policies against an app which is deployed by the cluster control plane, in
+// the same way as Admit. The namespace is empty if the deployment target of the app is not known.
+func (c *Controller) AdmitApp(ctx context.Context, app *porterv1.PorterApp, namespace string) error {
+	if len(c.collections) == 0 {
+		return nil
+	}
+
+	violations, err := opa.EvaluateAppAdmission(ctx, c.collections, app, namespace, string(c.cluster.ToClusterType().Service))
+	if err != nil {
+		return fmt.Errorf("error evaluating policies: %w", err)
+	}
+
+	return c.admit(app.Name, namespace, violations)
+}
+
+func (c *Controller) admit(name, namespace string, violations []*types.PolicyViolation) error {
+	blocking := make([]*types.PolicyViolation, 0)
+	warnings := make([]*types.PolicyViolation, 0)
+
+	for _, violation := range violations {
+		if violation.Enforcement == types.RecommenderPolicyEnforcementBlock {
+			blocking = append(blocking, violation)
+		} else {
+			warnings = append(warnings, violation)
+		}
+	}
+
+	c.Warnings = warnings
+
+	if len(blocking) == 0 {
+		return nil
+	}
+
+	if !c.override.OverridePolicies {
+		return &Error{Violations: violations}
+	}
+
+	violationBytes, err := json.Marshal(blocking)
+	if err != nil {
+		return fmt.Errorf("error marshaling policy violations: %w", err)
+	}
+
+	_, err = c.repo.RecommenderPolicy().CreateOverrideEvent(&models.PolicyOverrideEvent{
+		ProjectID:  c.cluster.ProjectID,
+		ClusterID:  c.cluster.ID,
+		UserID:     c.user.ID,
+		Name:       name,
+		Namespace:  namespace,
+		Reason:     c.override.OverrideReason,
+		Violations: violationBytes,
+	})
+	if err != nil {
+		return fmt.Errorf("error recording policy override: %w", err)
+	}
+
+	c.Warnings = append(c.Warnings, blocking...)
+
+	return nil
+}
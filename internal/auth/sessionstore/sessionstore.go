@@ -26,6 +26,8 @@ type PGStore struct {
 	Options *sessions.Options
 	Path    string
 	Repo    repository.SessionRepository
+
+	clientIP func(r *http.Request) string
 }
 
 // Helpers
@@ -56,20 +58,59 @@ func (store *PGStore) MaxAge(age int) {
 	}
 }
 
+// sessionActivityInterval is the minimum time between updates of a session's last seen
+// timestamp, so that every request does not write to the database
+const sessionActivityInterval = time.Minute
+
 // load fetches a session by ID from the database and decodes its content
-// into session.Values.
-func (store *PGStore) load(session *sessions.Session) error {
+// into session.Values. The last seen timestamp and client of the session are
+// recorded from the request.
+func (store *PGStore) load(r *http.Request, session *sessions.Session) error {
 	res, err := store.Repo.SelectSession(&models.Session{Key: session.ID})
 	if err != nil {
 		return err
 	}
 
-	return securecookie.DecodeMulti(session.Name(), string(res.Data), &session.Values, store.Codecs...)
+	if err := securecookie.DecodeMulti(session.Name(), string(res.Data), &session.Values, store.Codecs...); err != nil {
+		return err
+	}
+
+	// sessions which were authenticated before the user was recorded on the session cannot be
+	// found when the sessions of the user are revoked, so they are deleted and the user must log
+	// in again
+	if authenticated, _ := session.Values["authenticated"].(bool); authenticated && res.UserID == 0 {
+		if _, err := store.Repo.DeleteSession(&models.Session{Key: session.ID}); err != nil {
+			return err
+		}
+
+		session.Values = make(map[interface{}]interface{})
+
+		return gorm.ErrRecordNotFound
+	}
+
+	now := time.Now()
+
+	if res.LastSeenAt == nil || now.Sub(*res.LastSeenAt) > sessionActivityInterval {
+		// errors are not returned, since failing to record activity should not block the request
+		_ = store.Repo.TouchSession(session.ID, now, store.getClientIP(r), r.UserAgent())
+	}
+
+	return nil
+}
+
+// getClientIP returns the address of the client which made the request, or the remote address of
+// the request if the store was not given a func to resolve it
+func (store *PGStore) getClientIP(r *http.Request) string {
+	if store.clientIP != nil {
+		return store.clientIP(r)
+	}
+
+	return r.RemoteAddr
 }
 
 // save writes encoded session.Values to a database record.
 // writes to http_sessions table by default.
-func (store *PGStore) save(session *sessions.Session) error {
+func (store *PGStore) save(r *http.Request, session *sessions.Session) error {
 	encoded, err := securecookie.EncodeMulti(session.Name(), session.Values, store.Codecs...)
 	if err != nil {
 		return err
@@ -88,10 +129,24 @@ func (store *PGStore) save(session *sessions.Session) error {
 		}
 	}
 
+	// the authenticated user is stored outside of the encoded data so that sessions can be
+	// listed and revoked per user
+	userID, _ := session.Values["user_id"].(uint)
+
+	if authenticated, _ := session.Values["authenticated"].(bool); !authenticated {
+		userID = 0
+	}
+
+	now := time.Now()
+
 	s := &models.Session{
-		Key:       session.ID,
-		Data:      []byte(encoded),
-		ExpiresAt: expiresOn,
+		Key:        session.ID,
+		Data:       []byte(encoded),
+		ExpiresAt:  expiresOn,
+		UserID:     userID,
+		IPAddress:  store.getClientIP(r),
+		UserAgent:  r.UserAgent(),
+		LastSeenAt: &now,
 	}
 
 	repo := store.Repo
@@ -112,6 +167,10 @@ type NewStoreOpts struct {
 	CookieSecrets     []string
 
 	Insecure bool
+
+	// ClientIP returns the client address recorded for each session, which may be forwarded by a
+	// proxy. The remote address of the request is recorded if it is not set.
+	ClientIP func(r *http.Request) string
 }
 
 // NewStore takes an initialized db and session key pairs to create a session-store in postgres db.
@@ -131,7 +190,8 @@ func NewStore(opts *NewStoreOpts) (*PGStore, error) {
 			HttpOnly: true,
 			SameSite: http.SameSiteLaxMode,
 		},
-		Repo: opts.SessionRepository,
+		Repo:     opts.SessionRepository,
+		clientIP: opts.ClientIP,
 	}
 
 	return dbStore, nil
@@ -172,7 +232,7 @@ func (store *PGStore) New(r *http.Request, name string) (*sessions.Session, erro
 	if c, errCookie := r.Cookie(name); errCookie == nil {
 		err = securecookie.DecodeMulti(name, c.Value, &session.ID, store.Codecs...)
 		if err == nil {
-			err = store.load(session)
+			err = store.load(r, session)
 
 			if err != nil {
 				if err == gorm.ErrRecordNotFound {
@@ -213,7 +273,7 @@ func (store *PGStore) Save(r *http.Request, w http.ResponseWriter, session *sess
 			), "=")
 	}
 
-	if err := store.save(session); err != nil {
+	if err := store.save(r, session); err != nil {
 		return err
 	}
 
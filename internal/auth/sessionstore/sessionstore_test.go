@@ -4,9 +4,11 @@ import (
 	"encoding/base64"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gorilla/securecookie"
 	"github.com/gorilla/sessions"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository/test"
 
 	"github.com/porter-dev/porter/internal/auth/sessionstore"
@@ -161,3 +163,59 @@ func TestSessionOptionsAreUniquePerSession(t *testing.T) {
 		t.Fatalf("PGStore.Options.MaxAge: expected %d, got %d", 900, ss.Options.MaxAge)
 	}
 }
+
+func TestLegacyAuthenticatedSessionIsInvalidated(t *testing.T) {
+	repo := test.NewRepository(true)
+
+	ss, err := sessionstore.NewStore(
+		&sessionstore.NewStoreOpts{
+			SessionRepository: repo.Session(),
+			CookieSecrets:     []string{"secret"},
+		},
+	)
+	if err != nil {
+		t.Fatal("Failed to get store", err)
+	}
+
+	// a session authenticated before the user was recorded on the session has no user id
+	data, err := securecookie.EncodeMulti("mysess", map[interface{}]interface{}{
+		"authenticated": true,
+		"user_id":       uint(1),
+	}, ss.Codecs...)
+	if err != nil {
+		t.Fatal("Failed to encode session", err)
+	}
+
+	if _, err := repo.Session().CreateSession(&models.Session{
+		Key:       "legacy",
+		Data:      []byte(data),
+		ExpiresAt: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatal("Failed to create session", err)
+	}
+
+	req, err := http.NewRequest("GET", "http://www.example.com", nil)
+	if err != nil {
+		t.Fatal("failed to create request", err)
+	}
+
+	encoded, err := securecookie.EncodeMulti("mysess", "legacy", ss.Codecs...)
+	if err != nil {
+		t.Fatal("Failed to make cookie value", err)
+	}
+
+	req.AddCookie(sessions.NewCookie("mysess", encoded, ss.Options))
+
+	session, err := ss.Get(req, "mysess")
+	if err != nil {
+		t.Fatal("failed to get session", err.Error())
+	}
+
+	if !session.IsNew || len(session.Values) != 0 {
+		t.Fatal("Expected a new session, got values:", session.Values)
+	}
+
+	if _, err := repo.Session().SelectSession(&models.Session{Key: "legacy"}); err == nil {
+		t.Fatal("Expected the legacy session to be deleted")
+	}
+}
@@ -29,6 +29,9 @@ type Token struct {
 	// Additional fields that may or may not be set
 	TokenID string `json:"token_id"`
 	Secret  string `json:"secret"`
+
+	// TwoFactorVerified is set on user tokens issued from a session in which the user provided a second factor
+	TwoFactorVerified bool `json:"two_factor_verified"`
 }
 
 func GetTokenForUser(userID uint) (*Token, error) {
@@ -89,6 +92,8 @@ func (t *Token) EncodeToken(conf *TokenGeneratorConf) (string, error) {
 		"project_id": t.ProjectID,
 		"token_id":   t.TokenID,
 		"secret":     t.Secret,
+
+		"two_factor_verified": t.TwoFactorVerified,
 	})
 
 	// Sign and get the complete encoded token as a string using the secret
@@ -152,6 +157,10 @@ func GetTokenFromEncoded(tokenString string, conf *TokenGeneratorConf) (*Token,
 			}
 		}
 
+		if verified, ok := claims["two_factor_verified"].(bool); ok {
+			res.TwoFactorVerified = verified
+		}
+
 		supportID := "3140"
 		if res.Sub == supportID && res.IAt.Before(time.Date(2023, 0o1, 31, 14, 30, 0, 0, time.UTC)) {
 			return nil, fmt.Errorf("error with token. Please contact your admin or trying logging in again")
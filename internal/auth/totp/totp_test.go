@@ -0,0 +1,75 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// secret from the RFC 6238 test vectors ("12345678901234567890"), base32-encoded
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCode(t *testing.T) {
+	tests := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+
+	for unix, expected := range tests {
+		code, err := GenerateCode(rfcSecret, time.Unix(unix, 0))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, code, "unix time %d", unix)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	matched, ok := Validate(rfcSecret, "005924", now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/Period, matched)
+
+	// codes from the adjacent period are accepted to allow for clock drift
+	prev, err := GenerateCode(rfcSecret, now.Add(-Period*time.Second))
+	assert.NoError(t, err)
+
+	matched, ok = Validate(rfcSecret, prev, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/Period-1, matched)
+
+	old, err := GenerateCode(rfcSecret, now.Add(-3*Period*time.Second))
+	assert.NoError(t, err)
+
+	_, ok = Validate(rfcSecret, old, now)
+	assert.False(t, ok)
+
+	_, ok = Validate(rfcSecret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Porter", "jane@example.com", rfcSecret)
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Porter:jane@example.com?"))
+	assert.Contains(t, uri, "secret="+rfcSecret)
+	assert.Contains(t, uri, "issuer=Porter")
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+
+	seen := map[string]bool{}
+
+	for _, code := range codes {
+		assert.Len(t, code, 11)
+		assert.Equal(t, code, NormalizeRecoveryCode(" "+strings.ToUpper(code)+" "))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+}
@@ -0,0 +1,75 @@
+package bulkrelease
+
+import (
+	"context"
+	"sync"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const (
+	// DefaultConcurrency is the number of releases which are changed at the same time if no concurrency is requested
+	DefaultConcurrency = 5
+	// MaxConcurrency is the largest number of releases which can be changed at the same time
+	MaxConcurrency = 20
+)
+
+// ApplyFunc applies a bulk operation to a single release. It returns the status of the release and a
+// message which explains the status, such as the reason a release was skipped.
+type ApplyFunc func(ctx context.Context, release *models.Release) (types.BulkReleaseResultStatus, string, error)
+
+// Run applies fn to every release, running at most concurrency calls at the same time. Results are
+// returned in the order of releases, and a failure for one release does not stop the others.
+func Run(ctx context.Context, releases []*models.Release, concurrency int, fn ApplyFunc) []*types.BulkReleaseResult {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	if concurrency > MaxConcurrency {
+		concurrency = MaxConcurrency
+	}
+
+	results := make([]*types.BulkReleaseResult, len(releases))
+	sem := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, release := range releases {
+		wg.Add(1)
+
+		go func(i int, release *models.Release) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			result := &types.BulkReleaseResult{
+				Name:      release.Name,
+				Namespace: release.Namespace,
+			}
+
+			if ctx.Err() != nil {
+				result.Status = types.BulkReleaseResultFailed
+				result.Message = ctx.Err().Error()
+				results[i] = result
+
+				return
+			}
+
+			status, message, err := fn(ctx, release)
+			if err != nil {
+				status = types.BulkReleaseResultFailed
+				message = err.Error()
+			}
+
+			result.Status = status
+			result.Message = message
+			results[i] = result
+		}(i, release)
+	}
+
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,151 @@
+package bulkrelease
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	releases := make([]*models.Release, 0)
+
+	for i := 0; i < 12; i++ {
+		releases = append(releases, &models.Release{Name: fmt.Sprintf("release-%d", i), Namespace: "default"})
+	}
+
+	var running, maxRunning int32
+
+	results := Run(context.Background(), releases, 3, func(ctx context.Context, release *models.Release) (types.BulkReleaseResultStatus, string, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			seen := atomic.LoadInt32(&maxRunning)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxRunning, seen, current) {
+				break
+			}
+		}
+
+		time.Sleep(5 * time.Millisecond)
+
+		if release.Name == "release-4" {
+			return "", "", errors.New("upgrade failed")
+		}
+
+		return types.BulkReleaseResultSucceeded, "", nil
+	})
+
+	require.Len(t, results, len(releases))
+	assert.LessOrEqual(t, maxRunning, int32(3))
+
+	for i, result := range results {
+		assert.Equal(t, releases[i].Name, result.Name)
+
+		if i == 4 {
+			assert.Equal(t, types.BulkReleaseResultFailed, result.Status)
+			assert.Equal(t, "upgrade failed", result.Message)
+		} else {
+			assert.Equal(t, types.BulkReleaseResultSucceeded, result.Status)
+		}
+	}
+}
+
+func TestSetImageTag(t *testing.T) {
+	values := map[string]interface{}{
+		"image": map[string]interface{}{"repository": "nginx", "tag": "1.0"},
+	}
+
+	changed, err := SetImageTag(values, "1.0")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = SetImageTag(values, "1.1")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "1.1", values["image"].(map[string]interface{})["tag"])
+
+	_, err = SetImageTag(map[string]interface{}{}, "1.1")
+	assert.Error(t, err)
+}
+
+func TestSetReplicas(t *testing.T) {
+	values := map[string]interface{}{"replicaCount": float64(2)}
+
+	changed, err := SetReplicas(values, 2)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = SetReplicas(values, 0)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 0, values["replicaCount"])
+
+	_, err = SetReplicas(map[string]interface{}{
+		"replicaCount": 1,
+		"autoscaling":  map[string]interface{}{"enabled": true},
+	}, 3)
+	assert.Error(t, err)
+
+	_, err = SetReplicas(map[string]interface{}{}, 3)
+	assert.Error(t, err)
+}
+
+func TestAttachAndDetachEnvGroup(t *testing.T) {
+	values := map[string]interface{}{
+		"container": map[string]interface{}{},
+	}
+
+	envGroup := SyncedEnvGroup{
+		Name:    "shared",
+		Version: 2,
+		Variables: map[string]string{
+			"PORT":        "80",
+			"DB_PASSWORD": "PORTERSECRET_shared.v2",
+		},
+	}
+
+	changed, err := AttachEnvGroup(values, envGroup)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	synced := values["container"].(map[string]interface{})["env"].(map[string]interface{})["synced"].([]interface{})
+	require.Len(t, synced, 1)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "DB_PASSWORD", "secret": true},
+		map[string]interface{}{"name": "PORT", "secret": false},
+	}, synced[0].(map[string]interface{})["keys"])
+
+	changed, err = AttachEnvGroup(values, envGroup)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	envGroup.Version = 3
+
+	changed, err = AttachEnvGroup(values, envGroup)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	synced = values["container"].(map[string]interface{})["env"].(map[string]interface{})["synced"].([]interface{})
+	require.Len(t, synced, 1)
+	assert.Equal(t, uint(3), synced[0].(map[string]interface{})["version"])
+
+	changed, err = DetachEnvGroup(values, "other")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	changed, err = DetachEnvGroup(values, "shared")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Empty(t, values["container"].(map[string]interface{})["env"].(map[string]interface{})["synced"])
+
+	_, err = AttachEnvGroup(map[string]interface{}{}, envGroup)
+	assert.Error(t, err)
+}
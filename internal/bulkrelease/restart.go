@@ -0,0 +1,60 @@
+package bulkrelease
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+// restartedAtAnnotation is the pod template annotation that "kubectl rollout restart" sets
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// RestartableControllers returns the deployments, stateful sets and daemon sets in the manifest of a release
+func RestartableControllers(manifest, namespace string) []grapher.Object {
+	res := make([]grapher.Object, 0)
+
+	for _, controller := range grapher.ParseControllers(grapher.ImportMultiDocYAML([]byte(manifest))) {
+		switch strings.ToLower(controller.Kind) {
+		case "deployment", "statefulset", "daemonset":
+			controller.Namespace = namespace
+			res = append(res, controller)
+		}
+	}
+
+	return res
+}
+
+// RestartControllers rolls the pods of each controller in the same way as "kubectl rollout restart"
+func RestartControllers(ctx context.Context, agent *kubernetes.Agent, controllers []grapher.Object) error {
+	patch := []byte(fmt.Sprintf(
+		`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`,
+		restartedAtAnnotation, time.Now().UTC().Format(time.RFC3339),
+	))
+
+	for _, controller := range controllers {
+		var err error
+
+		apps := agent.Clientset.AppsV1()
+
+		switch strings.ToLower(controller.Kind) {
+		case "deployment":
+			_, err = apps.Deployments(controller.Namespace).Patch(ctx, controller.Name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		case "statefulset":
+			_, err = apps.StatefulSets(controller.Namespace).Patch(ctx, controller.Name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		case "daemonset":
+			_, err = apps.DaemonSets(controller.Namespace).Patch(ctx, controller.Name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		}
+
+		if err != nil {
+			return fmt.Errorf("error restarting %s %s: %w", strings.ToLower(controller.Kind), controller.Name, err)
+		}
+	}
+
+	return nil
+}
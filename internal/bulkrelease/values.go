@@ -0,0 +1,154 @@
+package bulkrelease
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SetImageTag sets the image tag in the values of a release. It returns false if the release already
+// has the tag, and an error if the values do not configure an image.
+func SetImageTag(values map[string]interface{}, tag string) (bool, error) {
+	image, ok := values["image"].(map[string]interface{})
+	if !ok {
+		return false, fmt.Errorf("release does not configure an image")
+	}
+
+	if current, _ := image["tag"].(string); current == tag {
+		return false, nil
+	}
+
+	image["tag"] = tag
+
+	return true, nil
+}
+
+// SetReplicas sets the replica count in the values of a release. It returns false if the release already
+// has the replica count, and an error if the release autoscales, since its replicas are managed by the autoscaler.
+func SetReplicas(values map[string]interface{}, replicas int) (bool, error) {
+	if autoscaling, ok := values["autoscaling"].(map[string]interface{}); ok {
+		if enabled, _ := autoscaling["enabled"].(bool); enabled {
+			return false, fmt.Errorf("release has autoscaling enabled")
+		}
+	}
+
+	current, exists := values["replicaCount"]
+	if !exists {
+		return false, fmt.Errorf("release does not configure a replica count")
+	}
+
+	if fmt.Sprint(current) == fmt.Sprint(replicas) {
+		return false, nil
+	}
+
+	values["replicaCount"] = replicas
+
+	return true, nil
+}
+
+// SyncedEnvGroup is an env group which is synced to a release under container.env.synced
+type SyncedEnvGroup struct {
+	Name    string
+	Version uint
+	// Variables are the variables of the env group. Secret variables have a PORTERSECRET value.
+	Variables map[string]string
+}
+
+// AttachEnvGroup syncs an env group to a release by adding it to container.env.synced. An env group
+// which is already synced is updated to the given version. It returns false if the release already
+// syncs the version.
+func AttachEnvGroup(values map[string]interface{}, envGroup SyncedEnvGroup) (bool, error) {
+	env, err := containerEnv(values)
+	if err != nil {
+		return false, err
+	}
+
+	keyNames := make([]string, 0, len(envGroup.Variables))
+
+	for key := range envGroup.Variables {
+		keyNames = append(keyNames, key)
+	}
+
+	sort.Strings(keyNames)
+
+	keys := make([]interface{}, 0, len(keyNames))
+
+	for _, key := range keyNames {
+		keys = append(keys, map[string]interface{}{
+			"name":   key,
+			"secret": strings.Contains(envGroup.Variables[key], "PORTERSECRET"),
+		})
+	}
+
+	section := map[string]interface{}{
+		"name":    envGroup.Name,
+		"version": envGroup.Version,
+		"keys":    keys,
+	}
+
+	synced, _ := env["synced"].([]interface{})
+
+	for i, existing := range synced {
+		existingMap, ok := existing.(map[string]interface{})
+		if !ok || existingMap["name"] != envGroup.Name {
+			continue
+		}
+
+		if fmt.Sprint(existingMap["version"]) == fmt.Sprint(envGroup.Version) {
+			return false, nil
+		}
+
+		synced[i] = section
+		env["synced"] = synced
+
+		return true, nil
+	}
+
+	env["synced"] = append(synced, section)
+
+	return true, nil
+}
+
+// DetachEnvGroup removes an env group from container.env.synced. It returns false if the release does
+// not sync the env group.
+func DetachEnvGroup(values map[string]interface{}, name string) (bool, error) {
+	env, err := containerEnv(values)
+	if err != nil {
+		return false, err
+	}
+
+	synced, _ := env["synced"].([]interface{})
+	remaining := make([]interface{}, 0, len(synced))
+
+	for _, existing := range synced {
+		if existingMap, ok := existing.(map[string]interface{}); ok && existingMap["name"] == name {
+			continue
+		}
+
+		remaining = append(remaining, existing)
+	}
+
+	if len(remaining) == len(synced) {
+		return false, nil
+	}
+
+	env["synced"] = remaining
+
+	return true, nil
+}
+
+// containerEnv returns container.env of the values, creating env if the release has a container
+func containerEnv(values map[string]interface{}) (map[string]interface{}, error) {
+	container, ok := values["container"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("release does not configure a container")
+	}
+
+	env, ok := container["env"].(map[string]interface{})
+	if !ok {
+		env = make(map[string]interface{})
+		container["env"] = env
+	}
+
+	return env, nil
+}
@@ -45,6 +45,12 @@ func (a *Agent) Namespace() string {
 	return a.namespace
 }
 
+// usesSecretStorage returns whether releases are stored in secrets. Release secrets are listed
+// directly by label, which is faster than decoding every revision through the storage driver.
+func (a *Agent) usesSecretStorage() bool {
+	return a.ActionConfig.Releases == nil || a.ActionConfig.Releases.Name() == driver.SecretsDriverName
+}
+
 // ListReleases lists releases based on a ListFilter
 func (a *Agent) ListReleases(
 	ctx context.Context,
@@ -58,6 +64,15 @@ func (a *Agent) ListReleases(
 		telemetry.AttributeKV{Key: "namespace", Value: namespace},
 	)
 
+	if !a.usesSecretStorage() {
+		res, err := a.listReleasesFromStorage(filter)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error listing releases from storage")
+		}
+
+		return res, nil
+	}
+
 	lsel := fmt.Sprintf("owner=helm,status in (%s)", strings.Join(filter.StatusFilter, ","))
 
 	// list secrets
@@ -114,6 +129,41 @@ func (a *Agent) ListReleases(
 	return res, nil
 }
 
+// listReleasesFromStorage returns the latest revision of each release in the storage which has
+// one of the statuses of the filter
+func (a *Agent) listReleasesFromStorage(filter *types.ReleaseListFilter) ([]*release.Release, error) {
+	statuses := make(map[string]bool, len(filter.StatusFilter))
+
+	for _, status := range filter.StatusFilter {
+		statuses[status] = true
+	}
+
+	revisions, err := a.ActionConfig.Releases.List(func(rel *release.Release) bool {
+		return rel.Info != nil && statuses[rel.Info.Status.String()]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	latestMap := make(map[string]*release.Release)
+
+	for _, rel := range revisions {
+		id := fmt.Sprintf("%s/%s", rel.Namespace, rel.Name)
+
+		if currLatest, exists := latestMap[id]; !exists || currLatest.Version < rel.Version {
+			latestMap[id] = rel
+		}
+	}
+
+	res := make([]*release.Release, 0, len(latestMap))
+
+	for _, rel := range latestMap {
+		res = append(res, rel)
+	}
+
+	return res, nil
+}
+
 // GetRelease returns the info of a release.
 func (a *Agent) GetRelease(
 	ctx context.Context,
@@ -198,6 +248,17 @@ func (a *Agent) getLatestReleaseVersion(ctx context.Context, name string) int {
 		telemetry.AttributeKV{Key: "name", Value: name},
 		telemetry.AttributeKV{Key: "namespace", Value: a.Namespace()},
 	)
+
+	if !a.usesSecretStorage() {
+		// as with release secrets, errors are ignored since getting version 0 returns the latest revision
+		last, err := a.ActionConfig.Releases.Last(name)
+		if err != nil {
+			return 0
+		}
+
+		return last.Version
+	}
+
 	helmStatuses := []string{
 		string(release.StatusDeployed),
 		string(release.StatusFailed),
@@ -289,8 +350,15 @@ type UpgradeReleaseConfig struct {
 	// Optional, if chart is part of a Porter Stack
 	StackName     string
 	StackRevision uint
+
+	// Optional, if the rendered release should be admitted before it is upgraded
+	Admit AdmissionFunc
 }
 
+// AdmissionFunc is called with a release rendered by a dry run before the release is installed or upgraded.
+// If it returns an error, the release is not installed or upgraded.
+type AdmissionFunc func(ctx context.Context, rel *release.Release) error
+
 // UpgradeRelease upgrades a specific release with new values.yaml
 func (a *Agent) UpgradeRelease(
 	ctx context.Context,
@@ -377,11 +445,40 @@ func (a *Agent) UpgradeReleaseByValues(
 		}
 	}
 
+	if conf.Admit != nil {
+		if err := a.admit(ctx, conf.Name, rel.Namespace, ch, conf.Values, conf.Admit); err != nil {
+			return nil, telemetry.Error(ctx, span, err, "release was not admitted")
+		}
+	}
+
 	res, err := cmd.Run(conf.Name, ch, conf.Values)
 	if err != nil {
 		// refer: https://github.com/helm/helm/blob/release-3.8/pkg/action/action.go#L62
 		// issue tracker: https://github.com/helm/helm/issues/4558
 		if err.Error() == "another operation (install/upgrade/rollback) is in progress" {
+			if !a.usesSecretStorage() {
+				pending, failed, updateErr := a.failStalePendingRevision(rel.Name)
+				if updateErr != nil {
+					return nil, telemetry.Error(ctx, span, updateErr, "error updating pending revision")
+				}
+
+				if failed {
+					// retry upgrade
+					res, err = cmd.Run(conf.Name, ch, conf.Values)
+					if err != nil {
+						return nil, telemetry.Error(ctx, span, err, "error running upgrade after updating pending revision")
+					}
+
+					return res, nil
+				}
+
+				if pending {
+					return nil, telemetry.Error(ctx, span, err, "another operation (install/upgrade/rollback) is in progress. If this error persists, please wait for 60 seconds to force an upgrade")
+				}
+
+				return nil, telemetry.Error(ctx, span, err, "error running upgrade")
+			}
+
 			secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace).List(
 				context.Background(),
 				v1.ListOptions{
@@ -430,29 +527,13 @@ func (a *Agent) UpgradeReleaseByValues(
 			}
 		} else if strings.Contains(err.Error(), "current release manifest contains removed kubernetes api(s)") || strings.Contains(err.Error(), "resource mapping not found for name") {
 			// ref: https://helm.sh/docs/topics/kubernetes_apis/#updating-api-versions-of-a-release-manifest
-			// in this case, we manually update the secret containing the new manifests
-			secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace).List(
-				context.Background(),
-				v1.ListOptions{
-					LabelSelector: fmt.Sprintf("owner=helm,name=%s", rel.Name),
-				},
-			)
+			// in this case, we manually update the latest revision with the new manifests
+			updateLatestRevision, err := a.latestRevisionUpdater(rel.Namespace, rel.Name)
 			if err != nil {
-				return nil, telemetry.Error(ctx, span, err, "error getting secret list")
+				return nil, telemetry.Error(ctx, span, err, "error getting latest revision")
 			}
 
-			if len(secretList.Items) > 0 {
-				mostRecentSecret := secretList.Items[0]
-
-				for i := 1; i < len(secretList.Items); i += 1 {
-					oldVersion, _ := strconv.Atoi(mostRecentSecret.Labels["version"])
-					newVersion, _ := strconv.Atoi(secretList.Items[i].Labels["version"])
-
-					if oldVersion < newVersion {
-						mostRecentSecret = secretList.Items[i]
-					}
-				}
-
+			if updateLatestRevision != nil {
 				// run the equivalent of `helm template` to get the manifest string for the new release
 				installCmd := action.NewInstall(a.ActionConfig)
 
@@ -481,17 +562,15 @@ func (a *Agent) UpgradeReleaseByValues(
 
 				rel.Manifest = updatedManifestBuffer.String()
 
-				helmSecrets := driver.NewSecrets(a.K8sAgent.Clientset.CoreV1().Secrets(rel.Namespace))
-
-				err = helmSecrets.Update(mostRecentSecret.GetName(), rel)
+				err = updateLatestRevision(rel)
 
 				if err != nil {
-					return nil, telemetry.Error(ctx, span, err, "error updating helm secret")
+					return nil, telemetry.Error(ctx, span, err, "error updating latest revision")
 				}
 
 				res, err := cmd.Run(conf.Name, ch, conf.Values)
 				if err != nil {
-					return nil, telemetry.Error(ctx, span, err, "error running upgrade after updating helm secrets")
+					return nil, telemetry.Error(ctx, span, err, "error running upgrade after updating latest revision")
 				}
 
 				return res, nil
@@ -504,6 +583,79 @@ func (a *Agent) UpgradeReleaseByValues(
 	return res, nil
 }
 
+// latestRevisionUpdater returns a function which overwrites the latest stored revision of a release,
+// or nil if the release has no stored revisions
+func (a *Agent) latestRevisionUpdater(namespace, name string) (func(rel *release.Release) error, error) {
+	if !a.usesSecretStorage() {
+		if _, err := a.ActionConfig.Releases.Last(name); err != nil {
+			if errors.Is(err, driver.ErrReleaseNotFound) {
+				return nil, nil
+			}
+
+			return nil, err
+		}
+
+		return a.ActionConfig.Releases.Update, nil
+	}
+
+	secretList, err := a.K8sAgent.Clientset.CoreV1().Secrets(namespace).List(
+		context.Background(),
+		v1.ListOptions{
+			LabelSelector: fmt.Sprintf("owner=helm,name=%s", name),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(secretList.Items) == 0 {
+		return nil, nil
+	}
+
+	mostRecentSecret := secretList.Items[0]
+
+	for i := 1; i < len(secretList.Items); i += 1 {
+		oldVersion, _ := strconv.Atoi(mostRecentSecret.Labels["version"])
+		newVersion, _ := strconv.Atoi(secretList.Items[i].Labels["version"])
+
+		if oldVersion < newVersion {
+			mostRecentSecret = secretList.Items[i]
+		}
+	}
+
+	helmSecrets := driver.NewSecrets(a.K8sAgent.Clientset.CoreV1().Secrets(namespace))
+
+	return func(rel *release.Release) error {
+		return helmSecrets.Update(mostRecentSecret.GetName(), rel)
+	}, nil
+}
+
+// failStalePendingRevision marks the latest revision of a release as failed if it has been pending
+// for over a minute, so that an interrupted operation does not block upgrades. It returns whether
+// the latest revision is pending and whether it was marked as failed.
+func (a *Agent) failStalePendingRevision(name string) (bool, bool, error) {
+	last, err := a.ActionConfig.Releases.Last(name)
+	if err != nil {
+		return false, false, err
+	}
+
+	if last.Info == nil || !last.Info.Status.IsPending() {
+		return false, false, nil
+	}
+
+	if time.Since(last.Info.LastDeployed.Time) < time.Minute {
+		return true, false, nil
+	}
+
+	last.Info.Status = release.StatusFailed
+
+	if err := a.ActionConfig.Releases.Update(last); err != nil {
+		return true, false, err
+	}
+
+	return true, true, nil
+}
+
 // InstallChartConfig is the config required to install a chart
 type InstallChartConfig struct {
 	Chart      *chart.Chart
@@ -513,6 +665,9 @@ type InstallChartConfig struct {
 	Cluster    *models.Cluster
 	Repo       repository.Repository
 	Registries []*models.Registry
+
+	// Optional, if the rendered release should be admitted before it is installed
+	Admit AdmissionFunc
 }
 
 // InstallChartFromValuesBytes reads the raw values and calls Agent.InstallChart
@@ -607,9 +762,41 @@ func (a *Agent) InstallChart(
 		}
 	}
 
+	if conf.Admit != nil {
+		if err := a.admit(ctx, conf.Name, conf.Namespace, conf.Chart, conf.Values, conf.Admit); err != nil {
+			return nil, telemetry.Error(ctx, span, err, "release was not admitted")
+		}
+	}
+
 	return cmd.Run(conf.Chart, conf.Values)
 }
 
+// admit renders the release with the equivalent of `helm template` and passes it to the admission func. The
+// porter postrenderer is not run, since it creates image pull secrets for a release which may not be admitted.
+func (a *Agent) admit(
+	ctx context.Context,
+	name, namespace string,
+	ch *chart.Chart,
+	values map[string]interface{},
+	admit AdmissionFunc,
+) error {
+	cmd := action.NewInstall(a.ActionConfig)
+
+	cmd.ReleaseName = name
+	cmd.Namespace = namespace
+	cmd.DryRun = true
+	cmd.Replace = true
+	cmd.ClientOnly = false
+	cmd.IncludeCRDs = true
+
+	rel, err := cmd.Run(ch, values)
+	if err != nil {
+		return fmt.Errorf("error rendering release: %w", err)
+	}
+
+	return admit(ctx, rel)
+}
+
 // UpgradeInstallChart installs a new chart if it doesn't exist, otherwise it upgrades it
 func (a *Agent) UpgradeInstallChart(
 	ctx context.Context,
@@ -714,6 +901,33 @@ func (a *Agent) RollbackRelease(
 	return cmd.Run(name)
 }
 
+// RestoreReleaseRevision writes a revision which was pruned from the release storage back to it as
+// a superseded revision, so that the release can be rolled back to it
+func (a *Agent) RestoreReleaseRevision(
+	ctx context.Context,
+	rel *release.Release,
+) error {
+	ctx, span := telemetry.NewSpan(ctx, "helm-restore-release-revision")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "name", Value: rel.Name},
+		telemetry.AttributeKV{Key: "version", Value: rel.Version},
+	)
+
+	if rel.Info == nil {
+		rel.Info = &release.Info{}
+	}
+
+	rel.Info.Status = release.StatusSuperseded
+
+	if err := a.ActionConfig.Releases.Create(rel); err != nil {
+		return telemetry.Error(ctx, span, err, "error restoring release revision")
+	}
+
+	return nil
+}
+
 // ------------------------ Helm agent helper functions ------------------------ //
 
 // checkIfInstallable validates if a chart can be installed
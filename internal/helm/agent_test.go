@@ -191,6 +191,31 @@ type listReleaseTest struct {
 // 	}
 // }
 
+func TestListReleasesFromStorage(t *testing.T) {
+	agent := newAgentFixture(t, "")
+
+	makeReleases(t, agent, []releaseStub{
+		{"wordpress", "default", 1, "1.0.1", release.StatusSuperseded},
+		{"wordpress", "default", 2, "1.0.2", release.StatusDeployed},
+		{"airwatch", "other", 1, "1.0.0", release.StatusFailed},
+	})
+
+	// calling agent.ActionConfig.Releases.Create in makeReleases will automatically set the
+	// namespace, so we have to reset the namespace of the storage driver
+	agent.ActionConfig.Releases.Driver.(*driver.Memory).SetNamespace("")
+
+	releases, err := agent.ListReleases(context.Background(), "", &types.ReleaseListFilter{
+		StatusFilter: []string{"deployed", "superseded"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compareReleaseToStubs(t, releases, []releaseStub{
+		{"wordpress", "default", 2, "1.0.2", release.StatusDeployed},
+	})
+}
+
 type getReleaseTest struct {
 	name       string
 	namespace  string
@@ -339,3 +364,50 @@ func TestRollbackRelease(t *testing.T) {
 		compareReleaseToStubs(t, []*release.Release{rel}, []releaseStub{tc.expRes})
 	}
 }
+
+func TestRestoreReleaseRevision(t *testing.T) {
+	agent := newAgentFixture(t, "default")
+	makeReleases(t, agent, []releaseStub{
+		{"wordpress", "default", 3, "1.0.3", release.StatusDeployed},
+		{"wordpress", "default", 2, "1.0.2", release.StatusSuperseded},
+	})
+
+	agent.ActionConfig.Releases.Driver.(*driver.Memory).SetNamespace("default")
+
+	// revision 1 was pruned from the release storage and archived while deployed
+	archived := &release.Release{
+		Name:      "wordpress",
+		Namespace: "default",
+		Version:   1,
+		Info: &release.Info{
+			Status: release.StatusDeployed,
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Version: "1.0.1",
+			},
+		},
+	}
+
+	err := agent.RestoreReleaseRevision(context.Background(), archived)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	err = agent.RollbackRelease(context.Background(), "wordpress", 1)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	releases, err := agent.GetReleaseHistory(context.Background(), "wordpress")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	compareReleaseToStubs(t, releases, []releaseStub{
+		{"wordpress", "default", 1, "1.0.1", release.StatusSuperseded},
+		{"wordpress", "default", 2, "1.0.2", release.StatusSuperseded},
+		{"wordpress", "default", 3, "1.0.3", release.StatusSuperseded},
+		{"wordpress", "default", 4, "1.0.1", release.StatusDeployed},
+	})
+}
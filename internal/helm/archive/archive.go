@@ -0,0 +1,276 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/stefanmcshane/helm/pkg/chart"
+	"github.com/stefanmcshane/helm/pkg/release"
+)
+
+// StatusArchived is the status of a revision which has been pruned from the cluster and is only
+// stored in the revision archive
+const StatusArchived release.Status = "archived"
+
+// ErrObjectNotFound is returned by an ObjectStore when no object exists with a key
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectStore is an object storage backend for archived revisions. Keys are slash-separated paths.
+type ObjectStore interface {
+	// Write writes an object, replacing any object with the same key
+	Write(ctx context.Context, key string, data []byte) error
+
+	// Read reads an object, returning ErrObjectNotFound if it does not exist
+	Read(ctx context.Context, key string) ([]byte, error)
+
+	// List lists the keys of all objects which start with prefix
+	List(ctx context.Context, prefix string) ([]string, error)
+}
+
+// Options configures the object storage backend of an Archive
+type Options struct {
+	// Backend is the object storage backend to use: s3, gcs or local
+	Backend string
+
+	// EncryptionKey encrypts revisions before they are written, if set
+	EncryptionKey string
+
+	AWSRegion          string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	S3BucketName       string
+
+	GCSBucketName string
+	// GCSCredentials is a service account JSON key. Application default credentials are used if it is empty
+	GCSCredentials string
+
+	// LocalDir is the directory revisions are written to by the local backend
+	LocalDir string
+}
+
+// Archive stores Helm release revisions which have been pruned from a cluster, so that they can be
+// listed and restored for a rollback. Revisions are written with the key
+// <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>, next to a small metadata object
+// with the key <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>.meta which is read
+// when revisions are listed.
+type Archive struct {
+	store         ObjectStore
+	encryptionKey *[32]byte
+}
+
+// New returns an Archive using the object storage backend configured by opts
+func New(ctx context.Context, opts *Options) (*Archive, error) {
+	var (
+		store ObjectStore
+		err   error
+	)
+
+	switch opts.Backend {
+	case "s3":
+		store, err = NewS3Store(opts.AWSRegion, opts.AWSAccessKeyID, opts.AWSSecretAccessKey, opts.S3BucketName)
+	case "gcs":
+		store, err = NewGCSStore(ctx, opts.GCSBucketName, opts.GCSCredentials)
+	case "local":
+		store, err = NewLocalStore(opts.LocalDir)
+	default:
+		return nil, fmt.Errorf("unsupported revision archive backend %q, must be one of: s3, gcs, local", opts.Backend)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s revision archive: %w", opts.Backend, err)
+	}
+
+	var key *[32]byte
+
+	if opts.EncryptionKey != "" {
+		key = &[32]byte{}
+
+		for i, b := range []byte(opts.EncryptionKey) {
+			key[i] = b
+		}
+	}
+
+	return NewArchive(store, key), nil
+}
+
+// NewArchive returns an Archive writing to store. Revisions are encrypted with encryptionKey, unless it is nil.
+func NewArchive(store ObjectStore, encryptionKey *[32]byte) *Archive {
+	return &Archive{
+		store:         store,
+		encryptionKey: encryptionKey,
+	}
+}
+
+// WriteRevision archives a revision of a release in a cluster
+func (a *Archive) WriteRevision(ctx context.Context, projectID, clusterID uint, rel *release.Release) error {
+	if err := a.write(ctx, revisionKey(projectID, clusterID, rel.Namespace, rel.Name, rel.Version), rel); err != nil {
+		return err
+	}
+
+	// the metadata is written last, so that a listed revision can always be read
+	return a.write(ctx, metadataKey(projectID, clusterID, rel.Namespace, rel.Name, rel.Version), revisionMetadata(rel))
+}
+
+// ReadRevision reads an archived revision of a release, returning ErrObjectNotFound if the revision
+// was never archived
+func (a *Archive) ReadRevision(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	version int,
+) (*release.Release, error) {
+	return a.read(ctx, revisionKey(projectID, clusterID, namespace, name, version))
+}
+
+// ListRevisions lists the archived revisions of a release from oldest to newest, with their status set to
+// StatusArchived. Only the metadata objects of the revisions are read, so revisions are listed without their
+// manifest, hooks and chart files, which can be read with ReadRevision. Revisions archived without a metadata
+// object are read in full once, and their metadata object is written for the next listing.
+func (a *Archive) ListRevisions(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+) ([]*release.Release, error) {
+	prefix := releasePrefix(projectID, clusterID, namespace, name)
+
+	keys, err := a.store.List(ctx, prefix)
+	if err != nil {
+		return nil, fmt.Errorf("error listing archived revisions: %w", err)
+	}
+
+	revisions := make(map[int]bool)
+	withMetadata := make(map[int]bool)
+
+	for _, key := range keys {
+		// keys of other objects under the prefix, such as those of a release whose name has the same
+		// prefix, are skipped
+		suffix := strings.TrimPrefix(key, prefix)
+
+		versionString, isMetadata := strings.CutSuffix(suffix, metadataSuffix)
+
+		version, err := strconv.Atoi(versionString)
+		if err != nil {
+			continue
+		}
+
+		if isMetadata {
+			withMetadata[version] = true
+		} else {
+			revisions[version] = true
+		}
+	}
+
+	res := make([]*release.Release, 0, len(revisions))
+
+	for version := range revisions {
+		var rel *release.Release
+
+		if withMetadata[version] {
+			rel, err = a.read(ctx, metadataKey(projectID, clusterID, namespace, name, version))
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			full, err := a.read(ctx, revisionKey(projectID, clusterID, namespace, name, version))
+			if err != nil {
+				return nil, err
+			}
+
+			rel = revisionMetadata(full)
+
+			// the listing does not depend on the metadata object, so it is written on a best effort basis
+			_ = a.write(ctx, metadataKey(projectID, clusterID, namespace, name, version), rel)
+		}
+
+		if rel.Info == nil {
+			rel.Info = &release.Info{}
+		}
+
+		rel.Info.Status = StatusArchived
+
+		res = append(res, rel)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Version < res[j].Version
+	})
+
+	return res, nil
+}
+
+// revisionMetadata returns a copy of a revision without its manifest, hooks and chart files
+func revisionMetadata(rel *release.Release) *release.Release {
+	res := &release.Release{
+		Name:      rel.Name,
+		Namespace: rel.Namespace,
+		Version:   rel.Version,
+		Info:      rel.Info,
+		Config:    rel.Config,
+	}
+
+	if rel.Chart != nil {
+		res.Chart = &chart.Chart{
+			Metadata: rel.Chart.Metadata,
+		}
+	}
+
+	return res
+}
+
+func (a *Archive) write(ctx context.Context, key string, rel *release.Release) error {
+	data, err := json.Marshal(rel)
+	if err != nil {
+		return fmt.Errorf("error marshalling revision: %w", err)
+	}
+
+	if a.encryptionKey != nil {
+		data, err = encryption.Encrypt(data, a.encryptionKey)
+		if err != nil {
+			return fmt.Errorf("error encrypting revision: %w", err)
+		}
+	}
+
+	return a.store.Write(ctx, key, data)
+}
+
+func (a *Archive) read(ctx context.Context, key string) (*release.Release, error) {
+	data, err := a.store.Read(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.encryptionKey != nil {
+		data, err = encryption.Decrypt(data, a.encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("error decrypting archived revision %s: %w", key, err)
+		}
+	}
+
+	rel := &release.Release{}
+
+	if err := json.Unmarshal(data, rel); err != nil {
+		return nil, fmt.Errorf("error unmarshalling archived revision %s: %w", key, err)
+	}
+
+	return rel, nil
+}
+
+func releasePrefix(projectID, clusterID uint, namespace, name string) string {
+	return fmt.Sprintf("%d/%d/%s/%s/", projectID, clusterID, namespace, name)
+}
+
+func revisionKey(projectID, clusterID uint, namespace, name string, version int) string {
+	return fmt.Sprintf("%s%d", releasePrefix(projectID, clusterID, namespace, name), version)
+}
+
+const metadataSuffix = ".meta"
+
+func metadataKey(projectID, clusterID uint, namespace, name string, version int) string {
+	return revisionKey(projectID, clusterID, namespace, name, version) + metadataSuffix
+}
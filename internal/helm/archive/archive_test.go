@@ -0,0 +1,140 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stefanmcshane/helm/pkg/chart"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRevision(name string, version int) *release.Release {
+	return &release.Release{
+		Name:      name,
+		Namespace: "default",
+		Version:   version,
+		Manifest:  "kind: Deployment",
+		Info:      &release.Info{Status: release.StatusSuperseded},
+		Chart: &chart.Chart{
+			Metadata:  &chart.Metadata{Name: "web", Version: "0.1.0"},
+			Templates: []*chart.File{{Name: "templates/deployment.yaml"}},
+		},
+		Config: map[string]interface{}{"replicaCount": float64(version)},
+	}
+}
+
+func TestArchiveWriteAndRead(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+
+	store, err := NewLocalStore(dir)
+	require.NoError(t, err)
+
+	a := NewArchive(store, &[32]byte{1, 2, 3})
+
+	require.NoError(t, a.WriteRevision(ctx, 1, 2, newRevision("web", 3)))
+
+	// revisions are written with the key <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>
+	data, err := os.ReadFile(filepath.Join(dir, "1", "2", "default", "web", "3"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "kind: Deployment")
+
+	rel, err := a.ReadRevision(ctx, 1, 2, "default", "web", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, rel.Version)
+	assert.Equal(t, "kind: Deployment", rel.Manifest)
+	assert.Equal(t, release.StatusSuperseded, rel.Info.Status)
+
+	_, err = a.ReadRevision(ctx, 1, 2, "default", "web", 4)
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	_, err = NewArchive(store, &[32]byte{4, 5, 6}).ReadRevision(ctx, 1, 2, "default", "web", 3)
+	assert.Error(t, err)
+}
+
+func TestArchiveListRevisions(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	a := NewArchive(store, nil)
+
+	for _, rel := range []*release.Release{
+		newRevision("web", 10),
+		newRevision("web", 2),
+		newRevision("web", 1),
+		newRevision("web-2", 1),
+	} {
+		require.NoError(t, a.WriteRevision(ctx, 1, 2, rel))
+	}
+
+	require.NoError(t, a.WriteRevision(ctx, 1, 3, newRevision("web", 4)))
+
+	revisions, err := a.ListRevisions(ctx, 1, 2, "default", "web")
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+
+	for i, version := range []int{1, 2, 10} {
+		assert.Equal(t, version, revisions[i].Version)
+		assert.Equal(t, StatusArchived, revisions[i].Info.Status)
+		assert.Empty(t, revisions[i].Manifest)
+		assert.Empty(t, revisions[i].Chart.Templates)
+		assert.Equal(t, "0.1.0", revisions[i].Chart.Metadata.Version)
+	}
+
+	revisions, err = a.ListRevisions(ctx, 1, 2, "default", "api")
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func TestArchiveListRevisionsReadsOnlyMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	dir := t.TempDir()
+
+	store, err := NewLocalStore(dir)
+	require.NoError(t, err)
+
+	a := NewArchive(store, &[32]byte{1, 2, 3})
+
+	require.NoError(t, a.WriteRevision(ctx, 1, 2, newRevision("web", 1)))
+
+	// a revision archived before metadata objects were written is read in full once
+	require.NoError(t, a.write(ctx, revisionKey(1, 2, "default", "web", 2), newRevision("web", 2)))
+
+	revisions, err := a.ListRevisions(ctx, 1, 2, "default", "web")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Equal(t, float64(2), revisions[1].Config["replicaCount"])
+
+	_, err = store.Read(ctx, metadataKey(1, 2, "default", "web", 2))
+	require.NoError(t, err)
+
+	// once every revision has a metadata object, the full revisions are no longer read
+	for _, version := range []int{1, 2} {
+		require.NoError(t, store.Write(ctx, revisionKey(1, 2, "default", "web", version), []byte("corrupted")))
+	}
+
+	revisions, err = a.ListRevisions(ctx, 1, 2, "default", "web")
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+
+	for i, version := range []int{1, 2} {
+		assert.Equal(t, version, revisions[i].Version)
+		assert.Equal(t, StatusArchived, revisions[i].Info.Status)
+		assert.Equal(t, "0.1.0", revisions[i].Chart.Metadata.Version)
+	}
+}
+
+func TestLocalStoreRejectsKeysOutsideDirectory(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	assert.Error(t, store.Write(context.Background(), "1/../../secret", []byte("data")))
+}
@@ -0,0 +1,89 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/option"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// GCSStore is an ObjectStore backed by a Google Cloud Storage bucket
+type GCSStore struct {
+	service *storage.Service
+	bucket  string
+}
+
+// NewGCSStore returns a GCSStore for a bucket. credentials is a service account JSON key; application
+// default credentials are used if it is empty.
+func NewGCSStore(ctx context.Context, bucket, credentials string) (*GCSStore, error) {
+	if bucket == "" {
+		return nil, errors.New("gcs bucket name must be set")
+	}
+
+	opts := []option.ClientOption{option.WithScopes(storage.DevstorageReadWriteScope)}
+
+	if credentials != "" {
+		opts = append(opts, option.WithCredentialsJSON([]byte(credentials)))
+	}
+
+	service, err := storage.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create GCS client: %w", err)
+	}
+
+	return &GCSStore{
+		service: service,
+		bucket:  bucket,
+	}, nil
+}
+
+// Write writes an object to the bucket
+func (s *GCSStore) Write(ctx context.Context, key string, data []byte) error {
+	_, err := s.service.Objects.Insert(s.bucket, &storage.Object{Name: key}).
+		Media(bytes.NewReader(data)).
+		Context(ctx).
+		Do()
+
+	return err
+}
+
+// Read reads an object from the bucket
+func (s *GCSStore) Read(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.service.Objects.Get(s.bucket, key).Context(ctx).Download()
+	if err != nil {
+		var gerr *googleapi.Error
+		if errors.As(err, &gerr) && gerr.Code == http.StatusNotFound {
+			return nil, ErrObjectNotFound
+		}
+
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	return io.ReadAll(resp.Body)
+}
+
+// List lists the keys of the objects in the bucket which start with prefix
+func (s *GCSStore) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	err := s.service.Objects.List(s.bucket).Prefix(prefix).Pages(ctx, func(objects *storage.Objects) error {
+		for _, obj := range objects.Items {
+			keys = append(keys, obj.Name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
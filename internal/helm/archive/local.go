@@ -0,0 +1,112 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore is an ObjectStore which writes objects to files in a directory, for self-hosted instances
+// without object storage
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore returns a LocalStore writing to dir, which is created if it does not exist
+func NewLocalStore(dir string) (*LocalStore, error) {
+	if dir == "" {
+		return nil, errors.New("local revision archive directory must be set")
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("cannot create directory %s: %w", dir, err)
+	}
+
+	return &LocalStore{dir: dir}, nil
+}
+
+// Write writes an object to a file in the directory
+func (s *LocalStore) Write(_ context.Context, key string, data []byte) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+
+	return os.WriteFile(p, data, 0o600)
+}
+
+// Read reads an object from a file in the directory
+func (s *LocalStore) Read(_ context.Context, key string) ([]byte, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotFound
+	}
+
+	return data, err
+}
+
+// List lists the keys of the objects in the directory which start with prefix
+func (s *LocalStore) List(_ context.Context, prefix string) ([]string, error) {
+	// only the deepest directory containing every key with the prefix is walked
+	root, err := s.path(path.Dir(prefix + "_"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0)
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// path returns the file path of a key, which may not point outside the directory
+func (s *LocalStore) path(key string) (string, error) {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("invalid key %s", key)
+		}
+	}
+
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
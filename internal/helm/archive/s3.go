@@ -0,0 +1,101 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// S3Store is an ObjectStore backed by an S3 bucket
+type S3Store struct {
+	client *s3.S3
+	bucket string
+}
+
+// NewS3Store returns an S3Store for a bucket. The default AWS credential chain is used if accessKeyID is empty.
+func NewS3Store(region, accessKeyID, secretAccessKey, bucket string) (*S3Store, error) {
+	if bucket == "" {
+		return nil, errors.New("s3 bucket name must be set")
+	}
+
+	awsConf := &aws.Config{
+		Region: aws.String(region),
+	}
+
+	if accessKeyID != "" {
+		awsConf.Credentials = credentials.NewStaticCredentials(accessKeyID, secretAccessKey, "")
+	}
+
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config:            *awsConf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create AWS session: %w", err)
+	}
+
+	return &S3Store{
+		client: s3.New(sess),
+		bucket: bucket,
+	}, nil
+}
+
+// Write writes an object to the bucket
+func (s *S3Store) Write(ctx context.Context, key string, data []byte) error {
+	_, err := s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Body:   aws.ReadSeekCloser(bytes.NewReader(data)),
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+
+	return err
+}
+
+// Read reads an object from the bucket
+func (s *S3Store) Read(ctx context.Context, key string) ([]byte, error) {
+	output, err := s.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var aerr awserr.Error
+		if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrObjectNotFound
+		}
+
+		return nil, err
+	}
+
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
+
+// List lists the keys of the objects in the bucket which start with prefix
+func (s *S3Store) List(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+
+	err := s.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, obj := range page.Contents {
+			keys = append(keys, aws.StringValue(obj.Key))
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
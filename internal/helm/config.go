@@ -3,6 +3,7 @@ package helm
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"time"
 
@@ -25,10 +26,13 @@ type Form struct {
 	Cluster                   *models.Cluster `form:"required"`
 	Repo                      repository.Repository
 	DigitalOceanOAuth         *oauth2.Config
-	Storage                   string `json:"storage" form:"oneof=secret configmap memory" default:"secret"`
+	Storage                   string `json:"storage" form:"oneof=secret configmap memory sql" default:"secret"`
 	Namespace                 string `json:"namespace"`
 	AllowInClusterConnections bool
 	Timeout                   time.Duration // optional
+
+	// SQLConnectionString is the database holding the releases of clusters which use the sql storage
+	SQLConnectionString string
 }
 
 // GetAgentOutOfClusterConfig creates a new Agent from outside the cluster using
@@ -49,9 +53,41 @@ func GetAgentOutOfClusterConfig(ctx context.Context, form *Form, l *logger.Logge
 		return nil, err
 	}
 
+	if form.Cluster.HelmStorage == ClusterStorageSQL {
+		return GetAgentForCluster(form.Cluster, form.SQLConnectionString, form.Namespace, l, k8sAgent)
+	}
+
 	return GetAgentFromK8sAgent(form.Storage, form.Namespace, l, k8sAgent)
 }
 
+// GetAgentForCluster creates a new Agent which reads the releases of the cluster from the storage
+// configured on it
+func GetAgentForCluster(
+	cluster *models.Cluster,
+	sqlConnectionString string,
+	ns string,
+	l *logger.Logger,
+	k8sAgent *kubernetes.Agent,
+) (*Agent, error) {
+	agent, err := GetAgentFromK8sAgent("secret", ns, l, k8sAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	if cluster.HelmStorage == "" || cluster.HelmStorage == ClusterStorageSecret {
+		return agent, nil
+	}
+
+	releases, err := NewClusterStorageDriver(l, nil, cluster, sqlConnectionString, ns)
+	if err != nil {
+		return nil, err
+	}
+
+	agent.ActionConfig.Releases = releases
+
+	return agent, nil
+}
+
 // GetAgentFromK8sAgent creates a new Agent
 func GetAgentFromK8sAgent(stg string, ns string, l *logger.Logger, k8sAgent *kubernetes.Agent) (*Agent, error) {
 	// clientset, ok := k8sAgent.Clientset.(*k8s.Clientset)
@@ -69,10 +105,27 @@ func GetAgentFromK8sAgent(stg string, ns string, l *logger.Logger, k8sAgent *kub
 
 	actionConf := &action.Configuration{}
 
-	if err := actionConf.Init(k8sAgent.RESTClientGetter, ns, stg, l.Printf); err != nil {
+	// Init panics if the sql driver cannot connect to its database, so the sql storage is created
+	// from the StorageMap once the rest of the configuration is initialized
+	initStg := stg
+
+	if stg == "sql" {
+		initStg = "secret"
+	}
+
+	if err := actionConf.Init(k8sAgent.RESTClientGetter, ns, initStg, l.Printf); err != nil {
 		return nil, err
 	}
 
+	if stg == "sql" {
+		releases, err := StorageMap[stg](l, nil, ns)
+		if err != nil {
+			return nil, err
+		}
+
+		actionConf.Releases = releases
+	}
+
 	// use k8s agent to create Helm agent
 	return &Agent{
 		ActionConfig: actionConf,
@@ -96,12 +149,23 @@ func GetAgentInClusterConfig(ctx context.Context, form *Form, l *logger.Logger)
 		return nil, errors.New("Agent Clientset was not of type *(k8s.io/client-go/kubernetes).Clientset")
 	}
 
+	newStorage, ok := StorageMap[form.Storage]
+
+	if !ok {
+		return nil, fmt.Errorf("unsupported helm storage driver %s", form.Storage)
+	}
+
+	releases, err := newStorage(l, clientset.CoreV1(), form.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	// use k8s agent to create Helm agent
 	return &Agent{
 		ActionConfig: &action.Configuration{
 			RESTClientGetter: k8sAgent.RESTClientGetter,
 			KubeClient:       kube.New(k8sAgent.RESTClientGetter),
-			Releases:         StorageMap[form.Storage](l, clientset.CoreV1(), form.Namespace),
+			Releases:         releases,
 			Log:              l.Printf,
 		},
 		K8sAgent: k8sAgent,
@@ -113,7 +177,7 @@ func GetAgentTesting(form *Form, storage *storage.Storage, l *logger.Logger, k8s
 	testStorage := storage
 
 	if testStorage == nil {
-		testStorage = StorageMap["memory"](nil, nil, "")
+		testStorage, _ = StorageMap["memory"](nil, nil, "")
 	}
 
 	return &Agent{
@@ -33,6 +33,10 @@ type SpecRel struct {
 type ParsedObjs struct {
 	Objects      []Object
 	PodSelectors []string
+
+	// SpecRules are applied by GetSpecRel in addition to DefaultSpecRules, so that relationships between custom
+	// resources can be declared in config
+	SpecRules []SpecRule `json:"-"`
 }
 
 // Relations is embedded into the Object struct and contains arrays of the three types of relationships.
@@ -59,7 +63,8 @@ type MatchExpression struct {
 
 // GetControlRel generates relationships and children objects for common k8s controller types.
 // Note that this only includes controllers whose children are 1) pods and 2) do not have its own YAML.
-// i.e. Children relies entirely on the parent's template. Controllers like CronJob are excluded because its children are not pods.
+// i.e. Children relies entirely on the parent's template. CronJobs are the exception, and get a single Job child built
+// from their job template.
 func (parsed *ParsedObjs) GetControlRel() {
 	// First collect all children (Pods) that are not included in the yaml as top-level object.
 	children := []Object{}
@@ -73,6 +78,37 @@ func (parsed *ParsedObjs) GetControlRel() {
 		}
 
 		switch kind.(string) {
+		case "CronJob":
+			template := getField(yaml, "spec", "jobTemplate")
+			if template == nil {
+				continue
+			}
+
+			cid := len(parsed.Objects) + len(children)
+			crel := ControlRel{
+				Relation: Relation{
+					Source: obj.ID,
+					Target: cid,
+				},
+				Replicas: 1,
+			}
+
+			job := Object{
+				ID:        cid,
+				Kind:      "Job",
+				Name:      obj.Name, // tentative name pre-deploy, jobs are suffixed with their schedule time
+				Namespace: obj.Namespace,
+				RawYAML:   template.(map[string]interface{}),
+				Relations: Relations{
+					ControlRels: []ControlRel{
+						crel,
+					},
+				},
+			}
+
+			children = append(children, job)
+			obj.Relations.ControlRels = append(obj.Relations.ControlRels, crel)
+			parsed.Objects[i] = obj
 		// Parse for all possible controller types
 		case "Deployment", "StatefulSet", "ReplicaSet", "DaemonSet", "Job":
 			rs := getField(yaml, "spec", "replicas")
@@ -226,6 +262,14 @@ func (parsed *ParsedObjs) GetSpecRel() {
 			}
 		}
 
+		for _, rules := range [][]SpecRule{DefaultSpecRules, parsed.SpecRules} {
+			for _, rule := range rules {
+				if rule.Kind == o.Kind {
+					tid = append(tid, parsed.applySpecRule(o, rule)...)
+				}
+			}
+		}
+
 		// Add edges to parent
 		rels := o.Relations.SpecRels
 		for _, id := range tid {
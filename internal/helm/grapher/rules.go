@@ -0,0 +1,275 @@
+package grapher
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// SpecRule declares a relationship between objects of Kind and the objects referenced from a field of their yaml.
+// A target is either referenced by name through NamePath, or by labels through SelectorPath.
+type SpecRule struct {
+	// Kind is the kind of object the rule applies to, such as ServiceMonitor
+	Kind string `yaml:"kind"`
+
+	// NamePath is the dot-separated path to the field containing the name of the target, such as spec.secretName.
+	// Lists along the path are traversed, so that spec.tls.secretName matches the secretName of every tls entry.
+	NamePath string `yaml:"namePath"`
+
+	// SelectorPath is the dot-separated path to a label selector, such as spec.selector. Targets whose labels match
+	// the selector are related. For controllers, the labels of the pod template are matched.
+	SelectorPath string `yaml:"selectorPath"`
+
+	// KindPath is the dot-separated path to the field containing the kind of the target, such as spec.scaleTargetRef.kind.
+	// If the field is not set, TargetKinds is used instead.
+	KindPath string `yaml:"kindPath"`
+
+	// TargetKinds are the kinds of object that can be targeted by the rule
+	TargetKinds []string `yaml:"targetKinds"`
+}
+
+// controllerKinds are the kinds whose pod template labels are matched by selector rules
+var controllerKinds = []string{"Deployment", "StatefulSet", "ReplicaSet", "DaemonSet", "Job"}
+
+// DefaultSpecRules are the relationships drawn for common kinds which are not handled by GetSpecRel directly
+var DefaultSpecRules = []SpecRule{
+	{
+		Kind:        "HorizontalPodAutoscaler",
+		NamePath:    "spec.scaleTargetRef.name",
+		KindPath:    "spec.scaleTargetRef.kind",
+		TargetKinds: []string{"Deployment"},
+	},
+	{
+		Kind:         "PodDisruptionBudget",
+		SelectorPath: "spec.selector",
+		TargetKinds:  controllerKinds,
+	},
+	{
+		Kind:         "NetworkPolicy",
+		SelectorPath: "spec.podSelector",
+		TargetKinds:  controllerKinds,
+	},
+	{
+		Kind:         "ServiceMonitor",
+		SelectorPath: "spec.selector",
+		TargetKinds:  []string{"Service"},
+	},
+	{
+		Kind:        "Certificate",
+		NamePath:    "spec.secretName",
+		TargetKinds: []string{"Secret"},
+	},
+	{
+		Kind:        "Certificate",
+		NamePath:    "spec.issuerRef.name",
+		KindPath:    "spec.issuerRef.kind",
+		TargetKinds: []string{"Issuer"},
+	},
+}
+
+// ParseSpecRules parses a yaml list of spec rules, such as one read from a config file
+func ParseSpecRules(source []byte) ([]SpecRule, error) {
+	rules := []SpecRule{}
+
+	if err := yaml.UnmarshalStrict(source, &rules); err != nil {
+		return nil, fmt.Errorf("error parsing spec rules: %w", err)
+	}
+
+	for i, rule := range rules {
+		if err := rule.validate(); err != nil {
+			return nil, fmt.Errorf("invalid spec rule %d: %w", i, err)
+		}
+	}
+
+	return rules, nil
+}
+
+func (rule SpecRule) validate() error {
+	if rule.Kind == "" {
+		return fmt.Errorf("kind is required")
+	}
+
+	if (rule.NamePath == "") == (rule.SelectorPath == "") {
+		return fmt.Errorf("exactly one of namePath and selectorPath must be set")
+	}
+
+	if len(rule.TargetKinds) == 0 && rule.KindPath == "" {
+		return fmt.Errorf("at least one of targetKinds and kindPath must be set")
+	}
+
+	return nil
+}
+
+// applySpecRule returns the ids of the objects targeted by the rule from the object with the given id, adding the
+// reverse relationship to each target
+func (parsed *ParsedObjs) applySpecRule(o Object, rule SpecRule) []int {
+	targetKinds := rule.TargetKinds
+	if rule.KindPath != "" {
+		if kind, ok := lookupPath(o.RawYAML, rule.KindPath).(string); ok && kind != "" {
+			targetKinds = []string{kind}
+		}
+	}
+
+	tid := []int{}
+
+	if rule.NamePath != "" {
+		for _, name := range collectPath(o.RawYAML, rule.NamePath) {
+			if _, ok := name.(string); !ok {
+				continue
+			}
+
+			for _, kind := range targetKinds {
+				tid = append(tid, parsed.findObjectByNameAndKind(o.ID, name, kind)...)
+			}
+		}
+
+		return tid
+	}
+
+	selector, ok := lookupPath(o.RawYAML, rule.SelectorPath).(map[string]interface{})
+	if !ok {
+		return tid
+	}
+
+	return parsed.findObjectsBySelector(o.ID, selectorLabels(selector), targetKinds)
+}
+
+// findObjectsBySelector returns the ids of objects of the given kinds whose labels match all of the labels of the
+// selector. An empty selector matches nothing, since drawing an edge to every object is not useful in a graph.
+func (parsed *ParsedObjs) findObjectsBySelector(parentID int, ml []MatchLabel, kinds []string) []int {
+	targets := []int{}
+
+	if len(ml) == 0 {
+		return targets
+	}
+
+	for i, o := range parsed.Objects {
+		if o.ID == parentID || !containsKind(kinds, o.Kind) {
+			continue
+		}
+
+		var labels interface{}
+		if containsKind(controllerKinds, o.Kind) {
+			labels = lookupPath(o.RawYAML, "spec.template.metadata.labels")
+		} else {
+			labels = lookupPath(o.RawYAML, "metadata.labels")
+		}
+
+		labelMap, ok := labels.(map[string]interface{})
+		if !ok || !matchesLabels(labelMap, ml) {
+			continue
+		}
+
+		// Add bidirectional link from children as well.
+		parsed.Objects[i].Relations.SpecRels = append(parsed.Objects[i].Relations.SpecRels, SpecRel{
+			Relation{
+				Source: parentID,
+				Target: o.ID,
+			},
+		})
+		targets = append(targets, o.ID)
+	}
+
+	return targets
+}
+
+// selectorLabels returns the equality-based labels of a label selector, which may either be a plain map of labels
+// or contain matchLabels
+func selectorLabels(selector map[string]interface{}) []MatchLabel {
+	matchLabels := []MatchLabel{}
+
+	if ml, ok := selector["matchLabels"].(map[string]interface{}); ok {
+		return addStringLabels(matchLabels, ml)
+	}
+
+	if _, ok := selector["matchExpressions"]; ok {
+		return matchLabels
+	}
+
+	return addStringLabels(matchLabels, selector)
+}
+
+func addStringLabels(matchLabels []MatchLabel, ml map[string]interface{}) []MatchLabel {
+	for k, v := range ml {
+		if value, ok := v.(string); ok {
+			matchLabels = append(matchLabels, MatchLabel{
+				key:   k,
+				value: value,
+			})
+		}
+	}
+
+	return matchLabels
+}
+
+func matchesLabels(labels map[string]interface{}, ml []MatchLabel) bool {
+	for _, l := range ml {
+		if labels[l.key] != l.value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsKind(kinds []string, kind string) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookupPath returns the value at a dot-separated path, or nil if any part of the path is missing or is not a map
+func lookupPath(yaml map[string]interface{}, path string) interface{} {
+	var curr interface{} = yaml
+
+	for _, key := range strings.Split(path, ".") {
+		m, ok := curr.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		curr = m[key]
+	}
+
+	return curr
+}
+
+// collectPath returns every value at a dot-separated path, traversing each element of the lists along the path
+func collectPath(yaml interface{}, path string) []interface{} {
+	res := []interface{}{}
+
+	if list, ok := yaml.([]interface{}); ok {
+		for _, elem := range list {
+			res = append(res, collectPath(elem, path)...)
+		}
+
+		return res
+	}
+
+	m, ok := yaml.(map[string]interface{})
+	if !ok {
+		return res
+	}
+
+	key, rest, hasRest := strings.Cut(path, ".")
+
+	val, ok := m[key]
+	if !ok || val == nil {
+		return res
+	}
+
+	if !hasRest {
+		if list, ok := val.([]interface{}); ok {
+			return append(res, list...)
+		}
+
+		return append(res, val)
+	}
+
+	return collectPath(val, rest)
+}
@@ -0,0 +1,133 @@
+package grapher_test
+
+import (
+	"io/ioutil"
+	"sort"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm/grapher"
+)
+
+const databaseRules = `
+- kind: Database
+  namePath: spec.credentialsSecret
+  targetKinds:
+    - Secret
+`
+
+func TestSpecRules(t *testing.T) {
+	file, err := ioutil.ReadFile("./test_yaml/spec_rules.yaml")
+	if err != nil {
+		t.Fatalf("Error reading file: %s", err)
+	}
+
+	rules, err := grapher.ParseSpecRules([]byte(databaseRules))
+	if err != nil {
+		t.Fatalf("Error parsing spec rules: %s", err)
+	}
+
+	yamlArr := grapher.ImportMultiDocYAML(file)
+	objects := grapher.ParseObjs(yamlArr, "default")
+	parsed := grapher.ParsedObjs{
+		Objects:   objects,
+		SpecRules: rules,
+	}
+
+	parsed.GetControlRel()
+	parsed.GetSpecRel()
+
+	expected := map[string][]string{
+		"HorizontalPodAutoscaler/web": {"Deployment/web"},
+		"PodDisruptionBudget/web":     {"Deployment/web"},
+		"NetworkPolicy/web":           {"Deployment/web"},
+		"ServiceMonitor/web":          {"Service/web"},
+		"Certificate/web":             {"ClusterIssuer/letsencrypt", "Secret/web-tls"},
+		"Database/db":                 {"Secret/web-tls"},
+	}
+
+	for source, targets := range expected {
+		o := findObject(t, parsed.Objects, source)
+
+		got := []string{}
+		for _, rel := range o.Relations.SpecRels {
+			if rel.Source == o.ID {
+				got = append(got, objectKey(findObjectByID(t, parsed.Objects, rel.Target)))
+			}
+		}
+		sort.Strings(got)
+
+		if len(got) != len(targets) {
+			t.Errorf("SpecRels differ for %s. Expected %v. Got %v", source, targets, got)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != targets[i] {
+				t.Errorf("SpecRels differ for %s. Expected %v. Got %v", source, targets, got)
+				break
+			}
+		}
+	}
+
+	// targets have the relationship added in reverse
+	secret := findObject(t, parsed.Objects, "Secret/web-tls")
+	if len(secret.Relations.SpecRels) != 2 {
+		t.Errorf("Expected 2 SpecRels for Secret/web-tls. Got %d", len(secret.Relations.SpecRels))
+	}
+
+	cronJob := findObject(t, parsed.Objects, "CronJob/cleanup")
+	if len(cronJob.Relations.ControlRels) != 1 {
+		t.Fatalf("Expected 1 ControlRel for CronJob/cleanup. Got %d", len(cronJob.Relations.ControlRels))
+	}
+
+	job := findObjectByID(t, parsed.Objects, cronJob.Relations.ControlRels[0].Target)
+	if objectKey(job) != "Job/cleanup" {
+		t.Errorf("Expected CronJob/cleanup to control Job/cleanup. Got %s", objectKey(job))
+	}
+}
+
+func TestParseSpecRulesInvalid(t *testing.T) {
+	invalid := []string{
+		"- namePath: spec.secretName\n  targetKinds: [Secret]",
+		"- kind: Database\n  targetKinds: [Secret]",
+		"- kind: Database\n  namePath: spec.secretName\n  selectorPath: spec.selector\n  targetKinds: [Secret]",
+		"- kind: Database\n  namePath: spec.secretName",
+		"- kind: Database\n  namePath: spec.secretName\n  targetKind: Secret",
+	}
+
+	for _, source := range invalid {
+		if _, err := grapher.ParseSpecRules([]byte(source)); err == nil {
+			t.Errorf("Expected error parsing spec rules:\n%s", source)
+		}
+	}
+}
+
+func objectKey(o grapher.Object) string {
+	return o.Kind + "/" + o.Name
+}
+
+func findObject(t *testing.T, objs []grapher.Object, key string) grapher.Object {
+	t.Helper()
+
+	for _, o := range objs {
+		if objectKey(o) == key {
+			return o
+		}
+	}
+
+	t.Fatalf("Object %s not found", key)
+	return grapher.Object{}
+}
+
+func findObjectByID(t *testing.T, objs []grapher.Object, id int) grapher.Object {
+	t.Helper()
+
+	for _, o := range objs {
+		if o.ID == id {
+			return o
+		}
+	}
+
+	t.Fatalf("Object with id %d not found", id)
+	return grapher.Object{}
+}
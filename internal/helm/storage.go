@@ -8,25 +8,47 @@ package helm
 // - memory
 // - postgres
 //
-// This file implements first-class support for the first three driver types
-// and integrates with the logger.
-//
-// TODO -- include support for SQL storage...
+// This file implements first-class support for all four driver types
+// and integrates with the logger. The postgres driver is registered as "sql",
+// as it is in the Helm CLI.
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lib/pq"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/pkg/logger"
 
+	"github.com/stefanmcshane/helm/pkg/release"
 	"github.com/stefanmcshane/helm/pkg/storage"
 	"github.com/stefanmcshane/helm/pkg/storage/driver"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 )
 
+// SQLConnectionStringEnv is the environment variable holding the Postgres DSN used by the
+// sql storage driver. This is the same variable read by the Helm CLI, so that releases stored
+// by Porter can also be managed with `helm` and HELM_DRIVER=sql.
+const SQLConnectionStringEnv = "HELM_DRIVER_SQL_CONNECTION_STRING"
+
+// The values of models.Cluster.HelmStorage. Clusters which have not set it keep their releases in secrets.
+const (
+	ClusterStorageSecret = "secret"
+	ClusterStorageSQL    = "sql"
+)
+
 // NewStorageDriver is a function type for returning a new storage driver
 type NewStorageDriver func(
 	l *logger.Logger,
 	v1Interface corev1.CoreV1Interface,
 	namespace string,
-) *storage.Storage
+) (*storage.Storage, error)
 
 // StorageMap is a map from storage configuration env variables to a function
 // that initializes that Helm storage driver.
@@ -34,6 +56,7 @@ var StorageMap map[string]NewStorageDriver = map[string]NewStorageDriver{
 	"secret":    newSecretStorageDriver,
 	"configmap": newConfigMapsStorageDriver,
 	"memory":    newMemoryStorageDriver,
+	"sql":       newSQLStorageDriver,
 }
 
 // NewSecretStorageDriver returns a storage using the Secret driver.
@@ -41,10 +64,10 @@ func newSecretStorageDriver(
 	l *logger.Logger,
 	v1Interface corev1.CoreV1Interface,
 	namespace string,
-) *storage.Storage {
+) (*storage.Storage, error) {
 	d := driver.NewSecrets(v1Interface.Secrets(namespace))
 	d.Log = l.Printf
-	return storage.Init(d)
+	return storage.Init(d), nil
 }
 
 // NewConfigMapsStorageDriver returns a storage using the ConfigMap driver.
@@ -52,10 +75,10 @@ func newConfigMapsStorageDriver(
 	l *logger.Logger,
 	v1Interface corev1.CoreV1Interface,
 	namespace string,
-) *storage.Storage {
+) (*storage.Storage, error) {
 	d := driver.NewConfigMaps(v1Interface.ConfigMaps(namespace))
 	d.Log = l.Printf
-	return storage.Init(d)
+	return storage.Init(d), nil
 }
 
 // NewMemoryStorageDriver returns a storage using the In-Memory driver.
@@ -63,7 +86,209 @@ func newMemoryStorageDriver(
 	_ *logger.Logger,
 	_ corev1.CoreV1Interface,
 	_ string,
-) *storage.Storage {
+) (*storage.Storage, error) {
 	d := driver.NewMemory()
-	return storage.Init(d)
+	return storage.Init(d), nil
+}
+
+// newSQLStorageDriver returns a storage using the SQL driver, connecting to the database
+// given by SQLConnectionStringEnv. The database can only hold the releases of a single cluster,
+// so this is only meant for agents running inside the cluster; the releases of clusters managed
+// by the server are read with NewClusterSQLStorageDriver.
+func newSQLStorageDriver(
+	l *logger.Logger,
+	_ corev1.CoreV1Interface,
+	namespace string,
+) (*storage.Storage, error) {
+	return NewSQLStorageDriver(l, os.Getenv(SQLConnectionStringEnv), namespace)
+}
+
+// NewSQLStorageDriver returns a storage using the SQL driver on a Postgres database. Releases
+// are stored by namespace and name, so a database should only hold the releases of one cluster.
+func NewSQLStorageDriver(
+	l *logger.Logger,
+	connectionString string,
+	namespace string,
+) (*storage.Storage, error) {
+	if connectionString == "" {
+		return nil, fmt.Errorf("a connection string must be set with %s to use the sql storage driver", SQLConnectionStringEnv)
+	}
+
+	d, err := driver.NewSQL(connectionString, l.Printf, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to sql storage: %w", err)
+	}
+
+	return storage.Init(d), nil
+}
+
+// ClusterSQLSchema returns the Postgres schema holding the Helm releases of a cluster. Helm's SQL
+// driver keys releases by namespace and name only, so each cluster gets its own schema in the
+// database.
+func ClusterSQLSchema(clusterID uint) string {
+	return fmt.Sprintf("porter_helm_cluster_%d", clusterID)
+}
+
+// clusterSQLConnectionString sets the search path of a connection string, in either URL or
+// key/value form, to the schema of the cluster
+func clusterSQLConnectionString(connectionString string, clusterID uint) (string, error) {
+	schema := ClusterSQLSchema(clusterID)
+
+	if strings.HasPrefix(connectionString, "postgres://") || strings.HasPrefix(connectionString, "postgresql://") {
+		u, err := url.Parse(connectionString)
+		if err != nil {
+			return "", fmt.Errorf("invalid sql connection string: %w", err)
+		}
+
+		query := u.Query()
+		query.Set("search_path", schema)
+		u.RawQuery = query.Encode()
+
+		return u.String(), nil
+	}
+
+	return fmt.Sprintf("%s search_path=%s", connectionString, schema), nil
+}
+
+// clusterSQLDrivers holds the sql drivers created by NewClusterSQLStorageDriver, keyed by cluster and
+// namespace. Every driver has its own connection pool, which Helm never closes, so drivers are reused
+// by all the agents of a cluster namespace instead of being created per request.
+var clusterSQLDrivers = struct {
+	sync.Mutex
+	drivers map[string]*driver.SQL
+}{
+	drivers: make(map[string]*driver.SQL),
+}
+
+// NewClusterSQLStorageDriver returns a storage using the SQL driver for the releases of a cluster. The
+// releases are kept in the schema of the cluster, which is created if it does not exist yet.
+func NewClusterSQLStorageDriver(
+	l *logger.Logger,
+	connectionString string,
+	clusterID uint,
+	namespace string,
+) (*storage.Storage, error) {
+	if connectionString == "" {
+		return nil, fmt.Errorf("a connection string must be set with %s to use the sql storage driver", SQLConnectionStringEnv)
+	}
+
+	key := fmt.Sprintf("%d/%s", clusterID, namespace)
+
+	clusterSQLDrivers.Lock()
+	defer clusterSQLDrivers.Unlock()
+
+	if d, ok := clusterSQLDrivers.drivers[key]; ok {
+		return storage.Init(d), nil
+	}
+
+	if err := createClusterSQLSchema(connectionString, clusterID); err != nil {
+		return nil, err
+	}
+
+	clusterConnectionString, err := clusterSQLConnectionString(connectionString, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := driver.NewSQL(clusterConnectionString, l.Printf, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to sql storage: %w", err)
+	}
+
+	clusterSQLDrivers.drivers[key] = d
+
+	return storage.Init(d), nil
+}
+
+func createClusterSQLSchema(connectionString string, clusterID uint) error {
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return fmt.Errorf("error connecting to sql storage: %w", err)
+	}
+
+	defer db.Close()
+
+	if _, err := db.Exec("CREATE SCHEMA IF NOT EXISTS " + pq.QuoteIdentifier(ClusterSQLSchema(clusterID))); err != nil {
+		return fmt.Errorf("error creating sql storage schema for cluster %d: %w", clusterID, err)
+	}
+
+	return nil
+}
+
+// NewClusterStorageDriver returns a storage for the releases of a cluster in a namespace, using the
+// storage configured on the cluster
+func NewClusterStorageDriver(
+	l *logger.Logger,
+	v1Interface corev1.CoreV1Interface,
+	cluster *models.Cluster,
+	sqlConnectionString string,
+	namespace string,
+) (*storage.Storage, error) {
+	switch cluster.HelmStorage {
+	case "", ClusterStorageSecret:
+		return newSecretStorageDriver(l, v1Interface, namespace)
+	case ClusterStorageSQL:
+		return NewClusterSQLStorageDriver(l, sqlConnectionString, cluster.ID, namespace)
+	default:
+		return nil, fmt.Errorf("unsupported helm storage driver %s", cluster.HelmStorage)
+	}
+}
+
+// CopyReleases writes every revision of the releases in one storage to another, keeping their revision
+// numbers and statuses, and returns the number of revisions written. Revisions which are already in the
+// destination are skipped.
+func CopyReleases(src, dst *storage.Storage) (int, error) {
+	_, written, err := copyReleases(src, dst)
+	return written, err
+}
+
+// MigrateReleases moves every revision of the releases in one storage to another, keeping their
+// revision numbers and statuses, and returns the number of revisions written. Revisions are only
+// deleted from the source once all of them are in the destination, and revisions which are already
+// in the destination are skipped, so that a failed migration can be run again.
+func MigrateReleases(src, dst *storage.Storage) (int, error) {
+	revisions, written, err := copyReleases(src, dst)
+	if err != nil {
+		return written, err
+	}
+
+	for _, rel := range revisions {
+		if _, err := src.Delete(rel.Name, rel.Version); err != nil {
+			return written, fmt.Errorf("revisions were written, but revision %d of release %s could not be deleted: %w",
+				rel.Version, rel.Name, err)
+		}
+	}
+
+	return written, nil
+}
+
+func copyReleases(src, dst *storage.Storage) ([]*release.Release, int, error) {
+	revisions, err := src.ListReleases()
+	if err != nil {
+		return nil, 0, fmt.Errorf("error listing releases: %w", err)
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		if revisions[i].Name != revisions[j].Name {
+			return revisions[i].Name < revisions[j].Name
+		}
+
+		return revisions[i].Version < revisions[j].Version
+	})
+
+	written := 0
+
+	for _, rel := range revisions {
+		if err := dst.Create(rel); err != nil {
+			if errors.Is(err, driver.ErrReleaseExists) {
+				continue
+			}
+
+			return nil, written, fmt.Errorf("error writing revision %d of release %s: %w", rel.Version, rel.Name, err)
+		}
+
+		written++
+	}
+
+	return revisions, written, nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/pkg/logger"
 	"github.com/stefanmcshane/helm/pkg/storage"
 	"k8s.io/client-go/kubernetes/fake"
@@ -62,7 +63,10 @@ func testStorageDriver(t *testing.T, name string) {
 		t.Fatal("Agent Clientset was not of type *(k8s.io/client-go/kubernetes/fake).Clientset")
 	}
 
-	driver := newDriver(l, clientset.CoreV1(), "default")
+	driver, err := newDriver(l, clientset.CoreV1(), "default")
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	testDriver(t, driver)
 }
@@ -78,3 +82,166 @@ func TestNewConfigMapStorageDriver(t *testing.T) {
 func TestNewMemoryStorageDriver(t *testing.T) {
 	testStorageDriver(t, "memory")
 }
+
+func TestNewSQLStorageDriverRequiresConnectionString(t *testing.T) {
+	t.Setenv(helm.SQLConnectionStringEnv, "")
+
+	_, err := helm.StorageMap["sql"](logger.NewConsole(true), nil, "default")
+	if err == nil {
+		t.Fatal("expected an error without a connection string")
+	}
+}
+
+func TestMigrateReleases(t *testing.T) {
+	src, err := helm.StorageMap["memory"](nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := helm.StorageMap["memory"](nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newRevision := func(name string, version int, status release.Status) *release.Release {
+		return &release.Release{
+			Name:      name,
+			Namespace: "default",
+			Version:   version,
+			Info: &release.Info{
+				Status: status,
+			},
+			Chart: &chart.Chart{
+				Metadata: &chart.Metadata{
+					Version: "1.0.0",
+				},
+			},
+		}
+	}
+
+	for _, rel := range []*release.Release{
+		newRevision("porter", 3, release.StatusSuperseded),
+		newRevision("porter", 7, release.StatusDeployed),
+		newRevision("wordpress", 1, release.StatusFailed),
+	} {
+		if err := src.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// revisions which were written by an earlier, failed migration are skipped
+	if err := dst.Create(newRevision("porter", 3, release.StatusSuperseded)); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := helm.MigrateReleases(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written != 2 {
+		t.Errorf("expected 2 revisions to be written, got %d", written)
+	}
+
+	remaining, err := src.ListReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(remaining) != 0 {
+		t.Errorf("expected no revisions in the source storage, got %d", len(remaining))
+	}
+
+	for _, exp := range []*release.Release{
+		newRevision("porter", 3, release.StatusSuperseded),
+		newRevision("porter", 7, release.StatusDeployed),
+		newRevision("wordpress", 1, release.StatusFailed),
+	} {
+		rel, err := dst.Get(exp.Name, exp.Version)
+		if err != nil {
+			t.Fatalf("revision %d of release %s was not migrated: %v", exp.Version, exp.Name, err)
+		}
+
+		if rel.Info.Status != exp.Info.Status {
+			t.Errorf("revision %d of release %s has status %s, expected %s", exp.Version, exp.Name, rel.Info.Status, exp.Info.Status)
+		}
+	}
+}
+
+func TestCopyReleasesKeepsSource(t *testing.T) {
+	src, err := helm.StorageMap["memory"](nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := helm.StorageMap["memory"](nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rel := &release.Release{
+		Name:      "porter",
+		Namespace: "default",
+		Version:   2,
+		Info: &release.Info{
+			Status: release.StatusDeployed,
+		},
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{
+				Version: "1.0.0",
+			},
+		},
+	}
+
+	if err := src.Create(rel); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := helm.CopyReleases(src, dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written != 1 {
+		t.Errorf("expected 1 revision to be written, got %d", written)
+	}
+
+	if _, err := src.Get("porter", 2); err != nil {
+		t.Errorf("expected the revision to stay in the source storage: %v", err)
+	}
+
+	if _, err := dst.Get("porter", 2); err != nil {
+		t.Errorf("expected the revision to be copied: %v", err)
+	}
+}
+
+func TestNewClusterStorageDriver(t *testing.T) {
+	k8sAgent := kubernetes.GetAgentTesting()
+
+	clientset, ok := k8sAgent.Clientset.(*fake.Clientset)
+	if !ok {
+		t.Fatal("Agent Clientset was not of type *(k8s.io/client-go/kubernetes/fake).Clientset")
+	}
+
+	l := logger.NewConsole(true)
+
+	// clusters which have not set a storage keep their releases in secrets
+	stg, err := helm.NewClusterStorageDriver(l, clientset.CoreV1(), &models.Cluster{}, "", "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testDriver(t, stg)
+
+	sqlCluster := &models.Cluster{HelmStorage: helm.ClusterStorageSQL}
+
+	if _, err := helm.NewClusterStorageDriver(l, clientset.CoreV1(), sqlCluster, "", "default"); err == nil {
+		t.Error("expected an error for sql storage without a connection string")
+	}
+
+	unknownCluster := &models.Cluster{HelmStorage: "configmap"}
+
+	if _, err := helm.NewClusterStorageDriver(l, clientset.CoreV1(), unknownCluster, "", "default"); err == nil {
+		t.Error("expected an error for an unsupported storage")
+	}
+}
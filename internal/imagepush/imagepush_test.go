@@ -0,0 +1,194 @@
+package imagepush_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/imagepush"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRepository(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                                                       "docker.io/library/nginx",
+		"porterdev/hello-porter:latest":                               "docker.io/porterdev/hello-porter",
+		"index.docker.io/porterdev/porter":                            "docker.io/porterdev/porter",
+		"https://ghcr.io/Porter-Dev/porter/":                          "ghcr.io/porter-dev/porter",
+		"localhost:5000/app:v1":                                       "localhost:5000/app",
+		"registry.example.com:5000/team/app":                          "registry.example.com:5000/team/app",
+		"123456789012.dkr.ecr.us-east-1.amazonaws.com/app@sha256:abc": "123456789012.dkr.ecr.us-east-1.amazonaws.com/app",
+	}
+
+	for input, expected := range tests {
+		assert.Equal(t, expected, imagepush.NormalizeRepository(input), input)
+	}
+}
+
+func TestParsePushes(t *testing.T) {
+	tests := []struct {
+		source   types.ImagePushSource
+		body     string
+		expected []imagepush.Push
+	}{
+		{
+			source: types.ImagePushSourceECR,
+			body: `{"detail-type":"ECR Image Action","source":"aws.ecr","account":"123456789012","region":"us-east-1",
+				"detail":{"result":"SUCCESS","repository-name":"app","image-digest":"sha256:abc","action-type":"PUSH","image-tag":"v1.2.0"}}`,
+			expected: []imagepush.Push{{Repository: "123456789012.dkr.ecr.us-east-1.amazonaws.com/app", Tag: "v1.2.0", Digest: "sha256:abc"}},
+		},
+		{
+			source: types.ImagePushSourceECR,
+			body: `{"source":"aws.ecr","account":"123456789012","region":"us-east-1",
+				"detail":{"result":"SUCCESS","repository-name":"app","action-type":"DELETE","image-tag":"v1.2.0"}}`,
+			expected: []imagepush.Push{},
+		},
+		{
+			source:   types.ImagePushSourceDockerHub,
+			body:     `{"push_data":{"tag":"latest"},"repository":{"repo_name":"porterdev/hello-porter"}}`,
+			expected: []imagepush.Push{{Repository: "docker.io/porterdev/hello-porter", Tag: "latest"}},
+		},
+		{
+			source: types.ImagePushSourceGHCR,
+			body: `{"action":"published","package":{"name":"porter","package_type":"CONTAINER","owner":{"login":"porter-dev"},
+				"package_version":{"container_metadata":{"tag":{"name":"main","digest":"sha256:def"}}}}}`,
+			expected: []imagepush.Push{{Repository: "ghcr.io/porter-dev/porter", Tag: "main", Digest: "sha256:def"}},
+		},
+		{
+			source: types.ImagePushSourceHarbor,
+			body: `{"type":"PUSH_ARTIFACT","event_data":{"resources":[
+				{"digest":"sha256:123","tag":"1.0.0","resource_url":"harbor.example.com/library/app:1.0.0"}]}}`,
+			expected: []imagepush.Push{{Repository: "harbor.example.com/library/app", Tag: "1.0.0", Digest: "sha256:123"}},
+		},
+		{
+			source: types.ImagePushSourceDistribution,
+			body: `{"events":[
+				{"action":"push","target":{"repository":"app","digest":"sha256:layer"},"request":{"host":"registry.example.com"}},
+				{"action":"push","target":{"repository":"app","tag":"v2","digest":"sha256:456"},"request":{"host":"registry.example.com"}},
+				{"action":"pull","target":{"repository":"app","tag":"v1","digest":"sha256:789"},"request":{"host":"registry.example.com"}}]}`,
+			expected: []imagepush.Push{{Repository: "registry.example.com/app", Tag: "v2", Digest: "sha256:456"}},
+		},
+	}
+
+	for _, test := range tests {
+		pushes, err := imagepush.ParsePushes(test.source, []byte(test.body))
+		require.NoError(t, err, test.source)
+		assert.Equal(t, test.expected, pushes, test.source)
+	}
+
+	_, err := imagepush.ParsePushes(types.ImagePushSourceDockerHub, []byte("not json"))
+	assert.Error(t, err)
+
+	_, err = imagepush.ParsePushes("quay", []byte("{}"))
+	assert.Error(t, err)
+}
+
+func TestMatch(t *testing.T) {
+	repo := "ghcr.io/porter-dev/porter"
+
+	tests := []struct {
+		name     string
+		policy   models.ImagePushPolicy
+		push     imagepush.Push
+		expected bool
+	}{
+		{
+			name:     "semver in range",
+			policy:   models.ImagePushPolicy{Type: "semver", Pattern: ">=1.2.0, <2", LastTag: "v1.2.0"},
+			push:     imagepush.Push{Tag: "v1.3.1"},
+			expected: true,
+		},
+		{
+			name:   "semver out of range",
+			policy: models.ImagePushPolicy{Type: "semver", Pattern: ">=1.2.0, <2"},
+			push:   imagepush.Push{Tag: "2.0.0"},
+		},
+		{
+			name:   "semver downgrade",
+			policy: models.ImagePushPolicy{Type: "semver", Pattern: "^1", LastTag: "1.4.0"},
+			push:   imagepush.Push{Tag: "1.3.9"},
+		},
+		{
+			name:   "semver not a version",
+			policy: models.ImagePushPolicy{Type: "semver", Pattern: "^1"},
+			push:   imagepush.Push{Tag: "main"},
+		},
+		{
+			name:     "regex full match",
+			policy:   models.ImagePushPolicy{Type: "regex", Pattern: "main-[0-9a-f]{7}"},
+			push:     imagepush.Push{Tag: "main-1a2b3c4"},
+			expected: true,
+		},
+		{
+			name:   "regex partial match",
+			policy: models.ImagePushPolicy{Type: "regex", Pattern: "main-[0-9a-f]{7}"},
+			push:   imagepush.Push{Tag: "main-1a2b3c4-dirty"},
+		},
+		{
+			name:     "digest changed",
+			policy:   models.ImagePushPolicy{Type: "digest", Pattern: "latest", LastTag: "latest", LastDigest: "sha256:old"},
+			push:     imagepush.Push{Tag: "latest", Digest: "sha256:new"},
+			expected: true,
+		},
+		{
+			name:   "digest redelivered",
+			policy: models.ImagePushPolicy{Type: "digest", Pattern: "latest", LastTag: "latest", LastDigest: "sha256:old"},
+			push:   imagepush.Push{Tag: "latest", Digest: "sha256:old"},
+		},
+		{
+			name:   "digest missing",
+			policy: models.ImagePushPolicy{Type: "digest", Pattern: "latest"},
+			push:   imagepush.Push{Tag: "latest"},
+		},
+		{
+			name:   "other repository",
+			policy: models.ImagePushPolicy{Type: "regex", Pattern: ".*", ImageRepository: "ghcr.io/porter-dev/other"},
+			push:   imagepush.Push{Tag: "latest"},
+		},
+	}
+
+	for _, test := range tests {
+		if test.policy.ImageRepository == "" {
+			test.policy.ImageRepository = repo
+		}
+		test.push.Repository = repo
+
+		matched, err := imagepush.Match(&test.policy, test.push)
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, matched, test.name)
+	}
+
+	policy := &models.ImagePushPolicy{Type: "digest", Pattern: "latest"}
+	assert.Equal(t, "latest@sha256:new", imagepush.DeployTag(policy, imagepush.Push{Tag: "latest", Digest: "sha256:new"}))
+}
+
+func TestValidatePolicy(t *testing.T) {
+	assert.NoError(t, imagepush.ValidatePolicy(types.ImagePushPolicySemver, "~1.2"))
+	assert.Error(t, imagepush.ValidatePolicy(types.ImagePushPolicySemver, "not a range"))
+	assert.NoError(t, imagepush.ValidatePolicy(types.ImagePushPolicyRegex, "v[0-9]+"))
+	assert.Error(t, imagepush.ValidatePolicy(types.ImagePushPolicyRegex, "v[0-9"))
+	assert.Error(t, imagepush.ValidatePolicy(types.ImagePushPolicyDigest, ""))
+	assert.Error(t, imagepush.ValidatePolicy("glob", "*"))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"action":"published"}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	signed := http.Header{"X-Hub-Signature-256": []string{"sha256=" + hex.EncodeToString(mac.Sum(nil))}}
+
+	assert.NoError(t, imagepush.VerifySignature(types.ImagePushSourceGHCR, "secret", signed, body))
+	assert.ErrorIs(t, imagepush.VerifySignature(types.ImagePushSourceGHCR, "other", signed, body), imagepush.ErrInvalidSignature)
+	assert.ErrorIs(t, imagepush.VerifySignature(types.ImagePushSourceGHCR, "secret", signed, []byte(`{}`)), imagepush.ErrInvalidSignature)
+	assert.ErrorIs(t, imagepush.VerifySignature(types.ImagePushSourceGHCR, "secret", http.Header{}, body), imagepush.ErrInvalidSignature)
+	assert.ErrorIs(t, imagepush.VerifySignature(types.ImagePushSourceGHCR, "", signed, body), imagepush.ErrInvalidSignature)
+
+	// registries which do not sign their events are only authenticated by the token in the url
+	assert.NoError(t, imagepush.VerifySignature(types.ImagePushSourceDockerHub, "secret", http.Header{}, body))
+}
@@ -0,0 +1,265 @@
+package imagepush
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// Push is a single tag pushed to an image repository
+type Push struct {
+	// Repository is the normalized repository that was pushed to, such as docker.io/library/nginx
+	Repository string
+	Tag        string
+	// Digest is the digest of the pushed manifest. Docker Hub does not send digests, so it may be empty.
+	Digest string
+}
+
+// ParsePushes parses the tagged pushes from the body of a push event sent by a registry. Events which are not
+// pushes, such as deletions or pulls, and untagged pushes are ignored.
+func ParsePushes(source types.ImagePushSource, body []byte) ([]Push, error) {
+	var pushes []Push
+	var err error
+
+	switch source {
+	case types.ImagePushSourceECR:
+		pushes, err = parseECR(body)
+	case types.ImagePushSourceDockerHub:
+		pushes, err = parseDockerHub(body)
+	case types.ImagePushSourceGHCR:
+		pushes, err = parseGHCR(body)
+	case types.ImagePushSourceHarbor:
+		pushes, err = parseHarbor(body)
+	case types.ImagePushSourceDistribution:
+		pushes, err = parseDistribution(body)
+	default:
+		return nil, fmt.Errorf("unsupported image push source %q", source)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s push event: %w", source, err)
+	}
+
+	res := make([]Push, 0, len(pushes))
+
+	for _, push := range pushes {
+		if push.Repository == "" || push.Tag == "" {
+			continue
+		}
+
+		push.Repository = NormalizeRepository(push.Repository)
+		res = append(res, push)
+	}
+
+	return res, nil
+}
+
+// NormalizeRepository returns the canonical form of an image repository, so that pushes and policies referring
+// to the same repository compare equal. Docker Hub repositories are expanded, so that nginx becomes
+// docker.io/library/nginx, and any tag or digest is removed.
+func NormalizeRepository(repository string) string {
+	repo := strings.ToLower(strings.TrimSpace(repository))
+	repo = strings.TrimPrefix(repo, "https://")
+	repo = strings.TrimPrefix(repo, "http://")
+	repo = strings.TrimSuffix(repo, "/")
+
+	if i := strings.Index(repo, "@"); i != -1 {
+		repo = repo[:i]
+	}
+
+	// a colon after the last slash separates the tag, while a colon before it is part of the host
+	if i := strings.LastIndex(repo, ":"); i != -1 && i > strings.LastIndex(repo, "/") {
+		repo = repo[:i]
+	}
+
+	host, path, found := strings.Cut(repo, "/")
+
+	if !found || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		host, path = "docker.io", repo
+	}
+
+	switch host {
+	case "index.docker.io", "registry-1.docker.io", "registry.hub.docker.com":
+		host = "docker.io"
+	}
+
+	if host == "docker.io" && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+
+	return host + "/" + path
+}
+
+type ecrEvent struct {
+	Source  string `json:"source"`
+	Account string `json:"account"`
+	Region  string `json:"region"`
+	Detail  struct {
+		Result         string `json:"result"`
+		ActionType     string `json:"action-type"`
+		RepositoryName string `json:"repository-name"`
+		ImageTag       string `json:"image-tag"`
+		ImageDigest    string `json:"image-digest"`
+	} `json:"detail"`
+}
+
+func parseECR(body []byte) ([]Push, error) {
+	event := &ecrEvent{}
+
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	if event.Source != "aws.ecr" || event.Detail.ActionType != "PUSH" || event.Detail.Result != "SUCCESS" {
+		return nil, nil
+	}
+
+	return []Push{
+		{
+			Repository: fmt.Sprintf("%s.dkr.ecr.%s.amazonaws.com/%s", event.Account, event.Region, event.Detail.RepositoryName),
+			Tag:        event.Detail.ImageTag,
+			Digest:     event.Detail.ImageDigest,
+		},
+	}, nil
+}
+
+type dockerHubEvent struct {
+	PushData struct {
+		Tag string `json:"tag"`
+	} `json:"push_data"`
+	Repository struct {
+		RepoName string `json:"repo_name"`
+	} `json:"repository"`
+}
+
+func parseDockerHub(body []byte) ([]Push, error) {
+	event := &dockerHubEvent{}
+
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	return []Push{
+		{
+			Repository: "docker.io/" + event.Repository.RepoName,
+			Tag:        event.PushData.Tag,
+		},
+	}, nil
+}
+
+type ghcrEvent struct {
+	Action  string `json:"action"`
+	Package struct {
+		Name        string `json:"name"`
+		PackageType string `json:"package_type"`
+		Owner       struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		PackageVersion struct {
+			ContainerMetadata struct {
+				Tag struct {
+					Name   string `json:"name"`
+					Digest string `json:"digest"`
+				} `json:"tag"`
+			} `json:"container_metadata"`
+		} `json:"package_version"`
+	} `json:"package"`
+}
+
+func parseGHCR(body []byte) ([]Push, error) {
+	event := &ghcrEvent{}
+
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	if event.Action != "published" || !strings.EqualFold(event.Package.PackageType, "container") {
+		return nil, nil
+	}
+
+	tag := event.Package.PackageVersion.ContainerMetadata.Tag
+
+	return []Push{
+		{
+			Repository: fmt.Sprintf("ghcr.io/%s/%s", event.Package.Owner.Login, event.Package.Name),
+			Tag:        tag.Name,
+			Digest:     tag.Digest,
+		},
+	}, nil
+}
+
+type harborEvent struct {
+	Type      string `json:"type"`
+	EventData struct {
+		Resources []struct {
+			Digest      string `json:"digest"`
+			Tag         string `json:"tag"`
+			ResourceURL string `json:"resource_url"`
+		} `json:"resources"`
+	} `json:"event_data"`
+}
+
+func parseHarbor(body []byte) ([]Push, error) {
+	event := &harborEvent{}
+
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+
+	if event.Type != "PUSH_ARTIFACT" && event.Type != "pushImage" {
+		return nil, nil
+	}
+
+	pushes := make([]Push, 0, len(event.EventData.Resources))
+
+	for _, resource := range event.EventData.Resources {
+		pushes = append(pushes, Push{
+			Repository: resource.ResourceURL,
+			Tag:        resource.Tag,
+			Digest:     resource.Digest,
+		})
+	}
+
+	return pushes, nil
+}
+
+type distributionEnvelope struct {
+	Events []struct {
+		Action string `json:"action"`
+		Target struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+			Digest     string `json:"digest"`
+		} `json:"target"`
+		Request struct {
+			Host string `json:"host"`
+		} `json:"request"`
+	} `json:"events"`
+}
+
+func parseDistribution(body []byte) ([]Push, error) {
+	envelope := &distributionEnvelope{}
+
+	if err := json.Unmarshal(body, envelope); err != nil {
+		return nil, err
+	}
+
+	pushes := make([]Push, 0, len(envelope.Events))
+
+	for _, event := range envelope.Events {
+		// blob pushes are also sent as push events, but only manifest pushes are tagged
+		if event.Action != "push" || event.Request.Host == "" {
+			continue
+		}
+
+		pushes = append(pushes, Push{
+			Repository: event.Request.Host + "/" + event.Target.Repository,
+			Tag:        event.Target.Tag,
+			Digest:     event.Target.Digest,
+		})
+	}
+
+	return pushes, nil
+}
@@ -0,0 +1,95 @@
+package imagepush
+
+import (
+	"fmt"
+	"regexp"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// ValidatePolicy returns an error if the pattern of a policy cannot be parsed for its type
+func ValidatePolicy(policyType types.ImagePushPolicyType, pattern string) error {
+	switch policyType {
+	case types.ImagePushPolicySemver:
+		if _, err := semver.NewConstraint(pattern); err != nil {
+			return fmt.Errorf("invalid semver range %q: %w", pattern, err)
+		}
+	case types.ImagePushPolicyRegex:
+		if _, err := compileTagRegex(pattern); err != nil {
+			return fmt.Errorf("invalid tag regex %q: %w", pattern, err)
+		}
+	case types.ImagePushPolicyDigest:
+		if pattern == "" {
+			return fmt.Errorf("a tag is required for digest policies")
+		}
+	default:
+		return fmt.Errorf("unsupported policy type %q", policyType)
+	}
+
+	return nil
+}
+
+// Match returns true if a push should trigger an upgrade by the policy. Pushes of a tag and digest which were
+// already deployed by the policy do not match, so that registries retrying a delivery do not cause a redeploy.
+func Match(policy *models.ImagePushPolicy, push Push) (bool, error) {
+	if push.Repository != policy.ImageRepository {
+		return false, nil
+	}
+
+	if push.Tag == policy.LastTag && push.Digest == policy.LastDigest {
+		return false, nil
+	}
+
+	switch types.ImagePushPolicyType(policy.Type) {
+	case types.ImagePushPolicySemver:
+		constraint, err := semver.NewConstraint(policy.Pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid semver range %q: %w", policy.Pattern, err)
+		}
+
+		version, err := semver.NewVersion(push.Tag)
+		if err != nil {
+			return false, nil
+		}
+
+		if !constraint.Check(version) {
+			return false, nil
+		}
+
+		// never downgrade, which could happen when an older release line receives a patch
+		if last, err := semver.NewVersion(policy.LastTag); err == nil && version.LessThan(last) {
+			return false, nil
+		}
+
+		return true, nil
+	case types.ImagePushPolicyRegex:
+		re, err := compileTagRegex(policy.Pattern)
+		if err != nil {
+			return false, fmt.Errorf("invalid tag regex %q: %w", policy.Pattern, err)
+		}
+
+		return re.MatchString(push.Tag), nil
+	case types.ImagePushPolicyDigest:
+		// without a digest, there is no way to tell whether the tag has changed
+		return push.Tag == policy.Pattern && push.Digest != "", nil
+	}
+
+	return false, fmt.Errorf("unsupported policy type %q", policy.Type)
+}
+
+// DeployTag returns the tag that is deployed for a push which matched the policy. Digest policies pin the digest,
+// since the tag itself does not change between pushes.
+func DeployTag(policy *models.ImagePushPolicy, push Push) string {
+	if types.ImagePushPolicyType(policy.Type) == types.ImagePushPolicyDigest {
+		return push.Tag + "@" + push.Digest
+	}
+
+	return push.Tag
+}
+
+// compileTagRegex compiles a regex which must match the whole tag
+func compileTagRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
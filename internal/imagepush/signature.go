@@ -0,0 +1,57 @@
+package imagepush
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ErrInvalidSignature is returned by VerifySignature when a push event is not signed with the secret
+var ErrInvalidSignature = errors.New("invalid push event signature")
+
+// githubSignatureHeader is the header GitHub sends the HMAC-SHA256 signature of a webhook body in
+const githubSignatureHeader = "X-Hub-Signature-256"
+
+// SignsEvents returns true if a registry signs its push events with the webhook secret. Events from other
+// registries are only authenticated by the token in the webhook url, since Docker Hub does not sign its webhooks,
+// and ECR (through EventBridge), Harbor and distribution registries can only send static headers.
+func SignsEvents(source types.ImagePushSource) bool {
+	return source == types.ImagePushSourceGHCR
+}
+
+// VerifySignature verifies the signature of a push event from a registry which signs its events. GHCR events are
+// signed by GitHub with an HMAC-SHA256 of the body in the X-Hub-Signature-256 header. Events from registries which
+// do not sign their events are not verified.
+func VerifySignature(source types.ImagePushSource, secret string, header http.Header, body []byte) error {
+	if !SignsEvents(source) {
+		return nil
+	}
+
+	if secret == "" {
+		return ErrInvalidSignature
+	}
+
+	signature, ok := strings.CutPrefix(header.Get(githubSignatureHeader), "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
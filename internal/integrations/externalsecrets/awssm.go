@@ -0,0 +1,81 @@
+package externalsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+)
+
+// AWSSecretsManagerProvider resolves awssm:// references. The reference path is the secret ID relative to the project's
+// prefix; if a key is given, the secret string is parsed as a JSON object and the key is read from it, so
+// ${awssm://prod/db#password} in project 12 reads the "password" field of the secret "porter/projects/12/prod/db".
+type AWSSecretsManagerProvider struct {
+	client *secretsmanager.SecretsManager
+}
+
+// NewAWSSecretsManagerProvider creates a new provider for AWS Secrets Manager in the given region. Credentials are
+// loaded from the default AWS credential chain.
+func NewAWSSecretsManagerProvider(region string) (*AWSSecretsManagerProvider, error) {
+	sess, err := session.NewSessionWithOptions(session.Options{
+		SharedConfigState: session.SharedConfigEnable,
+		Config: aws.Config{
+			Region: aws.String(region),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create AWS session: %w", err)
+	}
+
+	return &AWSSecretsManagerProvider{
+		client: secretsmanager.New(sess),
+	}, nil
+}
+
+// Scheme returns the AWS Secrets Manager reference scheme
+func (p *AWSSecretsManagerProvider) Scheme() string {
+	return SchemeAWSSecretsManager
+}
+
+// Resolve reads the current version of a secret belonging to a project. The raw secret string is always returned under
+// DefaultField, along with the fields of the secret if it is a JSON object.
+func (p *AWSSecretsManagerProvider) Resolve(ctx context.Context, projectID uint, path string) (map[string]string, error) {
+	out, err := p.client.GetSecretValueWithContext(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(ProjectPathPrefix(projectID) + "/" + path),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading secret from aws secrets manager: %w", err)
+	}
+
+	secretString := aws.StringValue(out.SecretString)
+	if secretString == "" && out.SecretBinary != nil {
+		secretString = string(out.SecretBinary)
+	}
+
+	fields := map[string]string{
+		DefaultField: secretString,
+	}
+
+	var structured map[string]interface{}
+
+	if err := json.Unmarshal([]byte(secretString), &structured); err == nil {
+		for k, val := range structured {
+			switch typed := val.(type) {
+			case string:
+				fields[k] = typed
+			default:
+				encoded, err := json.Marshal(typed)
+				if err != nil {
+					return nil, fmt.Errorf("error encoding secret field %s: %w", k, err)
+				}
+
+				fields[k] = string(encoded)
+			}
+		}
+	}
+
+	return fields, nil
+}
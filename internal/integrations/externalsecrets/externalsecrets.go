@@ -0,0 +1,229 @@
+package externalsecrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// SchemeVault is the reference scheme for secrets stored in a HashiCorp Vault KV v2 engine, e.g. ${vault://kv/app#DB_PASSWORD}
+	SchemeVault = "vault"
+	// SchemeAWSSecretsManager is the reference scheme for secrets stored in AWS Secrets Manager, e.g. ${awssm://prod/db#password}
+	SchemeAWSSecretsManager = "awssm"
+
+	// DefaultField is the field returned by providers for secrets which hold a single unstructured value
+	DefaultField = ""
+
+	// referencePrefix and referenceSuffix wrap every reference, so that literal values such as URLs are never mistaken for references
+	referencePrefix = "${"
+	referenceSuffix = "}"
+)
+
+// Reference points to a single value stored in an external secret manager
+type Reference struct {
+	// Scheme identifies the provider which resolves this reference
+	Scheme string
+	// Path is the location of the secret within the provider
+	Path string
+	// Key is the field within the secret. It may be empty for providers which store a single value per path
+	Key string
+}
+
+// String returns the reference in its ${<scheme>://<path>#<key>} form
+func (r Reference) String() string {
+	if r.Key == "" {
+		return fmt.Sprintf("%s%s://%s%s", referencePrefix, r.Scheme, r.Path, referenceSuffix)
+	}
+
+	return fmt.Sprintf("%s%s://%s#%s%s", referencePrefix, r.Scheme, r.Path, r.Key, referenceSuffix)
+}
+
+// Provider resolves references for a single scheme
+type Provider interface {
+	// Scheme is the reference scheme handled by this provider
+	Scheme() string
+	// Resolve returns all fields stored at the given path. Providers share a single set of server-wide credentials, so
+	// the path must be resolved within the project's namespace of the secret manager (see ProjectPathPrefix).
+	Resolve(ctx context.Context, projectID uint, path string) (map[string]string, error)
+}
+
+// ProjectPathPrefix returns the prefix under which the secrets of a project must be stored. References are always resolved
+// relative to this prefix so that a project can never read the secrets of another project, even though the Vault token and
+// AWS credentials are shared by the whole server. Those credentials should only be granted access to porter/projects/*.
+func ProjectPathPrefix(projectID uint) string {
+	return fmt.Sprintf("porter/projects/%d", projectID)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = make(map[string]Provider)
+)
+
+// RegisterProvider makes a provider available for resolving references of its scheme. Registering a provider
+// for a scheme which already has one replaces the existing provider.
+func RegisterProvider(p Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+
+	providers[p.Scheme()] = p
+}
+
+func getProvider(scheme string) (Provider, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+
+	p, ok := providers[scheme]
+	return p, ok
+}
+
+func isKnownScheme(scheme string) bool {
+	if scheme == SchemeVault || scheme == SchemeAWSSecretsManager {
+		return true
+	}
+
+	_, ok := getProvider(scheme)
+	return ok
+}
+
+// unwrapReference returns the <scheme>://<path>[#<key>] body of a ${...} wrapped value
+func unwrapReference(value string) (string, bool) {
+	if !strings.HasPrefix(value, referencePrefix) || !strings.HasSuffix(value, referenceSuffix) {
+		return "", false
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(value, referencePrefix), referenceSuffix), true
+}
+
+// IsReference returns true if the value should be resolved from an external secret manager. Only values of the form
+// ${<scheme>://...} are references; a value such as vault://kv/app is always treated as a literal.
+func IsReference(value string) bool {
+	body, ok := unwrapReference(value)
+	if !ok {
+		return false
+	}
+
+	scheme, _, found := strings.Cut(body, "://")
+	return found && isKnownScheme(scheme)
+}
+
+// ParseReference parses a value of the form ${<scheme>://<path>[#<key>]}
+func ParseReference(value string) (Reference, error) {
+	var ref Reference
+
+	body, ok := unwrapReference(value)
+	if !ok {
+		return ref, fmt.Errorf("%q is not an external secret reference", value)
+	}
+
+	scheme, rest, found := strings.Cut(body, "://")
+	if !found || !isKnownScheme(scheme) {
+		return ref, fmt.Errorf("%q is not an external secret reference", value)
+	}
+
+	path, key, _ := strings.Cut(rest, "#")
+	path = strings.Trim(path, "/")
+
+	if path == "" {
+		return ref, fmt.Errorf("external secret reference %q must contain a path", value)
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ref, fmt.Errorf("external secret reference %q contains an invalid path segment", value)
+		}
+	}
+	if scheme == SchemeVault && key == "" {
+		return ref, fmt.Errorf("vault reference %q must contain a key, e.g. ${vault://kv/app#DB_PASSWORD}", value)
+	}
+
+	return Reference{
+		Scheme: scheme,
+		Path:   path,
+		Key:    key,
+	}, nil
+}
+
+// ValidateReferences checks that every reference in the given variables can be parsed
+func ValidateReferences(variables map[string]string) error {
+	for name, value := range variables {
+		if !IsReference(value) {
+			continue
+		}
+
+		if _, err := ParseReference(value); err != nil {
+			return fmt.Errorf("invalid reference for variable %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ResolveAll resolves every reference in the given variables within the secrets of a project, and returns the resolved values
+// keyed by variable name. Variables which are not references are not included in the result. Each path is only fetched once per call.
+func ResolveAll(ctx context.Context, projectID uint, variables map[string]string) (map[string]string, error) {
+	if projectID == 0 {
+		return nil, errors.New("project id is required to resolve external secret references")
+	}
+
+	resolved := make(map[string]string)
+	fetched := make(map[string]map[string]string)
+
+	for name, value := range variables {
+		if !IsReference(value) {
+			continue
+		}
+
+		ref, err := ParseReference(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference for variable %s: %w", name, err)
+		}
+
+		provider, ok := getProvider(ref.Scheme)
+		if !ok {
+			return nil, fmt.Errorf("no external secret provider is configured for %s:// references", ref.Scheme)
+		}
+
+		cacheKey := ref.Scheme + "://" + ref.Path
+
+		fields, ok := fetched[cacheKey]
+		if !ok {
+			fields, err = provider.Resolve(ctx, projectID, ref.Path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to resolve %s for variable %s: %w", ref, name, err)
+			}
+
+			fetched[cacheKey] = fields
+		}
+
+		// references without a key resolve to the unstructured value of the secret
+		fieldValue, ok := fields[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("%s not found for variable %s", ref, name)
+		}
+
+		resolved[name] = fieldValue
+	}
+
+	return resolved, nil
+}
+
+// Checksum returns a stable hash of resolved values, used to detect when upstream values change
+func Checksum(resolved map[string]string) string {
+	keys := make([]string, 0, len(resolved))
+	for k := range resolved {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s=%s\n", k, resolved[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
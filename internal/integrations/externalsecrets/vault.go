@@ -0,0 +1,94 @@
+package externalsecrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider resolves vault:// references against a KV v2 secrets engine. The first segment of a reference
+// path is the engine mount, and the rest of the path is relative to the project's prefix within that engine, so
+// ${vault://kv/app#DB_PASSWORD} in project 12 reads the DB_PASSWORD field of the secret "porter/projects/12/app" in
+// the engine mounted at "kv".
+type VaultProvider struct {
+	serverURL string
+	token     string
+
+	httpClient *http.Client
+}
+
+// NewVaultProvider creates a new provider for a Vault server
+func NewVaultProvider(serverURL, token string) *VaultProvider {
+	return &VaultProvider{
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+		token:     token,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+type vaultKVv2Response struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+// Scheme returns the vault reference scheme
+func (v *VaultProvider) Scheme() string {
+	return SchemeVault
+}
+
+// Resolve reads the latest version of a KV v2 secret belonging to a project
+func (v *VaultProvider) Resolve(ctx context.Context, projectID uint, path string) (map[string]string, error) {
+	mount, secretPath, found := strings.Cut(path, "/")
+	if !found || secretPath == "" {
+		return nil, fmt.Errorf("vault path %s must be of the form <mount>/<secret>", path)
+	}
+
+	reqURL := fmt.Sprintf("%s/v1/%s/data/%s/%s", v.serverURL, mount, ProjectPathPrefix(projectID), secretPath)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error reading secret from vault: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vault returned status %d for %s", resp.StatusCode, path)
+	}
+
+	vaultResp := &vaultKVv2Response{}
+
+	if err := json.NewDecoder(resp.Body).Decode(vaultResp); err != nil {
+		return nil, fmt.Errorf("error decoding vault response: %w", err)
+	}
+
+	fields := make(map[string]string, len(vaultResp.Data.Data))
+
+	for k, val := range vaultResp.Data.Data {
+		switch typed := val.(type) {
+		case string:
+			fields[k] = typed
+		default:
+			encoded, err := json.Marshal(typed)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding vault field %s: %w", k, err)
+			}
+
+			fields[k] = string(encoded)
+		}
+	}
+
+	return fields, nil
+}
@@ -15,8 +15,11 @@ import (
 // CreateOrUpdateBaseEnvironmentGroup creates a new environment group in the porter-env-group namespace. If porter-env-group does not exist, it will be created.
 // If no existing environmentGroup exists by this name, a new one will be created as version 1, denoted by the label "porter.run/environment-group-version: 1".
 // If an environmentGroup already exists by this name, a new version will be created, and the label will be updated to reflect the new version.
-// Providing the Version field to this function will be ignored in order to not accidentally overwrite versions
-func CreateOrUpdateBaseEnvironmentGroup(ctx context.Context, a *kubernetes.Agent, environmentGroup EnvironmentGroup) error {
+// Providing the Version field to this function will be ignored in order to not accidentally overwrite versions.
+// projectID is the project which owns the cluster, and is used to resolve external secret references. References are always resolved,
+// so that references which cannot be read are rejected, and the ExternalSecretsChecksum field is ignored in favour of the checksum of
+// the resolved values.
+func CreateOrUpdateBaseEnvironmentGroup(ctx context.Context, a *kubernetes.Agent, projectID uint, environmentGroup EnvironmentGroup) error {
 	ctx, span := telemetry.NewSpan(ctx, "create-environment-group")
 	defer span.End()
 	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group", Value: environmentGroup.Name})
@@ -25,6 +28,27 @@ func CreateOrUpdateBaseEnvironmentGroup(ctx context.Context, a *kubernetes.Agent
 		return telemetry.Error(ctx, span, nil, "environment group name cannot be empty")
 	}
 
+	_, checksum, err := resolveExternalSecrets(ctx, projectID, environmentGroup)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "unable to resolve external secret references")
+	}
+	environmentGroup.ExternalSecretsChecksum = checksum
+
+	err = createBaseEnvironmentGroupVersion(ctx, a, environmentGroup)
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "unable to create new base environment group version")
+	}
+
+	return nil
+}
+
+// createBaseEnvironmentGroupVersion creates the next version of an environment group in the porter-env-group namespace, with
+// an ExternalSecretsChecksum which has already been computed from the resolved external secret references of the environment group.
+// Use CreateOrUpdateBaseEnvironmentGroup unless the references were resolved by the caller.
+func createBaseEnvironmentGroupVersion(ctx context.Context, a *kubernetes.Agent, environmentGroup EnvironmentGroup) error {
+	ctx, span := telemetry.NewSpan(ctx, "create-base-environment-group-version")
+	defer span.End()
+
 	_, err := a.Clientset.CoreV1().Namespaces().Get(ctx, Namespace_EnvironmentGroups, metav1.GetOptions{})
 	if err != nil {
 		if !k8serror.IsNotFound(err) {
@@ -44,11 +68,12 @@ func CreateOrUpdateBaseEnvironmentGroup(ctx context.Context, a *kubernetes.Agent
 	}
 
 	newEnvironmentGroup := EnvironmentGroup{
-		Name:            environmentGroup.Name,
-		Variables:       environmentGroup.Variables,
-		SecretVariables: environmentGroup.SecretVariables,
-		Version:         latestEnvironmentGroup.Version + 1,
-		CreatedAtUTC:    environmentGroup.CreatedAtUTC,
+		Name:                    environmentGroup.Name,
+		Variables:               environmentGroup.Variables,
+		SecretVariables:         environmentGroup.SecretVariables,
+		Version:                 latestEnvironmentGroup.Version + 1,
+		CreatedAtUTC:            environmentGroup.CreatedAtUTC,
+		ExternalSecretsChecksum: environmentGroup.ExternalSecretsChecksum,
 	}
 
 	err = createVersionedEnvironmentGroupInNamespace(ctx, a, newEnvironmentGroup, Namespace_EnvironmentGroups)
@@ -119,6 +144,11 @@ func createVersionedEnvironmentGroupInNamespace(ctx context.Context, a *kubernet
 		},
 		Data: environmentGroup.Variables,
 	}
+	if environmentGroup.ExternalSecretsChecksum != "" {
+		configMap.Annotations = map[string]string{
+			AnnotationKey_ExternalSecretsChecksum: environmentGroup.ExternalSecretsChecksum,
+		}
+	}
 	err := createConfigMapWithVersion(ctx, a, configMap, environmentGroup.Version)
 	if err != nil {
 		return telemetry.Error(ctx, span, err, "unable to create new environment group variables version")
@@ -0,0 +1,167 @@
+package environment_groups
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/internal/integrations/externalsecrets"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// externalSecretReferences returns all variables in the environment group whose value is a reference to an external secret manager,
+// such as ${vault://kv/app#DB_PASSWORD} or ${awssm://prod/db#password}
+func externalSecretReferences(environmentGroup EnvironmentGroup) map[string]string {
+	references := make(map[string]string)
+
+	for key, value := range environmentGroup.Variables {
+		if externalsecrets.IsReference(value) {
+			references[key] = value
+		}
+	}
+	for key, value := range environmentGroup.SecretVariables {
+		if externalsecrets.IsReference(string(value)) {
+			references[key] = string(value)
+		}
+	}
+
+	return references
+}
+
+// resolveExternalSecrets returns a copy of the environment group where all external secret references have been replaced by their
+// upstream values, along with a checksum of those values. Resolved values are always stored as secret variables, even if the
+// reference was added as a non-secret variable. References are resolved within the secrets of the project which owns the cluster.
+// If the environment group contains no references, it is returned unchanged with an empty checksum.
+func resolveExternalSecrets(ctx context.Context, projectID uint, environmentGroup EnvironmentGroup) (EnvironmentGroup, string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "resolve-env-group-external-secrets")
+	defer span.End()
+
+	references := externalSecretReferences(environmentGroup)
+	if len(references) == 0 {
+		return environmentGroup, "", nil
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroup.Name},
+		telemetry.AttributeKV{Key: "external-secret-reference-count", Value: len(references)},
+	)
+
+	resolved, err := externalsecrets.ResolveAll(ctx, projectID, references)
+	if err != nil {
+		return environmentGroup, "", telemetry.Error(ctx, span, err, "unable to resolve external secret references")
+	}
+
+	variables := make(map[string]string)
+	for key, value := range environmentGroup.Variables {
+		if _, ok := resolved[key]; !ok {
+			variables[key] = value
+		}
+	}
+
+	secrets := make(map[string][]byte)
+	for key, value := range environmentGroup.SecretVariables {
+		secrets[key] = value
+	}
+	for key, value := range resolved {
+		secrets[key] = []byte(value)
+	}
+
+	resolvedEnvironmentGroup := environmentGroup
+	resolvedEnvironmentGroup.Variables = variables
+	resolvedEnvironmentGroup.SecretVariables = secrets
+
+	return resolvedEnvironmentGroup, externalsecrets.Checksum(resolved), nil
+}
+
+// RefreshExternalSecrets checks whether the upstream values of any external secret references in the latest version of an environment group
+// have changed. If they have, a new version of the environment group is created so that the new values are picked up the next time the
+// environment group is synced. Returns true if a new version was created.
+func RefreshExternalSecrets(ctx context.Context, a *kubernetes.Agent, projectID uint, environmentGroupName string) (bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "refresh-env-group-external-secrets")
+	defer span.End()
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName})
+
+	latestEnvironmentGroup, err := LatestBaseEnvironmentGroup(ctx, a, environmentGroupName)
+	if err != nil {
+		return false, telemetry.Error(ctx, span, err, "unable to get latest base environment group")
+	}
+
+	_, checksum, err := resolveExternalSecrets(ctx, projectID, latestEnvironmentGroup)
+	if err != nil {
+		return false, telemetry.Error(ctx, span, err, "unable to resolve external secret references")
+	}
+
+	if checksum == latestEnvironmentGroup.ExternalSecretsChecksum {
+		return false, nil
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "previous-version", Value: latestEnvironmentGroup.Version},
+	)
+
+	newEnvironmentGroup := EnvironmentGroup{
+		Name:                    latestEnvironmentGroup.Name,
+		Variables:               latestEnvironmentGroup.Variables,
+		SecretVariables:         latestEnvironmentGroup.SecretVariables,
+		CreatedAtUTC:            time.Now().UTC(),
+		ExternalSecretsChecksum: checksum,
+	}
+
+	// the references were resolved above, so they are not resolved again when the version is created
+	err = createBaseEnvironmentGroupVersion(ctx, a, newEnvironmentGroup)
+	if err != nil {
+		return false, telemetry.Error(ctx, span, err, "unable to create new environment group version")
+	}
+
+	return true, nil
+}
+
+// RefreshAllExternalSecrets runs RefreshExternalSecrets for every environment group in the porter-env-group namespace which contains
+// external secret references. Returns the names of the environment groups for which a new version was created. A failure to refresh one
+// environment group does not prevent the others from being refreshed.
+func RefreshAllExternalSecrets(ctx context.Context, a *kubernetes.Agent, projectID uint) ([]string, error) {
+	ctx, span := telemetry.NewSpan(ctx, "refresh-all-env-group-external-secrets")
+	defer span.End()
+
+	environmentGroups, err := ListEnvironmentGroups(ctx, a, WithNamespace(Namespace_EnvironmentGroups))
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list environment groups")
+	}
+
+	latestVersions := make(map[string]EnvironmentGroup)
+	for _, environmentGroup := range environmentGroups {
+		if environmentGroup.Version > latestVersions[environmentGroup.Name].Version {
+			latestVersions[environmentGroup.Name] = environmentGroup
+		}
+	}
+
+	var refreshed []string
+	var refreshErrs []error
+	for name, environmentGroup := range latestVersions {
+		if len(externalSecretReferences(environmentGroup)) == 0 {
+			continue
+		}
+
+		created, err := RefreshExternalSecrets(ctx, a, projectID, name)
+		if err != nil {
+			refreshErrs = append(refreshErrs, fmt.Errorf("environment group %s: %w", name, err))
+			continue
+		}
+		if created {
+			refreshed = append(refreshed, name)
+		}
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "refreshed-count", Value: len(refreshed)},
+		telemetry.AttributeKV{Key: "failed-count", Value: len(refreshErrs)},
+	)
+
+	if len(refreshErrs) > 0 {
+		return refreshed, telemetry.Error(ctx, span, errors.Join(refreshErrs...), "unable to refresh external secrets for some environment groups")
+	}
+
+	return refreshed, nil
+}
@@ -0,0 +1,102 @@
+package environment_groups
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/externalsecrets"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecretProvider resolves fakesm:// references from a fixed set of secrets
+type fakeSecretProvider struct {
+	secrets map[string]map[string]string
+}
+
+func (p *fakeSecretProvider) Scheme() string {
+	return "fakesm"
+}
+
+func (p *fakeSecretProvider) Resolve(ctx context.Context, projectID uint, path string) (map[string]string, error) {
+	fields, ok := p.secrets[path]
+	if !ok {
+		return nil, errors.New("secret not found")
+	}
+
+	return fields, nil
+}
+
+func TestCreateOrUpdateBaseEnvironmentGroupExternalSecrets(t *testing.T) {
+	externalsecrets.RegisterProvider(&fakeSecretProvider{secrets: map[string]map[string]string{
+		"app": {"DB_PASSWORD": "hunter2"},
+	}})
+
+	tests := []struct {
+		name         string
+		variables    map[string]string
+		checksum     string
+		wantErr      bool
+		wantChecksum string
+	}{
+		{
+			name:         "reference is resolved",
+			variables:    map[string]string{"DB_PASSWORD": "${fakesm://app#DB_PASSWORD}"},
+			wantChecksum: externalsecrets.Checksum(map[string]string{"DB_PASSWORD": "hunter2"}),
+		},
+		{
+			name:         "client checksum is replaced by the resolved checksum",
+			variables:    map[string]string{"DB_PASSWORD": "${fakesm://app#DB_PASSWORD}"},
+			checksum:     "client-checksum",
+			wantChecksum: externalsecrets.Checksum(map[string]string{"DB_PASSWORD": "hunter2"}),
+		},
+		{
+			name:      "client checksum does not skip validation of missing secrets",
+			variables: map[string]string{"DB_PASSWORD": "${fakesm://other#DB_PASSWORD}"},
+			checksum:  "client-checksum",
+			wantErr:   true,
+		},
+		{
+			name:      "client checksum does not skip validation of missing keys",
+			variables: map[string]string{"DB_PASSWORD": "${fakesm://app#MISSING}"},
+			checksum:  "client-checksum",
+			wantErr:   true,
+		},
+		{
+			name:      "unwrapped reference is a literal",
+			variables: map[string]string{"DB_URL": "fakesm://app#DB_PASSWORD"},
+			checksum:  "client-checksum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			agent := kubernetes.GetAgentTesting()
+
+			err := CreateOrUpdateBaseEnvironmentGroup(ctx, agent, 1, EnvironmentGroup{
+				Name:                    "app-env",
+				Variables:               tt.variables,
+				ExternalSecretsChecksum: tt.checksum,
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				versions, err := ListEnvironmentGroups(ctx, agent, WithEnvironmentGroupName("app-env"))
+				require.NoError(t, err)
+				assert.Empty(t, versions)
+				return
+			}
+			require.NoError(t, err)
+
+			latest, err := LatestBaseEnvironmentGroup(ctx, agent, "app-env")
+			require.NoError(t, err)
+
+			assert.Equal(t, 1, latest.Version)
+			assert.Equal(t, tt.wantChecksum, latest.ExternalSecretsChecksum)
+			assert.Equal(t, tt.variables, latest.Variables)
+		})
+	}
+}
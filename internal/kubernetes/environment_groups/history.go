@@ -0,0 +1,335 @@
+package environment_groups
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/telemetry"
+	v1 "k8s.io/api/core/v1"
+	k8serror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EnvironmentGroupVersions returns all versions of an environment group stored in the porter-env-group namespace, ordered from newest to oldest
+func EnvironmentGroupVersions(ctx context.Context, a *kubernetes.Agent, environmentGroupName string) ([]EnvironmentGroup, error) {
+	ctx, span := telemetry.NewSpan(ctx, "list-env-group-versions")
+	defer span.End()
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName})
+
+	if environmentGroupName == "" {
+		return nil, telemetry.Error(ctx, span, nil, "environment group name cannot be empty")
+	}
+
+	versions, err := ListEnvironmentGroups(ctx, a, WithEnvironmentGroupName(environmentGroupName), WithNamespace(Namespace_EnvironmentGroups))
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list environment group versions")
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version > versions[j].Version
+	})
+
+	return versions, nil
+}
+
+// VariableChangeType describes how a variable changed between two versions of an environment group
+type VariableChangeType string
+
+const (
+	// VariableChangeType_Added is a variable which only exists in the newer version
+	VariableChangeType_Added VariableChangeType = "added"
+	// VariableChangeType_Removed is a variable which only exists in the older version
+	VariableChangeType_Removed VariableChangeType = "removed"
+	// VariableChangeType_Changed is a variable whose value differs between versions
+	VariableChangeType_Changed VariableChangeType = "changed"
+)
+
+// VariableChange is a single variable which differs between two versions of an environment group
+type VariableChange struct {
+	Key    string             `json:"key"`
+	Secret bool               `json:"secret"`
+	Type   VariableChangeType `json:"type"`
+	// OldValue and NewValue are only set for non-secret variables. Secret variables only report that the key changed
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+// DiffEnvironmentGroups returns the variables which differ between two versions of an environment group, sorted by key.
+// Values of secret variables are never included in the result.
+func DiffEnvironmentGroups(from EnvironmentGroup, to EnvironmentGroup) []VariableChange {
+	changes := make([]VariableChange, 0)
+
+	for key, newValue := range to.Variables {
+		oldValue, ok := from.Variables[key]
+		switch {
+		case !ok:
+			changes = append(changes, VariableChange{Key: key, Type: VariableChangeType_Added, NewValue: newValue})
+		case oldValue != newValue:
+			changes = append(changes, VariableChange{Key: key, Type: VariableChangeType_Changed, OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for key, oldValue := range from.Variables {
+		if _, ok := to.Variables[key]; !ok {
+			changes = append(changes, VariableChange{Key: key, Type: VariableChangeType_Removed, OldValue: oldValue})
+		}
+	}
+
+	for key, newValue := range to.SecretVariables {
+		oldValue, ok := from.SecretVariables[key]
+		switch {
+		case !ok:
+			changes = append(changes, VariableChange{Key: key, Secret: true, Type: VariableChangeType_Added})
+		case string(oldValue) != string(newValue):
+			changes = append(changes, VariableChange{Key: key, Secret: true, Type: VariableChangeType_Changed})
+		}
+	}
+	for key := range from.SecretVariables {
+		if _, ok := to.SecretVariables[key]; !ok {
+			changes = append(changes, VariableChange{Key: key, Secret: true, Type: VariableChangeType_Removed})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Key == changes[j].Key {
+			return !changes[i].Secret && changes[j].Secret
+		}
+		return changes[i].Key < changes[j].Key
+	})
+
+	return changes
+}
+
+// RollbackEnvironmentGroup creates a new version of an environment group with the variables of a previous version.
+// Previous versions are never modified, so a rollback can itself be rolled back. Returns the newly created version.
+func RollbackEnvironmentGroup(ctx context.Context, a *kubernetes.Agent, projectID uint, environmentGroupName string, version int) (EnvironmentGroup, error) {
+	ctx, span := telemetry.NewSpan(ctx, "rollback-env-group")
+	defer span.End()
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName},
+		telemetry.AttributeKV{Key: "rollback-version", Value: version},
+	)
+
+	var eg EnvironmentGroup
+
+	target, err := EnvironmentGroupInTargetNamespace(ctx, a, EnvironmentGroupInTargetNamespaceInput{
+		Name:      environmentGroupName,
+		Version:   version,
+		Namespace: Namespace_EnvironmentGroups,
+	})
+	if err != nil {
+		return eg, telemetry.Error(ctx, span, err, "unable to get environment group version")
+	}
+	if target.Name == "" {
+		return eg, telemetry.Error(ctx, span, nil, "environment group version does not exist")
+	}
+
+	err = CreateOrUpdateBaseEnvironmentGroup(ctx, a, projectID, EnvironmentGroup{
+		Name:            target.Name,
+		Variables:       target.Variables,
+		SecretVariables: target.SecretVariables,
+		CreatedAtUTC:    time.Now().UTC(),
+	})
+	if err != nil {
+		return eg, telemetry.Error(ctx, span, err, "unable to create rolled back environment group version")
+	}
+
+	latest, err := LatestBaseEnvironmentGroup(ctx, a, environmentGroupName)
+	if err != nil {
+		return eg, telemetry.Error(ctx, span, err, "unable to get latest environment group version")
+	}
+
+	return latest, nil
+}
+
+// SyncLinkedApplications syncs the latest version of an environment group to the namespaces of all applications linked to it
+func SyncLinkedApplications(ctx context.Context, a *kubernetes.Agent, projectID uint, environmentGroupName string) ([]LinkedPorterApplication, error) {
+	ctx, span := telemetry.NewSpan(ctx, "sync-env-group-linked-applications")
+	defer span.End()
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName})
+
+	applications, err := LinkedApplications(ctx, a, environmentGroupName)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list linked applications")
+	}
+
+	syncedNamespaces := make(map[string]bool)
+	for _, app := range applications {
+		if app.Namespace == "" || syncedNamespaces[app.Namespace] {
+			continue
+		}
+
+		_, err := SyncLatestVersionToNamespace(ctx, a, SyncLatestVersionToNamespaceInput{
+			BaseEnvironmentGroupName: environmentGroupName,
+			TargetNamespace:          app.Namespace,
+			ProjectID:                projectID,
+		})
+		if err != nil {
+			return applications, telemetry.Error(ctx, span, err, fmt.Sprintf("unable to sync environment group to namespace %s", app.Namespace))
+		}
+
+		syncedNamespaces[app.Namespace] = true
+	}
+
+	return applications, nil
+}
+
+// VersionsReferencedByWorkloads returns the versions of an environment group which are loaded by a deployment, cronjob or job in any namespace
+func VersionsReferencedByWorkloads(ctx context.Context, a *kubernetes.Agent, environmentGroupName string) (map[int]bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "env-group-versions-referenced-by-workloads")
+	defer span.End()
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "environment-group-name", Value: environmentGroupName})
+
+	referenced := make(map[int]bool)
+
+	addReferences := func(podSpec v1.PodSpec) {
+		containers := make([]v1.Container, 0, len(podSpec.InitContainers)+len(podSpec.Containers))
+		containers = append(containers, podSpec.InitContainers...)
+		containers = append(containers, podSpec.Containers...)
+
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				var name string
+				if envFrom.ConfigMapRef != nil {
+					name = envFrom.ConfigMapRef.Name
+				}
+				if envFrom.SecretRef != nil {
+					name = envFrom.SecretRef.Name
+				}
+
+				if version, ok := versionFromVersionedName(environmentGroupName, name); ok {
+					referenced[version] = true
+				}
+			}
+		}
+	}
+
+	deployments, err := a.Clientset.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list deployments")
+	}
+	for _, d := range deployments.Items {
+		addReferences(d.Spec.Template.Spec)
+	}
+
+	cronJobs, err := a.Clientset.BatchV1().CronJobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list cronjobs")
+	}
+	for _, c := range cronJobs.Items {
+		addReferences(c.Spec.JobTemplate.Spec.Template.Spec)
+	}
+
+	jobs, err := a.Clientset.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list jobs")
+	}
+	for _, j := range jobs.Items {
+		addReferences(j.Spec.Template.Spec)
+	}
+
+	return referenced, nil
+}
+
+// versionFromVersionedName parses the version from a configmap or secret name of the form <environment-group-name>.<version>
+func versionFromVersionedName(environmentGroupName string, versionedName string) (int, bool) {
+	versionString, ok := strings.CutPrefix(versionedName, environmentGroupName+".")
+	if !ok {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(versionString)
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
+
+// PruneEnvironmentGroupVersionsInput contains all information required to prune old versions of an environment group
+type PruneEnvironmentGroupVersionsInput struct {
+	Name string
+	// Retain is the number of most recent versions to always keep. If 0, no versions are pruned
+	Retain int
+	// ReferencedVersions are versions which must be kept regardless of their age, such as versions referenced by an app revision
+	ReferencedVersions map[int]bool
+}
+
+// PruneEnvironmentGroupVersions deletes versions of an environment group, from all namespaces, which are older than the retained versions and are neither
+// referenced by a running workload nor listed in ReferencedVersions. Returns the versions which were deleted.
+func PruneEnvironmentGroupVersions(ctx context.Context, a *kubernetes.Agent, inp PruneEnvironmentGroupVersionsInput) ([]int, error) {
+	ctx, span := telemetry.NewSpan(ctx, "prune-env-group-versions")
+	defer span.End()
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "environment-group-name", Value: inp.Name},
+		telemetry.AttributeKV{Key: "retain", Value: inp.Retain},
+	)
+
+	if inp.Retain <= 0 {
+		return nil, nil
+	}
+
+	versions, err := EnvironmentGroupVersions(ctx, a, inp.Name)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to list environment group versions")
+	}
+
+	if len(versions) <= inp.Retain {
+		return nil, nil
+	}
+
+	workloadVersions, err := VersionsReferencedByWorkloads(ctx, a, inp.Name)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "unable to find environment group versions referenced by workloads")
+	}
+
+	var pruned []int
+	for _, version := range versions[inp.Retain:] {
+		if workloadVersions[version.Version] || inp.ReferencedVersions[version.Version] {
+			continue
+		}
+
+		err := deleteEnvironmentGroupVersion(ctx, a, inp.Name, version.Version)
+		if err != nil {
+			return pruned, telemetry.Error(ctx, span, err, "unable to delete environment group version")
+		}
+
+		pruned = append(pruned, version.Version)
+	}
+
+	telemetry.WithAttributes(span, telemetry.AttributeKV{Key: "pruned-count", Value: len(pruned)})
+
+	return pruned, nil
+}
+
+// deleteEnvironmentGroupVersion deletes a single version of an environment group from all namespaces
+func deleteEnvironmentGroupVersion(ctx context.Context, a *kubernetes.Agent, name string, version int) error {
+	ctx, span := telemetry.NewSpan(ctx, "delete-env-group-version")
+	defer span.End()
+
+	versionedName := fmt.Sprintf("%s.%d", name, version)
+	labelSelector := fmt.Sprintf("%s=%s,%s=%d", LabelKey_EnvironmentGroupName, name, LabelKey_EnvironmentGroupVersion, version)
+
+	configMaps, err := a.Clientset.CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "unable to list environment group variables")
+	}
+
+	for _, cm := range configMaps.Items {
+		err := a.Clientset.CoreV1().ConfigMaps(cm.Namespace).Delete(ctx, versionedName, metav1.DeleteOptions{})
+		if err != nil && !k8serror.IsNotFound(err) {
+			return telemetry.Error(ctx, span, err, "unable to delete environment group variables")
+		}
+
+		err = a.Clientset.CoreV1().Secrets(cm.Namespace).Delete(ctx, versionedName, metav1.DeleteOptions{})
+		if err != nil && !k8serror.IsNotFound(err) {
+			return telemetry.Error(ctx, span, err, "unable to delete environment group secret variables")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package environment_groups
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createVersions creates versions 1 to count of the app-env environment group, where each version sets VERSION to its version
+func createVersions(t *testing.T, a *kubernetes.Agent, count int) {
+	t.Helper()
+
+	for i := 1; i <= count; i++ {
+		err := CreateOrUpdateBaseEnvironmentGroup(context.Background(), a, 1, EnvironmentGroup{
+			Name:            "app-env",
+			Variables:       map[string]string{"VERSION": strconv.Itoa(i)},
+			SecretVariables: map[string][]byte{"TOKEN": []byte("token-" + strconv.Itoa(i))},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func versionNumbers(versions []EnvironmentGroup) []int {
+	numbers := make([]int, 0, len(versions))
+	for _, version := range versions {
+		numbers = append(numbers, version.Version)
+	}
+
+	return numbers
+}
+
+func TestEnvironmentGroupVersions(t *testing.T) {
+	tests := []struct {
+		name         string
+		count        int
+		wantVersions []int
+	}{
+		{
+			name:         "no versions",
+			count:        0,
+			wantVersions: []int{},
+		},
+		{
+			name:         "single version",
+			count:        1,
+			wantVersions: []int{1},
+		},
+		{
+			name:         "newest first",
+			count:        11,
+			wantVersions: []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agent := kubernetes.GetAgentTesting()
+			createVersions(t, agent, tt.count)
+
+			versions, err := EnvironmentGroupVersions(context.Background(), agent, "app-env")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantVersions, versionNumbers(versions))
+		})
+	}
+}
+
+func TestDiffEnvironmentGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		from EnvironmentGroup
+		to   EnvironmentGroup
+		want []VariableChange
+	}{
+		{
+			name: "identical versions",
+			from: EnvironmentGroup{Variables: map[string]string{"A": "1"}, SecretVariables: map[string][]byte{"S": []byte("x")}},
+			to:   EnvironmentGroup{Variables: map[string]string{"A": "1"}, SecretVariables: map[string][]byte{"S": []byte("x")}},
+			want: []VariableChange{},
+		},
+		{
+			name: "variables added, changed and removed",
+			from: EnvironmentGroup{Variables: map[string]string{"A": "1", "B": "2"}},
+			to:   EnvironmentGroup{Variables: map[string]string{"A": "10", "C": "3"}},
+			want: []VariableChange{
+				{Key: "A", Type: VariableChangeType_Changed, OldValue: "1", NewValue: "10"},
+				{Key: "B", Type: VariableChangeType_Removed, OldValue: "2"},
+				{Key: "C", Type: VariableChangeType_Added, NewValue: "3"},
+			},
+		},
+		{
+			name: "secret values are not included",
+			from: EnvironmentGroup{SecretVariables: map[string][]byte{"S": []byte("old"), "R": []byte("removed")}},
+			to:   EnvironmentGroup{SecretVariables: map[string][]byte{"S": []byte("new"), "N": []byte("added")}},
+			want: []VariableChange{
+				{Key: "N", Secret: true, Type: VariableChangeType_Added},
+				{Key: "R", Secret: true, Type: VariableChangeType_Removed},
+				{Key: "S", Secret: true, Type: VariableChangeType_Changed},
+			},
+		},
+		{
+			name: "variable moved to secret",
+			from: EnvironmentGroup{Variables: map[string]string{"A": "1"}},
+			to:   EnvironmentGroup{SecretVariables: map[string][]byte{"A": []byte("1")}},
+			want: []VariableChange{
+				{Key: "A", Type: VariableChangeType_Removed, OldValue: "1"},
+				{Key: "A", Secret: true, Type: VariableChangeType_Added},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DiffEnvironmentGroups(tt.from, tt.to))
+		})
+	}
+}
+
+func TestDiffEnvironmentGroupVersions(t *testing.T) {
+	ctx := context.Background()
+	agent := kubernetes.GetAgentTesting()
+	createVersions(t, agent, 3)
+
+	versions, err := EnvironmentGroupVersions(ctx, agent, "app-env")
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+
+	// versions are ordered newest first
+	changes := DiffEnvironmentGroups(versions[2], versions[0])
+
+	assert.Equal(t, []VariableChange{
+		{Key: "TOKEN", Secret: true, Type: VariableChangeType_Changed},
+		{Key: "VERSION", Type: VariableChangeType_Changed, OldValue: "1", NewValue: "3"},
+	}, changes)
+}
+
+func TestRollbackEnvironmentGroup(t *testing.T) {
+	tests := []struct {
+		name            string
+		count           int
+		rollbackVersion int
+		wantErr         bool
+		wantVersion     int
+	}{
+		{
+			name:            "rollback to previous version",
+			count:           3,
+			rollbackVersion: 2,
+			wantVersion:     4,
+		},
+		{
+			name:            "rollback to first version",
+			count:           3,
+			rollbackVersion: 1,
+			wantVersion:     4,
+		},
+		{
+			name:            "rollback to latest version",
+			count:           2,
+			rollbackVersion: 2,
+			wantVersion:     3,
+		},
+		{
+			name:            "missing version",
+			count:           2,
+			rollbackVersion: 5,
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			agent := kubernetes.GetAgentTesting()
+			createVersions(t, agent, tt.count)
+
+			rolledBack, err := RollbackEnvironmentGroup(ctx, agent, 1, "app-env", tt.rollbackVersion)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				versions, err := EnvironmentGroupVersions(ctx, agent, "app-env")
+				require.NoError(t, err)
+				assert.Len(t, versions, tt.count)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantVersion, rolledBack.Version)
+			assert.Equal(t, map[string]string{"VERSION": strconv.Itoa(tt.rollbackVersion)}, rolledBack.Variables)
+			assert.Equal(t, []byte("token-"+strconv.Itoa(tt.rollbackVersion)), rolledBack.SecretVariables["TOKEN"])
+
+			// previous versions are kept unchanged
+			versions, err := EnvironmentGroupVersions(ctx, agent, "app-env")
+			require.NoError(t, err)
+			require.Len(t, versions, tt.count+1)
+
+			for _, version := range versions[1:] {
+				assert.Equal(t, map[string]string{"VERSION": strconv.Itoa(version.Version)}, version.Variables)
+			}
+		})
+	}
+}
+
+func TestPruneEnvironmentGroupVersions(t *testing.T) {
+	tests := []struct {
+		name               string
+		count              int
+		retain             int
+		referencedVersions map[int]bool
+		workloadVersion    int
+		wantPruned         []int
+		wantVersions       []int
+	}{
+		{
+			name:         "retention disabled",
+			count:        3,
+			retain:       0,
+			wantVersions: []int{3, 2, 1},
+		},
+		{
+			name:         "fewer versions than retained",
+			count:        2,
+			retain:       3,
+			wantVersions: []int{2, 1},
+		},
+		{
+			name:         "exactly the retained versions",
+			count:        3,
+			retain:       3,
+			wantVersions: []int{3, 2, 1},
+		},
+		{
+			name:         "one version past retention",
+			count:        4,
+			retain:       3,
+			wantPruned:   []int{1},
+			wantVersions: []int{4, 3, 2},
+		},
+		{
+			name:         "retain only the latest version",
+			count:        3,
+			retain:       1,
+			wantPruned:   []int{2, 1},
+			wantVersions: []int{3},
+		},
+		{
+			name:               "versions referenced by app revisions are kept",
+			count:              5,
+			retain:             2,
+			referencedVersions: map[int]bool{1: true},
+			wantPruned:         []int{3, 2},
+			wantVersions:       []int{5, 4, 1},
+		},
+		{
+			name:            "versions loaded by workloads are kept",
+			count:           5,
+			retain:          2,
+			workloadVersion: 2,
+			wantPruned:      []int{3, 1},
+			wantVersions:    []int{5, 4, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			agent := kubernetes.GetAgentTesting()
+			createVersions(t, agent, tt.count)
+
+			if tt.workloadVersion != 0 {
+				_, err := agent.Clientset.AppsV1().Deployments("default").Create(ctx, &appsv1.Deployment{
+					ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+					Spec: appsv1.DeploymentSpec{
+						Template: v1.PodTemplateSpec{
+							Spec: v1.PodSpec{
+								Containers: []v1.Container{{
+									Name: "web",
+									EnvFrom: []v1.EnvFromSource{{
+										ConfigMapRef: &v1.ConfigMapEnvSource{
+											LocalObjectReference: v1.LocalObjectReference{Name: "app-env." + strconv.Itoa(tt.workloadVersion)},
+										},
+									}},
+								}},
+							},
+						},
+					},
+				}, metav1.CreateOptions{})
+				require.NoError(t, err)
+			}
+
+			pruned, err := PruneEnvironmentGroupVersions(ctx, agent, PruneEnvironmentGroupVersionsInput{
+				Name:               "app-env",
+				Retain:             tt.retain,
+				ReferencedVersions: tt.referencedVersions,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPruned, pruned)
+
+			versions, err := EnvironmentGroupVersions(ctx, agent, "app-env")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantVersions, versionNumbers(versions))
+
+			// pruned versions are deleted along with their secret variables
+			secrets, err := agent.Clientset.CoreV1().Secrets(Namespace_EnvironmentGroups).List(ctx, metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Len(t, secrets.Items, len(tt.wantVersions))
+		})
+	}
+}
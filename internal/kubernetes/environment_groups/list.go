@@ -17,6 +17,10 @@ const (
 	LabelKey_EnvironmentGroupVersion = "porter.run/environment-group-version"
 	LabelKey_EnvironmentGroupName    = "porter.run/environment-group-name"
 
+	// AnnotationKey_ExternalSecretsChecksum is a hash of the resolved values of all external secret references in an environment group version.
+	// It is used to detect when upstream values have changed and a new version should be created
+	AnnotationKey_ExternalSecretsChecksum = "porter.run/external-secrets-checksum"
+
 	// Namespace_EnvironmentGroups is the base namespace for storing all environment groups.
 	// The configmaps and secrets here should be considered the source's of truth for a given version
 	Namespace_EnvironmentGroups = "porter-env-group"
@@ -34,6 +38,9 @@ type EnvironmentGroup struct {
 	SecretVariables map[string][]byte `json:"variables_secrets,omitempty"`
 	// CreatedAt is only used for display purposes and is in UTC Unix time
 	CreatedAtUTC time.Time `json:"created_at"`
+	// ExternalSecretsChecksum is the checksum of the resolved external secret references at the time this version was created.
+	// This can be found in the annotations (AnnotationKey_ExternalSecretsChecksum) of the ConfigMap
+	ExternalSecretsChecksum string `json:"-"`
 }
 
 type environmentGroupOptions struct {
@@ -125,11 +132,12 @@ func ListEnvironmentGroups(ctx context.Context, a *kubernetes.Agent, listOpts ..
 			envGroupSet[cm.Name] = EnvironmentGroup{}
 		}
 		envGroupSet[cm.Name] = EnvironmentGroup{
-			Name:            name,
-			Version:         version,
-			Variables:       cm.Data,
-			SecretVariables: envGroupSet[cm.Name].SecretVariables,
-			CreatedAtUTC:    cm.CreationTimestamp.Time.UTC(),
+			Name:                    name,
+			Version:                 version,
+			Variables:               cm.Data,
+			SecretVariables:         envGroupSet[cm.Name].SecretVariables,
+			CreatedAtUTC:            cm.CreationTimestamp.Time.UTC(),
+			ExternalSecretsChecksum: cm.Annotations[AnnotationKey_ExternalSecretsChecksum],
 		}
 	}
 
@@ -150,11 +158,12 @@ func ListEnvironmentGroups(ctx context.Context, a *kubernetes.Agent, listOpts ..
 			envGroupSet[secret.Name] = EnvironmentGroup{}
 		}
 		envGroupSet[secret.Name] = EnvironmentGroup{
-			Name:            name,
-			Version:         version,
-			SecretVariables: secret.Data,
-			Variables:       envGroupSet[secret.Name].Variables,
-			CreatedAtUTC:    secret.CreationTimestamp.Time.UTC(),
+			Name:                    name,
+			Version:                 version,
+			SecretVariables:         secret.Data,
+			Variables:               envGroupSet[secret.Name].Variables,
+			CreatedAtUTC:            secret.CreationTimestamp.Time.UTC(),
+			ExternalSecretsChecksum: envGroupSet[secret.Name].ExternalSecretsChecksum,
 		}
 	}
 
@@ -13,6 +13,8 @@ import (
 type SyncLatestVersionToNamespaceInput struct {
 	BaseEnvironmentGroupName string
 	TargetNamespace          string
+	// ProjectID is the project which owns the cluster, and is used to resolve external secret references
+	ProjectID uint
 }
 
 // SyncLatestVersionToNamespaceOutput returns the literal configmap name (as opposed to the environment group name) which can be used in kubernetes manifests
@@ -65,7 +67,13 @@ func SyncLatestVersionToNamespace(ctx context.Context, a *kubernetes.Agent, inp
 		}, nil
 	}
 
-	targetConfigmapName, err := createEnvironmentGroupInTargetNamespace(ctx, a, inp.TargetNamespace, baseEnvironmentGroup)
+	// external secret references are only resolved in the target namespace, so resolved values are never stored in porter-env-group
+	resolvedEnvironmentGroup, _, err := resolveExternalSecrets(ctx, inp.ProjectID, baseEnvironmentGroup)
+	if err != nil {
+		return output, telemetry.Error(ctx, span, err, "unable to resolve external secret references")
+	}
+
+	targetConfigmapName, err := createEnvironmentGroupInTargetNamespace(ctx, a, inp.TargetNamespace, resolvedEnvironmentGroup)
 	if err != nil {
 		return output, telemetry.Error(ctx, span, err, "unable to create environment group in target namespace")
 	}
@@ -0,0 +1,536 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes/domain"
+	"github.com/porter-dev/porter/internal/kubernetes/nodes"
+	porter_agent "github.com/porter-dev/porter/internal/kubernetes/porter_agent/v2"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	"github.com/porter-dev/porter/internal/opa"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// PendingPodThreshold is how long a schedulable pod can be pending before it is reported
+	PendingPodThreshold = 10 * time.Minute
+
+	// NodeRequestsWarningPercent is the percentage of a node's allocatable cpu or memory which can be requested
+	// before the node is reported as nearly full
+	NodeRequestsWarningPercent = 95
+
+	// VolumeUsageWarningFraction and VolumeUsageCriticalFraction are the fractions of a persistent volume's capacity
+	// which can be used before the volume is reported
+	VolumeUsageWarningFraction  = 0.8
+	VolumeUsageCriticalFraction = 0.95
+
+	certificatesCategory = "certificates"
+)
+
+// RecommendationRunner runs OPA policies against a cluster
+type RecommendationRunner interface {
+	GetRecommendations(categories []string) ([]*opa.OPARecommenderQueryResult, error)
+}
+
+// ReportInput contains the clients used to check the health of a cluster
+type ReportInput struct {
+	Clientset kubernetes.Interface
+
+	// PolicyRunner runs the OPA certificate policies. The certificates check is skipped if it is nil
+	PolicyRunner RecommendationRunner
+}
+
+type check struct {
+	name string
+	run  func(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error)
+}
+
+var checks = []check{
+	{name: "nodes", run: checkNodes},
+	{name: "version_skew", run: checkVersionSkew},
+	{name: "pending_pods", run: checkPendingPods},
+	{name: "daemonsets", run: checkDaemonSets},
+	{name: "volumes", run: checkVolumes},
+	{name: "agent", run: checkAgent},
+	{name: "ingress", run: checkIngress},
+	{name: "prometheus", run: checkPrometheus},
+	{name: "certificates", run: checkCertificates},
+}
+
+// GetReport runs every health check against the cluster. Checks run concurrently, and a check that cannot be run
+// is reported with an error rather than failing the whole report.
+func GetReport(ctx context.Context, inp ReportInput) *types.GetClusterHealthResponse {
+	res := &types.GetClusterHealthResponse{
+		Healthy: true,
+		Checks:  make([]types.ClusterHealthCheck, len(checks)),
+	}
+
+	var wg sync.WaitGroup
+
+	for i := range checks {
+		index := i
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			findings, err := checks[index].run(ctx, inp)
+
+			res.Checks[index] = types.ClusterHealthCheck{
+				Name:     checks[index].name,
+				Findings: []types.ClusterHealthFinding{},
+			}
+
+			if err != nil {
+				res.Checks[index].Error = err.Error()
+				return
+			}
+
+			if findings != nil {
+				res.Checks[index].Findings = findings
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for _, check := range res.Checks {
+		if check.Error != "" {
+			res.Healthy = false
+		}
+
+		for _, finding := range check.Findings {
+			if finding.Severity != types.ClusterHealthSeverityInfo {
+				res.Healthy = false
+			}
+		}
+	}
+
+	return res
+}
+
+func checkNodes(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	return nodeFindings(nodes.GetNodesUsage(inp.Clientset)), nil
+}
+
+func nodeFindings(nodeList []*nodes.NodeWithUsageData) []types.ClusterHealthFinding {
+	findings := []types.ClusterHealthFinding{}
+
+	for _, node := range nodeList {
+		if node == nil {
+			continue
+		}
+
+		object := "node/" + node.Name
+
+		for _, cond := range node.Condition {
+			switch {
+			case cond.Type == v1.NodeReady && cond.Status != v1.ConditionTrue:
+				findings = append(findings, types.ClusterHealthFinding{
+					Severity:    types.ClusterHealthSeverityCritical,
+					Object:      object,
+					Message:     fmt.Sprintf("Node is not ready: %s", conditionMessage(cond.Reason, cond.Message)),
+					Remediation: "Check the kubelet on the node, or replace the node by draining it and letting the node group create a new one",
+				})
+			case cond.Type == v1.NodeNetworkUnavailable && cond.Status == v1.ConditionTrue:
+				findings = append(findings, types.ClusterHealthFinding{
+					Severity:    types.ClusterHealthSeverityCritical,
+					Object:      object,
+					Message:     fmt.Sprintf("Node network is unavailable: %s", conditionMessage(cond.Reason, cond.Message)),
+					Remediation: "Check the CNI pods running on the node",
+				})
+			case (cond.Type == v1.NodeMemoryPressure || cond.Type == v1.NodeDiskPressure || cond.Type == v1.NodePIDPressure) &&
+				cond.Status == v1.ConditionTrue:
+				findings = append(findings, types.ClusterHealthFinding{
+					Severity:    types.ClusterHealthSeverityWarning,
+					Object:      object,
+					Message:     fmt.Sprintf("Node has condition %s: %s", cond.Type, conditionMessage(cond.Reason, cond.Message)),
+					Remediation: "Set resource limits on the workloads running on the node, or use larger nodes",
+				})
+			}
+		}
+
+		if node.FractionCpuReqs >= NodeRequestsWarningPercent || node.FractionMemoryReqs >= NodeRequestsWarningPercent {
+			findings = append(findings, types.ClusterHealthFinding{
+				Severity: types.ClusterHealthSeverityInfo,
+				Object:   object,
+				Message: fmt.Sprintf("Node is nearly fully requested (%.0f%% of cpu and %.0f%% of memory)",
+					node.FractionCpuReqs, node.FractionMemoryReqs),
+				Remediation: "Raise the maximum size of the node group so that new pods can be scheduled",
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkVersionSkew(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	serverVersion, err := inp.Clientset.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("error getting api server version: %w", err)
+	}
+
+	nodeList, err := inp.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	return versionSkewFindings(serverVersion.GitVersion, nodeList.Items)
+}
+
+func versionSkewFindings(serverGitVersion string, nodeList []v1.Node) ([]types.ClusterHealthFinding, error) {
+	serverVersion, err := version.ParseGeneric(serverGitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing api server version: %w", err)
+	}
+
+	// kubelets can be up to three minor versions older than the api server from 1.28, and two minor versions before
+	maxSkew := 2
+	if serverVersion.AtLeast(version.MustParseGeneric("1.28")) {
+		maxSkew = 3
+	}
+
+	findings := []types.ClusterHealthFinding{}
+
+	for _, node := range nodeList {
+		kubeletVersion, err := version.ParseGeneric(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			continue
+		}
+
+		object := "node/" + node.Name
+		skew := int(serverVersion.Minor()) - int(kubeletVersion.Minor())
+
+		switch {
+		case kubeletVersion.Major() != serverVersion.Major() || skew < 0:
+			findings = append(findings, types.ClusterHealthFinding{
+				Severity: types.ClusterHealthSeverityCritical,
+				Object:   object,
+				Message: fmt.Sprintf("Kubelet version %s is newer than the api server version %s, which is not supported",
+					node.Status.NodeInfo.KubeletVersion, serverGitVersion),
+				Remediation: "Upgrade the control plane before upgrading node groups",
+			})
+		case skew > maxSkew:
+			findings = append(findings, types.ClusterHealthFinding{
+				Severity: types.ClusterHealthSeverityWarning,
+				Object:   object,
+				Message: fmt.Sprintf("Kubelet version %s is %d minor versions behind the api server version %s, more than the supported %d",
+					node.Status.NodeInfo.KubeletVersion, skew, serverGitVersion, maxSkew),
+				Remediation: "Upgrade the node group to the control plane version",
+			})
+		case skew > 0:
+			findings = append(findings, types.ClusterHealthFinding{
+				Severity: types.ClusterHealthSeverityInfo,
+				Object:   object,
+				Message: fmt.Sprintf("Kubelet version %s is behind the api server version %s",
+					node.Status.NodeInfo.KubeletVersion, serverGitVersion),
+				Remediation: "Upgrade the node group to the control plane version before the next control plane upgrade",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func checkPendingPods(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	podList, err := inp.Clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "status.phase=Pending",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pending pods: %w", err)
+	}
+
+	return pendingPodFindings(podList.Items, time.Now()), nil
+}
+
+func pendingPodFindings(pods []v1.Pod, now time.Time) []types.ClusterHealthFinding {
+	findings := []types.ClusterHealthFinding{}
+
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodPending {
+			continue
+		}
+
+		object := fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name)
+
+		unschedulable := false
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == v1.PodScheduled && cond.Status == v1.ConditionFalse && cond.Reason == v1.PodReasonUnschedulable {
+				unschedulable = true
+
+				findings = append(findings, types.ClusterHealthFinding{
+					Severity:    types.ClusterHealthSeverityWarning,
+					Object:      object,
+					Message:     fmt.Sprintf("Pod cannot be scheduled: %s", cond.Message),
+					Remediation: "Lower the pod's resource requests, check its node selectors and tolerations, or raise the maximum size of the node group",
+				})
+			}
+		}
+
+		if !unschedulable && now.Sub(pod.CreationTimestamp.Time) > PendingPodThreshold {
+			findings = append(findings, types.ClusterHealthFinding{
+				Severity: types.ClusterHealthSeverityWarning,
+				Object:   object,
+				Message: fmt.Sprintf("Pod has been pending for %s",
+					now.Sub(pod.CreationTimestamp.Time).Truncate(time.Minute)),
+				Remediation: fmt.Sprintf("Run kubectl describe pod -n %s %s to check for image pull or volume mount errors", pod.Namespace, pod.Name),
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkDaemonSets(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	dsList, err := inp.Clientset.AppsV1().DaemonSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing daemonsets: %w", err)
+	}
+
+	return daemonSetFindings(dsList.Items), nil
+}
+
+func daemonSetFindings(daemonSets []appsv1.DaemonSet) []types.ClusterHealthFinding {
+	findings := []types.ClusterHealthFinding{}
+
+	for _, ds := range daemonSets {
+		desired := ds.Status.DesiredNumberScheduled
+		ready := ds.Status.NumberReady
+
+		if desired == 0 || ready >= desired {
+			continue
+		}
+
+		severity := types.ClusterHealthSeverityWarning
+		if ready == 0 {
+			severity = types.ClusterHealthSeverityCritical
+		}
+
+		findings = append(findings, types.ClusterHealthFinding{
+			Severity:    severity,
+			Object:      fmt.Sprintf("daemonset/%s/%s", ds.Namespace, ds.Name),
+			Message:     fmt.Sprintf("%d of %d pods are ready", ready, desired),
+			Remediation: fmt.Sprintf("Run kubectl get pods -n %s to find the failing pods of the daemonset and check their logs", ds.Namespace),
+		})
+	}
+
+	return findings
+}
+
+// nodeStatsSummary is the subset of the kubelet stats summary needed to read volume usage
+type nodeStatsSummary struct {
+	Pods []struct {
+		Volumes []volumeStats `json:"volume"`
+	} `json:"pods"`
+}
+
+type volumeStats struct {
+	UsedBytes     *uint64 `json:"usedBytes"`
+	CapacityBytes *uint64 `json:"capacityBytes"`
+	PVCRef        *struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"pvcRef"`
+}
+
+func checkVolumes(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	nodeList, err := inp.Clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	volumes := []volumeStats{}
+
+	for _, node := range nodeList.Items {
+		raw, err := inp.Clientset.CoreV1().RESTClient().Get().
+			Resource("nodes").
+			Name(node.Name).
+			SubResource("proxy").
+			Suffix("stats/summary").
+			DoRaw(ctx)
+		if err != nil {
+			// nodes which are not ready cannot report stats, and are reported by the nodes check
+			continue
+		}
+
+		summary := &nodeStatsSummary{}
+		if err := json.Unmarshal(raw, summary); err != nil {
+			return nil, fmt.Errorf("error parsing stats summary for node %s: %w", node.Name, err)
+		}
+
+		for _, pod := range summary.Pods {
+			volumes = append(volumes, pod.Volumes...)
+		}
+	}
+
+	return volumeFindings(volumes), nil
+}
+
+func volumeFindings(volumes []volumeStats) []types.ClusterHealthFinding {
+	findings := []types.ClusterHealthFinding{}
+
+	// a volume mounted by several pods is reported once
+	seen := make(map[string]bool)
+
+	for _, vol := range volumes {
+		if vol.PVCRef == nil || vol.UsedBytes == nil || vol.CapacityBytes == nil || *vol.CapacityBytes == 0 {
+			continue
+		}
+
+		object := fmt.Sprintf("persistentvolumeclaim/%s/%s", vol.PVCRef.Namespace, vol.PVCRef.Name)
+		if seen[object] {
+			continue
+		}
+		seen[object] = true
+
+		used := float64(*vol.UsedBytes) / float64(*vol.CapacityBytes)
+
+		severity := types.ClusterHealthSeverityWarning
+		switch {
+		case used >= VolumeUsageCriticalFraction:
+			severity = types.ClusterHealthSeverityCritical
+		case used < VolumeUsageWarningFraction:
+			continue
+		}
+
+		findings = append(findings, types.ClusterHealthFinding{
+			Severity:    severity,
+			Object:      object,
+			Message:     fmt.Sprintf("Volume is %.0f%% full", used*100),
+			Remediation: "Expand the persistent volume claim, or remove unused data from the volume",
+		})
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Object < findings[j].Object
+	})
+
+	return findings
+}
+
+func checkAgent(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	agentSvc, err := porter_agent.GetAgentService(inp.Clientset)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return []types.ClusterHealthFinding{
+				{
+					Severity:    types.ClusterHealthSeverityWarning,
+					Message:     "The Porter agent is not installed, so logs, events and incidents are not collected",
+					Remediation: "Install the Porter agent from the cluster settings in the dashboard",
+				},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("error getting agent service: %w", err)
+	}
+
+	if _, err := porter_agent.GetAgentStatus(inp.Clientset, agentSvc); err != nil {
+		return []types.ClusterHealthFinding{
+			{
+				Severity:    types.ClusterHealthSeverityWarning,
+				Object:      fmt.Sprintf("service/%s/%s", agentSvc.Namespace, agentSvc.Name),
+				Message:     fmt.Sprintf("The Porter agent is not responding: %s", err.Error()),
+				Remediation: "Check the pods in the porter-agent-system namespace, or upgrade the agent from the cluster settings in the dashboard",
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func checkIngress(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	_, found, err := domain.GetNGINXIngressServiceIP(inp.Clientset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting ingress load balancer: %w", err)
+	}
+
+	if !found {
+		return []types.ClusterHealthFinding{
+			{
+				Severity:    types.ClusterHealthSeverityWarning,
+				Message:     "No NGINX ingress load balancer with an external address was found, so applications cannot be reached from the internet",
+				Remediation: "Check that the nginx-ingress service in the ingress-nginx namespace has been assigned an address by the cloud provider",
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func checkPrometheus(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	_, found, err := prometheus.GetPrometheusService(inp.Clientset)
+	if err != nil {
+		return nil, fmt.Errorf("error getting prometheus service: %w", err)
+	}
+
+	if !found {
+		return []types.ClusterHealthFinding{
+			{
+				Severity:    types.ClusterHealthSeverityWarning,
+				Message:     "Prometheus is not installed, so metrics and autoscaling on custom metrics are unavailable",
+				Remediation: "Install the prometheus add-on in the monitoring namespace",
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func checkCertificates(ctx context.Context, inp ReportInput) ([]types.ClusterHealthFinding, error) {
+	if inp.PolicyRunner == nil {
+		return nil, fmt.Errorf("certificate policies are not configured on this Porter instance")
+	}
+
+	results, err := inp.PolicyRunner.GetRecommendations([]string{certificatesCategory})
+	if err != nil {
+		return nil, fmt.Errorf("error running certificate policies: %w", err)
+	}
+
+	return policyFindings(results), nil
+}
+
+func policyFindings(results []*opa.OPARecommenderQueryResult) []types.ClusterHealthFinding {
+	findings := []types.ClusterHealthFinding{}
+
+	for _, result := range results {
+		if result.Allow {
+			continue
+		}
+
+		severity := types.ClusterHealthSeverityInfo
+		switch result.PolicySeverity {
+		case "critical":
+			severity = types.ClusterHealthSeverityCritical
+		case "high":
+			severity = types.ClusterHealthSeverityWarning
+		}
+
+		findings = append(findings, types.ClusterHealthFinding{
+			Severity:    severity,
+			Object:      result.ObjectID,
+			Message:     fmt.Sprintf("%s: %s", result.PolicyTitle, result.PolicyMessage),
+			Remediation: "Check the status of the certificate and its issuer with kubectl describe certificate, and check the cert-manager logs",
+		})
+	}
+
+	return findings
+}
+
+func conditionMessage(reason, message string) string {
+	if message == "" {
+		return reason
+	}
+
+	return message
+}
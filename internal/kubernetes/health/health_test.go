@@ -0,0 +1,166 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func nodeWithKubelet(name, kubeletVersion string) v1.Node {
+	return v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: v1.NodeStatus{
+			NodeInfo: v1.NodeSystemInfo{KubeletVersion: kubeletVersion},
+		},
+	}
+}
+
+func TestVersionSkewFindings(t *testing.T) {
+	findings, err := versionSkewFindings("v1.27.4-eks-2d98532", []v1.Node{
+		nodeWithKubelet("current", "v1.27.3-eks-a5565ad"),
+		nodeWithKubelet("behind", "v1.26.7-eks-a5565ad"),
+		nodeWithKubelet("too-old", "v1.24.0"),
+		nodeWithKubelet("newer", "v1.28.1"),
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 3)
+
+	assert.Equal(t, "node/behind", findings[0].Object)
+	assert.Equal(t, types.ClusterHealthSeverityInfo, findings[0].Severity)
+
+	assert.Equal(t, "node/too-old", findings[1].Object)
+	assert.Equal(t, types.ClusterHealthSeverityWarning, findings[1].Severity)
+
+	assert.Equal(t, "node/newer", findings[2].Object)
+	assert.Equal(t, types.ClusterHealthSeverityCritical, findings[2].Severity)
+
+	// from 1.28, kubelets can be three minor versions behind
+	findings, err = versionSkewFindings("v1.28.0", []v1.Node{nodeWithKubelet("behind", "v1.25.0")})
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, types.ClusterHealthSeverityInfo, findings[0].Severity)
+
+	_, err = versionSkewFindings("unknown", nil)
+	assert.Error(t, err)
+}
+
+func TestPendingPodFindings(t *testing.T) {
+	now := time.Now()
+
+	pods := []v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unschedulable", Namespace: "default", CreationTimestamp: metav1.NewTime(now)},
+			Status: v1.PodStatus{
+				Phase: v1.PodPending,
+				Conditions: []v1.PodCondition{
+					{
+						Type:    v1.PodScheduled,
+						Status:  v1.ConditionFalse,
+						Reason:  v1.PodReasonUnschedulable,
+						Message: "0/3 nodes are available: 3 Insufficient cpu.",
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-time.Hour))},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "starting", Namespace: "default", CreationTimestamp: metav1.NewTime(now.Add(-time.Minute))},
+			Status:     v1.PodStatus{Phase: v1.PodPending},
+		},
+	}
+
+	findings := pendingPodFindings(pods, now)
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, "pod/default/unschedulable", findings[0].Object)
+	assert.Contains(t, findings[0].Message, "Insufficient cpu")
+	assert.NotEmpty(t, findings[0].Remediation)
+
+	assert.Equal(t, "pod/default/stuck", findings[1].Object)
+	assert.Equal(t, "Pod has been pending for 1h0m0s", findings[1].Message)
+}
+
+func TestDaemonSetFindings(t *testing.T) {
+	daemonSets := []appsv1.DaemonSet{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "healthy", Namespace: "kube-system"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "degraded", Namespace: "kube-system"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "down", Namespace: "kube-system"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "unscheduled", Namespace: "kube-system"},
+		},
+	}
+
+	findings := daemonSetFindings(daemonSets)
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, "daemonset/kube-system/degraded", findings[0].Object)
+	assert.Equal(t, types.ClusterHealthSeverityWarning, findings[0].Severity)
+	assert.Equal(t, "2 of 3 pods are ready", findings[0].Message)
+
+	assert.Equal(t, "daemonset/kube-system/down", findings[1].Object)
+	assert.Equal(t, types.ClusterHealthSeverityCritical, findings[1].Severity)
+}
+
+func volume(namespace, name string, used, capacity uint64) volumeStats {
+	vol := volumeStats{
+		UsedBytes:     &used,
+		CapacityBytes: &capacity,
+	}
+
+	vol.PVCRef = &struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	}{Name: name, Namespace: namespace}
+
+	return vol
+}
+
+func TestVolumeFindings(t *testing.T) {
+	findings := volumeFindings([]volumeStats{
+		volume("default", "full", 97, 100),
+		volume("default", "full", 97, 100),
+		volume("default", "filling", 85, 100),
+		volume("default", "empty", 10, 100),
+		{},
+	})
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, "persistentvolumeclaim/default/filling", findings[0].Object)
+	assert.Equal(t, types.ClusterHealthSeverityWarning, findings[0].Severity)
+	assert.Equal(t, "Volume is 85% full", findings[0].Message)
+
+	assert.Equal(t, "persistentvolumeclaim/default/full", findings[1].Object)
+	assert.Equal(t, types.ClusterHealthSeverityCritical, findings[1].Severity)
+}
+
+func TestPolicyFindings(t *testing.T) {
+	findings := policyFindings([]*opa.OPARecommenderQueryResult{
+		{Allow: true, PolicySeverity: "critical", ObjectID: "valid"},
+		{Allow: false, PolicySeverity: "critical", ObjectID: "expired", PolicyTitle: "Certificate should not be expired", PolicyMessage: "Certificate expired"},
+		{Allow: false, PolicySeverity: "high", ObjectID: "expiring"},
+	})
+	require.Len(t, findings, 2)
+
+	assert.Equal(t, types.ClusterHealthSeverityCritical, findings[0].Severity)
+	assert.Equal(t, "Certificate should not be expired: Certificate expired", findings[0].Message)
+	assert.Equal(t, types.ClusterHealthSeverityWarning, findings[1].Severity)
+}
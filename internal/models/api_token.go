@@ -1,9 +1,12 @@
 package models
 
 import (
+	"net"
+	"strings"
 	"time"
 
 	"github.com/porter-dev/porter/api/types"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -22,6 +25,19 @@ type APIToken struct {
 
 	// SecretKey is hashed like a password before storage
 	SecretKey []byte
+
+	// PreviousSecretKey is the hashed secret key that was replaced during the last
+	// rotation. It is accepted until PreviousSecretExpiry.
+	PreviousSecretKey    []byte
+	PreviousSecretExpiry *time.Time
+
+	// LastUsedAt and LastUsedIP are recorded by the authentication middleware
+	LastUsedAt *time.Time
+	LastUsedIP string
+
+	// AllowedCIDRs is a comma-separated list of CIDR blocks that requests using this
+	// token must originate from. An empty list allows all addresses.
+	AllowedCIDRs string
 }
 
 func (p *APIToken) IsExpired() bool {
@@ -29,14 +45,75 @@ func (p *APIToken) IsExpired() bool {
 	return timeLeft < 0
 }
 
+// VerifySecret checks the unhashed secret against the current secret key, and against
+// the previous secret key if the rotation grace period has not elapsed.
+func (p *APIToken) VerifySecret(secret string) bool {
+	if bcrypt.CompareHashAndPassword(p.SecretKey, []byte(secret)) == nil {
+		return true
+	}
+
+	if len(p.PreviousSecretKey) == 0 || p.PreviousSecretExpiry == nil || p.PreviousSecretExpiry.Before(time.Now()) {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(p.PreviousSecretKey, []byte(secret)) == nil
+}
+
+// GetAllowedCIDRs returns the list of CIDR blocks that this token is restricted to
+func (p *APIToken) GetAllowedCIDRs() []string {
+	res := make([]string, 0)
+
+	for _, cidr := range strings.Split(p.AllowedCIDRs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr != "" {
+			res = append(res, cidr)
+		}
+	}
+
+	return res
+}
+
+// IsAllowedIP returns true if the token has no CIDR restrictions, or if the ip address
+// is contained in one of the allowed CIDR blocks
+func (p *APIToken) IsAllowedIP(ip string) bool {
+	cidrs := p.GetAllowedCIDRs()
+
+	if len(cidrs) == 0 {
+		return true
+	}
+
+	parsedIP := net.ParseIP(ip)
+
+	if parsedIP == nil {
+		return false
+	}
+
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			continue
+		}
+
+		if ipNet.Contains(parsedIP) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (p *APIToken) ToAPITokenMetaType() *types.APITokenMeta {
 	return &types.APITokenMeta{
-		ID:         p.UniqueID,
-		CreatedAt:  p.CreatedAt,
-		ExpiresAt:  *p.Expiry,
-		PolicyName: p.PolicyName,
-		PolicyUID:  p.PolicyUID,
-		Name:       p.Name,
+		ID:                   p.UniqueID,
+		CreatedAt:            p.CreatedAt,
+		ExpiresAt:            *p.Expiry,
+		PolicyName:           p.PolicyName,
+		PolicyUID:            p.PolicyUID,
+		Name:                 p.Name,
+		Revoked:              p.Revoked,
+		LastUsedAt:           p.LastUsedAt,
+		LastUsedIP:           p.LastUsedIP,
+		AllowedCIDRs:         p.GetAllowedCIDRs(),
+		PreviousSecretExpiry: p.PreviousSecretExpiry,
 	}
 }
 
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AppSleepSchedule scales the web and worker services of the apps in a deployment target to zero outside
+// of the hours during which they are awake
+type AppSleepSchedule struct {
+	gorm.Model
+
+	ProjectID          uint `gorm:"index"`
+	ClusterID          uint
+	DeploymentTargetID string `gorm:"index"`
+
+	// AppName limits the schedule to a single app. Schedules without an app name apply to every app in
+	// the deployment target which does not have a schedule of its own.
+	AppName string
+
+	// Days is a comma-separated list of the days on which the apps are awake, such as "mon,tue,wed"
+	Days string
+	// WakeTime and SleepTime are times of day in the HH:MM format
+	WakeTime  string
+	SleepTime string
+	Timezone  string
+}
+
+// ToAppSleepScheduleType generates an external types.AppSleepSchedule to be shared over REST
+func (s *AppSleepSchedule) ToAppSleepScheduleType() *types.AppSleepSchedule {
+	days := []string{}
+
+	if s.Days != "" {
+		days = strings.Split(s.Days, ",")
+	}
+
+	return &types.AppSleepSchedule{
+		ID:                 s.ID,
+		ProjectID:          s.ProjectID,
+		ClusterID:          s.ClusterID,
+		DeploymentTargetID: s.DeploymentTargetID,
+		AppName:            s.AppName,
+		Days:               days,
+		WakeTime:           s.WakeTime,
+		SleepTime:          s.SleepTime,
+		Timezone:           s.Timezone,
+		CreatedAt:          s.CreatedAt,
+	}
+}
+
+// AppSleepState is the sleep state of an app in a deployment target
+type AppSleepState struct {
+	gorm.Model
+
+	ProjectID          uint   `gorm:"index"`
+	AppName            string `gorm:"index"`
+	DeploymentTargetID string `gorm:"index"`
+
+	Asleep bool
+
+	// OriginalScale stores the instance counts and autoscaling settings of the services of the app before
+	// it was put to sleep, so that they can be restored when it wakes up
+	OriginalScale []byte
+
+	// OverrideUntil is set when the app was put to sleep or woken up manually. Schedules do not change the
+	// state of the app until then.
+	OverrideUntil *time.Time
+}
+
+// ToAppSleepStateType generates an external types.AppSleepState to be shared over REST
+func (s *AppSleepState) ToAppSleepStateType() *types.AppSleepState {
+	return &types.AppSleepState{
+		AppName:            s.AppName,
+		DeploymentTargetID: s.DeploymentTargetID,
+		Asleep:             s.Asleep,
+		OverrideUntil:      s.OverrideUntil,
+	}
+}
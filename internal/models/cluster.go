@@ -63,6 +63,10 @@ type Cluster struct {
 
 	PreviewEnvsEnabled bool
 
+	// HelmStorage is the storage driver holding the Helm releases of the cluster, either secret or sql.
+	// Releases are kept in secrets if it is empty.
+	HelmStorage string `json:"helm_storage"`
+
 	AWSClusterID string
 
 	// Status defines the current status of the cluster. Accepted values: [READY, UPDATING]
@@ -100,6 +104,15 @@ type Cluster struct {
 
 	// MonitorHelmReleases to trim down the number of revisions per release
 	MonitorHelmReleases bool
+
+	// EnvGroupVersionRetention is the number of most recent environment group versions to keep. Older versions
+	// which are not referenced by an app revision or a running workload are pruned by the env-group-version-pruner
+	// worker job. If 0, all versions are kept
+	EnvGroupVersionRetention uint
+
+	// RecommenderAlertSeverity is the lowest severity of recommender checks which send a notification when they
+	// change from passing to failing. Recommender notifications are disabled if it is empty
+	RecommenderAlertSeverity string
 }
 
 // ToClusterType generates an external types.Cluster to be shared over REST
@@ -128,6 +141,8 @@ func (c *Cluster) ToClusterType() *types.Cluster {
 		AWSIntegrationID:                  c.AWSIntegrationID,
 		AWSClusterID:                      c.AWSClusterID,
 		PreviewEnvsEnabled:                c.PreviewEnvsEnabled,
+		EnvGroupVersionRetention:          c.EnvGroupVersionRetention,
+		RecommenderAlertSeverity:          types.MonitorTestSeverity(c.RecommenderAlertSeverity),
 		Status:                            c.Status,
 		ProvisionedBy:                     c.ProvisionedBy,
 		CloudProvider:                     c.CloudProvider,
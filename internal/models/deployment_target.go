@@ -1,7 +1,11 @@
 package models
 
 import (
+	"encoding/json"
+	"sort"
+
 	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
 
@@ -20,15 +24,79 @@ type DeploymentTarget struct {
 	// ID is a UUID for the Revision
 	ID uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
 
-	// ClusterID is the ID of the cluster that is being targeted.
-	ClusterID int `json:"cluster_id"`
+	// ClusterID is the ID of the cluster that is being targeted. A namespace can only be targeted once in a
+	// cluster, so the cluster and selector of targets which are not deleted are unique.
+	ClusterID int `gorm:"uniqueIndex:idx_deployment_targets_cluster_id_selector,where:deleted_at IS NULL" json:"cluster_id"`
 
 	// ProjectID is the ID of the project that the target belongs to.
 	ProjectID int `json:"project_id"`
 
 	// Selector is the identifier to target.
-	Selector string `json:"selector"`
+	Selector string `gorm:"uniqueIndex:idx_deployment_targets_cluster_id_selector,where:deleted_at IS NULL" json:"selector"`
 
 	// SelectorType is the kind of selector (i.e. NAMESPACE or LABEL).
 	SelectorType DeploymentTargetSelectorType `json:"selector_type"`
+
+	// Name is the user-facing name of the target (i.e. staging or prod-us), unique within a project.
+	// The default target in each cluster does not have a name.
+	Name string `json:"name"`
+
+	// EnvOverrides is the encrypted, json-encoded types.DeploymentTargetEnvOverrides for this target
+	EnvOverrides []byte `json:"env_overrides"`
+}
+
+// GetEnvOverrides returns the decoded env overrides for the target. EnvOverrides must already
+// be decrypted.
+func (d *DeploymentTarget) GetEnvOverrides() (types.DeploymentTargetEnvOverrides, error) {
+	overrides := types.DeploymentTargetEnvOverrides{}
+
+	if len(d.EnvOverrides) == 0 {
+		return overrides, nil
+	}
+
+	err := json.Unmarshal(d.EnvOverrides, &overrides)
+
+	return overrides, err
+}
+
+// SetEnvOverrides encodes the env overrides for the target
+func (d *DeploymentTarget) SetEnvOverrides(overrides types.DeploymentTargetEnvOverrides) error {
+	overridesBytes, err := json.Marshal(overrides)
+	if err != nil {
+		return err
+	}
+
+	d.EnvOverrides = overridesBytes
+
+	return nil
+}
+
+// ToDeploymentTargetType generates an external types.DeploymentTarget to be shared over REST
+func (d *DeploymentTarget) ToDeploymentTargetType() (*types.DeploymentTarget, error) {
+	overrides, err := d.GetEnvOverrides()
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0)
+
+	for key := range overrides.Variables {
+		keys = append(keys, key)
+	}
+
+	for key := range overrides.Secrets {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return &types.DeploymentTarget{
+		ID:              d.ID.String(),
+		Name:            d.Name,
+		ProjectID:       uint(d.ProjectID),
+		ClusterID:       uint(d.ClusterID),
+		Namespace:       d.Selector,
+		CreatedAt:       d.CreatedAt,
+		EnvOverrideKeys: keys,
+	}, nil
 }
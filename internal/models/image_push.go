@@ -0,0 +1,82 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// ImagePushPolicy upgrades a porter app or a helm release when an image matching the policy is
+// pushed to ImageRepository
+type ImagePushPolicy struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+
+	// AppName and DeploymentTargetID identify the porter app to upgrade
+	AppName            string
+	DeploymentTargetID string
+
+	// ReleaseName and Namespace identify the helm release to upgrade, for projects which do not use apps
+	ReleaseName string
+	Namespace   string
+
+	// ImageRepository is the normalized repository that pushes are matched against
+	ImageRepository string `gorm:"index"`
+	Type            string
+	Pattern         string
+
+	// LastTag and LastDigest were last deployed by the policy
+	LastTag    string
+	LastDigest string
+}
+
+// ToImagePushPolicyType generates an external types.ImagePushPolicy to be shared over REST
+func (p *ImagePushPolicy) ToImagePushPolicyType() *types.ImagePushPolicy {
+	return &types.ImagePushPolicy{
+		ID:                 p.ID,
+		ProjectID:          p.ProjectID,
+		ClusterID:          p.ClusterID,
+		AppName:            p.AppName,
+		DeploymentTargetID: p.DeploymentTargetID,
+		ReleaseName:        p.ReleaseName,
+		Namespace:          p.Namespace,
+		ImageRepository:    p.ImageRepository,
+		Type:               types.ImagePushPolicyType(p.Type),
+		Pattern:            p.Pattern,
+		LastTag:            p.LastTag,
+		LastDigest:         p.LastDigest,
+		CreatedAt:          p.CreatedAt,
+	}
+}
+
+// ImagePushEvent records an automatic upgrade triggered by an image push
+type ImagePushEvent struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	PolicyID  uint `gorm:"index"`
+
+	Source          string
+	ImageRepository string
+	Tag             string
+	Digest          string
+	Status          string
+	Message         string
+}
+
+// ToImagePushEventType generates an external types.ImagePushEvent to be shared over REST
+func (e *ImagePushEvent) ToImagePushEventType() *types.ImagePushEvent {
+	return &types.ImagePushEvent{
+		ID:              e.ID,
+		ProjectID:       e.ProjectID,
+		PolicyID:        e.PolicyID,
+		Source:          types.ImagePushSource(e.Source),
+		ImageRepository: e.ImageRepository,
+		Tag:             e.Tag,
+		Digest:          e.Digest,
+		Status:          types.ImagePushEventStatus(e.Status),
+		Message:         e.Message,
+		CreatedAt:       e.CreatedAt,
+	}
+}
@@ -0,0 +1,96 @@
+package integrations
+
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// PagingIntegration routes incidents to an external incident management service, such
+// as PagerDuty or Opsgenie.
+type PagingIntegration struct {
+	gorm.Model
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// The id of the user that created this integration
+	UserID uint `json:"user_id"`
+
+	// A human-readable name for the integration
+	Name string
+
+	// The service that incidents are sent to
+	Provider types.PagingProvider
+
+	// The region of the account with the provider, which determines the API that incidents are sent to
+	Region types.PagingRegion
+
+	// RulesBytes is the JSON-encoded list of routing rules for this integration
+	RulesBytes []byte
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The PagerDuty routing key or Opsgenie API key
+	Key []byte
+}
+
+// GetRules returns the routing rules for this integration
+func (p *PagingIntegration) GetRules() ([]types.IncidentRoutingRule, error) {
+	rules := make([]types.IncidentRoutingRule, 0)
+
+	if len(p.RulesBytes) == 0 {
+		return rules, nil
+	}
+
+	if err := json.Unmarshal(p.RulesBytes, &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// SetRules sets the routing rules for this integration
+func (p *PagingIntegration) SetRules(rules []types.IncidentRoutingRule) error {
+	rulesBytes, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	p.RulesBytes = rulesBytes
+
+	return nil
+}
+
+// PagingIncidentAlert records an alert which was opened for an incident through a paging integration, so that
+// the alert is resolved through the same integration when the incident is resolved
+type PagingIncidentAlert struct {
+	gorm.Model
+
+	PagingIntegrationID uint
+
+	// ClusterID and IncidentID identify the incident, since incident ids are generated by the agent running
+	// in each cluster
+	ClusterID  uint   `gorm:"index:idx_paging_incident_alert"`
+	IncidentID string `gorm:"index:idx_paging_incident_alert"`
+}
+
+func (p *PagingIntegration) ToPagingIntegrationType() (*types.PagingIntegration, error) {
+	rules, err := p.GetRules()
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PagingIntegration{
+		ID:        p.ID,
+		ProjectID: p.ProjectID,
+		Name:      p.Name,
+		Provider:  p.Provider,
+		Region:    p.Region,
+		Rules:     rules,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package integrations
+
+import (
+	"net/url"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// WebhookIntegration is a generic outgoing webhook which receives deployment and incident
+// notifications for a project as signed JSON payloads.
+type WebhookIntegration struct {
+	gorm.Model
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// The id of the user that created this integration
+	UserID uint `json:"user_id"`
+
+	// A human-readable name for the webhook
+	Name string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The URL to send payloads to
+	URL []byte
+
+	// The secret used to compute the HMAC signature of each payload
+	SigningSecret []byte
+}
+
+func (w *WebhookIntegration) ToWebhookIntegrationType() *types.WebhookIntegration {
+	res := &types.WebhookIntegration{
+		ID:        w.ID,
+		ProjectID: w.ProjectID,
+		Name:      w.Name,
+	}
+
+	// only the host is returned, since the full URL may contain credentials
+	if parsedURL, err := url.Parse(string(w.URL)); err == nil {
+		res.Host = parsedURL.Host
+	}
+
+	return res
+}
@@ -19,6 +19,9 @@ type Invite struct {
 	// Kind is the role kind that this refers to
 	Kind string
 
+	// PolicyUID is the project policy which defines the role when Kind is "custom"
+	PolicyUID string
+
 	ProjectID uint
 	UserID    uint
 }
@@ -26,12 +29,13 @@ type Invite struct {
 // ToInviteType generates an external Invite to be shared over REST
 func (i *Invite) ToInviteType() *types.Invite {
 	return &types.Invite{
-		ID:       i.Model.ID,
-		Token:    i.Token,
-		Email:    i.Email,
-		Expired:  i.IsExpired(),
-		Accepted: i.IsAccepted(),
-		Kind:     i.Kind,
+		ID:        i.Model.ID,
+		Token:     i.Token,
+		Email:     i.Email,
+		Expired:   i.IsExpired(),
+		Accepted:  i.IsAccepted(),
+		Kind:      i.Kind,
+		PolicyUID: i.PolicyUID,
 	}
 }
 
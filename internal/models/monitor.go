@@ -65,3 +65,33 @@ func GetLastRunResultEnum(lastRunResult string) uint {
 		return 0
 	}
 }
+
+// MonitorTestRunResult is the result of a single check in a single run of the recommender. Unlike
+// MonitorTestResult, which only holds the latest result of each check, a row is written for every run.
+type MonitorTestRunResult struct {
+	gorm.Model
+
+	ProjectID uint   `gorm:"index:idx_monitor_test_run_results_check"`
+	ClusterID uint   `gorm:"index:idx_monitor_test_run_results_check"`
+	ObjectID  string `gorm:"index:idx_monitor_test_run_results_check"`
+	Category  string
+
+	RecommenderRunID string
+	Result           string
+	TestedAt         time.Time `gorm:"index"`
+
+	Title    string
+	Severity string
+}
+
+func (m *MonitorTestRunResult) ToMonitorTestRunResultType() *types.MonitorTestRunResult {
+	return &types.MonitorTestRunResult{
+		ObjectID:         m.ObjectID,
+		Category:         m.Category,
+		RecommenderRunID: m.RecommenderRunID,
+		Result:           types.MonitorTestStatus(m.Result),
+		TestedAt:         m.TestedAt,
+		Title:            m.Title,
+		Severity:         types.MonitorTestSeverity(m.Severity),
+	}
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/porter-dev/porter/api/types"
@@ -17,6 +20,9 @@ type NotificationConfig struct {
 
 	LastNotifiedTime time.Time
 	NotifLimit       string
+
+	// Digest batches every notification into a single summary per NotifLimit
+	Digest bool
 }
 
 func (conf *NotificationConfig) ToNotificationConfigType() *types.NotificationConfig {
@@ -25,17 +31,88 @@ func (conf *NotificationConfig) ToNotificationConfigType() *types.NotificationCo
 		Success:    conf.Success,
 		Failure:    conf.Failure,
 		NotifLimit: conf.NotifLimit,
+		Digest:     conf.Digest,
 	}
 }
 
 func (conf *NotificationConfig) ShouldNotify() bool {
 	// check the last notified time against the notification limit
-	return conf.LastNotifiedTime.Before(notifLimitToTime(conf.NotifLimit))
+	return conf.LastNotifiedTime.Before(time.Now().Add(-conf.Limit()))
+}
+
+// Limit returns the minimum time between notifications. Limits which cannot be parsed fall back to the default,
+// since they may have been stored before limits were validated.
+func (conf *NotificationConfig) Limit() time.Duration {
+	limit, err := ParseNotifLimit(conf.NotifLimit)
+	if err != nil {
+		return DefaultNotifLimit
+	}
+
+	return limit
+}
+
+// DefaultNotifLimit is the minimum time between notifications when a notification config does not set a limit
+const DefaultNotifLimit = 10 * time.Minute
+
+// ParseNotifLimit parses a notification limit, which is a duration such as "30m" or "2h", or a number of days
+// such as "1d". An empty limit is the default limit, and "0" or "none" disables the limit.
+func ParseNotifLimit(limit string) (time.Duration, error) {
+	limit = strings.TrimSpace(strings.ToLower(limit))
+
+	switch limit {
+	case "":
+		return DefaultNotifLimit, nil
+	case "0", "none":
+		return 0, nil
+	}
+
+	if days, ok := strings.CutSuffix(limit, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid notification limit %q", limit)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	duration, err := time.ParseDuration(limit)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid notification limit %q: must be a duration such as 30m, 2h or 1d", limit)
+	}
+
+	return duration, nil
 }
 
-func notifLimitToTime(notifTime string) time.Time {
-	// TODO: compute a time that's not just 5 min
-	return time.Now().Add(-10 * time.Minute)
+// PendingNotification is a deployment notification which is held until it can be sent in a digest, either
+// because it was throttled by the notification limit of its release or because its project was in quiet hours
+type PendingNotification struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+
+	// NotificationConfigID is the notification config of the release, or 0 if the release has none
+	NotificationConfigID uint
+
+	ClusterName string
+	Name        string
+	Namespace   string
+	Status      string
+	Info        string
+	URL         string
+	Version     int
+
+	// NotifiedAt is when the notification was originally sent
+	NotifiedAt time.Time
+}
+
+// SentNotificationKey records that a notification identified by Key was sent, so that identical notifications
+// are not sent again before ExpiresAt
+type SentNotificationKey struct {
+	gorm.Model
+
+	Key       string    `gorm:"uniqueIndex"`
+	ExpiresAt time.Time `gorm:"index"`
 }
 
 type JobNotificationConfig struct {
@@ -163,6 +163,24 @@ type Project struct {
 	// Deprecated: use p.GetFeatureFlag(EnableReprovision, *features.Client) instead
 
 	EnableReprovision bool `gorm:"default:false"`
+
+	// TwoFactorRequired requires collaborators with password accounts to enroll in two-factor
+	// authentication before they can access project resources
+	TwoFactorRequired bool `gorm:"default:false"`
+
+	// ImagePushToken authenticates the image push events that registries send for this project. It is
+	// empty until the image push webhook token is first rotated.
+	ImagePushToken string `gorm:"index"`
+
+	// ImagePushSecret verifies the signatures of push events from registries which sign their events. It is
+	// rotated together with ImagePushToken.
+	ImagePushSecret string
+
+	// QuietHoursStart, QuietHoursEnd and QuietHoursTimezone configure a daily window during which deployment
+	// notifications are held. Quiet hours are disabled if QuietHoursStart is empty.
+	QuietHoursStart    string
+	QuietHoursEnd      string
+	QuietHoursTimezone string
 }
 
 // GetFeatureFlag calls launchdarkly for the specified flag
@@ -238,6 +256,21 @@ func (p *Project) ToProjectType(launchDarklyClient *features.Client) types.Proje
 		EnableReprovision:      p.GetFeatureFlag(EnableReprovision, launchDarklyClient),
 		ValidateApplyV2:        p.GetFeatureFlag(ValidateApplyV2, launchDarklyClient),
 		FullAddOns:             p.GetFeatureFlag(FullAddOns, launchDarklyClient),
+		TwoFactorRequired:      p.TwoFactorRequired,
+		QuietHours:             p.QuietHours(),
+	}
+}
+
+// QuietHours returns the quiet hours of the project, or nil if they are disabled
+func (p *Project) QuietHours() *types.ProjectQuietHours {
+	if p.QuietHoursStart == "" {
+		return nil
+	}
+
+	return &types.ProjectQuietHours{
+		Start:    p.QuietHoursStart,
+		End:      p.QuietHoursEnd,
+		Timezone: p.QuietHoursTimezone,
 	}
 }
 
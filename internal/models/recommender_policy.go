@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// RecommenderPolicyCollection is a set of Rego policies uploaded by a project, which the recommender
+// runs alongside the built-in policy collections
+type RecommenderPolicyCollection struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	Name      string
+
+	Kind             string
+	MustExist        bool
+	OverrideSeverity string
+
+	// Match is the JSON-encoded types.RecommenderPolicyMatch of the collection
+	Match []byte
+
+	// Policies is the JSON-encoded list of types.RecommenderPolicy in the collection
+	Policies []byte
+}
+
+// ToRecommenderPolicyCollectionType generates an external types.RecommenderPolicyCollection to be shared over REST
+func (c *RecommenderPolicyCollection) ToRecommenderPolicyCollectionType() (*types.RecommenderPolicyCollection, error) {
+	res := &types.RecommenderPolicyCollection{
+		ID:               c.ID,
+		ProjectID:        c.ProjectID,
+		Name:             c.Name,
+		Kind:             types.RecommenderPolicyKind(c.Kind),
+		MustExist:        c.MustExist,
+		OverrideSeverity: types.MonitorTestSeverity(c.OverrideSeverity),
+		Policies:         make([]types.RecommenderPolicy, 0),
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
+	}
+
+	if len(c.Match) > 0 {
+		if err := json.Unmarshal(c.Match, &res.Match); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.Policies) > 0 {
+		if err := json.Unmarshal(c.Policies, &res.Policies); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+// PolicyOverrideEvent records a deploy which violated blocking policies and was overridden by a project admin
+type PolicyOverrideEvent struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+	UserID    uint
+
+	// Name and Namespace are the name and namespace of the release which was deployed
+	Name      string
+	Namespace string
+
+	Reason string
+
+	// Violations is the JSON-encoded list of types.PolicyViolation which were overridden
+	Violations []byte
+}
+
+// ToPolicyOverrideEventType generates an external types.PolicyOverrideEvent to be shared over REST
+func (e *PolicyOverrideEvent) ToPolicyOverrideEventType() (*types.PolicyOverrideEvent, error) {
+	res := &types.PolicyOverrideEvent{
+		ID:         e.ID,
+		ProjectID:  e.ProjectID,
+		ClusterID:  e.ClusterID,
+		UserID:     e.UserID,
+		Name:       e.Name,
+		Namespace:  e.Namespace,
+		Reason:     e.Reason,
+		Violations: make([]*types.PolicyViolation, 0),
+		CreatedAt:  e.CreatedAt,
+	}
+
+	if len(e.Violations) > 0 {
+		if err := json.Unmarshal(e.Violations, &res.Violations); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"time"
 
+	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
 
@@ -15,4 +16,25 @@ type Session struct {
 	Data []byte
 	// Time the session will expire
 	ExpiresAt time.Time
+
+	// UserID is the user which the session is authenticated as, or 0 if the session is not
+	// authenticated
+	UserID uint `gorm:"index"`
+
+	// client metadata recorded by the session store, shown when listing sessions
+	IPAddress  string
+	UserAgent  string
+	LastSeenAt *time.Time
+}
+
+// ToSessionType generates an external types.Session to be shared over REST
+func (s *Session) ToSessionType() *types.Session {
+	return &types.Session{
+		ID:         s.ID,
+		CreatedAt:  s.CreatedAt,
+		LastSeenAt: s.LastSeenAt,
+		ExpiresAt:  s.ExpiresAt,
+		IPAddress:  s.IPAddress,
+		UserAgent:  s.UserAgent,
+	}
 }
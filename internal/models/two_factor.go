@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/json"
+
+	"gorm.io/gorm"
+)
+
+// UserTwoFactor stores the TOTP second factor for a user
+type UserTwoFactor struct {
+	gorm.Model
+
+	UserID uint `gorm:"unique"`
+
+	// Secret is the base32-encoded TOTP secret, encrypted at rest
+	Secret []byte
+
+	// Enabled is set once the user has confirmed enrollment with a valid code
+	Enabled bool
+
+	// LastUsedStep is the TOTP time step of the last accepted code, used to reject replays
+	LastUsedStep int64
+
+	// RecoveryCodes is a JSON-encoded list of bcrypt hashes of unused recovery codes
+	RecoveryCodes []byte
+}
+
+// GetRecoveryCodeHashes returns the hashes of the unused recovery codes
+func (t *UserTwoFactor) GetRecoveryCodeHashes() ([]string, error) {
+	hashes := []string{}
+
+	if len(t.RecoveryCodes) == 0 {
+		return hashes, nil
+	}
+
+	if err := json.Unmarshal(t.RecoveryCodes, &hashes); err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+// SetRecoveryCodeHashes sets the hashes of the unused recovery codes
+func (t *UserTwoFactor) SetRecoveryCodeHashes(hashes []string) error {
+	bytes, err := json.Marshal(hashes)
+	if err != nil {
+		return err
+	}
+
+	t.RecoveryCodes = bytes
+
+	return nil
+}
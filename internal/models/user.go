@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -23,6 +25,14 @@ type User struct {
 	// The github user id used for login (optional)
 	GithubUserID int64
 	GoogleUserID string
+
+	// The issuer and subject of the OIDC identity used for login (optional)
+	OIDCIssuer  string
+	OIDCSubject string
+
+	// SessionsRevokedAt is the last time all sessions of the user were revoked. User tokens, such as
+	// those of the CLI, which were issued before this time are rejected (optional)
+	SessionsRevokedAt *time.Time
 }
 
 // ToUserType generates an external types.User to be shared over REST
@@ -12,6 +12,9 @@ const (
 	StatusHelmDeployed DeploymentStatus = "helm_deployed"
 	StatusPodCrashed   DeploymentStatus = "pod_crashed"
 	StatusHelmFailed   DeploymentStatus = "helm_failed"
+
+	// StatusPolicyBlocked is sent when a deploy was not made because the release violates blocking policies
+	StatusPolicyBlocked DeploymentStatus = "policy_blocked"
 )
 
 type NotifyOpts struct {
@@ -43,3 +46,27 @@ type NotifyOpts struct {
 
 	Version int
 }
+
+// MultiNotifier sends a deployment notification through each of its notifiers. Each
+// notifier is responsible for filtering notifications based on its own configuration.
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+func NewMultiNotifier(notifiers ...Notifier) Notifier {
+	return &MultiNotifier{notifiers}
+}
+
+// Notify calls every notifier, even if a previous notifier failed, and returns the
+// first error encountered
+func (m *MultiNotifier) Notify(opts *NotifyOpts) error {
+	var firstErr error
+
+	for _, n := range m.notifiers {
+		if err := n.Notify(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
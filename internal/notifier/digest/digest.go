@@ -0,0 +1,256 @@
+// Package digest throttles the deployment notifications of releases, holding notifications which are sent too
+// often or during quiet hours and sending them later as a single digest.
+package digest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NewReleaseNotifier wraps the deployment notifier of a release, so that its notifications are held in digest mode
+// and during the quiet hours of its project, and sent later as a digest once the notification limit of the release
+// has passed. conf is nil if the release does not have a notification config, in which case notifications are only
+// held during quiet hours.
+func NewReleaseNotifier(
+	repo repository.Repository,
+	cluster *models.Cluster,
+	conf *models.NotificationConfig,
+	next notifier.Notifier,
+) (notifier.Notifier, error) {
+	project, err := repo.Project().ReadProject(cluster.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("error reading project: %w", err)
+	}
+
+	opts := notifier.ThrottleOpts{
+		QuietHours: projectQuietHours(project),
+		Hold: func(opts *notifier.NotifyOpts) error {
+			_, err := repo.NotificationConfig().CreatePendingNotification(toPendingNotification(conf, opts))
+			return err
+		},
+	}
+
+	if conf != nil {
+		opts.Digest = conf.Digest
+	}
+
+	return notifier.NewThrottledNotifier(next, opts), nil
+}
+
+// NewDeduplicator returns a notifier.Deduplicator which records sent notification keys in the database, next to
+// the notification limits of releases, so that notifications are deduplicated across server instances
+func NewDeduplicator(repo repository.Repository) notifier.Deduplicator {
+	return &deduplicator{repo: repo, now: time.Now}
+}
+
+type deduplicator struct {
+	repo repository.Repository
+	now  func() time.Time
+}
+
+// ShouldSend returns true if key was not sent within window, and records that it was sent. Keys are hashed, since
+// they may contain arbitrarily long notification info.
+func (d *deduplicator) ShouldSend(key string, window time.Duration) (bool, error) {
+	now := d.now()
+	hash := sha256.Sum256([]byte(key))
+
+	return d.repo.NotificationConfig().ClaimSentNotificationKey(hex.EncodeToString(hash[:]), now, now.Add(window))
+}
+
+// SendDueDigests sends a digest for each deployment with held notifications, once the notification limit of the
+// deployment has passed and its project is not in quiet hours. Notifications are deleted once they are sent.
+func SendDueDigests(repo repository.Repository, now time.Time) error {
+	pending, err := repo.NotificationConfig().ListPendingNotifications()
+	if err != nil {
+		return fmt.Errorf("error listing pending notifications: %w", err)
+	}
+
+	type deploymentKey struct {
+		projectID uint
+		clusterID uint
+		namespace string
+		name      string
+	}
+
+	keys := make([]deploymentKey, 0)
+	groups := make(map[deploymentKey][]*models.PendingNotification)
+
+	for _, notif := range pending {
+		key := deploymentKey{notif.ProjectID, notif.ClusterID, notif.Namespace, notif.Name}
+
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		groups[key] = append(groups[key], notif)
+	}
+
+	var errs []error
+
+	for _, key := range keys {
+		if err := sendDigest(repo, groups[key], now); err != nil {
+			errs = append(errs, fmt.Errorf("error sending digest for %s/%s in cluster %d: %w", key.namespace, key.name, key.clusterID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// sendDigest sends the held notifications of a single deployment if they are due
+func sendDigest(repo repository.Repository, notifs []*models.PendingNotification, now time.Time) error {
+	first := notifs[0]
+
+	ids := make([]uint, 0, len(notifs))
+	for _, notif := range notifs {
+		ids = append(ids, notif.ID)
+	}
+
+	cluster, err := repo.Cluster().ReadCluster(first.ProjectID, first.ClusterID)
+	if err != nil {
+		// the cluster was deleted, so the notifications are no longer relevant
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repo.NotificationConfig().DeletePendingNotifications(ids)
+		}
+
+		return err
+	}
+
+	if cluster.NotificationsDisabled {
+		return repo.NotificationConfig().DeletePendingNotifications(ids)
+	}
+
+	project, err := repo.Project().ReadProject(first.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	var conf *models.NotificationConfig
+	var notifConf *types.NotificationConfig
+
+	if first.NotificationConfigID != 0 {
+		conf, err = repo.NotificationConfig().ReadNotificationConfig(first.NotificationConfigID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		if conf != nil {
+			notifConf = conf.ToNotificationConfigType()
+		}
+	}
+
+	limit := time.Duration(0)
+	lastNotified := time.Time{}
+
+	if conf != nil {
+		limit = conf.Limit()
+		lastNotified = conf.LastNotifiedTime
+	}
+
+	if !notifier.DigestDue(limit, lastNotified, projectQuietHours(project), now) {
+		return nil
+	}
+
+	slackInts, err := repo.SlackIntegration().ListSlackIntegrationsByProjectID(first.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	webhookInts, err := repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(first.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	opts := make([]*notifier.NotifyOpts, 0, len(notifs))
+	for _, notif := range notifs {
+		opts = append(opts, toNotifyOpts(notif))
+	}
+
+	digestNotifiers := []notifier.DigestNotifier{
+		slack.NewDeploymentNotifier(notifConf, slackInts...),
+		webhook.NewDeploymentNotifier(notifConf, webhookInts...),
+	}
+
+	for _, digestNotifier := range digestNotifiers {
+		if err := digestNotifier.NotifyDigest(opts); err != nil {
+			return err
+		}
+	}
+
+	if conf != nil {
+		conf.LastNotifiedTime = now
+
+		if _, err := repo.NotificationConfig().UpdateNotificationConfig(conf); err != nil {
+			return err
+		}
+	}
+
+	return repo.NotificationConfig().DeletePendingNotifications(ids)
+}
+
+// projectQuietHours returns the quiet hours of a project, or nil if the project has none. Quiet hours are
+// validated when they are set, so invalid quiet hours are ignored.
+func projectQuietHours(project *models.Project) *notifier.QuietHours {
+	if project.QuietHoursStart == "" {
+		return nil
+	}
+
+	quietHours, err := notifier.ParseQuietHours(project.QuietHoursStart, project.QuietHoursEnd, project.QuietHoursTimezone)
+	if err != nil {
+		return nil
+	}
+
+	return quietHours
+}
+
+func toPendingNotification(conf *models.NotificationConfig, opts *notifier.NotifyOpts) *models.PendingNotification {
+	notif := &models.PendingNotification{
+		ProjectID:   opts.ProjectID,
+		ClusterID:   opts.ClusterID,
+		ClusterName: opts.ClusterName,
+		Name:        opts.Name,
+		Namespace:   opts.Namespace,
+		Status:      string(opts.Status),
+		Info:        opts.Info,
+		URL:         opts.URL,
+		Version:     opts.Version,
+		NotifiedAt:  time.Now(),
+	}
+
+	if opts.Timestamp != nil {
+		notif.NotifiedAt = *opts.Timestamp
+	}
+
+	if conf != nil {
+		notif.NotificationConfigID = conf.ID
+	}
+
+	return notif
+}
+
+func toNotifyOpts(notif *models.PendingNotification) *notifier.NotifyOpts {
+	notifiedAt := notif.NotifiedAt
+
+	return &notifier.NotifyOpts{
+		ProjectID:   notif.ProjectID,
+		ClusterID:   notif.ClusterID,
+		ClusterName: notif.ClusterName,
+		Status:      notifier.DeploymentStatus(notif.Status),
+		Info:        notif.Info,
+		Name:        notif.Name,
+		Namespace:   notif.Namespace,
+		URL:         notif.URL,
+		Timestamp:   &notifiedAt,
+		Version:     notif.Version,
+	}
+}
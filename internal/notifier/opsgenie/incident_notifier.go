@@ -0,0 +1,175 @@
+package opsgenie
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+const (
+	// DefaultAPIURL is the URL of the Opsgenie API in the US region
+	DefaultAPIURL = "https://api.opsgenie.com"
+
+	// EUAPIURL is the URL of the Opsgenie API in the EU region
+	EUAPIURL = "https://api.eu.opsgenie.com"
+)
+
+// APIURLForRegion returns the URL of the Opsgenie API in a region
+func APIURLForRegion(region types.PagingRegion) string {
+	if region == types.PagingRegionEU {
+		return EUAPIURL
+	}
+
+	return DefaultAPIURL
+}
+
+type IncidentNotifierOpts struct {
+	// APIKey is the key of an Opsgenie API integration
+	APIKey string
+
+	// APIURL overrides DefaultAPIURL. It must only be set by the server, such as with APIURLForRegion,
+	// since the API key is sent to it.
+	APIURL string
+
+	// ClusterID is used to make the alert alias unique across clusters, since incident ids
+	// are generated by the agent running in each cluster
+	ClusterID uint
+
+	// Rules determine which new incidents are sent to Opsgenie. Resolved incidents are not filtered,
+	// so that an alert is closed even if the rules changed after it was opened.
+	Rules []types.IncidentRoutingRule
+}
+
+type IncidentNotifier struct {
+	opts   *IncidentNotifierOpts
+	client *http.Client
+}
+
+func NewIncidentNotifier(opts *IncidentNotifierOpts) notifier.IncidentNotifier {
+	return &IncidentNotifier{
+		opts: opts,
+		client: &http.Client{
+			Timeout: time.Second * 5,
+		},
+	}
+}
+
+type createAlertRequest struct {
+	Message     string            `json:"message"`
+	Alias       string            `json:"alias"`
+	Description string            `json:"description,omitempty"`
+	Source      string            `json:"source,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
+	Priority    string            `json:"priority,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+}
+
+type closeAlertRequest struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Alias returns the alias used to deduplicate alerts for an incident, so that resolving
+// the incident in Porter closes the corresponding Opsgenie alert
+func Alias(clusterID uint, incidentID string) string {
+	return fmt.Sprintf("porter-%d-%s", clusterID, incidentID)
+}
+
+func (o *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	if !notifier.MatchesIncidentRoutingRules(o.opts.Rules, incident) {
+		return nil
+	}
+
+	resourceKind := "application"
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == "job" {
+		resourceKind = "job"
+	}
+
+	return o.post("/v2/alerts", &createAlertRequest{
+		// messages are limited to 130 characters by Opsgenie
+		Message:     truncate(fmt.Sprintf("Your %s %s crashed on Porter", resourceKind, incident.ReleaseName), 130),
+		Alias:       Alias(o.opts.ClusterID, incident.ID),
+		Description: truncate(fmt.Sprintf("%s\n\n%s\n\nView the incident: %s", incident.Summary, incident.Detail, url), 15000),
+		Source:      "Porter",
+		Entity:      fmt.Sprintf("%s/%s", incident.ReleaseNamespace, incident.ReleaseName),
+		Priority:    getPriority(incident.Severity),
+		Tags:        []string{"porter", string(incident.Severity)},
+		Details: map[string]string{
+			"release_name":      incident.ReleaseName,
+			"release_namespace": incident.ReleaseNamespace,
+			"involved_object":   incident.InvolvedObjectName,
+			"revision":          incident.Revision,
+			"url":               url,
+		},
+	})
+}
+
+func (o *IncidentNotifier) NotifyResolved(incident *types.Incident, incidentURL string) error {
+	return o.post(
+		fmt.Sprintf("/v2/alerts/%s/close?identifierType=alias", url.PathEscape(Alias(o.opts.ClusterID, incident.ID))),
+		&closeAlertRequest{
+			Source: "Porter",
+			Note:   "The incident has been resolved on Porter",
+		},
+	)
+}
+
+func (o *IncidentNotifier) post(relPath string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	apiURL := o.opts.APIURL
+
+	if apiURL == "" {
+		apiURL = DefaultAPIURL
+	}
+
+	req, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(apiURL, "/")+relPath, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "GenieKey "+o.opts.APIKey)
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request to opsgenie: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	// opsgenie processes alert requests asynchronously, and responds with 202
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("opsgenie responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func getPriority(severity types.SeverityType) string {
+	if severity == types.SeverityCritical {
+		return "P1"
+	}
+
+	return "P3"
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+
+	return s[:length-3] + "..."
+}
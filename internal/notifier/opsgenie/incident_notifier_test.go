@@ -0,0 +1,46 @@
+package opsgenie
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncidentNotifierAlias(t *testing.T) {
+	paths := make([]string, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GenieKey api-key", r.Header.Get("Authorization"))
+
+		paths = append(paths, r.URL.RequestURI())
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewIncidentNotifier(&IncidentNotifierOpts{
+		APIKey:    "api-key",
+		APIURL:    server.URL,
+		ClusterID: 2,
+	})
+
+	incident := &types.Incident{
+		IncidentMeta: &types.IncidentMeta{
+			ID:               "incident-1",
+			ReleaseName:      "web",
+			ReleaseNamespace: "default",
+			Severity:         types.SeverityCritical,
+		},
+	}
+
+	assert.Nil(t, notifier.NotifyNew(incident, "https://porter.run"))
+	assert.Nil(t, notifier.NotifyResolved(incident, "https://porter.run"))
+
+	assert.Equal(t, []string{
+		"/v2/alerts",
+		"/v2/alerts/porter-2-incident-1/close?identifierType=alias",
+	}, paths)
+}
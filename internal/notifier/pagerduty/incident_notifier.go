@@ -0,0 +1,183 @@
+package pagerduty
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+const (
+	// DefaultEventsAPIURL is the URL of the PagerDuty Events API v2
+	DefaultEventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+
+	// EUEventsAPIURL is the URL of the PagerDuty Events API v2 for accounts in the EU service region
+	EUEventsAPIURL = "https://events.eu.pagerduty.com/v2/enqueue"
+)
+
+// EventsAPIURLForRegion returns the URL of the PagerDuty Events API v2 in a service region
+func EventsAPIURLForRegion(region types.PagingRegion) string {
+	if region == types.PagingRegionEU {
+		return EUEventsAPIURL
+	}
+
+	return DefaultEventsAPIURL
+}
+
+type IncidentNotifierOpts struct {
+	// RoutingKey is the integration key of the PagerDuty service
+	RoutingKey string
+
+	// APIURL overrides DefaultEventsAPIURL. It must only be set by the server, such as with
+	// EventsAPIURLForRegion, since the routing key is sent to it.
+	APIURL string
+
+	// ClusterID is used to make the dedup key unique across clusters, since incident ids
+	// are generated by the agent running in each cluster
+	ClusterID uint
+
+	// Rules determine which new incidents are sent to PagerDuty. Resolved incidents are not filtered,
+	// so that an alert is resolved even if the rules changed after it was triggered.
+	Rules []types.IncidentRoutingRule
+}
+
+type IncidentNotifier struct {
+	opts   *IncidentNotifierOpts
+	client *http.Client
+}
+
+func NewIncidentNotifier(opts *IncidentNotifierOpts) notifier.IncidentNotifier {
+	return &IncidentNotifier{
+		opts: opts,
+		client: &http.Client{
+			Timeout: time.Second * 5,
+		},
+	}
+}
+
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"`
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+	Links       []*eventLink  `json:"links,omitempty"`
+	Client      string        `json:"client,omitempty"`
+	ClientURL   string        `json:"client_url,omitempty"`
+}
+
+type eventPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Component     string                 `json:"component,omitempty"`
+	Group         string                 `json:"group,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+type eventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// DedupKey returns the key used to deduplicate events for an incident, so that resolving
+// the incident in Porter resolves the corresponding PagerDuty alert
+func DedupKey(clusterID uint, incidentID string) string {
+	return fmt.Sprintf("porter-%d-%s", clusterID, incidentID)
+}
+
+func (p *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	if !notifier.MatchesIncidentRoutingRules(p.opts.Rules, incident) {
+		return nil
+	}
+
+	resourceKind := "application"
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == "job" {
+		resourceKind = "job"
+	}
+
+	return p.send(&event{
+		EventAction: "trigger",
+		Payload: &eventPayload{
+			Summary:   truncate(fmt.Sprintf("Your %s %s crashed on Porter: %s", resourceKind, incident.ReleaseName, incident.ShortSummary), 1024),
+			Source:    fmt.Sprintf("%s/%s", incident.ReleaseNamespace, incident.ReleaseName),
+			Severity:  getSeverity(incident.Severity),
+			Timestamp: incident.CreatedAt.UTC().Format(time.RFC3339),
+			Component: incident.InvolvedObjectName,
+			Group:     incident.ReleaseNamespace,
+			Class:     string(incident.InvolvedObjectKind),
+			CustomDetails: map[string]interface{}{
+				"summary":  incident.Summary,
+				"detail":   incident.Detail,
+				"pods":     incident.Pods,
+				"revision": incident.Revision,
+			},
+		},
+		Links: []*eventLink{
+			{
+				Href: url,
+				Text: "View the incident on Porter",
+			},
+		},
+		Client:    "Porter",
+		ClientURL: url,
+	}, incident)
+}
+
+func (p *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	return p.send(&event{
+		EventAction: "resolve",
+	}, incident)
+}
+
+func (p *IncidentNotifier) send(e *event, incident *types.Incident) error {
+	e.RoutingKey = p.opts.RoutingKey
+	e.DedupKey = DedupKey(p.opts.ClusterID, incident.ID)
+
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	apiURL := p.opts.APIURL
+
+	if apiURL == "" {
+		apiURL = DefaultEventsAPIURL
+	}
+
+	resp, err := p.client.Post(apiURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error sending event to pagerduty: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("pagerduty responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func getSeverity(severity types.SeverityType) string {
+	if severity == types.SeverityCritical {
+		return "critical"
+	}
+
+	return "warning"
+}
+
+func truncate(s string, length int) string {
+	if len(s) <= length {
+		return s
+	}
+
+	return s[:length-3] + "..."
+}
@@ -0,0 +1,103 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestIncident(name string, severity types.SeverityType) *types.Incident {
+	return &types.Incident{
+		IncidentMeta: &types.IncidentMeta{
+			ID:               "incident-1",
+			ReleaseName:      name,
+			ReleaseNamespace: "default",
+			Severity:         severity,
+			Summary:          "the application crashed",
+		},
+	}
+}
+
+func TestIncidentNotifierDedupKey(t *testing.T) {
+	events := make([]*event, 0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e := &event{}
+
+		if err := json.NewDecoder(r.Body).Decode(e); err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		events = append(events, e)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewIncidentNotifier(&IncidentNotifierOpts{
+		RoutingKey: "routing-key",
+		APIURL:     server.URL,
+		ClusterID:  2,
+	})
+
+	incident := getTestIncident("web", types.SeverityCritical)
+
+	assert.Nil(t, notifier.NotifyNew(incident, "https://porter.run"))
+	assert.Nil(t, notifier.NotifyResolved(incident, "https://porter.run"))
+
+	assert.Len(t, events, 2)
+	assert.Equal(t, "trigger", events[0].EventAction)
+	assert.Equal(t, "critical", events[0].Payload.Severity)
+	assert.Equal(t, "resolve", events[1].EventAction)
+	assert.Equal(t, "routing-key", events[1].RoutingKey)
+
+	// both events must share a dedup key so that the alert is resolved in PagerDuty
+	assert.Equal(t, "porter-2-incident-1", events[0].DedupKey)
+	assert.Equal(t, events[0].DedupKey, events[1].DedupKey)
+}
+
+func TestIncidentNotifierRoutingRules(t *testing.T) {
+	numEvents := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		numEvents++
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewIncidentNotifier(&IncidentNotifierOpts{
+		RoutingKey: "routing-key",
+		APIURL:     server.URL,
+		Rules: []types.IncidentRoutingRule{
+			{
+				ReleaseName: "api-*",
+				Severities:  []types.SeverityType{types.SeverityCritical},
+			},
+		},
+	})
+
+	assert.Nil(t, notifier.NotifyNew(getTestIncident("web", types.SeverityCritical), ""))
+	assert.Nil(t, notifier.NotifyNew(getTestIncident("api-server", types.SeverityNormal), ""))
+	assert.Equal(t, 0, numEvents)
+
+	assert.Nil(t, notifier.NotifyNew(getTestIncident("api-server", types.SeverityCritical), ""))
+	assert.Equal(t, 1, numEvents)
+}
+
+func TestIncidentNotifierErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	notifier := NewIncidentNotifier(&IncidentNotifierOpts{
+		RoutingKey: "routing-key",
+		APIURL:     server.URL,
+	})
+
+	assert.NotNil(t, notifier.NotifyNew(getTestIncident("web", types.SeverityCritical), ""))
+}
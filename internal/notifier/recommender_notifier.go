@@ -0,0 +1,34 @@
+package notifier
+
+import "github.com/porter-dev/porter/api/types"
+
+// RecommenderNotifier sends a notification when recommender checks start failing
+type RecommenderNotifier interface {
+	NotifyRegressions(regressions *types.RecommenderRegressions, url string) error
+}
+
+type MultiRecommenderNotifier struct {
+	notifiers []RecommenderNotifier
+}
+
+func NewMultiRecommenderNotifier(notifiers ...RecommenderNotifier) RecommenderNotifier {
+	return &MultiRecommenderNotifier{notifiers}
+}
+
+// NotifyRegressions notifies every notifier, and returns the last error encountered so that a failing
+// notifier does not prevent the remaining notifiers from being notified
+func (m *MultiRecommenderNotifier) NotifyRegressions(regressions *types.RecommenderRegressions, url string) error {
+	if len(regressions.Regressions) == 0 {
+		return nil
+	}
+
+	var lastErr error
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyRegressions(regressions, url); err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
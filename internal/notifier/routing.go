@@ -0,0 +1,51 @@
+package notifier
+
+import (
+	"path"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// MatchesIncidentRoutingRules returns true if the incident matches any of the routing
+// rules. If there are no rules, every incident matches.
+func MatchesIncidentRoutingRules(rules []types.IncidentRoutingRule, incident *types.Incident) bool {
+	if len(rules) == 0 {
+		return true
+	}
+
+	for _, rule := range rules {
+		if matchesIncidentRoutingRule(rule, incident) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesIncidentRoutingRule(rule types.IncidentRoutingRule, incident *types.Incident) bool {
+	if !matchesGlob(rule.ReleaseName, incident.ReleaseName) || !matchesGlob(rule.ReleaseNamespace, incident.ReleaseNamespace) {
+		return false
+	}
+
+	if len(rule.Severities) == 0 {
+		return true
+	}
+
+	for _, severity := range rule.Severities {
+		if severity == incident.Severity {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesGlob(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	matched, err := path.Match(pattern, name)
+
+	return err == nil && matched
+}
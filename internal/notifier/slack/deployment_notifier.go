@@ -3,7 +3,9 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/porter-dev/porter/api/types"
@@ -14,6 +16,10 @@ import (
 type DeploymentNotifier struct {
 	slackInts []*integrations.SlackIntegration
 	Config    *types.NotificationConfig
+
+	// dedup drops pod_crashed notifications which were already sent within podCrashDedupWindow. Notifications
+	// are not deduplicated if it is nil.
+	dedup notifier.Deduplicator
 }
 
 func NewDeploymentNotifier(conf *types.NotificationConfig, slackInts ...*integrations.SlackIntegration) *DeploymentNotifier {
@@ -23,6 +29,12 @@ func NewDeploymentNotifier(conf *types.NotificationConfig, slackInts ...*integra
 	}
 }
 
+// WithDeduplicator sets the deduplicator which drops repeated pod_crashed notifications
+func (s *DeploymentNotifier) WithDeduplicator(dedup notifier.Deduplicator) *DeploymentNotifier {
+	s.dedup = dedup
+	return s
+}
+
 type SlackPayload struct {
 	Blocks []*SlackBlock `json:"blocks"`
 }
@@ -38,17 +50,17 @@ type SlackText struct {
 }
 
 func (s *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
-	if s.Config != nil {
-		if !s.Config.Enabled {
-			return nil
-		}
-		if opts.Status == notifier.StatusHelmDeployed && !s.Config.Success {
-			return nil
-		}
-		if opts.Status == notifier.StatusPodCrashed && !s.Config.Failure {
-			return nil
+	if !s.isEnabled(opts) {
+		return nil
+	}
+
+	if opts.Status == notifier.StatusPodCrashed && s.dedup != nil {
+		shouldSend, err := s.dedup.ShouldSend(podCrashKey(opts), podCrashDedupWindow)
+		if err != nil {
+			return fmt.Errorf("error deduplicating pod crash notification: %w", err)
 		}
-		if opts.Status == notifier.StatusHelmFailed && !s.Config.Failure {
+
+		if !shouldSend {
 			return nil
 		}
 	}
@@ -57,6 +69,48 @@ func (s *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
 	// marshaling errors on the Slack API side.
 	blocks, basicBlocks := getSlackBlocks(opts)
 
+	return s.post(blocks, basicBlocks)
+}
+
+// NotifyDigest sends a single message summarizing notifications which were held by a throttled notifier
+func (s *DeploymentNotifier) NotifyDigest(notifs []*notifier.NotifyOpts) error {
+	enabled := make([]*notifier.NotifyOpts, 0, len(notifs))
+
+	for _, opts := range notifs {
+		if s.isEnabled(opts) {
+			enabled = append(enabled, opts)
+		}
+	}
+
+	if len(enabled) == 0 {
+		return nil
+	}
+
+	blocks, basicBlocks := getSlackDigestBlocks(enabled)
+
+	return s.post(blocks, basicBlocks)
+}
+
+func (s *DeploymentNotifier) isEnabled(opts *notifier.NotifyOpts) bool {
+	if s.Config == nil {
+		return true
+	}
+
+	if !s.Config.Enabled {
+		return false
+	}
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		return s.Config.Success
+	case notifier.StatusPodCrashed, notifier.StatusHelmFailed, notifier.StatusPolicyBlocked:
+		return s.Config.Failure
+	}
+
+	return true
+}
+
+func (s *DeploymentNotifier) post(blocks, basicBlocks []*SlackBlock) error {
 	slackPayload := &SlackPayload{
 		Blocks: blocks,
 	}
@@ -75,19 +129,36 @@ func (s *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
 		return err
 	}
 
-	basicReqBody := bytes.NewReader(basicPayload)
-	reqBody := bytes.NewReader(payload)
 	client := &http.Client{
 		Timeout: time.Second * 5,
 	}
 
 	for _, slackInt := range s.slackInts {
-		resp, err := client.Post(string(slackInt.Webhook), "application/json", reqBody)
+		resp, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
 
 		if err != nil || resp.StatusCode != 200 {
-			client.Post(string(slackInt.Webhook), "application/json", basicReqBody)
+			client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(basicPayload))
 		}
 	}
 
 	return nil
 }
+
+// podCrashDedupWindow is the time during which identical pod_crashed notifications are only sent once, since
+// every replica of a crashing deployment reports the same crash
+const podCrashDedupWindow = 10 * time.Minute
+
+// podCrashKey identifies a crash of a deployment independently of the replica which reported it, by replacing
+// generated pod names in the crash info with the name of the deployment
+func podCrashKey(opts *notifier.NotifyOpts) string {
+	podName := regexp.MustCompile(regexp.QuoteMeta(opts.Name) + `(-[a-z0-9]{6,10})?-[a-z0-9]{5}\b`)
+
+	return fmt.Sprintf(
+		"%d/%d/%s/%s/%s",
+		opts.ProjectID,
+		opts.ClusterID,
+		opts.Namespace,
+		opts.Name,
+		podName.ReplaceAllString(opts.Info, opts.Name),
+	)
+}
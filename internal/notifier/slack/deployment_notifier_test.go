@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDeduplicator struct {
+	sent map[string]bool
+}
+
+func (f *fakeDeduplicator) ShouldSend(key string, window time.Duration) (bool, error) {
+	if f.sent[key] {
+		return false, nil
+	}
+
+	f.sent[key] = true
+
+	return true, nil
+}
+
+func TestDeploymentNotifierDeduplicatesPodCrashes(t *testing.T) {
+	var posts int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+	}))
+	defer server.Close()
+
+	n := NewDeploymentNotifier(nil, &integrations.SlackIntegration{Webhook: []byte(server.URL)}).
+		WithDeduplicator(&fakeDeduplicator{sent: make(map[string]bool)})
+
+	crash := func(pod string) *notifier.NotifyOpts {
+		return &notifier.NotifyOpts{
+			ProjectID: 1,
+			ClusterID: 2,
+			Namespace: "default",
+			Name:      "web",
+			Status:    notifier.StatusPodCrashed,
+			Info:      "pod " + pod + " exited with code 137",
+		}
+	}
+
+	assert.NoError(t, n.Notify(crash("web-7d9f8b6c5d-x2k4p")))
+	assert.NoError(t, n.Notify(crash("web-7d9f8b6c5d-9qzlm")))
+	assert.Equal(t, 1, posts)
+
+	// other statuses are never deduplicated
+	assert.NoError(t, n.Notify(&notifier.NotifyOpts{Name: "web", Status: notifier.StatusHelmDeployed}))
+	assert.NoError(t, n.Notify(&notifier.NotifyOpts{Name: "web", Status: notifier.StatusHelmDeployed}))
+	assert.Equal(t, 3, posts)
+}
+
+func TestPodCrashKeyIgnoresReplica(t *testing.T) {
+	crash := func(pod string) *notifier.NotifyOpts {
+		return &notifier.NotifyOpts{
+			ProjectID: 1,
+			ClusterID: 2,
+			Namespace: "default",
+			Name:      "web",
+			Status:    notifier.StatusPodCrashed,
+			Info:      "pod " + pod + " exited with code 137",
+		}
+	}
+
+	assert.Equal(t, podCrashKey(crash("web-7d9f8b6c5d-x2k4p")), podCrashKey(crash("web-7d9f8b6c5d-9qzlm")))
+	assert.Equal(t, podCrashKey(crash("web-7d9f8b6c5d-x2k4p")), podCrashKey(crash("web-5c4b8d7f9a-abcde")))
+	assert.NotEqual(t, podCrashKey(crash("web-7d9f8b6c5d-x2k4p")), podCrashKey(&notifier.NotifyOpts{
+		ProjectID: 1,
+		ClusterID: 2,
+		Namespace: "default",
+		Name:      "web",
+		Info:      "pod web-7d9f8b6c5d-x2k4p exited with code 1",
+	}))
+}
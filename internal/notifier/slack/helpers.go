@@ -14,6 +14,8 @@ func getSlackBlocks(opts *notifier.NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 		res = append(res, getHelmMessageBlock(opts))
 	} else if opts.Status == notifier.StatusPodCrashed {
 		res = append(res, getPodCrashedMessageBlock(opts))
+	} else if opts.Status == notifier.StatusPolicyBlocked {
+		res = append(res, getPolicyBlockedMessageBlock(opts))
 	}
 
 	res = append(
@@ -86,6 +88,16 @@ func getPodCrashedMessageBlock(opts *notifier.NotifyOpts) *SlackBlock {
 	return getMarkdownBlock(md)
 }
 
+func getPolicyBlockedMessageBlock(opts *notifier.NotifyOpts) *SlackBlock {
+	md := fmt.Sprintf(
+		":no_entry: A deploy of your application %s was blocked by the policies of your project. <%s|View the application.>",
+		"`"+opts.Name+"`",
+		opts.URL,
+	)
+
+	return getMarkdownBlock(md)
+}
+
 func getInfoBlock(opts *notifier.NotifyOpts) *SlackBlock {
 	var md string
 
@@ -94,6 +106,8 @@ func getInfoBlock(opts *notifier.NotifyOpts) *SlackBlock {
 		md = getFailedInfoMessage(opts)
 	case notifier.StatusPodCrashed:
 		md = getFailedInfoMessage(opts)
+	case notifier.StatusPolicyBlocked:
+		md = getFailedInfoMessage(opts)
 	default:
 		return nil
 	}
@@ -140,3 +154,79 @@ func getFailedInfoMessage(opts *notifier.NotifyOpts) string {
 
 	return fmt.Sprintf("```\n%s\n```", info)
 }
+
+// getSlackDigestBlocks summarizes held notifications for a single deployment, counting the notifications of
+// each status. As with getSlackBlocks, the basic blocks omit the error info.
+func getSlackDigestBlocks(notifs []*notifier.NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
+	latest := notifs[len(notifs)-1]
+
+	counts := make(map[notifier.DeploymentStatus]int)
+	var lastFailure *notifier.NotifyOpts
+
+	for _, opts := range notifs {
+		counts[opts.Status]++
+
+		if opts.Status == notifier.StatusHelmFailed || opts.Status == notifier.StatusPodCrashed ||
+			opts.Status == notifier.StatusPolicyBlocked {
+			lastFailure = opts
+		}
+	}
+
+	res := []*SlackBlock{
+		getMarkdownBlock(fmt.Sprintf(
+			":bell: %d notifications for your application %s were held. <%s|View the application.>",
+			len(notifs),
+			"`"+latest.Name+"`",
+			latest.URL,
+		)),
+		getDividerBlock(),
+		getMarkdownBlock(fmt.Sprintf("*Name:* %s", "`"+latest.Name+"`")),
+		getMarkdownBlock(fmt.Sprintf("*Namespace:* %s", "`"+latest.Namespace+"`")),
+	}
+
+	if first := notifs[0].Timestamp; first != nil && latest.Timestamp != nil {
+		res = append(res, getMarkdownBlock(fmt.Sprintf(
+			"*Between:* <!date^%d^{date_num} {time_secs}|%s> and <!date^%d^{date_num} {time_secs}|%s>",
+			first.Unix(),
+			first.Format("2006-01-02 15:04:05 UTC"),
+			latest.Timestamp.Unix(),
+			latest.Timestamp.Format("2006-01-02 15:04:05 UTC"),
+		)))
+	}
+
+	if n := counts[notifier.StatusHelmDeployed]; n > 0 {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*Deployed:* %d (latest version %d)", n, latestVersion(notifs))))
+	}
+
+	if n := counts[notifier.StatusHelmFailed]; n > 0 {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*Failed to deploy:* %d", n)))
+	}
+
+	if n := counts[notifier.StatusPodCrashed]; n > 0 {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*Crashed:* %d", n)))
+	}
+
+	if n := counts[notifier.StatusPolicyBlocked]; n > 0 {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("*Blocked by policies:* %d", n)))
+	}
+
+	basicRes := res
+
+	if lastFailure != nil && lastFailure.Info != "" {
+		res = append(res, getMarkdownBlock(getFailedInfoMessage(lastFailure)))
+	}
+
+	return res, basicRes
+}
+
+func latestVersion(notifs []*notifier.NotifyOpts) int {
+	version := 0
+
+	for _, opts := range notifs {
+		if opts.Status == notifier.StatusHelmDeployed && opts.Version > version {
+			version = opts.Version
+		}
+	}
+
+	return version
+}
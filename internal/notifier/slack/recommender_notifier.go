@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type RecommenderNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewRecommenderNotifier(slackInts ...*integrations.SlackIntegration) *RecommenderNotifier {
+	return &RecommenderNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *RecommenderNotifier) NotifyRegressions(regressions *types.RecommenderRegressions, url string) error {
+	checks := "check"
+
+	if len(regressions.Regressions) > 1 {
+		checks = "checks"
+	}
+
+	res := []*SlackBlock{
+		getMarkdownBlock(fmt.Sprintf(
+			":rotating_light: %d recommender %s started failing on cluster %s. <%s|View the cluster.>",
+			len(regressions.Regressions),
+			checks,
+			"`"+regressions.ClusterName+"`",
+			url,
+		)),
+		getDividerBlock(),
+	}
+
+	for _, regression := range regressions.Regressions {
+		res = append(res, getMarkdownBlock(fmt.Sprintf(
+			"*%s* (%s, %s)\n%s",
+			regression.Title,
+			regression.Severity,
+			"`"+regression.ObjectID+"`",
+			regression.Message,
+		)))
+	}
+
+	payload, err := json.Marshal(&SlackPayload{
+		Blocks: res,
+	})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, slackInt := range s.slackInts {
+		resp, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		resp.Body.Close()
+	}
+
+	return nil
+}
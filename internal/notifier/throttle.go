@@ -0,0 +1,152 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+)
+
+// DigestNotifier sends a single summary of notifications which were held by a ThrottledNotifier. Every
+// notification in a digest refers to the same deployment.
+type DigestNotifier interface {
+	NotifyDigest(notifs []*NotifyOpts) error
+}
+
+// NotifyDigest sends the digest through each notifier which supports digests, even if a previous notifier
+// failed, and returns the first error encountered
+func (m *MultiNotifier) NotifyDigest(notifs []*NotifyOpts) error {
+	var firstErr error
+
+	for _, n := range m.notifiers {
+		digestNotifier, ok := n.(DigestNotifier)
+		if !ok {
+			continue
+		}
+
+		if err := digestNotifier.NotifyDigest(notifs); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// QuietHours is a daily window in a timezone during which notifications are held
+type QuietHours struct {
+	// Start and End are offsets from midnight. The window spans midnight if End is before Start.
+	Start time.Duration
+	End   time.Duration
+
+	Location *time.Location
+}
+
+// ParseQuietHours parses quiet hours with a start and end such as "22:00" and "07:30" in an IANA timezone
+func ParseQuietHours(start, end, timezone string) (*QuietHours, error) {
+	startOffset, err := parseTimeOfDay(start)
+	if err != nil {
+		return nil, err
+	}
+
+	endOffset, err := parseTimeOfDay(end)
+	if err != nil {
+		return nil, err
+	}
+
+	if startOffset == endOffset {
+		return nil, fmt.Errorf("quiet hours must start and end at different times")
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	return &QuietHours{
+		Start:    startOffset,
+		End:      endOffset,
+		Location: loc,
+	}, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: must be formatted as HH:MM", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Contains returns true if t is within quiet hours. A nil QuietHours contains no time.
+func (q *QuietHours) Contains(t time.Time) bool {
+	if q == nil {
+		return false
+	}
+
+	local := t.In(q.Location)
+	offset := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute
+
+	if q.Start < q.End {
+		return offset >= q.Start && offset < q.End
+	}
+
+	return offset >= q.Start || offset < q.End
+}
+
+// Deduplicator records the keys of sent notifications, so that identical notifications are only sent once
+// within a window, across requests and server instances
+type Deduplicator interface {
+	// ShouldSend returns true if key was not sent within window, and records that it was sent
+	ShouldSend(key string, window time.Duration) (bool, error)
+}
+
+// ThrottleOpts configures a ThrottledNotifier
+type ThrottleOpts struct {
+	// Digest holds every notification, so that a single digest is sent per notification limit
+	Digest bool
+
+	// QuietHours holds every notification while they are active
+	QuietHours *QuietHours
+
+	// Hold persists a notification, so that it is included in the next digest
+	Hold func(opts *NotifyOpts) error
+
+	// Now returns the current time, and defaults to time.Now
+	Now func() time.Time
+}
+
+// ThrottledNotifier sends notifications through another notifier, unless they are held by digest mode or quiet
+// hours, in which case they are sent later as a digest. Notifications which are not held are always sent, so the
+// notification limit only applies to digests.
+type ThrottledNotifier struct {
+	next Notifier
+	opts ThrottleOpts
+}
+
+// NewThrottledNotifier returns a ThrottledNotifier which sends notifications through next
+func NewThrottledNotifier(next Notifier, opts ThrottleOpts) *ThrottledNotifier {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	return &ThrottledNotifier{next, opts}
+}
+
+func (t *ThrottledNotifier) Notify(opts *NotifyOpts) error {
+	now := t.opts.Now()
+
+	if opts.Timestamp == nil {
+		opts.Timestamp = &now
+	}
+
+	if t.opts.Digest || t.opts.QuietHours.Contains(now) {
+		return t.opts.Hold(opts)
+	}
+
+	return t.next.Notify(opts)
+}
+
+// DigestDue returns true if the held notifications for a deployment can be sent, which is once the limit has
+// passed since the last message and quiet hours are over
+func DigestDue(limit time.Duration, lastNotified time.Time, quietHours *QuietHours, now time.Time) bool {
+	return !quietHours.Contains(now) && now.Sub(lastNotified) >= limit
+}
@@ -0,0 +1,136 @@
+package notifier_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingNotifier struct {
+	sent []*notifier.NotifyOpts
+}
+
+func (r *recordingNotifier) Notify(opts *notifier.NotifyOpts) error {
+	r.sent = append(r.sent, opts)
+	return nil
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	overnight, err := notifier.ParseQuietHours("22:00", "07:30", "America/New_York")
+	assert.NoError(t, err)
+
+	daytime, err := notifier.ParseQuietHours("09:00", "17:00", "UTC")
+	assert.NoError(t, err)
+
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name       string
+		quietHours *notifier.QuietHours
+		t          time.Time
+		want       bool
+	}{
+		{"before overnight window", overnight, time.Date(2024, 1, 1, 21, 59, 0, 0, newYork), false},
+		{"start of overnight window", overnight, time.Date(2024, 1, 1, 22, 0, 0, 0, newYork), true},
+		{"after midnight", overnight, time.Date(2024, 1, 2, 3, 0, 0, 0, newYork), true},
+		{"end of overnight window", overnight, time.Date(2024, 1, 2, 7, 30, 0, 0, newYork), false},
+		{"converts to timezone", overnight, time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC), true},
+		{"within daytime window", daytime, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), true},
+		{"after daytime window", daytime, time.Date(2024, 1, 1, 17, 0, 0, 0, time.UTC), false},
+		{"no quiet hours", nil, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.quietHours.Contains(tt.t))
+		})
+	}
+}
+
+func TestParseQuietHoursErrors(t *testing.T) {
+	_, err := notifier.ParseQuietHours("25:00", "07:00", "UTC")
+	assert.Error(t, err)
+
+	_, err = notifier.ParseQuietHours("07:00", "07:00", "UTC")
+	assert.Error(t, err)
+
+	_, err = notifier.ParseQuietHours("22:00", "07:00", "Not/AZone")
+	assert.Error(t, err)
+}
+
+func TestThrottledNotifier(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	quietHours, _ := notifier.ParseQuietHours("11:00", "13:00", "UTC")
+	otherQuietHours, _ := notifier.ParseQuietHours("22:00", "07:00", "UTC")
+
+	tests := []struct {
+		name     string
+		opts     notifier.ThrottleOpts
+		wantSent bool
+	}{
+		{"sends by default", notifier.ThrottleOpts{}, true},
+		{"sends outside quiet hours", notifier.ThrottleOpts{QuietHours: otherQuietHours}, true},
+		{"holds in digest mode", notifier.ThrottleOpts{Digest: true}, false},
+		{"holds during quiet hours", notifier.ThrottleOpts{QuietHours: quietHours}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &recordingNotifier{}
+
+			var held []*notifier.NotifyOpts
+
+			opts := tt.opts
+			opts.Now = func() time.Time { return now }
+			opts.Hold = func(opts *notifier.NotifyOpts) error {
+				held = append(held, opts)
+				return nil
+			}
+
+			err := notifier.NewThrottledNotifier(next, opts).Notify(&notifier.NotifyOpts{Name: "web"})
+			assert.NoError(t, err)
+
+			if tt.wantSent {
+				assert.Len(t, next.sent, 1)
+				assert.Empty(t, held)
+			} else {
+				assert.Empty(t, next.sent)
+				assert.Len(t, held, 1)
+				assert.Equal(t, now, *held[0].Timestamp)
+			}
+		})
+	}
+}
+
+func TestThrottledNotifierSendsRepeatedDeployNotifications(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	next := &recordingNotifier{}
+	held := 0
+
+	throttled := notifier.NewThrottledNotifier(next, notifier.ThrottleOpts{
+		Now: func() time.Time { return now },
+		Hold: func(*notifier.NotifyOpts) error {
+			held++
+			return nil
+		},
+	})
+
+	// deploy notifications are not limited outside of digest mode, even when they are sent in quick succession
+	for _, status := range []notifier.DeploymentStatus{notifier.StatusHelmDeployed, notifier.StatusHelmFailed, notifier.StatusHelmDeployed} {
+		assert.NoError(t, throttled.Notify(&notifier.NotifyOpts{Name: "web", Status: status}))
+	}
+
+	assert.Len(t, next.sent, 3)
+	assert.Equal(t, 0, held)
+}
+
+func TestDigestDue(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	quietHours, _ := notifier.ParseQuietHours("11:00", "13:00", "UTC")
+
+	assert.True(t, notifier.DigestDue(10*time.Minute, now.Add(-time.Hour), nil, now))
+	assert.False(t, notifier.DigestDue(10*time.Minute, now.Add(-time.Minute), nil, now))
+	assert.False(t, notifier.DigestDue(0, time.Time{}, quietHours, now))
+}
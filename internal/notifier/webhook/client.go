@@ -0,0 +1,281 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+const (
+	// SignatureHeader contains the hex-encoded HMAC-SHA256 signature of the payload, prefixed
+	// with "sha256=". The signature is computed over "<timestamp>.<body>".
+	SignatureHeader = "X-Porter-Signature"
+
+	// TimestampHeader contains the unix timestamp at which the payload was signed, so that
+	// receivers can reject replayed payloads
+	TimestampHeader = "X-Porter-Timestamp"
+
+	// EventHeader contains the event type of the payload
+	EventHeader = "X-Porter-Event"
+)
+
+// EventType is the type of event that a webhook payload describes
+type EventType string
+
+const (
+	EventDeploymentSucceeded EventType = "deployment.succeeded"
+	EventDeploymentFailed    EventType = "deployment.failed"
+	EventDeploymentCrashed   EventType = "deployment.pod_crashed"
+	EventDeploymentBlocked   EventType = "deployment.policy_blocked"
+	EventIncidentCreated     EventType = "incident.created"
+	EventIncidentResolved    EventType = "incident.resolved"
+
+	// EventRecommenderChecksFailing is sent when recommender checks change from passing to failing
+	EventRecommenderChecksFailing EventType = "recommender.checks_failing"
+)
+
+// Payload is the JSON body sent to each webhook
+type Payload struct {
+	Event     EventType `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+	ProjectID uint      `json:"project_id"`
+	ClusterID uint      `json:"cluster_id,omitempty"`
+
+	// URL links to the resource in the Porter dashboard
+	URL string `json:"url,omitempty"`
+
+	Deployment *DeploymentPayload `json:"deployment,omitempty"`
+	Incident   *types.Incident    `json:"incident,omitempty"`
+
+	Recommender *types.RecommenderRegressions `json:"recommender,omitempty"`
+}
+
+// DeploymentPayload describes a deployment event
+type DeploymentPayload struct {
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	ClusterName string `json:"cluster_name"`
+	Version     int    `json:"version,omitempty"`
+	Info        string `json:"info,omitempty"`
+}
+
+// ClientOpts configures how payloads are delivered
+type ClientOpts struct {
+	// MaxAttempts is the number of times a payload is sent before giving up
+	MaxAttempts int
+
+	// Backoff is the initial amount of time to wait between attempts. It doubles after
+	// each failed attempt.
+	Backoff time.Duration
+
+	// Timeout is the timeout for each individual request
+	Timeout time.Duration
+
+	// MaxElapsed bounds the total time spent delivering a payload to all webhooks, including retries, since
+	// payloads are delivered while handling API requests. It defaults to DefaultMaxElapsed.
+	MaxElapsed time.Duration
+
+	// AllowPrivateDestinations allows payloads to be sent to private, loopback and link-local addresses. It
+	// must only be set in tests.
+	AllowPrivateDestinations bool
+}
+
+// DefaultMaxElapsed is the total time spent delivering a payload when ClientOpts.MaxElapsed is not set
+const DefaultMaxElapsed = 10 * time.Second
+
+// DefaultClientOpts are the options used when a notifier is not given client options
+var DefaultClientOpts = &ClientOpts{
+	MaxAttempts: 3,
+	Backoff:     500 * time.Millisecond,
+	Timeout:     5 * time.Second,
+	MaxElapsed:  DefaultMaxElapsed,
+}
+
+// ErrDisallowedDestination is returned when a webhook URL resolves to a private, loopback or link-local address
+var ErrDisallowedDestination = errors.New("webhook destination is not a public address")
+
+type client struct {
+	opts       *ClientOpts
+	httpClient *http.Client
+}
+
+func newClient(opts *ClientOpts) *client {
+	if opts == nil {
+		opts = DefaultClientOpts
+	}
+
+	dialer := &net.Dialer{
+		Timeout: opts.Timeout,
+	}
+
+	if !opts.AllowPrivateDestinations {
+		// the address is checked after it is resolved, so that a public hostname which resolves to a private
+		// address is rejected as well
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || isDisallowedIP(ip) {
+				return ErrDisallowedDestination
+			}
+
+			return nil
+		}
+	}
+
+	return &client{
+		opts: opts,
+		httpClient: &http.Client{
+			Timeout: opts.Timeout,
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: opts.Timeout,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     30 * time.Second,
+			},
+		},
+	}
+}
+
+func isDisallowedIP(ip net.IP) bool {
+	return ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified()
+}
+
+// Sign returns the signature header value for a body signed at the given timestamp
+func Sign(secret []byte, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// sendAll sends the payload to every webhook concurrently, and returns the last error encountered. A
+// failing webhook does not prevent delivery to the remaining webhooks, and delivery to all webhooks is
+// abandoned once MaxElapsed has passed.
+func (c *client) sendAll(webhookInts []*integrations.WebhookIntegration, payload *Payload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	maxElapsed := c.opts.MaxElapsed
+
+	if maxElapsed == 0 {
+		maxElapsed = DefaultMaxElapsed
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), maxElapsed)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		lastErr error
+	)
+
+	for _, webhookInt := range webhookInts {
+		wg.Add(1)
+
+		go func(webhookInt *integrations.WebhookIntegration) {
+			defer wg.Done()
+
+			if err := c.send(ctx, webhookInt, payload.Event, body); err != nil {
+				mu.Lock()
+				lastErr = fmt.Errorf("error sending webhook %d: %w", webhookInt.ID, err)
+				mu.Unlock()
+			}
+		}(webhookInt)
+	}
+
+	wg.Wait()
+
+	return lastErr
+}
+
+// send delivers a single payload, retrying on network errors, 429s and 5xx responses until the context
+// is done
+func (c *client) send(ctx context.Context, webhookInt *integrations.WebhookIntegration, event EventType, body []byte) error {
+	backoff := c.opts.Backoff
+
+	var err error
+
+	for attempt := 1; attempt <= c.opts.MaxAttempts; attempt++ {
+		var retryable bool
+
+		retryable, err = c.sendOnce(ctx, webhookInt, event, body)
+
+		if err == nil || !retryable {
+			return err
+		}
+
+		if attempt < c.opts.MaxAttempts {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+
+			backoff *= 2
+		}
+	}
+
+	return err
+}
+
+func (c *client) sendOnce(ctx context.Context, webhookInt *integrations.WebhookIntegration, event EventType, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, string(webhookInt.URL), bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Porter-Webhook")
+	req.Header.Set(EventHeader, string(event))
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+
+	if len(webhookInt.SigningSecret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(webhookInt.SigningSecret, timestamp, body))
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return !errors.Is(err, ErrDisallowedDestination) && ctx.Err() == nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	err = fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type DeploymentNotifier struct {
+	webhookInts []*integrations.WebhookIntegration
+	client      *client
+	Config      *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, webhookInts ...*integrations.WebhookIntegration) *DeploymentNotifier {
+	return NewDeploymentNotifierWithClientOpts(conf, nil, webhookInts...)
+}
+
+func NewDeploymentNotifierWithClientOpts(
+	conf *types.NotificationConfig,
+	clientOpts *ClientOpts,
+	webhookInts ...*integrations.WebhookIntegration,
+) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		webhookInts: webhookInts,
+		client:      newClient(clientOpts),
+		Config:      conf,
+	}
+}
+
+func (w *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if w.Config != nil {
+		if !w.Config.Enabled {
+			return nil
+		}
+		if opts.Status == notifier.StatusHelmDeployed && !w.Config.Success {
+			return nil
+		}
+		if opts.Status == notifier.StatusPodCrashed && !w.Config.Failure {
+			return nil
+		}
+		if opts.Status == notifier.StatusHelmFailed && !w.Config.Failure {
+			return nil
+		}
+		if opts.Status == notifier.StatusPolicyBlocked && !w.Config.Failure {
+			return nil
+		}
+	}
+
+	if len(w.webhookInts) == 0 {
+		return nil
+	}
+
+	var event EventType
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		event = EventDeploymentSucceeded
+	case notifier.StatusHelmFailed:
+		event = EventDeploymentFailed
+	case notifier.StatusPodCrashed:
+		event = EventDeploymentCrashed
+	case notifier.StatusPolicyBlocked:
+		event = EventDeploymentBlocked
+	default:
+		return nil
+	}
+
+	timestamp := time.Now().UTC()
+
+	if opts.Timestamp != nil {
+		timestamp = opts.Timestamp.UTC()
+	}
+
+	return w.client.sendAll(w.webhookInts, &Payload{
+		Event:     event,
+		Timestamp: timestamp,
+		ProjectID: opts.ProjectID,
+		ClusterID: opts.ClusterID,
+		URL:       opts.URL,
+		Deployment: &DeploymentPayload{
+			Name:        opts.Name,
+			Namespace:   opts.Namespace,
+			ClusterName: opts.ClusterName,
+			Version:     opts.Version,
+			Info:        opts.Info,
+		},
+	})
+}
+
+// NotifyDigest sends each held notification as its own payload, since webhook consumers are usually automated
+// and expect one payload per event. Each payload keeps the timestamp of the original notification.
+func (w *DeploymentNotifier) NotifyDigest(notifs []*notifier.NotifyOpts) error {
+	var firstErr error
+
+	for _, opts := range notifs {
+		if err := w.Notify(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
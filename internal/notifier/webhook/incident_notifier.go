@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type IncidentNotifierOpts struct {
+	ProjectID uint
+	ClusterID uint
+
+	// Config is the notification config of the release, if it has one. Incidents are only sent when
+	// notifications and failure notifications are enabled.
+	Config *types.NotificationConfig
+
+	// ClientOpts configures retries and timeouts, and defaults to DefaultClientOpts
+	ClientOpts *ClientOpts
+}
+
+type IncidentNotifier struct {
+	opts        *IncidentNotifierOpts
+	webhookInts []*integrations.WebhookIntegration
+	client      *client
+}
+
+func NewIncidentNotifier(opts *IncidentNotifierOpts, webhookInts ...*integrations.WebhookIntegration) *IncidentNotifier {
+	return &IncidentNotifier{
+		opts:        opts,
+		webhookInts: webhookInts,
+		client:      newClient(opts.ClientOpts),
+	}
+}
+
+func (w *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	return w.notify(EventIncidentCreated, incident, url)
+}
+
+func (w *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	return w.notify(EventIncidentResolved, incident, url)
+}
+
+func (w *IncidentNotifier) notify(event EventType, incident *types.Incident, url string) error {
+	if conf := w.opts.Config; conf != nil && (!conf.Enabled || !conf.Failure) {
+		return nil
+	}
+
+	if len(w.webhookInts) == 0 {
+		return nil
+	}
+
+	return w.client.sendAll(w.webhookInts, &Payload{
+		Event:     event,
+		Timestamp: time.Now().UTC(),
+		ProjectID: w.opts.ProjectID,
+		ClusterID: w.opts.ClusterID,
+		URL:       url,
+		Incident:  incident,
+	})
+}
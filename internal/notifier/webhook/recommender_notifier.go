@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type RecommenderNotifierOpts struct {
+	// ClientOpts configures retries and timeouts, and defaults to DefaultClientOpts
+	ClientOpts *ClientOpts
+}
+
+type RecommenderNotifier struct {
+	webhookInts []*integrations.WebhookIntegration
+	client      *client
+}
+
+func NewRecommenderNotifier(opts *RecommenderNotifierOpts, webhookInts ...*integrations.WebhookIntegration) *RecommenderNotifier {
+	return &RecommenderNotifier{
+		webhookInts: webhookInts,
+		client:      newClient(opts.ClientOpts),
+	}
+}
+
+func (w *RecommenderNotifier) NotifyRegressions(regressions *types.RecommenderRegressions, url string) error {
+	if len(w.webhookInts) == 0 {
+		return nil
+	}
+
+	return w.client.sendAll(w.webhookInts, &Payload{
+		Event:       EventRecommenderChecksFailing,
+		Timestamp:   time.Now().UTC(),
+		ProjectID:   regressions.ProjectID,
+		ClusterID:   regressions.ClusterID,
+		URL:         url,
+		Recommender: regressions,
+	})
+}
@@ -0,0 +1,238 @@
+package webhook_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/stretchr/testify/assert"
+)
+
+var testClientOpts = &webhook.ClientOpts{
+	MaxAttempts: 3,
+	Backoff:     time.Millisecond,
+	Timeout:     time.Second,
+
+	// httptest servers listen on the loopback address
+	AllowPrivateDestinations: true,
+}
+
+func TestDeploymentNotifierSignsPayload(t *testing.T) {
+	secret := []byte("secret")
+
+	var gotPayload webhook.Payload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		timestamp, err := strconv.ParseInt(r.Header.Get(webhook.TimestampHeader), 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, webhook.Sign(secret, timestamp, body), r.Header.Get(webhook.SignatureHeader))
+		assert.Equal(t, string(webhook.EventDeploymentSucceeded), r.Header.Get(webhook.EventHeader))
+
+		if err := json.Unmarshal(body, &gotPayload); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	n := webhook.NewDeploymentNotifierWithClientOpts(nil, testClientOpts, &integrations.WebhookIntegration{
+		URL:           []byte(server.URL),
+		SigningSecret: secret,
+	})
+
+	err := n.Notify(&notifier.NotifyOpts{
+		ProjectID: 1,
+		ClusterID: 2,
+		Status:    notifier.StatusHelmDeployed,
+		Name:      "web",
+		Namespace: "default",
+		Version:   3,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, webhook.EventDeploymentSucceeded, gotPayload.Event)
+	assert.Equal(t, uint(1), gotPayload.ProjectID)
+	assert.Equal(t, "web", gotPayload.Deployment.Name)
+	assert.Equal(t, 3, gotPayload.Deployment.Version)
+}
+
+func TestDeploymentNotifierRespectsConfig(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	n := webhook.NewDeploymentNotifierWithClientOpts(
+		&types.NotificationConfig{Enabled: true, Success: false, Failure: true},
+		testClientOpts,
+		&integrations.WebhookIntegration{URL: []byte(server.URL)},
+	)
+
+	assert.NoError(t, n.Notify(&notifier.NotifyOpts{Status: notifier.StatusHelmDeployed}))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls), "success notifications should be filtered")
+
+	assert.NoError(t, n.Notify(&notifier.NotifyOpts{Status: notifier.StatusHelmFailed}))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestIncidentNotifierRetries(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	n := webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+		ProjectID:  1,
+		ClientOpts: testClientOpts,
+	}, &integrations.WebhookIntegration{URL: []byte(server.URL)})
+
+	err := n.NotifyNew(&types.Incident{IncidentMeta: &types.IncidentMeta{ID: "incident-1"}}, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestIncidentNotifierDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	n := webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+		ProjectID:  1,
+		ClientOpts: testClientOpts,
+	}, &integrations.WebhookIntegration{URL: []byte(server.URL)})
+
+	err := n.NotifyResolved(&types.Incident{IncidentMeta: &types.IncidentMeta{ID: "incident-1"}}, "")
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRecommenderNotifierSendsRegressions(t *testing.T) {
+	var gotPayload webhook.Payload
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, string(webhook.EventRecommenderChecksFailing), r.Header.Get(webhook.EventHeader))
+
+		if err := json.NewDecoder(r.Body).Decode(&gotPayload); err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	n := webhook.NewRecommenderNotifier(&webhook.RecommenderNotifierOpts{
+		ClientOpts: testClientOpts,
+	}, &integrations.WebhookIntegration{URL: []byte(server.URL)})
+
+	err := n.NotifyRegressions(&types.RecommenderRegressions{
+		ProjectID:   1,
+		ClusterID:   2,
+		ClusterName: "production",
+		Regressions: []*types.RecommenderRegression{{
+			ObjectID: "pod_security/default/web",
+			Severity: types.MonitorTestSeverityCritical,
+		}},
+	}, "https://dashboard.porter.run")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), gotPayload.ClusterID)
+
+	if assert.NotNil(t, gotPayload.Recommender) && assert.Len(t, gotPayload.Recommender.Regressions, 1) {
+		assert.Equal(t, "pod_security/default/web", gotPayload.Recommender.Regressions[0].ObjectID)
+	}
+}
+
+func TestClientRejectsPrivateDestinations(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	n := webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+		ProjectID: 1,
+		ClientOpts: &webhook.ClientOpts{
+			MaxAttempts: 3,
+			Backoff:     time.Millisecond,
+			Timeout:     time.Second,
+		},
+	}, &integrations.WebhookIntegration{URL: []byte(server.URL)})
+
+	err := n.NotifyNew(&types.Incident{IncidentMeta: &types.IncidentMeta{ID: "incident-1"}}, "")
+
+	assert.ErrorIs(t, err, webhook.ErrDisallowedDestination)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestClientBoundsTotalDeliveryTime(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	n := webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+		ProjectID: 1,
+		ClientOpts: &webhook.ClientOpts{
+			MaxAttempts:              10,
+			Backoff:                  time.Second,
+			Timeout:                  time.Second,
+			MaxElapsed:               100 * time.Millisecond,
+			AllowPrivateDestinations: true,
+		},
+	}, &integrations.WebhookIntegration{URL: []byte(server.URL)})
+
+	start := time.Now()
+
+	err := n.NotifyNew(&types.Incident{IncidentMeta: &types.IncidentMeta{ID: "incident-1"}}, "")
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestIncidentNotifierRespectsConfig(t *testing.T) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	n := webhook.NewIncidentNotifier(&webhook.IncidentNotifierOpts{
+		ProjectID:  1,
+		ClientOpts: testClientOpts,
+		Config:     &types.NotificationConfig{Enabled: true, Success: true, Failure: false},
+	}, &integrations.WebhookIntegration{URL: []byte(server.URL)})
+
+	incident := &types.Incident{IncidentMeta: &types.IncidentMeta{ID: "incident-1"}}
+
+	assert.NoError(t, n.NotifyNew(incident, ""))
+	assert.NoError(t, n.NotifyResolved(incident, ""))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,310 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+// OIDCProviderMetadata is the subset of an OpenID Connect discovery document that
+// Porter needs in order to log users in
+type OIDCProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCConf is a standard oauth2 config along with the discovered provider metadata
+type OIDCConf struct {
+	Provider *OIDCProviderMetadata
+	oauth2.Config
+}
+
+// OIDCUserInfo contains the standard claims returned by an OIDC userinfo endpoint
+type OIDCUserInfo struct {
+	Sub           string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	GivenName     string `json:"given_name"`
+	FamilyName    string `json:"family_name"`
+}
+
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// DiscoverOIDCProvider fetches the discovery document for an OIDC provider. The discovery
+// URL may either be the issuer URL or the full path to the .well-known/openid-configuration
+// document.
+func DiscoverOIDCProvider(ctx context.Context, discoveryURL string) (*OIDCProviderMetadata, error) {
+	if discoveryURL == "" {
+		return nil, fmt.Errorf("oidc discovery url cannot be empty")
+	}
+
+	if !strings.HasSuffix(discoveryURL, "/.well-known/openid-configuration") {
+		discoveryURL = strings.TrimSuffix(discoveryURL, "/") + "/.well-known/openid-configuration"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating oidc discovery request: %w", err)
+	}
+
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oidc discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc discovery document returned status %d", resp.StatusCode)
+	}
+
+	metadata := &OIDCProviderMetadata{}
+
+	if err := json.NewDecoder(resp.Body).Decode(metadata); err != nil {
+		return nil, fmt.Errorf("error decoding oidc discovery document: %w", err)
+	}
+
+	if metadata.Issuer == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc discovery document must contain an issuer and a jwks uri")
+	}
+
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.UserinfoEndpoint == "" {
+		return nil, fmt.Errorf("oidc discovery document must contain authorization, token and userinfo endpoints")
+	}
+
+	return metadata, nil
+}
+
+// NewOIDCClient returns an oauth2 config for a discovered OIDC provider
+func NewOIDCClient(cfg *Config, provider *OIDCProviderMetadata) *OIDCConf {
+	scopes := cfg.Scopes
+
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+
+	return &OIDCConf{
+		Provider: provider,
+		Config: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  provider.AuthorizationEndpoint,
+				TokenURL: provider.TokenEndpoint,
+			},
+			RedirectURL: cfg.BaseURL + "/api/oauth/oidc/callback",
+			Scopes:      scopes,
+		},
+	}
+}
+
+// GetUserInfo queries the provider's userinfo endpoint with the given access token
+func (c *OIDCConf) GetUserInfo(ctx context.Context, tok *oauth2.Token) (*OIDCUserInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Provider.UserinfoEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating oidc userinfo request: %w", err)
+	}
+
+	req.Header.Add("Authorization", "Bearer "+tok.AccessToken)
+
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed getting user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc userinfo endpoint returned status %d", resp.StatusCode)
+	}
+
+	info := &OIDCUserInfo{}
+
+	if err := json.Unmarshal(contents, info); err != nil {
+		return nil, fmt.Errorf("failed parsing user info: %w", err)
+	}
+
+	if info.Sub == "" {
+		return nil, fmt.Errorf("oidc userinfo response did not contain a subject")
+	}
+
+	return info, nil
+}
+
+// OIDCIDTokenClaims are the claims of a verified ID token which identify the user
+type OIDCIDTokenClaims struct {
+	Sub           string
+	Email         string
+	EmailVerified bool
+}
+
+// VerifyIDToken verifies the ID token returned alongside an access token: the token must be signed by
+// one of the provider's published keys, issued by the provider for this client, unexpired, and must
+// contain the nonce sent with the authorization request
+func (c *OIDCConf) VerifyIDToken(ctx context.Context, tok *oauth2.Token, nonce string) (*OIDCIDTokenClaims, error) {
+	rawIDToken, ok := tok.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("oidc token response did not contain an id token")
+	}
+
+	if nonce == "" {
+		return nil, fmt.Errorf("oidc nonce cannot be empty")
+	}
+
+	keys, err := c.getSigningKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := jwt.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected id token signing method: %v", token.Header["alg"])
+		}
+
+		kid, _ := token.Header["kid"].(string)
+
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+
+		// providers with a single key may omit the key id
+		if kid == "" && len(keys) == 1 {
+			for _, key := range keys {
+				return key, nil
+			}
+		}
+
+		return nil, fmt.Errorf("id token signed with unknown key %q", kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return nil, fmt.Errorf("invalid id token")
+	}
+
+	if iss, _ := claims["iss"].(string); iss != c.Provider.Issuer {
+		return nil, fmt.Errorf("id token issuer %q does not match provider issuer", iss)
+	}
+
+	if !idTokenHasAudience(claims["aud"], c.ClientID) {
+		return nil, fmt.Errorf("id token was not issued for this client")
+	}
+
+	// expiry is only verified by the jwt library if present, so it is required here
+	if _, ok := claims["exp"]; !ok {
+		return nil, fmt.Errorf("id token does not have an expiry")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("id token nonce does not match")
+	}
+
+	res := &OIDCIDTokenClaims{}
+
+	res.Sub, _ = claims["sub"].(string)
+	res.Email, _ = claims["email"].(string)
+	res.EmailVerified, _ = claims["email_verified"].(bool)
+
+	if res.Sub == "" {
+		return nil, fmt.Errorf("id token did not contain a subject")
+	}
+
+	return res, nil
+}
+
+func idTokenHasAudience(aud interface{}, clientID string) bool {
+	switch typed := aud.(type) {
+	case string:
+		return typed == clientID
+	case []interface{}:
+		for _, a := range typed {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+type oidcJSONWebKeySet struct {
+	Keys []struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	} `json:"keys"`
+}
+
+// getSigningKeys fetches the provider's RSA signing keys, keyed by key id
+func (c *OIDCConf) getSigningKeys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Provider.JWKSURI, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating oidc jwks request: %w", err)
+	}
+
+	resp, err := oidcHTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching oidc jwks: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc jwks endpoint returned status %d", resp.StatusCode)
+	}
+
+	jwks := &oidcJSONWebKeySet{}
+
+	if err := json.NewDecoder(resp.Body).Decode(jwks); err != nil {
+		return nil, fmt.Errorf("error decoding oidc jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+
+	for _, jwk := range jwks.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus for oidc key %s: %w", jwk.Kid, err)
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent for oidc key %s: %w", jwk.Kid, err)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("oidc jwks did not contain any rsa signing keys")
+	}
+
+	return keys, nil
+}
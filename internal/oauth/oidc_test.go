@@ -0,0 +1,195 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+var testOIDCSigningKey *rsa.PrivateKey
+
+func init() {
+	var err error
+
+	testOIDCSigningKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func newMockOIDCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(OIDCProviderMetadata{
+			Issuer:                server.URL,
+			AuthorizationEndpoint: server.URL + "/authorize",
+			TokenEndpoint:         server.URL + "/token",
+			UserinfoEndpoint:      server.URL + "/userinfo",
+			JWKSURI:               server.URL + "/jwks",
+		})
+	})
+
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		pub := testOIDCSigningKey.PublicKey
+
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"kid": "key-1",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer valid-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(OIDCUserInfo{
+			Sub:           "user-1",
+			Email:         "jane@example.com",
+			EmailVerified: true,
+		})
+	})
+
+	return server
+}
+
+func TestDiscoverOIDCProvider(t *testing.T) {
+	server := newMockOIDCServer(t)
+	defer server.Close()
+
+	for _, discoveryURL := range []string{
+		server.URL,
+		server.URL + "/",
+		server.URL + "/.well-known/openid-configuration",
+	} {
+		metadata, err := DiscoverOIDCProvider(context.Background(), discoveryURL)
+		assert.NoError(t, err)
+		assert.Equal(t, server.URL+"/authorize", metadata.AuthorizationEndpoint)
+		assert.Equal(t, server.URL+"/token", metadata.TokenEndpoint)
+		assert.Equal(t, server.URL+"/userinfo", metadata.UserinfoEndpoint)
+	}
+
+	_, err := DiscoverOIDCProvider(context.Background(), server.URL+"/missing")
+	assert.Error(t, err)
+}
+
+func TestOIDCGetUserInfo(t *testing.T) {
+	server := newMockOIDCServer(t)
+	defer server.Close()
+
+	metadata, err := DiscoverOIDCProvider(context.Background(), server.URL)
+	assert.NoError(t, err)
+
+	conf := NewOIDCClient(&Config{
+		ClientID:     "porter",
+		ClientSecret: "secret",
+		BaseURL:      "https://porter.example.com",
+	}, metadata)
+
+	assert.Equal(t, "https://porter.example.com/api/oauth/oidc/callback", conf.RedirectURL)
+	assert.Equal(t, []string{"openid", "email", "profile"}, conf.Scopes)
+
+	info, err := conf.GetUserInfo(context.Background(), &oauth2.Token{AccessToken: "valid-token"})
+	assert.NoError(t, err)
+	assert.Equal(t, "user-1", info.Sub)
+	assert.Equal(t, "jane@example.com", info.Email)
+	assert.True(t, info.EmailVerified)
+
+	_, err = conf.GetUserInfo(context.Background(), &oauth2.Token{AccessToken: "bad-token"})
+	assert.Error(t, err)
+}
+
+func signTestIDToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) *oauth2.Token {
+	t.Helper()
+
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	idToken.Header["kid"] = "key-1"
+
+	signed, err := idToken.SignedString(key)
+	require.NoError(t, err)
+
+	return (&oauth2.Token{AccessToken: "valid-token"}).WithExtra(map[string]interface{}{
+		"id_token": signed,
+	})
+}
+
+func TestOIDCVerifyIDToken(t *testing.T) {
+	server := newMockOIDCServer(t)
+	defer server.Close()
+
+	metadata, err := DiscoverOIDCProvider(context.Background(), server.URL)
+	require.NoError(t, err)
+
+	conf := NewOIDCClient(&Config{
+		ClientID:     "porter",
+		ClientSecret: "secret",
+		BaseURL:      "https://porter.example.com",
+	}, metadata)
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            server.URL,
+			"aud":            "porter",
+			"sub":            "user-1",
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"nonce":          "nonce-1",
+			"exp":            time.Now().Add(time.Minute).Unix(),
+		}
+	}
+
+	claims, err := conf.VerifyIDToken(context.Background(), signTestIDToken(t, testOIDCSigningKey, validClaims()), "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Sub)
+	assert.Equal(t, "jane@example.com", claims.Email)
+	assert.True(t, claims.EmailVerified)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	_, err = conf.VerifyIDToken(context.Background(), signTestIDToken(t, otherKey, validClaims()), "nonce-1")
+	assert.Error(t, err, "tokens signed by another key should be rejected")
+
+	_, err = conf.VerifyIDToken(context.Background(), signTestIDToken(t, testOIDCSigningKey, validClaims()), "nonce-2")
+	assert.Error(t, err, "tokens with another nonce should be rejected")
+
+	for name, modify := range map[string]func(jwt.MapClaims){
+		"issuer":    func(c jwt.MapClaims) { c["iss"] = "https://attacker.example.com" },
+		"audience":  func(c jwt.MapClaims) { c["aud"] = []string{"another-client"} },
+		"expired":   func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() },
+		"no expiry": func(c jwt.MapClaims) { delete(c, "exp") },
+	} {
+		claims := validClaims()
+		modify(claims)
+
+		_, err = conf.VerifyIDToken(context.Background(), signTestIDToken(t, testOIDCSigningKey, claims), "nonce-1")
+		assert.Error(t, err, name)
+	}
+
+	_, err = conf.VerifyIDToken(context.Background(), &oauth2.Token{AccessToken: "valid-token"}, "nonce-1")
+	assert.Error(t, err, "token responses without an id token should be rejected")
+}
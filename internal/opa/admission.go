@@ -0,0 +1,457 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm/grapher"
+	"github.com/stefanmcshane/helm/pkg/release"
+)
+
+// admissionObject is an object of a rendered release which the policies of a collection are evaluated against
+type admissionObject struct {
+	objectID string
+	input    map[string]interface{}
+}
+
+// EvaluateAdmission evaluates the enforced policies of the collections against a release which was rendered by
+// a dry run, and returns a violation for each policy that does not allow an object of the release. Pod
+// collections are evaluated against the pod templates of the workloads in the manifest, since the pods do not
+// exist yet.
+func EvaluateAdmission(ctx context.Context, collections []*CustomPolicyCollection, rel *release.Release, kubernetesService string) ([]*types.PolicyViolation, error) {
+	var manifest []map[string]interface{}
+
+	return evaluateAdmission(ctx, collections, kubernetesService, func(collection *CustomPolicyCollection) []admissionObject {
+		if manifest == nil && collection.Kind != HelmRelease {
+			manifest = grapher.ImportMultiDocYAML([]byte(rel.Manifest))
+		}
+
+		switch collection.Kind {
+		case HelmRelease:
+			return helmReleaseAdmissionObjects(collection.Match, rel)
+		case Pod:
+			return podAdmissionObjects(collection.Match, manifest, rel.Namespace)
+		case Daemonset:
+			return daemonsetAdmissionObjects(collection.Match, manifest, rel.Namespace)
+		}
+
+		return nil
+	})
+}
+
+// EvaluateAppAdmission evaluates the enforced policies of the collections against an app which is deployed by the
+// cluster control plane. The control plane renders the manifests of the app itself, so pod collections are evaluated
+// against a pod built from each service of the app and its pre-deploy job, and helm release collections which match
+// the app by name are evaluated against the app. The namespace is empty if the deployment target of the app is not
+// known, in which case collections which match a namespace are evaluated as if the app were deployed to it.
+func EvaluateAppAdmission(ctx context.Context, collections []*CustomPolicyCollection, app *porterv1.PorterApp, namespace, kubernetesService string) ([]*types.PolicyViolation, error) {
+	return evaluateAdmission(ctx, collections, kubernetesService, func(collection *CustomPolicyCollection) []admissionObject {
+		if namespace != "" && collection.Match.Namespace != "" && collection.Match.Namespace != namespace {
+			return nil
+		}
+
+		switch collection.Kind {
+		case HelmRelease:
+			return appHelmReleaseAdmissionObjects(collection.Match, app, namespace)
+		case Pod:
+			return appPodAdmissionObjects(collection.Match, app, namespace)
+		}
+
+		return nil
+	})
+}
+
+// evaluateAdmission evaluates the enforced policies of each collection against the objects returned for it
+func evaluateAdmission(
+	ctx context.Context,
+	collections []*CustomPolicyCollection,
+	kubernetesService string,
+	objectsFunc func(collection *CustomPolicyCollection) []admissionObject,
+) ([]*types.PolicyViolation, error) {
+	res := make([]*types.PolicyViolation, 0)
+
+	for _, collection := range collections {
+		policies := collection.enforcedPolicies()
+		if len(policies) == 0 {
+			continue
+		}
+
+		if s := collection.Match.KubernetesService; s != "" && strings.ToLower(kubernetesService) != s {
+			continue
+		}
+
+		objects := objectsFunc(collection)
+
+		if len(objects) == 0 {
+			continue
+		}
+
+		for _, policy := range policies {
+			query, err := compilePolicy(ctx, policy)
+			if err != nil {
+				return nil, fmt.Errorf("error compiling policy collection %s: %w", collection.Name, err)
+			}
+
+			for _, object := range objects {
+				results, err := evalQuery(ctx, query, object.input)
+				if err != nil {
+					return nil, fmt.Errorf("error evaluating policy %s: %w", policy.Name, err)
+				}
+
+				if len(results) != 1 {
+					continue
+				}
+
+				rawQueryRes := &rawQueryResult{}
+
+				if err := mapstructure.Decode(results[0].Expressions[0].Value, rawQueryRes); err != nil {
+					return nil, fmt.Errorf("error decoding result of policy %s: %w", policy.Name, err)
+				}
+
+				if rawQueryRes.Allow {
+					continue
+				}
+
+				severity := rawQueryRes.PolicySeverity
+				if collection.OverrideSeverity != "" {
+					severity = collection.OverrideSeverity
+				}
+
+				res = append(res, &types.PolicyViolation{
+					Collection:  collection.Name,
+					PolicyID:    rawQueryRes.PolicyID,
+					PolicyTitle: rawQueryRes.PolicyTitle,
+					Severity:    severity,
+					Enforcement: policy.Enforcement,
+					ObjectID:    object.objectID,
+					Message:     strings.Join(rawQueryRes.FailureMessage, ". "),
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (c *CustomPolicyCollection) enforcedPolicies() []types.RecommenderPolicy {
+	res := make([]types.RecommenderPolicy, 0)
+
+	for _, policy := range c.Policies {
+		if policy.Enforcement != "" {
+			res = append(res, policy)
+		}
+	}
+
+	return res
+}
+
+func helmReleaseAdmissionObjects(match MatchParameters, rel *release.Release) []admissionObject {
+	if match.Namespace != "" && match.Namespace != rel.Namespace {
+		return nil
+	}
+
+	if match.Name != "" {
+		if match.Name != rel.Name {
+			return nil
+		}
+	} else if rel.Chart == nil || rel.Chart.Name() != match.ChartName {
+		return nil
+	}
+
+	var version string
+
+	if rel.Chart != nil && rel.Chart.Metadata != nil {
+		version = rel.Chart.Metadata.Version
+	}
+
+	return []admissionObject{{
+		objectID: fmt.Sprintf("helm_release/%s/%s", rel.Namespace, rel.Name),
+		input: map[string]interface{}{
+			"version":   version,
+			"values":    rel.Config,
+			"name":      rel.Name,
+			"namespace": rel.Namespace,
+		},
+	}}
+}
+
+// appHelmReleaseAdmissionObjects returns the app as a helm release if the collection matches it by name. Apps are
+// not installed from a chart, so collections which match a chart name do not apply to them.
+func appHelmReleaseAdmissionObjects(match MatchParameters, app *porterv1.PorterApp, namespace string) []admissionObject {
+	if match.Name == "" || match.Name != app.Name {
+		return nil
+	}
+
+	values := make(map[string]interface{})
+
+	for name, service := range app.Services {
+		values[name] = appServiceValues(service)
+	}
+
+	if app.Predeploy != nil {
+		values["predeploy"] = appServiceValues(app.Predeploy)
+	}
+
+	if app.Image != nil {
+		values["image"] = map[string]interface{}{
+			"repository": app.Image.Repository,
+			"tag":        app.Image.Tag,
+		}
+	}
+
+	return []admissionObject{{
+		objectID: fmt.Sprintf("helm_release/%s/%s", namespace, app.Name),
+		input: map[string]interface{}{
+			"version":   "",
+			"values":    values,
+			"name":      app.Name,
+			"namespace": namespace,
+		},
+	}}
+}
+
+// appPodAdmissionObjects returns a pod for each service of the app, and for its pre-deploy job, running the image
+// of the app with the resources of the service
+func appPodAdmissionObjects(match MatchParameters, app *porterv1.PorterApp, namespace string) []admissionObject {
+	res := make([]admissionObject, 0)
+
+	labels := map[string]interface{}{
+		"porter.run/app-name": app.Name,
+	}
+
+	if !matchesLabels(match.Labels, map[string]interface{}{"labels": labels}) {
+		return res
+	}
+
+	var image string
+
+	if app.Image != nil {
+		image = app.Image.Repository
+
+		if app.Image.Tag != "" {
+			image += ":" + app.Image.Tag
+		}
+	}
+
+	add := func(kind, name string, service *porterv1.Service) {
+		container := map[string]interface{}{
+			"name":  name,
+			"image": image,
+		}
+
+		if service.Run != "" {
+			container["command"] = []interface{}{"/bin/sh", "-c", service.Run}
+		}
+
+		if service.Port != 0 {
+			container["ports"] = []interface{}{
+				map[string]interface{}{"containerPort": int(service.Port)},
+			}
+		}
+
+		resources := make(map[string]interface{})
+
+		if service.CpuCores != 0 {
+			resources["cpu"] = fmt.Sprintf("%dm", int(service.CpuCores*1000))
+		}
+
+		if service.RamMegabytes != 0 {
+			resources["memory"] = fmt.Sprintf("%dMi", service.RamMegabytes)
+		}
+
+		if len(resources) != 0 {
+			container["resources"] = map[string]interface{}{
+				"requests": resources,
+				"limits":   resources,
+			}
+		}
+
+		podName := fmt.Sprintf("%s-%s", app.Name, name)
+
+		res = append(res, admissionObject{
+			objectID: fmt.Sprintf("%s/%s/%s", kind, namespace, podName),
+			input: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata": map[string]interface{}{
+					"name":      podName,
+					"namespace": namespace,
+					"labels":    labels,
+				},
+				"spec": map[string]interface{}{
+					"containers": []interface{}{container},
+				},
+			},
+		})
+	}
+
+	names := make([]string, 0, len(app.Services))
+
+	for name := range app.Services {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		service := app.Services[name]
+
+		kind := "deployment"
+		if service.Type == porterv1.ServiceType_SERVICE_TYPE_JOB {
+			kind = "cronjob"
+		}
+
+		add(kind, name, service)
+	}
+
+	if app.Predeploy != nil {
+		add("job", "predeploy", app.Predeploy)
+	}
+
+	return res
+}
+
+func appServiceValues(service *porterv1.Service) map[string]interface{} {
+	return map[string]interface{}{
+		"run":          service.Run,
+		"instances":    int(service.Instances),
+		"port":         int(service.Port),
+		"cpuCores":     float64(service.CpuCores),
+		"ramMegabytes": int(service.RamMegabytes),
+		"type":         service.Type.String(),
+	}
+}
+
+// podAdmissionObjects returns a pod for the pod template of each workload in the manifest, with the name and
+// namespace of the workload
+func podAdmissionObjects(match MatchParameters, manifest []map[string]interface{}, namespace string) []admissionObject {
+	res := make([]admissionObject, 0)
+
+	for _, obj := range manifest {
+		kind, _ := obj["kind"].(string)
+
+		var template map[string]interface{}
+
+		switch strings.ToLower(kind) {
+		case "pod":
+			template = obj
+		case "deployment", "statefulset", "daemonset", "replicaset", "job":
+			template = nestedMap(obj, "spec", "template")
+		case "cronjob":
+			template = nestedMap(obj, "spec", "jobTemplate", "spec", "template")
+		default:
+			continue
+		}
+
+		if template == nil {
+			continue
+		}
+
+		name, objNamespace := objectNameAndNamespace(obj, namespace)
+
+		if match.Namespace != "" && match.Namespace != objNamespace {
+			continue
+		}
+
+		metadata := nestedMap(template, "metadata")
+		if metadata == nil {
+			metadata = make(map[string]interface{})
+		}
+
+		if !matchesLabels(match.Labels, metadata) {
+			continue
+		}
+
+		podMetadata := make(map[string]interface{}, len(metadata)+2)
+
+		for k, v := range metadata {
+			podMetadata[k] = v
+		}
+
+		podMetadata["name"] = name
+		podMetadata["namespace"] = objNamespace
+
+		res = append(res, admissionObject{
+			objectID: fmt.Sprintf("%s/%s/%s", strings.ToLower(kind), objNamespace, name),
+			input: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"metadata":   podMetadata,
+				"spec":       template["spec"],
+			},
+		})
+	}
+
+	return res
+}
+
+func daemonsetAdmissionObjects(match MatchParameters, manifest []map[string]interface{}, namespace string) []admissionObject {
+	res := make([]admissionObject, 0)
+
+	for _, obj := range manifest {
+		if kind, _ := obj["kind"].(string); kind != "DaemonSet" {
+			continue
+		}
+
+		name, objNamespace := objectNameAndNamespace(obj, namespace)
+
+		if match.Namespace != "" && match.Namespace != objNamespace {
+			continue
+		}
+
+		if !matchesLabels(match.Labels, nestedMap(obj, "metadata")) {
+			continue
+		}
+
+		res = append(res, admissionObject{
+			objectID: fmt.Sprintf("daemonset/%s/%s", objNamespace, name),
+			input:    obj,
+		})
+	}
+
+	return res
+}
+
+// objectNameAndNamespace returns the name of an object in a manifest, and its namespace or the namespace of
+// the release if it does not set one
+func objectNameAndNamespace(obj map[string]interface{}, namespace string) (string, string) {
+	metadata := nestedMap(obj, "metadata")
+
+	name, _ := metadata["name"].(string)
+
+	if ns, _ := metadata["namespace"].(string); ns != "" {
+		namespace = ns
+	}
+
+	return name, namespace
+}
+
+func matchesLabels(labels map[string]string, metadata map[string]interface{}) bool {
+	objLabels := nestedMap(metadata, "labels")
+
+	for k, v := range labels {
+		if objValue, _ := objLabels[k].(string); objValue != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+func nestedMap(obj map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		next, ok := obj[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		obj = next
+	}
+
+	return obj
+}
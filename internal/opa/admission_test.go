@@ -0,0 +1,238 @@
+package opa
+
+import (
+	"context"
+	"testing"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stefanmcshane/helm/pkg/chart"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const replicasModule = `package porter.custom.replicas
+
+POLICY_ID = "replicas"
+
+POLICY_VERSION = "v0.0.1"
+
+POLICY_SEVERITY = "low"
+
+POLICY_TITLE = "Web releases must run at least two replicas"
+
+default ALLOW = false
+
+ALLOW {
+	input.values.replicaCount >= 2
+}
+
+FAILURE_MESSAGE[msg] {
+	not ALLOW
+	msg := sprintf("release %s runs fewer than two replicas", [input.name])
+}
+`
+
+const admissionManifest = `---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    porter.run/app-name: web
+spec:
+  ports:
+  - port: 80
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    metadata:
+      labels:
+        porter.run/app-name: web
+    spec:
+      containers:
+      - name: web
+        image: nginx
+---
+apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: web-cleanup
+  namespace: jobs
+spec:
+  jobTemplate:
+    spec:
+      template:
+        metadata:
+          labels:
+            porter.run/app-name: web
+        spec:
+          containers:
+          - name: cleanup
+            image: nginx
+            resources:
+              limits:
+                memory: 256Mi
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  template:
+    metadata:
+      labels:
+        porter.run/app-name: worker
+    spec:
+      containers:
+      - name: worker
+        image: nginx
+`
+
+func admissionRelease() *release.Release {
+	return &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "web", Version: "0.50.0"},
+		},
+		Config:   map[string]interface{}{"replicaCount": 1},
+		Manifest: admissionManifest,
+	}
+}
+
+func TestEvaluateAdmissionPods(t *testing.T) {
+	collection := memoryLimitsCollection()
+	collection.Policies[0].Enforcement = types.RecommenderPolicyEnforcementBlock
+
+	violations, err := EvaluateAdmission(context.Background(), []*CustomPolicyCollection{collection}, admissionRelease(), "eks")
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+
+	assert.Equal(t, &types.PolicyViolation{
+		Collection:  "memory-limits",
+		PolicyID:    "memory_limits",
+		PolicyTitle: "Web apps must set memory limits",
+		Severity:    "high",
+		Enforcement: types.RecommenderPolicyEnforcementBlock,
+		ObjectID:    "deployment/default/web",
+		Message:     "container web does not set a memory limit",
+	}, violations[0])
+}
+
+func TestEvaluateAdmissionSkipsCollections(t *testing.T) {
+	// policies without an enforcement are only run by the recommender
+	notEnforced := memoryLimitsCollection()
+
+	otherService := memoryLimitsCollection()
+	otherService.Policies[0].Enforcement = types.RecommenderPolicyEnforcementBlock
+	otherService.Match.KubernetesService = "gke"
+
+	otherLabels := memoryLimitsCollection()
+	otherLabels.Policies[0].Enforcement = types.RecommenderPolicyEnforcementBlock
+	otherLabels.Match.Labels = map[string]string{"porter.run/app-name": "api"}
+
+	violations, err := EvaluateAdmission(
+		context.Background(),
+		[]*CustomPolicyCollection{notEnforced, otherService, otherLabels},
+		admissionRelease(),
+		"eks",
+	)
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestEvaluateAdmissionHelmRelease(t *testing.T) {
+	collection := &CustomPolicyCollection{
+		Name:             "replicas",
+		Kind:             HelmRelease,
+		Match:            MatchParameters{ChartName: "web"},
+		OverrideSeverity: "critical",
+		Policies: []types.RecommenderPolicy{{
+			Name:        "porter.custom.replicas",
+			Module:      replicasModule,
+			Enforcement: types.RecommenderPolicyEnforcementWarn,
+		}},
+	}
+
+	violations, err := EvaluateAdmission(context.Background(), []*CustomPolicyCollection{collection}, admissionRelease(), "eks")
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "helm_release/default/web", violations[0].ObjectID)
+	assert.Equal(t, "critical", violations[0].Severity)
+	assert.Equal(t, types.RecommenderPolicyEnforcementWarn, violations[0].Enforcement)
+	assert.Equal(t, "release web runs fewer than two replicas", violations[0].Message)
+
+	rel := admissionRelease()
+	rel.Config["replicaCount"] = 3
+
+	violations, err = EvaluateAdmission(context.Background(), []*CustomPolicyCollection{collection}, rel, "eks")
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	rel.Chart.Metadata.Name = "worker"
+	rel.Config["replicaCount"] = 1
+
+	violations, err = EvaluateAdmission(context.Background(), []*CustomPolicyCollection{collection}, rel, "eks")
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestEvaluateAppAdmission(t *testing.T) {
+	collection := memoryLimitsCollection()
+	collection.Policies[0].Enforcement = types.RecommenderPolicyEnforcementBlock
+	collection.Match.Namespace = "default"
+
+	app := &porterv1.PorterApp{
+		Name: "web",
+		Services: map[string]*porterv1.Service{
+			"api":    {Run: "./api", RamMegabytes: 512, Type: porterv1.ServiceType_SERVICE_TYPE_WEB},
+			"worker": {Run: "./worker", Type: porterv1.ServiceType_SERVICE_TYPE_WORKER},
+		},
+		Predeploy: &porterv1.Service{Run: "./migrate"},
+		Image:     &porterv1.AppImage{Repository: "nginx", Tag: "latest"},
+	}
+
+	violations, err := EvaluateAppAdmission(context.Background(), []*CustomPolicyCollection{collection}, app, "default", "eks")
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+	assert.Equal(t, "deployment/default/web-worker", violations[0].ObjectID)
+	assert.Equal(t, "container worker does not set a memory limit", violations[0].Message)
+	assert.Equal(t, "job/default/web-predeploy", violations[1].ObjectID)
+
+	// collections which match another namespace do not apply, unless the namespace of the app is not known
+	violations, err = EvaluateAppAdmission(context.Background(), []*CustomPolicyCollection{collection}, app, "other", "eks")
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	violations, err = EvaluateAppAdmission(context.Background(), []*CustomPolicyCollection{collection}, app, "", "eks")
+	require.NoError(t, err)
+	assert.Len(t, violations, 2)
+
+	app.Name = "api"
+
+	violations, err = EvaluateAppAdmission(context.Background(), []*CustomPolicyCollection{collection}, app, "default", "eks")
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestCustomPolicyCollectionCompileEnforcedCRDList(t *testing.T) {
+	collection := &CustomPolicyCollection{
+		Name:  "certificates",
+		Kind:  CRDList,
+		Match: MatchParameters{Group: "cert-manager.io", Version: "v1", Resource: "certificates"},
+		Policies: []types.RecommenderPolicy{{
+			Name:        "porter.custom.memory_limits",
+			Module:      memoryLimitsModule,
+			Enforcement: types.RecommenderPolicyEnforcementBlock,
+		}},
+	}
+
+	_, err := collection.Compile(context.Background())
+	assert.ErrorContains(t, err, "crd_list collections cannot be enforced")
+}
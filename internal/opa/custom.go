@@ -0,0 +1,211 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// CustomCategoryPrefix is prepended to the names of custom policy collections, so that the categories and
+// object ids of their results cannot collide with those of the built-in collections
+const CustomCategoryPrefix = "custom-"
+
+// CustomPolicyCollection is a policy collection defined by a project rather than the config file
+type CustomPolicyCollection struct {
+	Name             string
+	Kind             KubernetesBuiltInKind
+	Match            MatchParameters
+	MustExist        bool
+	OverrideSeverity string
+	Policies         []types.RecommenderPolicy
+}
+
+// NewCustomPolicyCollection converts the collection in an API request to a CustomPolicyCollection
+func NewCustomPolicyCollection(req *types.CreateRecommenderPolicyCollectionRequest) *CustomPolicyCollection {
+	return &CustomPolicyCollection{
+		Name:             req.Name,
+		Kind:             KubernetesBuiltInKind(req.Kind),
+		Match:            matchParametersFromType(req.Match),
+		MustExist:        req.MustExist,
+		OverrideSeverity: string(req.OverrideSeverity),
+		Policies:         req.Policies,
+	}
+}
+
+// CustomPolicyCollectionFromModel converts a stored policy collection to a CustomPolicyCollection
+func CustomPolicyCollectionFromModel(collection *models.RecommenderPolicyCollection) (*CustomPolicyCollection, error) {
+	collectionType, err := collection.ToRecommenderPolicyCollectionType()
+	if err != nil {
+		return nil, fmt.Errorf("error decoding policy collection %s: %w", collection.Name, err)
+	}
+
+	return &CustomPolicyCollection{
+		Name:             collectionType.Name,
+		Kind:             KubernetesBuiltInKind(collectionType.Kind),
+		Match:            matchParametersFromType(collectionType.Match),
+		MustExist:        collectionType.MustExist,
+		OverrideSeverity: string(collectionType.OverrideSeverity),
+		Policies:         collectionType.Policies,
+	}, nil
+}
+
+func matchParametersFromType(match types.RecommenderPolicyMatch) MatchParameters {
+	return MatchParameters{
+		KubernetesService: match.KubernetesService,
+		Name:              match.Name,
+		Namespace:         match.Namespace,
+		ChartName:         match.ChartName,
+		Labels:            match.Labels,
+		Group:             match.Group,
+		Version:           match.Version,
+		Resource:          match.Resource,
+	}
+}
+
+// Compile validates the collection and compiles each of its policies. The package of each Rego module must
+// be the name of its policy, since policies are evaluated by querying data.<name>.
+func (c *CustomPolicyCollection) Compile(ctx context.Context) (KubernetesOPAQueryCollection, error) {
+	if err := c.validateMatch(); err != nil {
+		return KubernetesOPAQueryCollection{}, err
+	}
+
+	if len(c.Policies) == 0 {
+		return KubernetesOPAQueryCollection{}, fmt.Errorf("at least one policy is required")
+	}
+
+	queries := make([]rego.PreparedEvalQuery, 0, len(c.Policies))
+
+	for _, policy := range c.Policies {
+		query, err := compilePolicy(ctx, policy)
+		if err != nil {
+			return KubernetesOPAQueryCollection{}, err
+		}
+
+		queries = append(queries, query)
+	}
+
+	return KubernetesOPAQueryCollection{
+		Kind:             c.Kind,
+		Queries:          queries,
+		Match:            c.Match,
+		OverrideSeverity: c.OverrideSeverity,
+		MustExist:        c.MustExist,
+		ObjectIDPrefix:   CustomCategoryPrefix + c.Name,
+	}, nil
+}
+
+func compilePolicy(ctx context.Context, policy types.RecommenderPolicy) (rego.PreparedEvalQuery, error) {
+	module, err := ast.ParseModule(policy.Name, policy.Module)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("error parsing policy %s: %w", policy.Name, err)
+	}
+
+	if module == nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("policy %s is empty", policy.Name)
+	}
+
+	if pkg := strings.TrimPrefix(module.Package.Path.String(), "data."); pkg != policy.Name {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("policy %s must declare package %s, but declares package %s", policy.Name, policy.Name, pkg)
+	}
+
+	query, err := prepareQuery(ctx, policy.Name, policy.Module,
+		rego.Capabilities(customPolicyCapabilities()),
+		rego.StrictBuiltinErrors(true),
+	)
+	if err != nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("error compiling policy %s: %w", policy.Name, err)
+	}
+
+	return query, nil
+}
+
+// customPolicyCapabilities are the capabilities of policies uploaded by projects. These are evaluated
+// by the workers and API server, so only pure built-in functions are allowed: network access, the OPA
+// runtime (which exposes the environment of the process) and non-deterministic functions are removed.
+func customPolicyCapabilities() *ast.Capabilities {
+	caps := ast.CapabilitiesForThisVersion()
+
+	builtins := make([]*ast.Builtin, 0, len(caps.Builtins))
+
+	for _, builtin := range caps.Builtins {
+		if builtin.Nondeterministic || isUnsafeBuiltin(builtin.Name) {
+			continue
+		}
+
+		builtins = append(builtins, builtin)
+	}
+
+	caps.Builtins = builtins
+	caps.AllowNet = []string{}
+
+	return caps
+}
+
+func isUnsafeBuiltin(name string) bool {
+	switch {
+	case name == "http.send", name == "opa.runtime":
+		return true
+	case strings.HasPrefix(name, "net."), strings.HasPrefix(name, "time.now"), strings.HasPrefix(name, "rand."),
+		strings.HasPrefix(name, "uuid."):
+		return true
+	}
+
+	return false
+}
+
+func (c *CustomPolicyCollection) validateMatch() error {
+	switch c.Kind {
+	case HelmRelease:
+		if c.Match.Name == "" && c.Match.ChartName == "" {
+			return fmt.Errorf("helm_release collections must match a release name or chart name")
+		}
+	case CRDList:
+		if c.Match.Version == "" || c.Match.Resource == "" {
+			return fmt.Errorf("crd_list collections must match a version and resource")
+		}
+	case Pod, Daemonset:
+	default:
+		return fmt.Errorf("unsupported collection kind %s", c.Kind)
+	}
+
+	if c.MustExist && (c.Kind != HelmRelease || c.Match.Name == "") {
+		return fmt.Errorf("must_exist is only supported for helm_release collections which match a release name")
+	}
+
+	if c.Kind == CRDList && len(c.enforcedPolicies()) > 0 {
+		return fmt.Errorf("crd_list collections cannot be enforced, since their objects are not part of a release")
+	}
+
+	return nil
+}
+
+// WithCustomCollections returns the policies together with the compiled custom collections. Collections which
+// fail to compile are left out, and their errors are returned.
+func (p *KubernetesPolicies) WithCustomCollections(ctx context.Context, collections []*CustomPolicyCollection) (*KubernetesPolicies, []error) {
+	res := &KubernetesPolicies{
+		Policies: make(map[string]KubernetesOPAQueryCollection, len(p.Policies)+len(collections)),
+	}
+
+	for name, collection := range p.Policies {
+		res.Policies[name] = collection
+	}
+
+	errs := make([]error, 0)
+
+	for _, collection := range collections {
+		compiled, err := collection.Compile(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error compiling policy collection %s: %w", collection.Name, err))
+			continue
+		}
+
+		res.Policies[CustomCategoryPrefix+collection.Name] = compiled
+	}
+
+	return res, errs
+}
@@ -0,0 +1,173 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const memoryLimitsModule = `package porter.custom.memory_limits
+
+POLICY_ID = "memory_limits"
+
+POLICY_VERSION = "v0.0.1"
+
+POLICY_SEVERITY = "high"
+
+POLICY_TITLE = "Web apps must set memory limits"
+
+POLICY_SUCCESS_MESSAGE = "All containers set memory limits"
+
+default ALLOW = false
+
+ALLOW {
+	count(FAILURE_MESSAGE) == 0
+}
+
+FAILURE_MESSAGE[msg] {
+	container := input.spec.containers[_]
+	not container.resources.limits.memory
+	msg := sprintf("container %s does not set a memory limit", [container.name])
+}
+`
+
+func memoryLimitsCollection() *CustomPolicyCollection {
+	return &CustomPolicyCollection{
+		Name: "memory-limits",
+		Kind: Pod,
+		Match: MatchParameters{
+			Labels: map[string]string{"porter.run/app-name": "web"},
+		},
+		Policies: []types.RecommenderPolicy{{
+			Name:   "porter.custom.memory_limits",
+			Module: memoryLimitsModule,
+		}},
+	}
+}
+
+func TestCustomPolicyCollectionCompile(t *testing.T) {
+	collection, err := memoryLimitsCollection().Compile(context.Background())
+	require.NoError(t, err)
+	require.Len(t, collection.Queries, 1)
+	assert.Equal(t, "custom-memory-limits", collection.ObjectIDPrefix)
+
+	pod := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web"},
+			},
+		},
+	}
+
+	results, err := collection.Queries[0].Eval(context.Background(), rego.EvalInput(pod))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	rawQueryRes := &rawQueryResult{}
+	require.NoError(t, mapstructure.Decode(results[0].Expressions[0].Value, rawQueryRes))
+
+	queryRes := rawQueryResToRecommenderQueryResult(rawQueryRes, "pod/default/web-1", "custom-memory-limits", collection)
+
+	assert.False(t, queryRes.Allow)
+	assert.Equal(t, "custom-memory-limits/pod/default/web-1/memory_limits", queryRes.ObjectID)
+	assert.Equal(t, "high", queryRes.PolicySeverity)
+	assert.Equal(t, "container web does not set a memory limit", queryRes.PolicyMessage)
+}
+
+func TestCustomPolicyCollectionCompileErrors(t *testing.T) {
+	wrongPackage := memoryLimitsCollection()
+	wrongPackage.Policies[0].Name = "porter.custom.other"
+
+	_, err := wrongPackage.Compile(context.Background())
+	assert.ErrorContains(t, err, "must declare package porter.custom.other")
+
+	invalidModule := memoryLimitsCollection()
+	invalidModule.Policies[0].Module = "package porter.custom.memory_limits\n\nALLOW {"
+
+	_, err = invalidModule.Compile(context.Background())
+	assert.Error(t, err)
+
+	unmatchedRelease := memoryLimitsCollection()
+	unmatchedRelease.Kind = HelmRelease
+
+	_, err = unmatchedRelease.Compile(context.Background())
+	assert.ErrorContains(t, err, "must match a release name or chart name")
+
+	unsupportedKind := memoryLimitsCollection()
+	unsupportedKind.Kind = "node"
+
+	_, err = unsupportedKind.Compile(context.Background())
+	assert.ErrorContains(t, err, "unsupported collection kind")
+
+	noPolicies := memoryLimitsCollection()
+	noPolicies.Policies = nil
+
+	_, err = noPolicies.Compile(context.Background())
+	assert.Error(t, err)
+}
+
+func TestWithCustomCollections(t *testing.T) {
+	builtIn := &KubernetesPolicies{
+		Policies: map[string]KubernetesOPAQueryCollection{
+			"pod": {Kind: Pod},
+		},
+	}
+
+	invalid := memoryLimitsCollection()
+	invalid.Name = "invalid"
+	invalid.Kind = "node"
+
+	policies, errs := builtIn.WithCustomCollections(context.Background(), []*CustomPolicyCollection{memoryLimitsCollection(), invalid})
+
+	assert.Len(t, errs, 1)
+	assert.Len(t, policies.Policies, 2)
+	assert.Contains(t, policies.Policies, "pod")
+	assert.Contains(t, policies.Policies, "custom-memory-limits")
+
+	// the built-in policies are not modified
+	assert.Len(t, builtIn.Policies, 1)
+}
+
+func TestCollectionObjectID(t *testing.T) {
+	builtIn := KubernetesOPAQueryCollection{}
+	assert.Equal(t, "pod/default/web-1", builtIn.objectID("pod/default/web-1", "memory_limits"))
+
+	custom := KubernetesOPAQueryCollection{ObjectIDPrefix: "custom-limits"}
+	assert.Equal(t, "custom-limits/pod/default/web-1/memory_limits", custom.objectID("pod/default/web-1", "memory_limits"))
+	assert.Equal(t, "custom-limits/helm_release/default/web/memory_limits", custom.objectID("helm_release/default/web/memory_limits", "memory_limits"))
+	assert.Equal(t, "custom-limits/helm_release/default/web/exists", custom.objectID("helm_release/default/web/exists", ""))
+}
+
+func TestCompilePolicyRejectsUnsafeBuiltins(t *testing.T) {
+	for name, expr := range map[string]string{
+		"http.send":          `http.send({"method": "get", "url": "http://169.254.169.254/"})`,
+		"opa.runtime":        `opa.runtime().env`,
+		"net.lookup_ip_addr": `net.lookup_ip_addr("example.com")`,
+		"time.now_ns":        `time.now_ns()`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			module := fmt.Sprintf(`package porter.custom.unsafe
+
+FAILURE_MESSAGE[msg] {
+	msg := sprintf("%%v", [%s])
+}
+`, expr)
+
+			collection := memoryLimitsCollection()
+			collection.Policies = []types.RecommenderPolicy{{
+				Name:   "porter.custom.unsafe",
+				Module: module,
+			}}
+
+			_, err := collection.Compile(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "undefined function")
+		})
+	}
+}
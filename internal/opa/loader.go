@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/open-policy-agent/opa/rego"
 	"sigs.k8s.io/yaml"
@@ -52,10 +53,7 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 				return nil, err
 			}
 
-			query, err := rego.New(
-				rego.Query(fmt.Sprintf("data.%s", cfPolicy.Name)),
-				rego.Module(cfPolicy.Name, string(fileBytes)),
-			).PrepareForEval(context.Background())
+			query, err := prepareQuery(context.Background(), cfPolicy.Name, string(fileBytes))
 			if err != nil {
 				// Handle error.
 				return nil, err
@@ -77,3 +75,25 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 		Policies: policies,
 	}, nil
 }
+
+// policyEvalTimeout bounds the evaluation of a single policy against a single object
+const policyEvalTimeout = 5 * time.Second
+
+// prepareQuery compiles a Rego module, which is evaluated by querying data.<name>
+func prepareQuery(ctx context.Context, name, module string, opts ...func(*rego.Rego)) (rego.PreparedEvalQuery, error) {
+	opts = append([]func(*rego.Rego){
+		rego.Query(fmt.Sprintf("data.%s", name)),
+		rego.Module(name, module),
+	}, opts...)
+
+	return rego.New(opts...).PrepareForEval(ctx)
+}
+
+// evalQuery evaluates a compiled policy against an input, stopping the evaluation once it has run
+// for policyEvalTimeout
+func evalQuery(ctx context.Context, query rego.PreparedEvalQuery, input interface{}) (rego.ResultSet, error) {
+	ctx, cancel := context.WithTimeout(ctx, policyEvalTimeout)
+	defer cancel()
+
+	return query.Eval(ctx, rego.EvalInput(input))
+}
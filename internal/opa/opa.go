@@ -30,6 +30,9 @@ type KubernetesOPARunner struct {
 	cluster       *models.Cluster
 	k8sAgent      *kubernetes.Agent
 	dynamicClient dynamic.Interface
+
+	// helmSQLConnectionString is the database holding the Helm releases of clusters which use the sql storage
+	helmSQLConnectionString string
 }
 
 type KubernetesBuiltInKind string
@@ -47,6 +50,10 @@ type KubernetesOPAQueryCollection struct {
 	MustExist        bool
 	OverrideSeverity string
 	Queries          []rego.PreparedEvalQuery
+
+	// ObjectIDPrefix is prepended to the object ids of the results of custom collections. It is empty for
+	// the built-in collections.
+	ObjectIDPrefix string
 }
 
 type MatchParameters struct {
@@ -92,8 +99,14 @@ type rawQueryResult struct {
 	FailureMessage []string `mapstructure:"FAILURE_MESSAGE"`
 }
 
-func NewRunner(policies *KubernetesPolicies, cluster *models.Cluster, k8sAgent *kubernetes.Agent, dynamicClient dynamic.Interface) *KubernetesOPARunner {
-	return &KubernetesOPARunner{policies, cluster, k8sAgent, dynamicClient}
+func NewRunner(
+	policies *KubernetesPolicies,
+	cluster *models.Cluster,
+	k8sAgent *kubernetes.Agent,
+	dynamicClient dynamic.Interface,
+	helmSQLConnectionString string,
+) *KubernetesOPARunner {
+	return &KubernetesOPARunner{policies, cluster, k8sAgent, dynamicClient, helmSQLConnectionString}
 }
 
 func (runner *KubernetesOPARunner) GetRecommendations(categories []string) ([]*OPARecommenderQueryResult, error) {
@@ -163,7 +176,13 @@ func (runner *KubernetesOPARunner) SetK8sAgent(k8sAgent *kubernetes.Agent) {
 func (runner *KubernetesOPARunner) runHelmReleaseQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
 	res := make([]*OPARecommenderQueryResult, 0)
 
-	helmAgent, err := helm.GetAgentFromK8sAgent("secret", collection.Match.Namespace, logger.New(false, os.Stdout), runner.k8sAgent)
+	helmAgent, err := helm.GetAgentForCluster(
+		runner.cluster,
+		runner.helmSQLConnectionString,
+		collection.Match.Namespace,
+		logger.New(false, os.Stdout),
+		runner.k8sAgent,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -179,7 +198,7 @@ func (runner *KubernetesOPARunner) runHelmReleaseQueries(name string, collection
 				return []*OPARecommenderQueryResult{
 					{
 						Allow:          false,
-						ObjectID:       fmt.Sprintf("helm_release/%s/%s/%s", collection.Match.Namespace, collection.Match.Name, "exists"),
+						ObjectID:       collection.objectID(fmt.Sprintf("helm_release/%s/%s/%s", collection.Match.Namespace, collection.Match.Name, "exists"), ""),
 						CategoryName:   name,
 						PolicyVersion:  "v0.0.1",
 						PolicySeverity: getSeverity("high", collection),
@@ -193,7 +212,7 @@ func (runner *KubernetesOPARunner) runHelmReleaseQueries(name string, collection
 		} else if collection.MustExist {
 			res = append(res, &OPARecommenderQueryResult{
 				Allow:          true,
-				ObjectID:       fmt.Sprintf("helm_release/%s/%s/%s", collection.Match.Namespace, collection.Match.Name, "exists"),
+				ObjectID:       collection.objectID(fmt.Sprintf("helm_release/%s/%s/%s", collection.Match.Namespace, collection.Match.Name, "exists"), ""),
 				CategoryName:   name,
 				PolicyVersion:  "v0.0.1",
 				PolicySeverity: getSeverity("high", collection),
@@ -230,14 +249,15 @@ func (runner *KubernetesOPARunner) runHelmReleaseQueries(name string, collection
 
 	for _, helmRelease := range helmReleases {
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
+			results, err := evalQuery(
 				context.Background(),
-				rego.EvalInput(map[string]interface{}{
+				query,
+				map[string]interface{}{
 					"version":   helmRelease.Chart.Metadata.Version,
 					"values":    helmRelease.Config,
 					"name":      helmRelease.Name,
 					"namespace": helmRelease.Namespace,
-				}),
+				},
 			)
 			if err != nil {
 				return nil, err
@@ -265,6 +285,20 @@ func (runner *KubernetesOPARunner) runHelmReleaseQueries(name string, collection
 	return res, nil
 }
 
+// objectID returns the object id of a result of the collection. The object ids of custom collections are
+// prefixed, and always end with the policy id since several custom policies may check the same pod.
+func (collection KubernetesOPAQueryCollection) objectID(objectID, policyID string) string {
+	if collection.ObjectIDPrefix == "" {
+		return objectID
+	}
+
+	if policyID != "" && !strings.HasSuffix(objectID, "/"+policyID) {
+		objectID = fmt.Sprintf("%s/%s", objectID, policyID)
+	}
+
+	return fmt.Sprintf("%s/%s", collection.ObjectIDPrefix, objectID)
+}
+
 func getSeverity(defaultSeverity string, collection KubernetesOPAQueryCollection) string {
 	if collection.OverrideSeverity != "" {
 		return collection.OverrideSeverity
@@ -296,9 +330,10 @@ func (runner *KubernetesOPARunner) runPodQueries(name string, collection Kuberne
 		}
 
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
+			results, err := evalQuery(
 				context.Background(),
-				rego.EvalInput(unstructuredPod),
+				query,
+				unstructuredPod,
 			)
 			if err != nil {
 				return nil, err
@@ -351,9 +386,10 @@ func (runner *KubernetesOPARunner) runDaemonsetQueries(name string, collection K
 		}
 
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
+			results, err := evalQuery(
 				context.Background(),
-				rego.EvalInput(unstructuredDS),
+				query,
+				unstructuredDS,
 			)
 			if err != nil {
 				return nil, err
@@ -402,9 +438,10 @@ func (runner *KubernetesOPARunner) runCRDListQueries(name string, collection Kub
 
 	for _, crd := range crdList.Items {
 		for _, query := range collection.Queries {
-			results, err := query.Eval(
+			results, err := evalQuery(
 				context.Background(),
-				rego.EvalInput(crd.Object),
+				query,
+				crd.Object,
 			)
 			if err != nil {
 				return nil, err
@@ -434,7 +471,7 @@ func (runner *KubernetesOPARunner) runCRDListQueries(name string, collection Kub
 
 func rawQueryResToRecommenderQueryResult(rawQueryRes *rawQueryResult, objectID, categoryName string, collection KubernetesOPAQueryCollection) *OPARecommenderQueryResult {
 	queryRes := &OPARecommenderQueryResult{
-		ObjectID:     objectID,
+		ObjectID:     collection.objectID(objectID, rawQueryRes.PolicyID),
 		CategoryName: categoryName,
 	}
 
@@ -0,0 +1,237 @@
+package porter_app
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+)
+
+// DiffStatus describes how a service changed between two versions of an app
+type DiffStatus string
+
+const (
+	// DiffStatus_Added indicates that the service only exists in the newer version of the app
+	DiffStatus_Added DiffStatus = "added"
+	// DiffStatus_Removed indicates that the service only exists in the older version of the app
+	DiffStatus_Removed DiffStatus = "removed"
+	// DiffStatus_Modified indicates that the service exists in both versions of the app with different settings
+	DiffStatus_Modified DiffStatus = "modified"
+)
+
+// FieldChange is a change to a single field of an app or service. Empty values indicate that the field is unset.
+type FieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ServiceDiff is the set of changes to a single service
+type ServiceDiff struct {
+	Name    string        `json:"name"`
+	Status  DiffStatus    `json:"status"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// AppDiff is a semantic diff between two versions of an app
+type AppDiff struct {
+	// Changes are the app-level changes, such as the image and env group versions
+	Changes []FieldChange `json:"changes"`
+	// Services are the changed services, sorted by name
+	Services []ServiceDiff `json:"services"`
+	// Predeploy is the change to the predeploy job, if any
+	Predeploy *ServiceDiff `json:"predeploy,omitempty"`
+}
+
+// IsEmpty returns true if there are no differences between the two versions of the app
+func (d AppDiff) IsEmpty() bool {
+	return len(d.Changes) == 0 && len(d.Services) == 0 && d.Predeploy == nil
+}
+
+// DiffApps returns the semantic diff between two versions of an app. Either app may be nil, in which case it is
+// treated as an app with no services.
+func DiffApps(from, to *porterv1.PorterApp) AppDiff {
+	if from == nil {
+		from = &porterv1.PorterApp{}
+	}
+	if to == nil {
+		to = &porterv1.PorterApp{}
+	}
+
+	diff := AppDiff{
+		Changes:  diffFields(appFields(from), appFields(to)),
+		Services: make([]ServiceDiff, 0),
+	}
+
+	names := make(map[string]bool)
+	for name := range from.Services {
+		names[name] = true
+	}
+	for name := range to.Services {
+		names[name] = true
+	}
+
+	sortedNames := make([]string, 0, len(names))
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		if serviceDiff := diffService(name, from.Services[name], to.Services[name]); serviceDiff != nil {
+			diff.Services = append(diff.Services, *serviceDiff)
+		}
+	}
+
+	diff.Predeploy = diffService("predeploy", from.Predeploy, to.Predeploy)
+
+	return diff
+}
+
+func diffService(name string, from, to *porterv1.Service) *ServiceDiff {
+	if from == nil && to == nil {
+		return nil
+	}
+
+	serviceDiff := &ServiceDiff{
+		Name:    name,
+		Status:  DiffStatus_Modified,
+		Changes: diffFields(serviceFields(from), serviceFields(to)),
+	}
+
+	switch {
+	case from == nil:
+		serviceDiff.Status = DiffStatus_Added
+	case to == nil:
+		serviceDiff.Status = DiffStatus_Removed
+	case len(serviceDiff.Changes) == 0:
+		return nil
+	}
+
+	return serviceDiff
+}
+
+// diffFields compares two flattened sets of fields, returning the changes sorted by field name
+func diffFields(from, to map[string]string) []FieldChange {
+	fields := make(map[string]bool)
+	for field := range from {
+		fields[field] = true
+	}
+	for field := range to {
+		fields[field] = true
+	}
+
+	changes := make([]FieldChange, 0)
+
+	for field := range fields {
+		if from[field] != to[field] {
+			changes = append(changes, FieldChange{
+				Field: field,
+				From:  from[field],
+				To:    to[field],
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+
+	return changes
+}
+
+func appFields(app *porterv1.PorterApp) map[string]string {
+	fields := make(map[string]string)
+
+	if app.Image != nil {
+		setField(fields, "image.repository", app.Image.Repository)
+		setField(fields, "image.tag", app.Image.Tag)
+	}
+
+	for _, envGroup := range app.EnvGroups {
+		if envGroup != nil {
+			fields[fmt.Sprintf("env_groups.%s.version", envGroup.Name)] = strconv.FormatInt(envGroup.Version, 10)
+		}
+	}
+
+	return fields
+}
+
+func serviceFields(service *porterv1.Service) map[string]string {
+	fields := make(map[string]string)
+
+	if service == nil {
+		return fields
+	}
+
+	setField(fields, "type", serviceTypeName(service.Type))
+	setField(fields, "run", service.Run)
+	setField(fields, "instances", strconv.Itoa(int(service.Instances)))
+	setField(fields, "port", strconv.Itoa(int(service.Port)))
+	setField(fields, "resources.cpu_cores", strconv.FormatFloat(float64(service.CpuCores), 'f', -1, 32))
+	setField(fields, "resources.ram_megabytes", strconv.Itoa(int(service.RamMegabytes)))
+
+	if webConfig := service.GetWebConfig(); webConfig != nil {
+		setAutoscalingFields(fields, webConfig.Autoscaling)
+
+		domains := make([]string, 0)
+		for _, domain := range webConfig.Domains {
+			if domain != nil {
+				domains = append(domains, domain.Name)
+			}
+		}
+		sort.Strings(domains)
+
+		setField(fields, "domains", strings.Join(domains, ", "))
+		setField(fields, "private", strconv.FormatBool(webConfig.Private))
+
+		if webConfig.HealthCheck != nil && webConfig.HealthCheck.Enabled {
+			setField(fields, "health_check.http_path", webConfig.HealthCheck.HttpPath)
+		}
+	}
+
+	if workerConfig := service.GetWorkerConfig(); workerConfig != nil {
+		setAutoscalingFields(fields, workerConfig.Autoscaling)
+	}
+
+	if jobConfig := service.GetJobConfig(); jobConfig != nil {
+		setField(fields, "cron", jobConfig.Cron)
+		setField(fields, "allow_concurrent", strconv.FormatBool(jobConfig.AllowConcurrent))
+	}
+
+	return fields
+}
+
+func setAutoscalingFields(fields map[string]string, autoscaling *porterv1.Autoscaling) {
+	if autoscaling == nil || !autoscaling.Enabled {
+		return
+	}
+
+	fields["autoscaling.enabled"] = "true"
+	setField(fields, "autoscaling.min_instances", strconv.Itoa(int(autoscaling.MinInstances)))
+	setField(fields, "autoscaling.max_instances", strconv.Itoa(int(autoscaling.MaxInstances)))
+	setField(fields, "autoscaling.cpu_threshold_percent", strconv.Itoa(int(autoscaling.CpuThresholdPercent)))
+	setField(fields, "autoscaling.memory_threshold_percent", strconv.Itoa(int(autoscaling.MemoryThresholdPercent)))
+}
+
+// setField sets a field only if it has a non-zero value, so that unset and zero values compare as equal
+func setField(fields map[string]string, field, value string) {
+	if value != "" && value != "0" && value != "false" {
+		fields[field] = value
+	}
+}
+
+func serviceTypeName(serviceType porterv1.ServiceType) string {
+	switch serviceType {
+	case porterv1.ServiceType_SERVICE_TYPE_WEB:
+		return "web"
+	case porterv1.ServiceType_SERVICE_TYPE_WORKER:
+		return "worker"
+	case porterv1.ServiceType_SERVICE_TYPE_JOB:
+		return "job"
+	default:
+		return ""
+	}
+}
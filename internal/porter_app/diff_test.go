@@ -0,0 +1,101 @@
+package porter_app
+
+import (
+	"testing"
+
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffApps(t *testing.T) {
+	from := &porterv1.PorterApp{
+		Name:  "test-app",
+		Image: &porterv1.AppImage{Repository: "nginx", Tag: "1.0.0"},
+		EnvGroups: []*porterv1.EnvGroup{
+			{Name: "app-env", Version: 1},
+		},
+		Services: map[string]*porterv1.Service{
+			"web": {
+				Run:          "node index.js",
+				Instances:    1,
+				CpuCores:     0.5,
+				RamMegabytes: 512,
+				Port:         8080,
+				Type:         porterv1.ServiceType_SERVICE_TYPE_WEB,
+				Config: &porterv1.Service_WebConfig{
+					WebConfig: &porterv1.WebServiceConfig{
+						Domains: []*porterv1.Domain{{Name: "example.com"}},
+					},
+				},
+			},
+			"worker": {
+				Run:  "node worker.js",
+				Type: porterv1.ServiceType_SERVICE_TYPE_WORKER,
+			},
+		},
+	}
+
+	to := &porterv1.PorterApp{
+		Name:  "test-app",
+		Image: &porterv1.AppImage{Repository: "nginx", Tag: "1.1.0"},
+		EnvGroups: []*porterv1.EnvGroup{
+			{Name: "app-env", Version: 2},
+		},
+		Services: map[string]*porterv1.Service{
+			"web": {
+				Run:          "node index.js",
+				Instances:    1,
+				CpuCores:     1,
+				RamMegabytes: 512,
+				Port:         8080,
+				Type:         porterv1.ServiceType_SERVICE_TYPE_WEB,
+				Config: &porterv1.Service_WebConfig{
+					WebConfig: &porterv1.WebServiceConfig{
+						Autoscaling: &porterv1.Autoscaling{
+							Enabled:      true,
+							MinInstances: 1,
+							MaxInstances: 5,
+						},
+						Domains: []*porterv1.Domain{{Name: "example.com"}},
+					},
+				},
+			},
+			"job": {
+				Run:  "node job.js",
+				Type: porterv1.ServiceType_SERVICE_TYPE_JOB,
+				Config: &porterv1.Service_JobConfig{
+					JobConfig: &porterv1.JobServiceConfig{Cron: "0 * * * *"},
+				},
+			},
+		},
+	}
+
+	diff := DiffApps(from, to)
+
+	assert.Equal(t, []FieldChange{
+		{Field: "env_groups.app-env.version", From: "1", To: "2"},
+		{Field: "image.tag", From: "1.0.0", To: "1.1.0"},
+	}, diff.Changes)
+
+	assert.Len(t, diff.Services, 3)
+
+	assert.Equal(t, "job", diff.Services[0].Name)
+	assert.Equal(t, DiffStatus_Added, diff.Services[0].Status)
+
+	assert.Equal(t, ServiceDiff{
+		Name:   "web",
+		Status: DiffStatus_Modified,
+		Changes: []FieldChange{
+			{Field: "autoscaling.enabled", From: "", To: "true"},
+			{Field: "autoscaling.max_instances", From: "", To: "5"},
+			{Field: "autoscaling.min_instances", From: "", To: "1"},
+			{Field: "resources.cpu_cores", From: "0.5", To: "1"},
+		},
+	}, diff.Services[1])
+
+	assert.Equal(t, "worker", diff.Services[2].Name)
+	assert.Equal(t, DiffStatus_Removed, diff.Services[2].Status)
+
+	assert.Nil(t, diff.Predeploy)
+	assert.True(t, DiffApps(from, from).IsEmpty())
+}
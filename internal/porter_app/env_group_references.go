@@ -0,0 +1,91 @@
+package porter_app
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+)
+
+// EnvGroupVersionsReferencedByRevisionsInput is the input struct for EnvGroupVersionsReferencedByRevisions
+type EnvGroupVersionsReferencedByRevisionsInput struct {
+	ProjectID uint
+	ClusterID uint
+
+	PorterAppRepository        repository.PorterAppRepository
+	DeploymentTargetRepository repository.DeploymentTargetRepository
+	CCPClient                  porterv1connect.ClusterControlPlaneServiceClient
+}
+
+// EnvGroupVersionsReferencedByRevisions returns the environment group versions referenced by any revision of any app in the cluster,
+// keyed by environment group name. Rolling back an app to one of these revisions requires the referenced versions to still exist.
+func EnvGroupVersionsReferencedByRevisions(ctx context.Context, inp EnvGroupVersionsReferencedByRevisionsInput) (map[string]map[int]bool, error) {
+	ctx, span := telemetry.NewSpan(ctx, "env-group-versions-referenced-by-revisions")
+	defer span.End()
+
+	referenced := make(map[string]map[int]bool)
+
+	if inp.ProjectID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "must provide a project id")
+	}
+	if inp.ClusterID == 0 {
+		return nil, telemetry.Error(ctx, span, nil, "must provide a cluster id")
+	}
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: inp.ProjectID},
+		telemetry.AttributeKV{Key: "cluster-id", Value: inp.ClusterID},
+	)
+
+	// clusters without a control plane have no app revisions
+	if inp.CCPClient == nil {
+		return referenced, nil
+	}
+
+	apps, err := inp.PorterAppRepository.ListPorterAppByClusterID(inp.ClusterID)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing porter apps")
+	}
+
+	deploymentTargets, err := inp.DeploymentTargetRepository.List(inp.ProjectID)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error listing deployment targets")
+	}
+
+	for _, app := range apps {
+		for _, deploymentTarget := range deploymentTargets {
+			if deploymentTarget.ClusterID != int(inp.ClusterID) {
+				continue
+			}
+
+			resp, err := inp.CCPClient.ListAppRevisions(ctx, connect.NewRequest(&porterv1.ListAppRevisionsRequest{
+				ProjectId:          int64(inp.ProjectID),
+				AppId:              int64(app.ID),
+				DeploymentTargetId: deploymentTarget.ID.String(),
+			}))
+			if err != nil {
+				return nil, telemetry.Error(ctx, span, err, "error listing app revisions")
+			}
+			if resp == nil || resp.Msg == nil {
+				continue
+			}
+
+			for _, revision := range resp.Msg.AppRevisions {
+				if revision == nil || revision.App == nil {
+					continue
+				}
+
+				for _, envGroup := range revision.App.EnvGroups {
+					if referenced[envGroup.GetName()] == nil {
+						referenced[envGroup.GetName()] = make(map[int]bool)
+					}
+					referenced[envGroup.GetName()][int(envGroup.GetVersion())] = true
+				}
+			}
+		}
+	}
+
+	return referenced, nil
+}
@@ -0,0 +1,546 @@
+package porter_app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/porter-dev/api-contracts/generated/go/helpers"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/telemetry"
+	"gorm.io/gorm"
+)
+
+const (
+	// SleepReasonSchedule is the reason recorded on sleep and wake events triggered by a sleep schedule
+	SleepReasonSchedule = "schedule"
+	// SleepReasonManual is the reason recorded on sleep and wake events triggered by a user
+	SleepReasonManual = "manual"
+)
+
+// serviceScale is the scale of a service before it was put to sleep
+type serviceScale struct {
+	Instances int32 `json:"instances"`
+	// Autoscaling is the contract-encoded autoscaling config of the service, if any
+	Autoscaling []byte `json:"autoscaling,omitempty"`
+}
+
+// SleepAppInput is the input struct for SleepApp and WakeApp
+type SleepAppInput struct {
+	ProjectID          uint
+	ClusterID          uint
+	AppName            string
+	DeploymentTargetID string
+
+	// Reason is recorded on the app event, and is either SleepReasonSchedule or SleepReasonManual
+	Reason string
+	// OverrideUntil is set for manual requests, so that schedules do not change the state of the app until then
+	OverrideUntil *time.Time
+
+	CCPClient porterv1connect.ClusterControlPlaneServiceClient
+	Repo      repository.Repository
+}
+
+// isScaledDown returns whether a web or worker service has the scale that SleepApp gives it
+func isScaledDown(service *porterv1.Service) bool {
+	if service == nil || service.Instances != 0 {
+		return false
+	}
+
+	switch service.Type {
+	case porterv1.ServiceType_SERVICE_TYPE_WEB:
+		return service.GetWebConfig().GetAutoscaling() == nil
+	case porterv1.ServiceType_SERVICE_TYPE_WORKER:
+		return service.GetWorkerConfig().GetAutoscaling() == nil
+	}
+
+	return false
+}
+
+// scaledDownServices returns the services which were put to sleep and still have zero instances. A revision
+// deployed while the app was asleep may have scaled some or all of them up again, in which case their
+// original scale is stale.
+func scaledDownServices(app *porterv1.PorterApp, original map[string]serviceScale) map[string]bool {
+	res := make(map[string]bool)
+
+	for name := range original {
+		if isScaledDown(app.Services[name]) {
+			res[name] = true
+		}
+	}
+
+	return res
+}
+
+func decodeOriginalScale(state *models.AppSleepState) (map[string]serviceScale, error) {
+	original := make(map[string]serviceScale)
+
+	if len(state.OriginalScale) == 0 {
+		return original, nil
+	}
+
+	if err := json.Unmarshal(state.OriginalScale, &original); err != nil {
+		return nil, err
+	}
+
+	return original, nil
+}
+
+// SleepApp scales the web and worker services of an app to zero and disables their autoscaling. The
+// original instance counts and autoscaling configs are stored so that WakeApp can restore them.
+func SleepApp(ctx context.Context, inp SleepAppInput) (*models.AppSleepState, error) {
+	ctx, span := telemetry.NewSpan(ctx, "sleep-app")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-name", Value: inp.AppName},
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: inp.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "reason", Value: inp.Reason},
+	)
+
+	porterApp, state, err := readAppSleepState(ctx, inp)
+	if err != nil {
+		return nil, err
+	}
+
+	state.OverrideUntil = inp.OverrideUntil
+
+	app, err := currentApp(ctx, inp, porterApp)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Asleep {
+		original, err := decodeOriginalScale(state)
+		if err != nil {
+			return nil, telemetry.Error(ctx, span, err, "error unmarshalling original scale")
+		}
+
+		// the app is only still asleep if a revision deployed since has not scaled it up again
+		if len(scaledDownServices(app, original)) != 0 {
+			return inp.Repo.AppSleep().SaveState(state)
+		}
+	}
+
+	original := make(map[string]serviceScale)
+
+	for name, service := range app.Services {
+		if service == nil {
+			continue
+		}
+
+		var autoscaling *porterv1.Autoscaling
+
+		switch service.Type {
+		case porterv1.ServiceType_SERVICE_TYPE_WEB:
+			if webConfig := service.GetWebConfig(); webConfig != nil {
+				autoscaling = webConfig.Autoscaling
+				webConfig.Autoscaling = nil
+			}
+		case porterv1.ServiceType_SERVICE_TYPE_WORKER:
+			if workerConfig := service.GetWorkerConfig(); workerConfig != nil {
+				autoscaling = workerConfig.Autoscaling
+				workerConfig.Autoscaling = nil
+			}
+		default:
+			continue
+		}
+
+		scale := serviceScale{Instances: service.Instances}
+
+		if autoscaling != nil {
+			scale.Autoscaling, err = helpers.MarshalContractObject(ctx, autoscaling)
+			if err != nil {
+				return nil, telemetry.Error(ctx, span, err, "error marshalling autoscaling config")
+			}
+		}
+
+		original[name] = scale
+		service.Instances = 0
+	}
+
+	originalScale, err := json.Marshal(original)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error marshalling original scale")
+	}
+
+	if err := applyApp(ctx, inp, app); err != nil {
+		createSleepEvent(ctx, inp, porterApp, types.PorterAppEventType_Sleep, types.PorterAppEventStatus_Failed, err.Error())
+		return nil, err
+	}
+
+	state.Asleep = true
+	state.OriginalScale = originalScale
+
+	state, err = inp.Repo.AppSleep().SaveState(state)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error saving sleep state")
+	}
+
+	createSleepEvent(ctx, inp, porterApp, types.PorterAppEventType_Sleep, types.PorterAppEventStatus_Success, "")
+
+	return state, nil
+}
+
+// WakeApp restores the instance counts and autoscaling configs that the web and worker services of an
+// app had before it was put to sleep. Services which were added while the app was asleep, or which were
+// scaled up by a revision deployed while the app was asleep, are not changed.
+func WakeApp(ctx context.Context, inp SleepAppInput) (*models.AppSleepState, error) {
+	ctx, span := telemetry.NewSpan(ctx, "wake-app")
+	defer span.End()
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "app-name", Value: inp.AppName},
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: inp.DeploymentTargetID},
+		telemetry.AttributeKV{Key: "reason", Value: inp.Reason},
+	)
+
+	porterApp, state, err := readAppSleepState(ctx, inp)
+	if err != nil {
+		return nil, err
+	}
+
+	state.OverrideUntil = inp.OverrideUntil
+
+	if !state.Asleep {
+		return inp.Repo.AppSleep().SaveState(state)
+	}
+
+	original, err := decodeOriginalScale(state)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error unmarshalling original scale")
+	}
+
+	app, err := currentApp(ctx, inp, porterApp)
+	if err != nil {
+		return nil, err
+	}
+
+	scaledDown := scaledDownServices(app, original)
+
+	if len(scaledDown) == 0 {
+		// a revision deployed while the app was asleep already scaled it up again
+		state.Asleep = false
+		state.OriginalScale = nil
+
+		return inp.Repo.AppSleep().SaveState(state)
+	}
+
+	for name := range scaledDown {
+		service := app.Services[name]
+		scale := original[name]
+
+		service.Instances = scale.Instances
+
+		var autoscaling *porterv1.Autoscaling
+
+		if len(scale.Autoscaling) != 0 {
+			autoscaling = &porterv1.Autoscaling{}
+
+			if err := helpers.UnmarshalContractObject(scale.Autoscaling, autoscaling); err != nil {
+				return nil, telemetry.Error(ctx, span, err, "error unmarshalling autoscaling config")
+			}
+		}
+
+		if webConfig := service.GetWebConfig(); webConfig != nil {
+			webConfig.Autoscaling = autoscaling
+		}
+
+		if workerConfig := service.GetWorkerConfig(); workerConfig != nil {
+			workerConfig.Autoscaling = autoscaling
+		}
+	}
+
+	if err := applyApp(ctx, inp, app); err != nil {
+		createSleepEvent(ctx, inp, porterApp, types.PorterAppEventType_Wake, types.PorterAppEventStatus_Failed, err.Error())
+		return nil, err
+	}
+
+	state.Asleep = false
+	state.OriginalScale = nil
+
+	state, err = inp.Repo.AppSleep().SaveState(state)
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error saving sleep state")
+	}
+
+	createSleepEvent(ctx, inp, porterApp, types.PorterAppEventType_Wake, types.PorterAppEventStatus_Success, "")
+
+	return state, nil
+}
+
+// readAppSleepState reads the app and its sleep state, which is created if the app has never slept
+func readAppSleepState(ctx context.Context, inp SleepAppInput) (*models.PorterApp, *models.AppSleepState, error) {
+	ctx, span := telemetry.NewSpan(ctx, "read-app-sleep-state")
+	defer span.End()
+
+	if inp.ProjectID == 0 {
+		return nil, nil, telemetry.Error(ctx, span, nil, "must provide a project id")
+	}
+	if inp.AppName == "" {
+		return nil, nil, telemetry.Error(ctx, span, nil, "must provide an app name")
+	}
+	if _, err := uuid.Parse(inp.DeploymentTargetID); err != nil {
+		return nil, nil, telemetry.Error(ctx, span, err, "invalid deployment target id")
+	}
+
+	porterApp, err := inp.Repo.PorterApp().ReadPorterAppByName(inp.ClusterID, inp.AppName)
+	if err != nil {
+		return nil, nil, telemetry.Error(ctx, span, err, "error reading app")
+	}
+	if porterApp == nil || porterApp.ID == 0 {
+		return nil, nil, telemetry.Error(ctx, span, nil, "app not found in cluster")
+	}
+
+	state, err := inp.Repo.AppSleep().ReadState(inp.ProjectID, inp.DeploymentTargetID, inp.AppName)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, telemetry.Error(ctx, span, err, "error reading sleep state")
+		}
+
+		state = &models.AppSleepState{
+			ProjectID:          inp.ProjectID,
+			AppName:            inp.AppName,
+			DeploymentTargetID: inp.DeploymentTargetID,
+		}
+	}
+
+	return porterApp, state, nil
+}
+
+func currentApp(ctx context.Context, inp SleepAppInput, porterApp *models.PorterApp) (*porterv1.PorterApp, error) {
+	ctx, span := telemetry.NewSpan(ctx, "get-current-app")
+	defer span.End()
+
+	resp, err := inp.CCPClient.CurrentAppRevision(ctx, connect.NewRequest(&porterv1.CurrentAppRevisionRequest{
+		ProjectId:          int64(inp.ProjectID),
+		AppId:              int64(porterApp.ID),
+		DeploymentTargetId: inp.DeploymentTargetID,
+	}))
+	if err != nil {
+		return nil, telemetry.Error(ctx, span, err, "error getting current app revision")
+	}
+
+	if resp == nil || resp.Msg == nil || resp.Msg.AppRevision == nil || resp.Msg.AppRevision.App == nil {
+		return nil, telemetry.Error(ctx, span, nil, "app has no current revision")
+	}
+
+	return resp.Msg.AppRevision.App, nil
+}
+
+func applyApp(ctx context.Context, inp SleepAppInput, app *porterv1.PorterApp) error {
+	ctx, span := telemetry.NewSpan(ctx, "apply-app-scale")
+	defer span.End()
+
+	resp, err := inp.CCPClient.ApplyPorterApp(ctx, connect.NewRequest(&porterv1.ApplyPorterAppRequest{
+		ProjectId:          int64(inp.ProjectID),
+		DeploymentTargetId: inp.DeploymentTargetID,
+		App:                app,
+	}))
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error applying app")
+	}
+
+	if resp == nil || resp.Msg == nil {
+		return telemetry.Error(ctx, span, nil, "apply response is nil")
+	}
+
+	if resp.Msg.CliAction == porterv1.EnumCLIAction_ENUM_CLI_ACTION_BUILD {
+		return telemetry.Error(ctx, span, nil, "app must be built before it can be scaled")
+	}
+
+	return nil
+}
+
+// createSleepEvent records a sleep or wake event in the activity feed of the app. Errors are only
+// reported to telemetry, since the app has already been scaled.
+func createSleepEvent(
+	ctx context.Context,
+	inp SleepAppInput,
+	porterApp *models.PorterApp,
+	eventType types.PorterAppEventType,
+	status types.PorterAppEventStatus,
+	message string,
+) {
+	ctx, span := telemetry.NewSpan(ctx, "create-sleep-event")
+	defer span.End()
+
+	metadata := map[string]any{
+		"reason": inp.Reason,
+	}
+
+	if inp.OverrideUntil != nil {
+		metadata["override_until"] = inp.OverrideUntil.UTC().Format(time.RFC3339)
+	}
+
+	if message != "" {
+		metadata["message"] = message
+	}
+
+	event := models.PorterAppEvent{
+		ID:                 uuid.New(),
+		Status:             string(status),
+		Type:               string(eventType),
+		TypeExternalSource: "PORTER",
+		PorterAppID:        porterApp.ID,
+		DeploymentTargetID: uuid.MustParse(inp.DeploymentTargetID),
+		Metadata:           metadata,
+	}
+
+	if err := inp.Repo.PorterAppEvent().CreateEvent(ctx, &event); err != nil {
+		_ = telemetry.Error(ctx, span, err, "error creating sleep event")
+	}
+}
+
+// RunSleepSchedulesInput is the input struct for RunSleepSchedules
+type RunSleepSchedulesInput struct {
+	Now time.Time
+
+	CCPClient porterv1connect.ClusterControlPlaneServiceClient
+	Repo      repository.Repository
+}
+
+// RunSleepSchedules puts the apps of every deployment target with a sleep schedule to sleep, or wakes them
+// up, according to the schedule which applies to each app. Apps which were put to sleep or woken up manually
+// are left alone until the override expires. Errors for single apps are reported to telemetry, so that one
+// app does not block the schedules of other apps.
+func RunSleepSchedules(ctx context.Context, inp RunSleepSchedulesInput) error {
+	ctx, span := telemetry.NewSpan(ctx, "run-sleep-schedules")
+	defer span.End()
+
+	schedules, err := inp.Repo.AppSleep().ListSchedules()
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error listing sleep schedules")
+	}
+
+	schedulesByTarget := make(map[string][]*models.AppSleepSchedule)
+	targetIDs := make([]string, 0)
+
+	for _, schedule := range schedules {
+		if _, ok := schedulesByTarget[schedule.DeploymentTargetID]; !ok {
+			targetIDs = append(targetIDs, schedule.DeploymentTargetID)
+		}
+
+		schedulesByTarget[schedule.DeploymentTargetID] = append(schedulesByTarget[schedule.DeploymentTargetID], schedule)
+	}
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "schedule-count", Value: len(schedules)},
+		telemetry.AttributeKV{Key: "deployment-target-count", Value: len(targetIDs)},
+	)
+
+	for _, targetID := range targetIDs {
+		targetSchedules := schedulesByTarget[targetID]
+
+		if err := runTargetSleepSchedules(ctx, inp, targetSchedules); err != nil {
+			_ = telemetry.Error(ctx, span, err, "error running sleep schedules of deployment target")
+		}
+	}
+
+	return nil
+}
+
+func runTargetSleepSchedules(ctx context.Context, inp RunSleepSchedulesInput, schedules []*models.AppSleepSchedule) error {
+	ctx, span := telemetry.NewSpan(ctx, "run-target-sleep-schedules")
+	defer span.End()
+
+	// all schedules of a deployment target belong to the same project and cluster
+	projectID := schedules[0].ProjectID
+	clusterID := schedules[0].ClusterID
+	targetID := schedules[0].DeploymentTargetID
+
+	telemetry.WithAttributes(span,
+		telemetry.AttributeKV{Key: "project-id", Value: projectID},
+		telemetry.AttributeKV{Key: "deployment-target-id", Value: targetID},
+	)
+
+	resp, err := inp.CCPClient.LatestAppRevisions(ctx, connect.NewRequest(&porterv1.LatestAppRevisionsRequest{
+		ProjectId:          int64(projectID),
+		DeploymentTargetId: targetID,
+	}))
+	if err != nil {
+		return telemetry.Error(ctx, span, err, "error getting latest app revisions")
+	}
+
+	if resp == nil || resp.Msg == nil {
+		return telemetry.Error(ctx, span, nil, "latest app revisions response is nil")
+	}
+
+	for _, revision := range resp.Msg.AppRevisions {
+		if revision == nil || revision.App == nil {
+			continue
+		}
+
+		appName := revision.App.Name
+
+		schedule := SleepScheduleForApp(schedules, targetID, appName)
+		if schedule == nil {
+			continue
+		}
+
+		parsed, err := ParseSleepScheduleModel(schedule)
+		if err != nil {
+			_ = telemetry.Error(ctx, span, err, "error parsing sleep schedule")
+			continue
+		}
+
+		state, err := inp.Repo.AppSleep().ReadState(projectID, targetID, appName)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			_ = telemetry.Error(ctx, span, err, "error reading sleep state")
+			continue
+		}
+
+		asleep := false
+
+		if state != nil {
+			if state.OverrideUntil != nil && inp.Now.Before(*state.OverrideUntil) {
+				continue
+			}
+
+			if state.Asleep {
+				original, err := decodeOriginalScale(state)
+				if err != nil {
+					_ = telemetry.Error(ctx, span, err, "error unmarshalling original scale")
+					continue
+				}
+
+				// the stored state is stale if a revision deployed since scaled the app up again
+				asleep = len(scaledDownServices(revision.App, original)) != 0
+			}
+		}
+
+		awake := parsed.ShouldBeAwake(inp.Now)
+		if awake != asleep {
+			continue
+		}
+
+		sleepInput := SleepAppInput{
+			ProjectID:          projectID,
+			ClusterID:          clusterID,
+			AppName:            appName,
+			DeploymentTargetID: targetID,
+			Reason:             SleepReasonSchedule,
+			CCPClient:          inp.CCPClient,
+			Repo:               inp.Repo,
+		}
+
+		if awake {
+			_, err = WakeApp(ctx, sleepInput)
+		} else {
+			_, err = SleepApp(ctx, sleepInput)
+		}
+
+		if err != nil {
+			_ = telemetry.Error(ctx, span, err, "error applying sleep schedule to app")
+		}
+	}
+
+	return nil
+}
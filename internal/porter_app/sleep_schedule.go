@@ -0,0 +1,203 @@
+package porter_app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+var sleepScheduleDays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+var sleepScheduleDayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+var sleepScheduleDayAliases = map[string][]string{
+	"weekdays": {"mon", "tue", "wed", "thu", "fri"},
+	"weekends": {"sat", "sun"},
+	"daily":    {"sun", "mon", "tue", "wed", "thu", "fri", "sat"},
+}
+
+// SleepSchedule is a parsed sleep schedule. Apps are awake from WakeTime to SleepTime on each of the
+// days of the schedule, and asleep otherwise.
+type SleepSchedule struct {
+	Days      map[time.Weekday]bool
+	WakeTime  time.Duration
+	SleepTime time.Duration
+	Location  *time.Location
+}
+
+// ParseSleepSchedule parses the days, times of day and timezone of a sleep schedule. Days can be the
+// three-letter names of the days of the week, or one of "weekdays", "weekends" and "daily".
+func ParseSleepSchedule(days []string, wakeTime, sleepTime, timezone string) (*SleepSchedule, error) {
+	if len(days) == 0 {
+		return nil, fmt.Errorf("sleep schedule must have at least one day")
+	}
+
+	schedule := &SleepSchedule{
+		Days: make(map[time.Weekday]bool),
+	}
+
+	for _, day := range days {
+		day = strings.ToLower(strings.TrimSpace(day))
+
+		if aliased, ok := sleepScheduleDayAliases[day]; ok {
+			for _, d := range aliased {
+				schedule.Days[sleepScheduleDays[d]] = true
+			}
+
+			continue
+		}
+
+		if len(day) > 3 {
+			day = day[:3]
+		}
+
+		weekday, ok := sleepScheduleDays[day]
+		if !ok {
+			return nil, fmt.Errorf("invalid day %q: must be a day of the week, weekdays, weekends or daily", day)
+		}
+
+		schedule.Days[weekday] = true
+	}
+
+	var err error
+
+	schedule.WakeTime, err = parseSleepTimeOfDay(wakeTime)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule.SleepTime, err = parseSleepTimeOfDay(sleepTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.WakeTime == schedule.SleepTime {
+		return nil, fmt.Errorf("wake time and sleep time must be different")
+	}
+
+	schedule.Location, err = time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	return schedule, nil
+}
+
+func parseSleepTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q: must be formatted as HH:MM", s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// DayList returns the days of the schedule in the order of the week, as stored on the schedule model
+func (s *SleepSchedule) DayList() []string {
+	res := make([]string, 0, len(s.Days))
+
+	for i, name := range sleepScheduleDayNames {
+		if s.Days[time.Weekday(i)] {
+			res = append(res, name)
+		}
+	}
+
+	return res
+}
+
+// awakePeriod returns the period during which apps are awake if they wake up on the day of t. Periods
+// whose sleep time is before their wake time end on the following day.
+func (s *SleepSchedule) awakePeriod(t time.Time) (time.Time, time.Time) {
+	year, month, day := t.Date()
+
+	wake := time.Date(year, month, day, 0, 0, 0, 0, s.Location).Add(s.WakeTime)
+	sleep := time.Date(year, month, day, 0, 0, 0, 0, s.Location).Add(s.SleepTime)
+
+	if s.SleepTime < s.WakeTime {
+		sleep = time.Date(year, month, day+1, 0, 0, 0, 0, s.Location).Add(s.SleepTime)
+	}
+
+	return wake, sleep
+}
+
+// ShouldBeAwake returns true if apps should be awake at t
+func (s *SleepSchedule) ShouldBeAwake(t time.Time) bool {
+	local := t.In(s.Location)
+
+	// a period which starts on the previous day may still be running
+	for _, day := range []time.Time{local.AddDate(0, 0, -1), local} {
+		if !s.Days[day.Weekday()] {
+			continue
+		}
+
+		wake, sleep := s.awakePeriod(day)
+
+		if !local.Before(wake) && local.Before(sleep) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextTransition returns the next time after t at which apps wake up or go to sleep
+func (s *SleepSchedule) NextTransition(t time.Time) time.Time {
+	local := t.In(s.Location)
+
+	var next time.Time
+
+	for i := -1; i <= 7; i++ {
+		day := local.AddDate(0, 0, i)
+
+		if !s.Days[day.Weekday()] {
+			continue
+		}
+
+		wake, sleep := s.awakePeriod(day)
+
+		for _, transition := range []time.Time{wake, sleep} {
+			if transition.After(local) && (next.IsZero() || transition.Before(next)) {
+				next = transition
+			}
+		}
+	}
+
+	return next
+}
+
+// ParseSleepScheduleModel parses a sleep schedule as stored in the database
+func ParseSleepScheduleModel(schedule *models.AppSleepSchedule) (*SleepSchedule, error) {
+	return ParseSleepSchedule(strings.Split(schedule.Days, ","), schedule.WakeTime, schedule.SleepTime, schedule.Timezone)
+}
+
+// SleepScheduleForApp returns the schedule which applies to an app in a deployment target. A schedule for
+// the app takes precedence over a schedule for the whole deployment target. It returns nil if no schedule applies.
+func SleepScheduleForApp(schedules []*models.AppSleepSchedule, deploymentTargetID, appName string) *models.AppSleepSchedule {
+	var targetSchedule *models.AppSleepSchedule
+
+	for _, schedule := range schedules {
+		if schedule.DeploymentTargetID != deploymentTargetID {
+			continue
+		}
+
+		if schedule.AppName == appName {
+			return schedule
+		}
+
+		if schedule.AppName == "" && targetSchedule == nil {
+			targetSchedule = schedule
+		}
+	}
+
+	return targetSchedule
+}
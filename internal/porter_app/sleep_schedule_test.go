@@ -0,0 +1,75 @@
+package porter_app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSleepSchedule(t *testing.T) {
+	schedule, err := ParseSleepSchedule([]string{"weekdays"}, "08:00", "20:00", "Europe/Berlin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mon", "tue", "wed", "thu", "fri"}, schedule.DayList())
+
+	schedule, err = ParseSleepSchedule([]string{"Saturday", "sun"}, "10:00", "12:00", "UTC")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sun", "sat"}, schedule.DayList())
+
+	_, err = ParseSleepSchedule([]string{"someday"}, "08:00", "20:00", "UTC")
+	assert.Error(t, err)
+
+	_, err = ParseSleepSchedule([]string{"mon"}, "8am", "20:00", "UTC")
+	assert.Error(t, err)
+
+	_, err = ParseSleepSchedule([]string{"mon"}, "08:00", "08:00", "UTC")
+	assert.Error(t, err)
+
+	_, err = ParseSleepSchedule([]string{"mon"}, "08:00", "20:00", "Mars/Olympus")
+	assert.Error(t, err)
+
+	_, err = ParseSleepSchedule(nil, "08:00", "20:00", "UTC")
+	assert.Error(t, err)
+}
+
+func TestSleepScheduleShouldBeAwake(t *testing.T) {
+	schedule, err := ParseSleepSchedule([]string{"weekdays"}, "08:00", "20:00", "Europe/Berlin")
+	require.NoError(t, err)
+
+	berlin := schedule.Location
+
+	// 2026-10-19 is a Monday
+	assert.True(t, schedule.ShouldBeAwake(time.Date(2026, 10, 19, 8, 0, 0, 0, berlin)))
+	assert.True(t, schedule.ShouldBeAwake(time.Date(2026, 10, 19, 19, 59, 0, 0, berlin)))
+	assert.False(t, schedule.ShouldBeAwake(time.Date(2026, 10, 19, 20, 0, 0, 0, berlin)))
+	assert.False(t, schedule.ShouldBeAwake(time.Date(2026, 10, 19, 7, 59, 0, 0, berlin)))
+	assert.False(t, schedule.ShouldBeAwake(time.Date(2026, 10, 24, 12, 0, 0, 0, berlin)), "saturdays are not in the schedule")
+
+	// times are compared in the timezone of the schedule
+	assert.True(t, schedule.ShouldBeAwake(time.Date(2026, 10, 19, 6, 30, 0, 0, time.UTC)))
+}
+
+func TestSleepScheduleOvernight(t *testing.T) {
+	schedule, err := ParseSleepSchedule([]string{"fri"}, "22:00", "06:00", "UTC")
+	require.NoError(t, err)
+
+	// the period which starts on friday ends on saturday morning
+	assert.True(t, schedule.ShouldBeAwake(time.Date(2026, 10, 23, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, schedule.ShouldBeAwake(time.Date(2026, 10, 24, 5, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.ShouldBeAwake(time.Date(2026, 10, 24, 6, 0, 0, 0, time.UTC)))
+	assert.False(t, schedule.ShouldBeAwake(time.Date(2026, 10, 23, 5, 0, 0, 0, time.UTC)))
+}
+
+func TestSleepScheduleNextTransition(t *testing.T) {
+	schedule, err := ParseSleepSchedule([]string{"weekdays"}, "08:00", "20:00", "UTC")
+	require.NoError(t, err)
+
+	// monday during the day goes to sleep in the evening
+	next := schedule.NextTransition(time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC), next)
+
+	// friday evening wakes up on monday
+	next = schedule.NextTransition(time.Date(2026, 10, 23, 21, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 10, 26, 8, 0, 0, 0, time.UTC), next.UTC())
+}
@@ -0,0 +1,184 @@
+package porter_app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	porterv1 "github.com/porter-dev/api-contracts/generated/go/porter/v1"
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+const testDeploymentTargetID = "6c5f1a8e-5d3f-4d0a-9a57-7c0e7b9d6a11"
+
+// fakeCCPClient serves the current revision of a single app, and replaces it on every apply
+type fakeCCPClient struct {
+	porterv1connect.ClusterControlPlaneServiceClient
+
+	app     *porterv1.PorterApp
+	applies int
+}
+
+func (c *fakeCCPClient) CurrentAppRevision(
+	ctx context.Context,
+	req *connect.Request[porterv1.CurrentAppRevisionRequest],
+) (*connect.Response[porterv1.CurrentAppRevisionResponse], error) {
+	return connect.NewResponse(&porterv1.CurrentAppRevisionResponse{
+		AppRevision: &porterv1.AppRevision{App: proto.Clone(c.app).(*porterv1.PorterApp)},
+	}), nil
+}
+
+func (c *fakeCCPClient) LatestAppRevisions(
+	ctx context.Context,
+	req *connect.Request[porterv1.LatestAppRevisionsRequest],
+) (*connect.Response[porterv1.LatestAppRevisionsResponse], error) {
+	return connect.NewResponse(&porterv1.LatestAppRevisionsResponse{
+		AppRevisions: []*porterv1.AppRevision{{App: proto.Clone(c.app).(*porterv1.PorterApp)}},
+	}), nil
+}
+
+func (c *fakeCCPClient) ApplyPorterApp(
+	ctx context.Context,
+	req *connect.Request[porterv1.ApplyPorterAppRequest],
+) (*connect.Response[porterv1.ApplyPorterAppResponse], error) {
+	c.app = proto.Clone(req.Msg.App).(*porterv1.PorterApp)
+	c.applies++
+
+	return connect.NewResponse(&porterv1.ApplyPorterAppResponse{}), nil
+}
+
+type fakePorterAppRepository struct {
+	repository.PorterAppRepository
+}
+
+func (r *fakePorterAppRepository) ReadPorterAppByName(clusterID uint, name string) (*models.PorterApp, error) {
+	return &models.PorterApp{Model: gorm.Model{ID: 1}, ClusterID: clusterID, Name: name}, nil
+}
+
+type fakeRepository struct {
+	repository.Repository
+}
+
+func (r *fakeRepository) PorterApp() repository.PorterAppRepository {
+	return &fakePorterAppRepository{}
+}
+
+func testApp(webInstances, workerInstances int32) *porterv1.PorterApp {
+	return &porterv1.PorterApp{
+		Name: "app",
+		Services: map[string]*porterv1.Service{
+			"web": {
+				Instances: webInstances,
+				Type:      porterv1.ServiceType_SERVICE_TYPE_WEB,
+				Config: &porterv1.Service_WebConfig{WebConfig: &porterv1.WebServiceConfig{
+					Autoscaling: &porterv1.Autoscaling{Enabled: true, MinInstances: 2, MaxInstances: 5, CpuThresholdPercent: 80},
+				}},
+			},
+			"worker": {
+				Instances: workerInstances,
+				Type:      porterv1.ServiceType_SERVICE_TYPE_WORKER,
+				Config:    &porterv1.Service_WorkerConfig{WorkerConfig: &porterv1.WorkerServiceConfig{}},
+			},
+		},
+	}
+}
+
+func testSleepInput(ccp *fakeCCPClient) SleepAppInput {
+	return SleepAppInput{
+		ProjectID:          1,
+		ClusterID:          1,
+		AppName:            "app",
+		DeploymentTargetID: testDeploymentTargetID,
+		Reason:             SleepReasonManual,
+		CCPClient:          ccp,
+		Repo:               &fakeRepository{Repository: test.NewRepository(true)},
+	}
+}
+
+func TestSleepAndWakeRestoresExactScale(t *testing.T) {
+	ctx := context.Background()
+	original := testApp(3, 2)
+	ccp := &fakeCCPClient{app: proto.Clone(original).(*porterv1.PorterApp)}
+	inp := testSleepInput(ccp)
+
+	state, err := SleepApp(ctx, inp)
+	require.NoError(t, err)
+	assert.True(t, state.Asleep)
+	assert.Equal(t, int32(0), ccp.app.Services["web"].Instances)
+	assert.Nil(t, ccp.app.Services["web"].GetWebConfig().Autoscaling)
+	assert.Equal(t, int32(0), ccp.app.Services["worker"].Instances)
+
+	// sleeping an app which is already asleep does not apply it again, or overwrite the original scale
+	_, err = SleepApp(ctx, inp)
+	require.NoError(t, err)
+	assert.Equal(t, 1, ccp.applies)
+
+	state, err = WakeApp(ctx, inp)
+	require.NoError(t, err)
+	assert.False(t, state.Asleep)
+	assert.Nil(t, state.OriginalScale)
+	assert.True(t, proto.Equal(original, ccp.app), "expected %v, got %v", original, ccp.app)
+}
+
+func TestWakeAppKeepsScaleOfRevisionDeployedWhileAsleep(t *testing.T) {
+	ctx := context.Background()
+	ccp := &fakeCCPClient{app: testApp(3, 2)}
+	inp := testSleepInput(ccp)
+
+	_, err := SleepApp(ctx, inp)
+	require.NoError(t, err)
+
+	// a new revision scales the worker up while the app is asleep
+	deployed := proto.Clone(ccp.app).(*porterv1.PorterApp)
+	deployed.Services["worker"].Instances = 4
+	ccp.app = deployed
+
+	_, err = WakeApp(ctx, inp)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), ccp.app.Services["web"].Instances)
+	assert.NotNil(t, ccp.app.Services["web"].GetWebConfig().Autoscaling)
+	assert.Equal(t, int32(4), ccp.app.Services["worker"].Instances)
+}
+
+func TestSleepScheduleRefreshesStaleState(t *testing.T) {
+	ctx := context.Background()
+	ccp := &fakeCCPClient{app: testApp(3, 2)}
+	inp := testSleepInput(ccp)
+
+	_, err := SleepApp(ctx, inp)
+	require.NoError(t, err)
+
+	// a new revision scales every service up while the app is asleep, so the stored scale is stale
+	ccp.app = testApp(6, 1)
+
+	_, err = inp.Repo.AppSleep().CreateSchedule(&models.AppSleepSchedule{
+		ProjectID:          1,
+		ClusterID:          1,
+		DeploymentTargetID: testDeploymentTargetID,
+		Days:               "mon,tue,wed,thu,fri,sat,sun",
+		WakeTime:           "08:00",
+		SleepTime:          "09:00",
+		Timezone:           "UTC",
+	})
+	require.NoError(t, err)
+
+	err = RunSleepSchedules(ctx, RunSleepSchedulesInput{
+		Now:       time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		CCPClient: ccp,
+		Repo:      inp.Repo,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), ccp.app.Services["web"].Instances)
+
+	_, err = WakeApp(ctx, inp)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(testApp(6, 1), ccp.app), "expected the scale of the new revision, got %v", ccp.app)
+}
@@ -0,0 +1,221 @@
+package projectexport
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"golang.org/x/crypto/scrypt"
+)
+
+// ArchiveVersion is the version of the archive format written by Export. Archives written by a newer
+// version of Porter are rejected on import.
+const ArchiveVersion = 2
+
+// MaxArchiveSize is the maximum size of an archive, both compressed and decompressed
+const MaxArchiveSize = 64 << 20
+
+// MinPassphraseLength is the minimum length of the passphrase which protects the credentials of an archive
+const MinPassphraseLength = 12
+
+// ErrInvalidPassphrase is returned when the credentials of an archive cannot be decrypted with a passphrase
+var ErrInvalidPassphrase = errors.New("the passphrase does not match the one used to export the project")
+
+// Archive is a versioned copy of every project-scoped model of a project
+type Archive struct {
+	// Version is the version of the archive format
+	Version int `json:"version"`
+
+	// ExportedAt is the time at which the archive was written
+	ExportedAt time.Time `json:"exported_at"`
+
+	// SourceProjectID and SourceProjectName identify the project that was exported
+	SourceProjectID   uint   `json:"source_project_id"`
+	SourceProjectName string `json:"source_project_name"`
+
+	// Resources are the models of the project, with all credentials removed
+	Resources Resources `json:"resources"`
+
+	// Salt is the salt used to derive the credentials key from the export passphrase
+	Salt []byte `json:"salt"`
+
+	// Credentials is the json-encoded Credentials of the project, encrypted with a key derived from the
+	// export passphrase
+	Credentials []byte `json:"credentials"`
+}
+
+// Resources are the models of a project which do not hold credentials
+type Resources struct {
+	Clusters          []*models.Cluster          `json:"clusters"`
+	Registries        []*models.Registry         `json:"registries"`
+	DeploymentTargets []*models.DeploymentTarget `json:"deployment_targets"`
+	PorterApps        []*models.PorterApp        `json:"porter_apps"`
+	AppRevisions      []*models.AppRevision      `json:"app_revisions"`
+	Environments      []*models.Environment      `json:"environments"`
+	Policies          []*models.Policy           `json:"policies"`
+	HelmRepos         []*models.HelmRepo         `json:"helm_repos"`
+
+	// CustomRoles are the roles of collaborators which are defined by one of the exported policies
+	CustomRoles []*models.Role `json:"custom_roles"`
+
+	// EnvGroups describe the environment groups of each cluster. Their variables are stored in the cluster,
+	// so they are not part of the archive.
+	EnvGroups []*EnvGroupMetadata `json:"env_groups"`
+}
+
+// EnvGroupMetadata describes the latest version of an environment group in a cluster
+type EnvGroupMetadata struct {
+	ClusterID           uint     `json:"cluster_id"`
+	Name                string   `json:"name"`
+	LatestVersion       int      `json:"latest_version"`
+	VariableNames       []string `json:"variable_names"`
+	SecretVariableNames []string `json:"secret_variable_names"`
+}
+
+// Credentials are the secret values of a project. They are stored encrypted in an archive.
+type Credentials struct {
+	KubeIntegrations  []*ints.KubeIntegration  `json:"kube_integrations"`
+	BasicIntegrations []*ints.BasicIntegration `json:"basic_integrations"`
+	OIDCIntegrations  []*ints.OIDCIntegration  `json:"oidc_integrations"`
+	OAuthIntegrations []*ints.OAuthIntegration `json:"oauth_integrations"`
+	AWSIntegrations   []*ints.AWSIntegration   `json:"aws_integrations"`
+	GCPIntegrations   []*ints.GCPIntegration   `json:"gcp_integrations"`
+	AzureIntegrations []*ints.AzureIntegration `json:"azure_integrations"`
+
+	SlackIntegrations   []*ints.SlackIntegration   `json:"slack_integrations"`
+	WebhookIntegrations []*ints.WebhookIntegration `json:"webhook_integrations"`
+	PagingIntegrations  []*ints.PagingIntegration  `json:"paging_integrations"`
+
+	// ClusterCertificateAuthorityData is the certificate authority data of each cluster, keyed by cluster id
+	ClusterCertificateAuthorityData map[uint][]byte `json:"cluster_certificate_authority_data"`
+
+	// DeploymentTargetEnvOverrides is the env overrides of each deployment target, keyed by deployment target id
+	DeploymentTargetEnvOverrides map[string][]byte `json:"deployment_target_env_overrides"`
+}
+
+// Encode writes the archive as gzipped json
+func (a *Archive) Encode() ([]byte, error) {
+	var buf bytes.Buffer
+
+	gz := gzip.NewWriter(&buf)
+
+	if err := json.NewEncoder(gz).Encode(a); err != nil {
+		return nil, fmt.Errorf("error encoding archive: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("error compressing archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Decode reads an archive written by Encode, and checks that its version can be imported. Archives larger
+// than MaxArchiveSize once decompressed are rejected.
+func Decode(data []byte) (*Archive, error) {
+	if len(data) > MaxArchiveSize {
+		return nil, fmt.Errorf("archive is larger than the maximum size of %d bytes", MaxArchiveSize)
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("archive is not a porter project export: %w", err)
+	}
+	defer gz.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(gz, MaxArchiveSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing archive: %w", err)
+	}
+
+	if len(raw) > MaxArchiveSize {
+		return nil, fmt.Errorf("decompressed archive is larger than the maximum size of %d bytes", MaxArchiveSize)
+	}
+
+	archive := &Archive{}
+
+	if err := json.Unmarshal(raw, archive); err != nil {
+		return nil, fmt.Errorf("error decoding archive: %w", err)
+	}
+
+	if archive.Version < 1 || archive.Version > ArchiveVersion {
+		return nil, fmt.Errorf("unsupported archive version %d, this version of porter supports versions up to %d", archive.Version, ArchiveVersion)
+	}
+
+	return archive, nil
+}
+
+// SealCredentials encrypts the credentials into the archive with a key derived from the passphrase
+func (a *Archive) SealCredentials(creds *Credentials, passphrase string) error {
+	if len(passphrase) < MinPassphraseLength {
+		return fmt.Errorf("the passphrase must be at least %d characters long", MinPassphraseLength)
+	}
+
+	salt := make([]byte, 16)
+
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return fmt.Errorf("error generating salt: %w", err)
+	}
+
+	key, err := deriveKey(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := json.Marshal(creds)
+	if err != nil {
+		return fmt.Errorf("error encoding credentials: %w", err)
+	}
+
+	ciphertext, err := encryption.Encrypt(plaintext, key)
+	if err != nil {
+		return fmt.Errorf("error encrypting credentials: %w", err)
+	}
+
+	a.Salt = salt
+	a.Credentials = ciphertext
+
+	return nil
+}
+
+// OpenCredentials decrypts the credentials of the archive. ErrInvalidPassphrase is returned if the
+// passphrase is not the one used to seal the credentials.
+func (a *Archive) OpenCredentials(passphrase string) (*Credentials, error) {
+	key, err := deriveKey(passphrase, a.Salt)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := encryption.Decrypt(a.Credentials, key)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	creds := &Credentials{}
+
+	if err := json.Unmarshal(plaintext, creds); err != nil {
+		return nil, fmt.Errorf("error decoding credentials: %w", err)
+	}
+
+	return creds, nil
+}
+
+func deriveKey(passphrase string, salt []byte) (*[32]byte, error) {
+	derived, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving key from passphrase: %w", err)
+	}
+
+	key := [32]byte{}
+	copy(key[:], derived)
+
+	return &key, nil
+}
@@ -0,0 +1,262 @@
+package projectexport
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// ExportOptions configure the export of a project
+type ExportOptions struct {
+	// Passphrase seals the credentials of the archive
+	Passphrase string
+
+	// ListEnvGroups returns the environment groups of a cluster. Environment groups are not exported if it
+	// is not set.
+	ListEnvGroups func(cluster *models.Cluster) ([]*EnvGroupMetadata, error)
+}
+
+// Export reads every project-scoped model of a project into an archive. Credentials are moved out of the
+// exported models and sealed with the passphrase.
+func Export(repo repository.Repository, project *models.Project, opts ExportOptions) (*Archive, error) {
+	archive := &Archive{
+		Version:           ArchiveVersion,
+		ExportedAt:        time.Now().UTC(),
+		SourceProjectID:   project.ID,
+		SourceProjectName: project.Name,
+	}
+
+	creds := &Credentials{
+		ClusterCertificateAuthorityData: make(map[uint][]byte),
+		DeploymentTargetEnvOverrides:    make(map[string][]byte),
+	}
+
+	err := exportIntegrations(repo, project.ID, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &archive.Resources
+
+	res.Clusters, err = repo.Cluster().ListClustersByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusters: %w", err)
+	}
+
+	for _, cluster := range res.Clusters {
+		if len(cluster.CertificateAuthorityData) != 0 {
+			creds.ClusterCertificateAuthorityData[cluster.ID] = cluster.CertificateAuthorityData
+		}
+
+		cluster.CertificateAuthorityData = nil
+		cluster.TokenCache = ints.ClusterTokenCache{}
+
+		apps, err := repo.PorterApp().ListPorterAppByClusterID(cluster.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing apps in cluster %d: %w", cluster.ID, err)
+		}
+
+		res.PorterApps = append(res.PorterApps, apps...)
+
+		envs, err := repo.Environment().ListEnvironments(project.ID, cluster.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error listing environments in cluster %d: %w", cluster.ID, err)
+		}
+
+		res.Environments = append(res.Environments, envs...)
+
+		if opts.ListEnvGroups != nil {
+			envGroups, err := opts.ListEnvGroups(cluster)
+			if err != nil {
+				return nil, fmt.Errorf("error listing environment groups in cluster %d: %w", cluster.ID, err)
+			}
+
+			for _, envGroup := range envGroups {
+				envGroup.ClusterID = cluster.ID
+			}
+
+			res.EnvGroups = append(res.EnvGroups, envGroups...)
+		}
+	}
+
+	res.Registries, err = repo.Registry().ListRegistriesByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing registries: %w", err)
+	}
+
+	for _, reg := range res.Registries {
+		reg.TokenCache = ints.RegTokenCache{}
+	}
+
+	res.DeploymentTargets, err = repo.DeploymentTarget().List(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deployment targets: %w", err)
+	}
+
+	for _, target := range res.DeploymentTargets {
+		if len(target.EnvOverrides) != 0 {
+			creds.DeploymentTargetEnvOverrides[target.ID.String()] = target.EnvOverrides
+		}
+
+		target.EnvOverrides = nil
+	}
+
+	res.AppRevisions, err = repo.AppRevision().ListAppRevisionsByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing app revisions: %w", err)
+	}
+
+	res.Policies, err = repo.Policy().ListPoliciesByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing policies: %w", err)
+	}
+
+	res.HelmRepos, err = repo.HelmRepo().ListHelmReposByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing helm repos: %w", err)
+	}
+
+	for _, hr := range res.HelmRepos {
+		hr.TokenCache = ints.HelmRepoTokenCache{}
+	}
+
+	roles, err := repo.Project().ListProjectRoles(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing roles: %w", err)
+	}
+
+	for i := range roles {
+		if roles[i].Kind == types.RoleCustom {
+			res.CustomRoles = append(res.CustomRoles, &roles[i])
+		}
+	}
+
+	if err := archive.SealCredentials(creds, opts.Passphrase); err != nil {
+		return nil, err
+	}
+
+	return archive, nil
+}
+
+// exportIntegrations reads every auth mechanism and notification integration of the project. List queries
+// return the auth mechanisms as stored, so each one is read again to get its decrypted credentials.
+func exportIntegrations(repo repository.Repository, projectID uint, creds *Credentials) error {
+	kubes, err := repo.KubeIntegration().ListKubeIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing kube integrations: %w", err)
+	}
+
+	for _, stored := range kubes {
+		integration, err := repo.KubeIntegration().ReadKubeIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading kube integration %d: %w", stored.ID, err)
+		}
+
+		creds.KubeIntegrations = append(creds.KubeIntegrations, integration)
+	}
+
+	basics, err := repo.BasicIntegration().ListBasicIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing basic integrations: %w", err)
+	}
+
+	for _, stored := range basics {
+		integration, err := repo.BasicIntegration().ReadBasicIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading basic integration %d: %w", stored.ID, err)
+		}
+
+		creds.BasicIntegrations = append(creds.BasicIntegrations, integration)
+	}
+
+	oidcs, err := repo.OIDCIntegration().ListOIDCIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing oidc integrations: %w", err)
+	}
+
+	for _, stored := range oidcs {
+		integration, err := repo.OIDCIntegration().ReadOIDCIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading oidc integration %d: %w", stored.ID, err)
+		}
+
+		creds.OIDCIntegrations = append(creds.OIDCIntegrations, integration)
+	}
+
+	oauths, err := repo.OAuthIntegration().ListOAuthIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing oauth integrations: %w", err)
+	}
+
+	for _, stored := range oauths {
+		integration, err := repo.OAuthIntegration().ReadOAuthIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading oauth integration %d: %w", stored.ID, err)
+		}
+
+		creds.OAuthIntegrations = append(creds.OAuthIntegrations, integration)
+	}
+
+	awss, err := repo.AWSIntegration().ListAWSIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing aws integrations: %w", err)
+	}
+
+	for _, stored := range awss {
+		integration, err := repo.AWSIntegration().ReadAWSIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading aws integration %d: %w", stored.ID, err)
+		}
+
+		creds.AWSIntegrations = append(creds.AWSIntegrations, integration)
+	}
+
+	gcps, err := repo.GCPIntegration().ListGCPIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing gcp integrations: %w", err)
+	}
+
+	for _, stored := range gcps {
+		integration, err := repo.GCPIntegration().ReadGCPIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading gcp integration %d: %w", stored.ID, err)
+		}
+
+		creds.GCPIntegrations = append(creds.GCPIntegrations, integration)
+	}
+
+	azures, err := repo.AzureIntegration().ListAzureIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing azure integrations: %w", err)
+	}
+
+	for _, stored := range azures {
+		integration, err := repo.AzureIntegration().ReadAzureIntegration(projectID, stored.ID)
+		if err != nil {
+			return fmt.Errorf("error reading azure integration %d: %w", stored.ID, err)
+		}
+
+		creds.AzureIntegrations = append(creds.AzureIntegrations, integration)
+	}
+
+	creds.SlackIntegrations, err = repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing slack integrations: %w", err)
+	}
+
+	creds.WebhookIntegrations, err = repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing webhook integrations: %w", err)
+	}
+
+	creds.PagingIntegrations, err = repo.PagingIntegration().ListPagingIntegrationsByProjectID(projectID)
+	if err != nil {
+		return fmt.Errorf("error listing paging integrations: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,749 @@
+package projectexport
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/features"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// The kinds of models in an archive, as reported in import counts and id mappings
+const (
+	KindKubeIntegration    = "kube_integrations"
+	KindBasicIntegration   = "basic_integrations"
+	KindOIDCIntegration    = "oidc_integrations"
+	KindOAuthIntegration   = "oauth_integrations"
+	KindAWSIntegration     = "aws_integrations"
+	KindGCPIntegration     = "gcp_integrations"
+	KindAzureIntegration   = "azure_integrations"
+	KindCluster            = "clusters"
+	KindRegistry           = "registries"
+	KindDeploymentTarget   = "deployment_targets"
+	KindPorterApp          = "porter_apps"
+	KindAppRevision        = "app_revisions"
+	KindEnvironment        = "environments"
+	KindPolicy             = "policies"
+	KindSlackIntegration   = "slack_integrations"
+	KindWebhookIntegration = "webhook_integrations"
+	KindPagingIntegration  = "paging_integrations"
+	KindHelmRepo           = "helm_repos"
+	KindCustomRole         = "custom_roles"
+	KindEnvGroup           = "env_groups"
+)
+
+// ImportOptions configure the import of an archive into a project
+type ImportOptions struct {
+	// Passphrase is the passphrase the archive was exported with
+	Passphrase string
+
+	// DryRun validates the archive without creating any models
+	DryRun bool
+
+	// UserID is the id of the user running the import. Imported integrations and policies are attributed to them.
+	UserID uint
+
+	LaunchDarklyClient *features.Client
+}
+
+// Import creates every model of an archive in a project. All models get new ids, and the references between
+// them are remapped to the new ids. The archive is validated before anything is written: if validation fails,
+// or if opts.DryRun is set, the returned response lists what would be imported and nothing is created. The
+// models are created in a single transaction, so nothing is created if the import fails part way.
+func Import(repo repository.Repository, project *models.Project, archive *Archive, opts ImportOptions) (*types.ImportProjectResponse, error) {
+	creds, err := archive.OpenCredentials(opts.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	existingTargets, err := repo.DeploymentTarget().List(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing deployment targets: %w", err)
+	}
+
+	existingClusters, err := repo.Cluster().ListClustersByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing clusters: %w", err)
+	}
+
+	resp := &types.ImportProjectResponse{
+		DryRun:     opts.DryRun,
+		Counts:     make(map[string]int),
+		IDMappings: make(map[string]map[string]string),
+		Warnings:   make([]string, 0),
+		Errors:     make([]string, 0),
+	}
+
+	validate(archive, creds, existingClusters, existingTargets, resp)
+
+	if opts.DryRun || len(resp.Errors) != 0 {
+		countArchive(archive, creds, resp)
+		return resp, nil
+	}
+
+	err = repo.Transaction(func(tx repository.Repository) error {
+		imp := &importer{
+			repo:    tx,
+			project: project,
+			opts:    opts,
+			creds:   creds,
+			resp:    resp,
+			ids:     make(map[string]map[uint]uint),
+			uuids:   make(map[string]map[uuid.UUID]uuid.UUID),
+		}
+
+		return imp.importArchive(archive)
+	})
+	if err != nil {
+		resp.Counts = make(map[string]int)
+		resp.IDMappings = make(map[string]map[string]string)
+
+		return resp, fmt.Errorf("import was rolled back: %w", err)
+	}
+
+	return resp, nil
+}
+
+// validate checks that every reference in the archive points to a model of the archive, and that the models
+// do not conflict with the ones already in the project
+func validate(
+	archive *Archive,
+	creds *Credentials,
+	existingClusters []*models.Cluster,
+	existingTargets []*models.DeploymentTarget,
+	resp *types.ImportProjectResponse,
+) {
+	errorf := func(format string, args ...interface{}) {
+		resp.Errors = append(resp.Errors, fmt.Sprintf(format, args...))
+	}
+
+	warnf := func(format string, args ...interface{}) {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf(format, args...))
+	}
+
+	integrations := credentialIDs(creds)
+	res := archive.Resources
+
+	checkIntegration := func(owner string, kind string, id uint) {
+		if id != 0 && !integrations[kind][id] {
+			errorf("%s references %s %d, which is not in the archive", owner, kind, id)
+		}
+	}
+
+	clusterNames := make(map[string]bool)
+
+	for _, cluster := range existingClusters {
+		clusterNames[cluster.Name] = true
+	}
+
+	clusters := make(map[uint]bool)
+
+	for _, cluster := range res.Clusters {
+		owner := fmt.Sprintf("cluster %q", cluster.Name)
+		clusters[cluster.ID] = true
+
+		checkIntegration(owner, KindKubeIntegration, cluster.KubeIntegrationID)
+		checkIntegration(owner, KindOIDCIntegration, cluster.OIDCIntegrationID)
+		checkIntegration(owner, KindGCPIntegration, cluster.GCPIntegrationID)
+		checkIntegration(owner, KindAWSIntegration, cluster.AWSIntegrationID)
+		checkIntegration(owner, KindOAuthIntegration, cluster.DOIntegrationID)
+		checkIntegration(owner, KindAzureIntegration, cluster.AzureIntegrationID)
+
+		if clusterNames[cluster.Name] {
+			warnf("a cluster named %q already exists in the project, the imported cluster will have the same name", cluster.Name)
+		}
+
+		if cluster.InfraID != 0 {
+			warnf("%s was provisioned by porter, it is imported without a link to its infrastructure", owner)
+		}
+	}
+
+	for _, reg := range res.Registries {
+		owner := fmt.Sprintf("registry %q", reg.Name)
+
+		checkIntegration(owner, KindGCPIntegration, reg.GCPIntegrationID)
+		checkIntegration(owner, KindAWSIntegration, reg.AWSIntegrationID)
+		checkIntegration(owner, KindAzureIntegration, reg.AzureIntegrationID)
+		checkIntegration(owner, KindOAuthIntegration, reg.DOIntegrationID)
+		checkIntegration(owner, KindBasicIntegration, reg.BasicIntegrationID)
+
+		if reg.InfraID != 0 {
+			warnf("%s was provisioned by porter, it is imported without a link to its infrastructure", owner)
+		}
+	}
+
+	targetNames := make(map[string]bool)
+
+	for _, target := range existingTargets {
+		if target.Name != "" {
+			targetNames[target.Name] = true
+		}
+	}
+
+	targets := make(map[uuid.UUID]bool)
+
+	for _, target := range res.DeploymentTargets {
+		targets[target.ID] = true
+
+		if !clusters[uint(target.ClusterID)] {
+			errorf("deployment target %s references cluster %d, which is not in the archive", target.ID, target.ClusterID)
+		}
+
+		if target.Name != "" && targetNames[target.Name] {
+			errorf("a deployment target named %q already exists in the project", target.Name)
+		}
+	}
+
+	apps := make(map[uint]bool)
+
+	for _, app := range res.PorterApps {
+		apps[app.ID] = true
+
+		if !clusters[app.ClusterID] {
+			errorf("app %q references cluster %d, which is not in the archive", app.Name, app.ClusterID)
+		}
+	}
+
+	for _, revision := range res.AppRevisions {
+		if !apps[uint(revision.PorterAppID)] {
+			errorf("app revision %s references app %d, which is not in the archive", revision.ID, revision.PorterAppID)
+		}
+
+		if !targets[revision.DeploymentTargetID] {
+			errorf("app revision %s references deployment target %s, which is not in the archive", revision.ID, revision.DeploymentTargetID)
+		}
+	}
+
+	for _, env := range res.Environments {
+		if !clusters[env.ClusterID] {
+			errorf("environment %q references cluster %d, which is not in the archive", env.Name, env.ClusterID)
+		}
+
+		if env.GithubWebhookID != 0 {
+			warnf("the github webhook of environment %q is not imported, the environment must be re-enabled to receive events", env.Name)
+		}
+	}
+
+	for _, hr := range res.HelmRepos {
+		owner := fmt.Sprintf("helm repo %q", hr.Name)
+
+		checkIntegration(owner, KindBasicIntegration, hr.BasicAuthIntegrationID)
+		checkIntegration(owner, KindGCPIntegration, hr.GCPIntegrationID)
+		checkIntegration(owner, KindAWSIntegration, hr.AWSIntegrationID)
+	}
+
+	policies := make(map[string]bool)
+
+	for _, policy := range res.Policies {
+		policies[policy.UniqueID] = true
+	}
+
+	for _, role := range res.CustomRoles {
+		if !policies[role.PolicyUID] {
+			errorf("the custom role of user %d references policy %s, which is not in the archive", role.UserID, role.PolicyUID)
+		}
+	}
+
+	envGroupNames := make([]string, 0)
+
+	for _, envGroup := range res.EnvGroups {
+		if !clusters[envGroup.ClusterID] {
+			errorf("environment group %q references cluster %d, which is not in the archive", envGroup.Name, envGroup.ClusterID)
+		}
+
+		envGroupNames = append(envGroupNames, envGroup.Name)
+	}
+
+	if len(envGroupNames) != 0 {
+		warnf(
+			"the variables of environment groups are stored in their clusters and are not copied, the imported clusters must contain %s",
+			strings.Join(envGroupNames, ", "),
+		)
+	}
+}
+
+// credentialIDs returns the ids of the integrations in the credentials, keyed by kind
+func credentialIDs(creds *Credentials) map[string]map[uint]bool {
+	res := make(map[string]map[uint]bool)
+
+	add := func(kind string, id uint) {
+		if res[kind] == nil {
+			res[kind] = make(map[uint]bool)
+		}
+
+		res[kind][id] = true
+	}
+
+	for _, ki := range creds.KubeIntegrations {
+		add(KindKubeIntegration, ki.ID)
+	}
+
+	for _, bi := range creds.BasicIntegrations {
+		add(KindBasicIntegration, bi.ID)
+	}
+
+	for _, oi := range creds.OIDCIntegrations {
+		add(KindOIDCIntegration, oi.ID)
+	}
+
+	for _, oi := range creds.OAuthIntegrations {
+		add(KindOAuthIntegration, oi.ID)
+	}
+
+	for _, ai := range creds.AWSIntegrations {
+		add(KindAWSIntegration, ai.ID)
+	}
+
+	for _, gi := range creds.GCPIntegrations {
+		add(KindGCPIntegration, gi.ID)
+	}
+
+	for _, ai := range creds.AzureIntegrations {
+		add(KindAzureIntegration, ai.ID)
+	}
+
+	return res
+}
+
+// countArchive sets the counts of the response to the number of models in the archive
+func countArchive(archive *Archive, creds *Credentials, resp *types.ImportProjectResponse) {
+	for kind, ids := range credentialIDs(creds) {
+		resp.Counts[kind] = len(ids)
+	}
+
+	res := archive.Resources
+
+	resp.Counts[KindCluster] = len(res.Clusters)
+	resp.Counts[KindRegistry] = len(res.Registries)
+	resp.Counts[KindDeploymentTarget] = len(res.DeploymentTargets)
+	resp.Counts[KindPorterApp] = len(res.PorterApps)
+	resp.Counts[KindAppRevision] = len(res.AppRevisions)
+	resp.Counts[KindEnvironment] = len(res.Environments)
+	resp.Counts[KindPolicy] = len(res.Policies)
+	resp.Counts[KindHelmRepo] = len(res.HelmRepos)
+	resp.Counts[KindCustomRole] = len(res.CustomRoles)
+	resp.Counts[KindEnvGroup] = len(res.EnvGroups)
+	resp.Counts[KindSlackIntegration] = len(creds.SlackIntegrations)
+	resp.Counts[KindWebhookIntegration] = len(creds.WebhookIntegrations)
+	resp.Counts[KindPagingIntegration] = len(creds.PagingIntegrations)
+}
+
+// importer creates the models of an archive in a project, keeping track of the id of each created model
+type importer struct {
+	repo    repository.Repository
+	project *models.Project
+	opts    ImportOptions
+	creds   *Credentials
+	resp    *types.ImportProjectResponse
+
+	ids   map[string]map[uint]uint
+	uuids map[string]map[uuid.UUID]uuid.UUID
+}
+
+func (i *importer) record(kind string, oldID, newID string) {
+	if i.resp.IDMappings[kind] == nil {
+		i.resp.IDMappings[kind] = make(map[string]string)
+	}
+
+	i.resp.IDMappings[kind][oldID] = newID
+	i.resp.Counts[kind]++
+}
+
+func (i *importer) recordID(kind string, oldID, newID uint) {
+	if i.ids[kind] == nil {
+		i.ids[kind] = make(map[uint]uint)
+	}
+
+	i.ids[kind][oldID] = newID
+	i.record(kind, strconv.FormatUint(uint64(oldID), 10), strconv.FormatUint(uint64(newID), 10))
+}
+
+func (i *importer) recordUUID(kind string, oldID, newID uuid.UUID) {
+	if i.uuids[kind] == nil {
+		i.uuids[kind] = make(map[uuid.UUID]uuid.UUID)
+	}
+
+	i.uuids[kind][oldID] = newID
+	i.record(kind, oldID.String(), newID.String())
+}
+
+// remap returns the new id of a model, or 0 if the model was not referenced
+func (i *importer) remap(kind string, oldID uint) uint {
+	if oldID == 0 {
+		return 0
+	}
+
+	return i.ids[kind][oldID]
+}
+
+func (i *importer) importArchive(archive *Archive) error {
+	if err := i.importIntegrations(); err != nil {
+		return err
+	}
+
+	res := archive.Resources
+
+	for _, cluster := range res.Clusters {
+		oldID := cluster.ID
+
+		cluster.Model = gorm.Model{}
+		cluster.ProjectID = i.project.ID
+		cluster.InfraID = 0
+		cluster.TokenCacheID = 0
+		cluster.CertificateAuthorityData = i.creds.ClusterCertificateAuthorityData[oldID]
+		cluster.KubeIntegrationID = i.remap(KindKubeIntegration, cluster.KubeIntegrationID)
+		cluster.OIDCIntegrationID = i.remap(KindOIDCIntegration, cluster.OIDCIntegrationID)
+		cluster.GCPIntegrationID = i.remap(KindGCPIntegration, cluster.GCPIntegrationID)
+		cluster.AWSIntegrationID = i.remap(KindAWSIntegration, cluster.AWSIntegrationID)
+		cluster.DOIntegrationID = i.remap(KindOAuthIntegration, cluster.DOIntegrationID)
+		cluster.AzureIntegrationID = i.remap(KindAzureIntegration, cluster.AzureIntegrationID)
+
+		created, err := i.repo.Cluster().CreateCluster(cluster, i.opts.LaunchDarklyClient)
+		if err != nil {
+			return fmt.Errorf("error creating cluster %q: %w", cluster.Name, err)
+		}
+
+		i.recordID(KindCluster, oldID, created.ID)
+	}
+
+	for _, reg := range res.Registries {
+		oldID := reg.ID
+
+		reg.Model = gorm.Model{}
+		reg.ProjectID = i.project.ID
+		reg.InfraID = 0
+		reg.GCPIntegrationID = i.remap(KindGCPIntegration, reg.GCPIntegrationID)
+		reg.AWSIntegrationID = i.remap(KindAWSIntegration, reg.AWSIntegrationID)
+		reg.AzureIntegrationID = i.remap(KindAzureIntegration, reg.AzureIntegrationID)
+		reg.DOIntegrationID = i.remap(KindOAuthIntegration, reg.DOIntegrationID)
+		reg.BasicIntegrationID = i.remap(KindBasicIntegration, reg.BasicIntegrationID)
+
+		created, err := i.repo.Registry().CreateRegistry(reg)
+		if err != nil {
+			return fmt.Errorf("error creating registry %q: %w", reg.Name, err)
+		}
+
+		i.recordID(KindRegistry, oldID, created.ID)
+	}
+
+	for _, target := range res.DeploymentTargets {
+		oldID := target.ID
+
+		target.Model = gorm.Model{}
+		target.ID = uuid.New()
+		target.ProjectID = int(i.project.ID)
+		target.ClusterID = int(i.remap(KindCluster, uint(target.ClusterID)))
+		target.EnvOverrides = i.creds.DeploymentTargetEnvOverrides[oldID.String()]
+
+		created, err := i.repo.DeploymentTarget().CreateDeploymentTarget(target)
+		if err != nil {
+			return fmt.Errorf("error creating deployment target %s: %w", oldID, err)
+		}
+
+		i.recordUUID(KindDeploymentTarget, oldID, created.ID)
+	}
+
+	for _, app := range res.PorterApps {
+		oldID := app.ID
+
+		app.Model = gorm.Model{}
+		app.ProjectID = i.project.ID
+		app.ClusterID = i.remap(KindCluster, app.ClusterID)
+
+		created, err := i.repo.PorterApp().CreatePorterApp(app)
+		if err != nil {
+			return fmt.Errorf("error creating app %q: %w", app.Name, err)
+		}
+
+		i.recordID(KindPorterApp, oldID, created.ID)
+	}
+
+	for _, revision := range res.AppRevisions {
+		oldID := revision.ID
+
+		revision.Model = gorm.Model{}
+		revision.ID = uuid.New()
+		revision.ProjectID = int(i.project.ID)
+		revision.PorterAppID = int(i.remap(KindPorterApp, uint(revision.PorterAppID)))
+		revision.DeploymentTargetID = i.uuids[KindDeploymentTarget][revision.DeploymentTargetID]
+
+		created, err := i.repo.AppRevision().CreateAppRevision(revision)
+		if err != nil {
+			return fmt.Errorf("error creating app revision %s: %w", oldID, err)
+		}
+
+		i.recordUUID(KindAppRevision, oldID, created.ID)
+	}
+
+	for _, env := range res.Environments {
+		oldID := env.ID
+
+		webhookUID, err := encryption.GenerateRandomBytes(32)
+		if err != nil {
+			return fmt.Errorf("error generating webhook id for environment %q: %w", env.Name, err)
+		}
+
+		env.Model = gorm.Model{}
+		env.ProjectID = i.project.ID
+		env.ClusterID = i.remap(KindCluster, env.ClusterID)
+		env.WebhookID = webhookUID
+		env.GithubWebhookID = 0
+
+		created, err := i.repo.Environment().CreateEnvironment(env)
+		if err != nil {
+			return fmt.Errorf("error creating environment %q: %w", env.Name, err)
+		}
+
+		i.recordID(KindEnvironment, oldID, created.ID)
+	}
+
+	for _, policy := range res.Policies {
+		oldUID := policy.UniqueID
+
+		uid, err := encryption.GenerateRandomBytes(16)
+		if err != nil {
+			return fmt.Errorf("error generating id for policy %q: %w", policy.Name, err)
+		}
+
+		policy.Model = gorm.Model{}
+		policy.UniqueID = uid
+		policy.ProjectID = i.project.ID
+		policy.CreatedByUserID = i.opts.UserID
+
+		created, err := i.repo.Policy().CreatePolicy(policy)
+		if err != nil {
+			return fmt.Errorf("error creating policy %q: %w", policy.Name, err)
+		}
+
+		i.record(KindPolicy, oldUID, created.UniqueID)
+	}
+
+	for _, hr := range res.HelmRepos {
+		oldID := hr.ID
+
+		hr.Model = gorm.Model{}
+		hr.ProjectID = i.project.ID
+		hr.TokenCache = ints.HelmRepoTokenCache{}
+		hr.BasicAuthIntegrationID = i.remap(KindBasicIntegration, hr.BasicAuthIntegrationID)
+		hr.GCPIntegrationID = i.remap(KindGCPIntegration, hr.GCPIntegrationID)
+		hr.AWSIntegrationID = i.remap(KindAWSIntegration, hr.AWSIntegrationID)
+
+		created, err := i.repo.HelmRepo().CreateHelmRepo(hr)
+		if err != nil {
+			return fmt.Errorf("error creating helm repo %q: %w", hr.Name, err)
+		}
+
+		i.recordID(KindHelmRepo, oldID, created.ID)
+	}
+
+	if err := i.importCustomRoles(res.CustomRoles); err != nil {
+		return err
+	}
+
+	// environment groups are stored in the clusters, which keep their names when they are imported
+	for _, envGroup := range res.EnvGroups {
+		i.record(KindEnvGroup, envGroup.Name, envGroup.Name)
+	}
+
+	return nil
+}
+
+// importCustomRoles assigns the custom roles of the archive to the same users in the project. Users who no
+// longer exist, or who already have a role in the project, are skipped with a warning.
+func (i *importer) importCustomRoles(roles []*models.Role) error {
+	for _, role := range roles {
+		if _, err := i.repo.User().ReadUser(role.UserID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				i.resp.Warnings = append(i.resp.Warnings, fmt.Sprintf("the custom role of user %d is not imported, the user does not exist", role.UserID))
+				continue
+			}
+
+			return fmt.Errorf("error reading user %d: %w", role.UserID, err)
+		}
+
+		if _, err := i.repo.Project().ReadProjectRole(i.project.ID, role.UserID); err == nil {
+			i.resp.Warnings = append(i.resp.Warnings, fmt.Sprintf("the custom role of user %d is not imported, the user already has a role in the project", role.UserID))
+			continue
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("error reading role of user %d: %w", role.UserID, err)
+		}
+
+		created, err := i.repo.Project().CreateProjectRole(i.project, &models.Role{
+			Role: types.Role{
+				Kind:      types.RoleCustom,
+				UserID:    role.UserID,
+				ProjectID: i.project.ID,
+				PolicyUID: i.resp.IDMappings[KindPolicy][role.PolicyUID],
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("error creating custom role of user %d: %w", role.UserID, err)
+		}
+
+		i.record(KindCustomRole, strconv.FormatUint(uint64(role.UserID), 10), created.PolicyUID)
+	}
+
+	return nil
+}
+
+func (i *importer) importIntegrations() error {
+	for _, ki := range i.creds.KubeIntegrations {
+		oldID := ki.ID
+
+		ki.Model = gorm.Model{}
+		ki.ProjectID = i.project.ID
+		ki.UserID = i.opts.UserID
+
+		created, err := i.repo.KubeIntegration().CreateKubeIntegration(ki)
+		if err != nil {
+			return fmt.Errorf("error creating kube integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindKubeIntegration, oldID, created.ID)
+	}
+
+	for _, bi := range i.creds.BasicIntegrations {
+		oldID := bi.ID
+
+		bi.Model = gorm.Model{}
+		bi.ProjectID = i.project.ID
+		bi.UserID = i.opts.UserID
+
+		created, err := i.repo.BasicIntegration().CreateBasicIntegration(bi)
+		if err != nil {
+			return fmt.Errorf("error creating basic integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindBasicIntegration, oldID, created.ID)
+	}
+
+	for _, oi := range i.creds.OIDCIntegrations {
+		oldID := oi.ID
+
+		oi.Model = gorm.Model{}
+		oi.ProjectID = i.project.ID
+		oi.UserID = i.opts.UserID
+
+		created, err := i.repo.OIDCIntegration().CreateOIDCIntegration(oi)
+		if err != nil {
+			return fmt.Errorf("error creating oidc integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindOIDCIntegration, oldID, created.ID)
+	}
+
+	for _, oi := range i.creds.OAuthIntegrations {
+		oldID := oi.ID
+
+		oi.Model = gorm.Model{}
+		oi.ProjectID = i.project.ID
+		oi.UserID = i.opts.UserID
+
+		created, err := i.repo.OAuthIntegration().CreateOAuthIntegration(oi)
+		if err != nil {
+			return fmt.Errorf("error creating oauth integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindOAuthIntegration, oldID, created.ID)
+	}
+
+	for _, ai := range i.creds.AWSIntegrations {
+		oldID := ai.ID
+
+		ai.Model = gorm.Model{}
+		ai.ProjectID = i.project.ID
+		ai.UserID = i.opts.UserID
+
+		created, err := i.repo.AWSIntegration().CreateAWSIntegration(ai)
+		if err != nil {
+			return fmt.Errorf("error creating aws integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindAWSIntegration, oldID, created.ID)
+	}
+
+	for _, gi := range i.creds.GCPIntegrations {
+		oldID := gi.ID
+
+		gi.Model = gorm.Model{}
+		gi.ProjectID = i.project.ID
+		gi.UserID = i.opts.UserID
+
+		created, err := i.repo.GCPIntegration().CreateGCPIntegration(gi)
+		if err != nil {
+			return fmt.Errorf("error creating gcp integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindGCPIntegration, oldID, created.ID)
+	}
+
+	for _, ai := range i.creds.AzureIntegrations {
+		oldID := ai.ID
+
+		ai.Model = gorm.Model{}
+		ai.ProjectID = i.project.ID
+		ai.UserID = i.opts.UserID
+
+		created, err := i.repo.AzureIntegration().CreateAzureIntegration(ai)
+		if err != nil {
+			return fmt.Errorf("error creating azure integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindAzureIntegration, oldID, created.ID)
+	}
+
+	for _, si := range i.creds.SlackIntegrations {
+		oldID := si.ID
+
+		si.Model = gorm.Model{}
+		si.ProjectID = i.project.ID
+		si.UserID = i.opts.UserID
+
+		created, err := i.repo.SlackIntegration().CreateSlackIntegration(si)
+		if err != nil {
+			return fmt.Errorf("error creating slack integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindSlackIntegration, oldID, created.ID)
+	}
+
+	for _, wi := range i.creds.WebhookIntegrations {
+		oldID := wi.ID
+
+		wi.Model = gorm.Model{}
+		wi.ProjectID = i.project.ID
+		wi.UserID = i.opts.UserID
+
+		created, err := i.repo.WebhookIntegration().CreateWebhookIntegration(wi)
+		if err != nil {
+			return fmt.Errorf("error creating webhook integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindWebhookIntegration, oldID, created.ID)
+	}
+
+	for _, pi := range i.creds.PagingIntegrations {
+		oldID := pi.ID
+
+		pi.Model = gorm.Model{}
+		pi.ProjectID = i.project.ID
+		pi.UserID = i.opts.UserID
+
+		created, err := i.repo.PagingIntegration().CreatePagingIntegration(pi)
+		if err != nil {
+			return fmt.Errorf("error creating paging integration %d: %w", oldID, err)
+		}
+
+		i.recordID(KindPagingIntegration, oldID, created.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package projectexport_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/projectexport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const passphrase = "correct horse battery staple"
+
+func TestArchiveRoundTrip(t *testing.T) {
+	archive := &projectexport.Archive{
+		Version:           projectexport.ArchiveVersion,
+		SourceProjectID:   4,
+		SourceProjectName: "production",
+		Resources: projectexport.Resources{
+			Clusters: []*models.Cluster{{Model: gorm.Model{ID: 7}, Name: "prod", AWSIntegrationID: 2}},
+		},
+	}
+
+	creds := &projectexport.Credentials{
+		AWSIntegrations: []*ints.AWSIntegration{{
+			Model:              gorm.Model{ID: 2},
+			AWSAccessKeyID:     []byte("AKIAEXAMPLE"),
+			AWSSecretAccessKey: []byte("secret"),
+		}},
+		ClusterCertificateAuthorityData: map[uint][]byte{7: []byte("ca-data")},
+	}
+
+	require.NoError(t, archive.SealCredentials(creds, passphrase))
+	assert.NotContains(t, string(archive.Credentials), "AKIAEXAMPLE")
+
+	data, err := archive.Encode()
+	require.NoError(t, err)
+
+	decoded, err := projectexport.Decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, "production", decoded.SourceProjectName)
+	require.Len(t, decoded.Resources.Clusters, 1)
+	assert.Equal(t, uint(7), decoded.Resources.Clusters[0].ID)
+
+	opened, err := decoded.OpenCredentials(passphrase)
+	require.NoError(t, err)
+	require.Len(t, opened.AWSIntegrations, 1)
+	assert.Equal(t, []byte("secret"), opened.AWSIntegrations[0].AWSSecretAccessKey)
+	assert.Equal(t, []byte("ca-data"), opened.ClusterCertificateAuthorityData[7])
+
+	_, err = decoded.OpenCredentials("not the right passphrase")
+	assert.ErrorIs(t, err, projectexport.ErrInvalidPassphrase)
+}
+
+func TestSealCredentialsRejectsShortPassphrase(t *testing.T) {
+	archive := &projectexport.Archive{Version: projectexport.ArchiveVersion}
+
+	assert.Error(t, archive.SealCredentials(&projectexport.Credentials{}, "short"))
+}
+
+func TestDecodeRejectsUnsupportedArchives(t *testing.T) {
+	_, err := projectexport.Decode([]byte(`{"version":1}`))
+	assert.Error(t, err, "archives must be gzipped")
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err = gz.Write([]byte(`{"version":99}`))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	_, err = projectexport.Decode(buf.Bytes())
+	assert.ErrorContains(t, err, "unsupported archive version 99")
+}
+
+func TestDecodeRejectsOversizedArchives(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(`{"version":1,"source_project_name":"`))
+	require.NoError(t, err)
+	_, err = gz.Write(bytes.Repeat([]byte("a"), projectexport.MaxArchiveSize))
+	require.NoError(t, err)
+	_, err = gz.Write([]byte(`"}`))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	_, err = projectexport.Decode(buf.Bytes())
+	assert.ErrorContains(t, err, "larger than the maximum size")
+}
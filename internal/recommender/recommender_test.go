@@ -0,0 +1,83 @@
+package recommender
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRegression(t *testing.T) {
+	assert.True(t, IsRegression(types.MonitorTestStatusSuccess, types.MonitorTestStatusFailed))
+	assert.False(t, IsRegression(types.MonitorTestStatusFailed, types.MonitorTestStatusFailed))
+	assert.False(t, IsRegression(types.MonitorTestStatusFailed, types.MonitorTestStatusSuccess))
+	assert.False(t, IsRegression("", types.MonitorTestStatusFailed))
+}
+
+func TestMeetsSeverity(t *testing.T) {
+	assert.True(t, MeetsSeverity(types.MonitorTestSeverityCritical, types.MonitorTestSeverityHigh))
+	assert.True(t, MeetsSeverity(types.MonitorTestSeverityHigh, types.MonitorTestSeverityHigh))
+	assert.False(t, MeetsSeverity(types.MonitorTestSeverityLow, types.MonitorTestSeverityHigh))
+	assert.True(t, MeetsSeverity(types.MonitorTestSeverityLow, types.MonitorTestSeverityLow))
+	assert.False(t, MeetsSeverity(types.MonitorTestSeverityCritical, ""))
+}
+
+func TestTrend(t *testing.T) {
+	first := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	third := second.Add(24 * time.Hour)
+
+	result := func(runID string, testedAt time.Time, objectID, status, severity string) *models.MonitorTestRunResult {
+		return &models.MonitorTestRunResult{
+			ObjectID:         objectID,
+			Category:         "pod",
+			RecommenderRunID: runID,
+			Result:           status,
+			TestedAt:         testedAt,
+			Title:            objectID,
+			Severity:         severity,
+		}
+	}
+
+	trend := Trend([]*models.MonitorTestRunResult{
+		result("run-1", first, "flappy", "success", "high"),
+		result("run-1", first, "broken", "failed", "critical"),
+		result("run-1", first, "healthy", "success", "low"),
+		result("run-2", second, "flappy", "failed", "high"),
+		result("run-2", second, "broken", "failed", "critical"),
+		result("run-2", second, "healthy", "success", "low"),
+		result("run-3", third, "flappy", "success", "high"),
+		result("run-3", third, "broken", "failed", "critical"),
+	})
+
+	require.Len(t, trend.Runs, 3)
+
+	assert.Equal(t, "run-1", trend.Runs[0].RecommenderRunID)
+	assert.Equal(t, first, trend.Runs[0].TestedAt)
+	assert.Equal(t, 2, trend.Runs[0].Passed)
+	assert.Equal(t, 1, trend.Runs[0].Failed)
+
+	assert.Equal(t, 2, trend.Runs[1].Failed)
+	assert.Equal(t, map[types.MonitorTestSeverity]int{
+		types.MonitorTestSeverityCritical: 1,
+		types.MonitorTestSeverityHigh:     1,
+	}, trend.Runs[1].FailedBySeverity)
+
+	require.Len(t, trend.Checks, 3)
+
+	assert.Equal(t, "broken", trend.Checks[0].ObjectID)
+	assert.Equal(t, 3, trend.Checks[0].Failures)
+	assert.Equal(t, 0, trend.Checks[0].Transitions)
+
+	assert.Equal(t, "flappy", trend.Checks[1].ObjectID)
+	assert.Equal(t, 1, trend.Checks[1].Failures)
+	assert.Equal(t, 2, trend.Checks[1].Transitions)
+	assert.Equal(t, types.MonitorTestStatusSuccess, trend.Checks[1].LastRunResult)
+
+	assert.Equal(t, "healthy", trend.Checks[2].ObjectID)
+	assert.Equal(t, 2, trend.Checks[2].Runs)
+	assert.Equal(t, 0, trend.Checks[2].Failures)
+}
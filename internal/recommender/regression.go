@@ -0,0 +1,84 @@
+package recommender
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// IsRegression returns true if a check which passed in its previous run fails in its current run
+func IsRegression(previous, current types.MonitorTestStatus) bool {
+	return previous == types.MonitorTestStatusSuccess && current == types.MonitorTestStatusFailed
+}
+
+// MeetsSeverity returns true if severity is at or above threshold. No severity meets an empty threshold.
+func MeetsSeverity(severity, threshold types.MonitorTestSeverity) bool {
+	if threshold == "" {
+		return false
+	}
+
+	return models.GetSeverityEnum(string(severity)) >= models.GetSeverityEnum(string(threshold))
+}
+
+// NotifyRegressionsInput is the input to NotifyRegressions
+type NotifyRegressionsInput struct {
+	Repo      repository.Repository
+	Cluster   *models.Cluster
+	ServerURL string
+
+	// Regressions are the checks in the cluster which started failing in a single run of the recommender
+	Regressions []*types.RecommenderRegression
+}
+
+// NotifyRegressions notifies the slack and webhook integrations of the cluster's project about the regressions at or
+// above the recommender alert severity of the cluster. Nothing is sent if the cluster has notifications disabled.
+func NotifyRegressions(input NotifyRegressionsInput) error {
+	cluster := input.Cluster
+
+	if cluster.NotificationsDisabled {
+		return nil
+	}
+
+	threshold := types.MonitorTestSeverity(cluster.RecommenderAlertSeverity)
+
+	regressions := make([]*types.RecommenderRegression, 0)
+
+	for _, regression := range input.Regressions {
+		if MeetsSeverity(regression.Severity, threshold) {
+			regressions = append(regressions, regression)
+		}
+	}
+
+	if len(regressions) == 0 {
+		return nil
+	}
+
+	slackInts, err := input.Repo.SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	if err != nil {
+		return fmt.Errorf("error listing slack integrations: %w", err)
+	}
+
+	webhookInts, err := input.Repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(cluster.ProjectID)
+	if err != nil {
+		return fmt.Errorf("error listing webhook integrations: %w", err)
+	}
+
+	multi := notifier.NewMultiRecommenderNotifier(
+		slack.NewRecommenderNotifier(slackInts...),
+		webhook.NewRecommenderNotifier(&webhook.RecommenderNotifierOpts{}, webhookInts...),
+	)
+
+	url := fmt.Sprintf("%s/infrastructure?project_id=%d&cluster_id=%d", input.ServerURL, cluster.ProjectID, cluster.ID)
+
+	return multi.NotifyRegressions(&types.RecommenderRegressions{
+		ProjectID:   cluster.ProjectID,
+		ClusterID:   cluster.ID,
+		ClusterName: cluster.Name,
+		Regressions: regressions,
+	}, url)
+}
@@ -0,0 +1,74 @@
+package recommender
+
+import (
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// Trend summarizes the history of the recommender checks in a cluster. Results must be ordered by the time
+// they were tested, oldest first.
+func Trend(results []*models.MonitorTestRunResult) *types.GetRecommenderTrendResponse {
+	res := &types.GetRecommenderTrendResponse{
+		Runs:   make([]*types.RecommenderTrendPoint, 0),
+		Checks: make([]*types.RecommenderCheckTrend, 0),
+	}
+
+	runs := make(map[string]*types.RecommenderTrendPoint)
+	checks := make(map[string]*types.RecommenderCheckTrend)
+
+	for _, result := range results {
+		run, ok := runs[result.RecommenderRunID]
+		if !ok {
+			run = &types.RecommenderTrendPoint{
+				RecommenderRunID: result.RecommenderRunID,
+				TestedAt:         result.TestedAt,
+				FailedBySeverity: make(map[types.MonitorTestSeverity]int),
+			}
+
+			runs[result.RecommenderRunID] = run
+			res.Runs = append(res.Runs, run)
+		}
+
+		check, ok := checks[result.ObjectID]
+		if !ok {
+			check = &types.RecommenderCheckTrend{
+				ObjectID: result.ObjectID,
+			}
+
+			checks[result.ObjectID] = check
+			res.Checks = append(res.Checks, check)
+		}
+
+		status := types.MonitorTestStatus(result.Result)
+
+		if status == types.MonitorTestStatusFailed {
+			run.Failed++
+			run.FailedBySeverity[types.MonitorTestSeverity(result.Severity)]++
+			check.Failures++
+		} else {
+			run.Passed++
+		}
+
+		if check.Runs > 0 && check.LastRunResult != status {
+			check.Transitions++
+		}
+
+		check.Runs++
+		check.LastRunResult = status
+		check.Category = result.Category
+		check.Title = result.Title
+		check.Severity = types.MonitorTestSeverity(result.Severity)
+	}
+
+	sort.SliceStable(res.Checks, func(i, j int) bool {
+		if res.Checks[i].Failures != res.Checks[j].Failures {
+			return res.Checks[i].Failures > res.Checks[j].Failures
+		}
+
+		return res.Checks[i].ObjectID < res.Checks[j].ObjectID
+	})
+
+	return res
+}
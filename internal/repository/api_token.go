@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -10,4 +12,5 @@ type APITokenRepository interface {
 	ListAPITokensByProjectID(projectID uint) ([]*models.APIToken, error)
 	ReadAPIToken(projectID uint, uid string) (*models.APIToken, error)
 	UpdateAPIToken(token *models.APIToken) (*models.APIToken, error)
+	UpdateAPITokenLastUsed(id uint, lastUsedAt time.Time, lastUsedIP string) error
 }
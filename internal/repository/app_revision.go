@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// AppRevisionRepository represents the set of queries on the AppRevision model
+type AppRevisionRepository interface {
+	// CreateAppRevision creates a new app revision
+	CreateAppRevision(revision *models.AppRevision) (*models.AppRevision, error)
+	// ListAppRevisionsByProjectID lists every app revision in a project, ordered by revision number
+	ListAppRevisionsByProjectID(projectID uint) ([]*models.AppRevision, error)
+}
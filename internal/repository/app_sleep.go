@@ -0,0 +1,21 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// AppSleepRepository represents the set of queries on the AppSleepSchedule and AppSleepState models
+type AppSleepRepository interface {
+	// CreateSchedule creates a new sleep schedule
+	CreateSchedule(schedule *models.AppSleepSchedule) (*models.AppSleepSchedule, error)
+	// ReadSchedule reads a sleep schedule by id in a cluster
+	ReadSchedule(projectID, clusterID, scheduleID uint) (*models.AppSleepSchedule, error)
+	// ListSchedulesByClusterID lists the sleep schedules in a cluster
+	ListSchedulesByClusterID(projectID, clusterID uint) ([]*models.AppSleepSchedule, error)
+	// ListSchedules lists the sleep schedules of every project
+	ListSchedules() ([]*models.AppSleepSchedule, error)
+	// DeleteSchedule deletes a sleep schedule
+	DeleteSchedule(schedule *models.AppSleepSchedule) (*models.AppSleepSchedule, error)
+	// ReadState reads the sleep state of an app in a deployment target
+	ReadState(projectID uint, deploymentTargetID, appName string) (*models.AppSleepState, error)
+	// SaveState creates or updates the sleep state of an app
+	SaveState(state *models.AppSleepState) (*models.AppSleepState, error)
+}
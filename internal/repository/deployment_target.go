@@ -8,6 +8,18 @@ import (
 type DeploymentTargetRepository interface {
 	// DeploymentTargetBySelectorAndSelectorType finds a deployment target for a projectID and clusterID by its selector and selector type
 	DeploymentTargetBySelectorAndSelectorType(projectID uint, clusterID uint, selector, selectorType string) (*models.DeploymentTarget, error)
+	// DeploymentTargetBySelector finds a deployment target for a projectID and clusterID by its selector, of any selector type
+	DeploymentTargetBySelector(projectID uint, clusterID uint, selector string) (*models.DeploymentTarget, error)
+	// DeploymentTarget finds a deployment target in a project by its id
+	DeploymentTarget(projectID uint, deploymentTargetID string) (*models.DeploymentTarget, error)
+	// DeploymentTargetByName finds a deployment target in a project by its name
+	DeploymentTargetByName(projectID uint, name string) (*models.DeploymentTarget, error)
 	// List returns all deployment targets for a project
 	List(projectID uint) ([]*models.DeploymentTarget, error)
+	// CreateDeploymentTarget creates a new deployment target
+	CreateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error)
+	// UpdateDeploymentTarget updates an existing deployment target
+	UpdateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error)
+	// DeleteDeploymentTarget deletes a deployment target
+	DeleteDeploymentTarget(deploymentTarget *models.DeploymentTarget) error
 }
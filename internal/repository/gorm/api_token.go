@@ -1,6 +1,8 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -53,3 +55,12 @@ func (repo *APITokenRepository) UpdateAPIToken(
 
 	return token, nil
 }
+
+// UpdateAPITokenLastUsed only writes the usage columns, so that concurrent updates to the
+// token (such as a rotation) are not overwritten by the authentication middleware
+func (repo *APITokenRepository) UpdateAPITokenLastUsed(id uint, lastUsedAt time.Time, lastUsedIP string) error {
+	return repo.db.Model(&models.APIToken{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"last_used_at": lastUsedAt,
+		"last_used_ip": lastUsedIP,
+	}).Error
+}
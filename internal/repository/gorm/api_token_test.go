@@ -2,6 +2,7 @@ package gorm_test
 
 import (
 	"testing"
+	"time"
 )
 
 func TestListAPITokensByProjectID(t *testing.T) {
@@ -29,3 +30,39 @@ func TestListAPITokensByProjectID(t *testing.T) {
 		t.Errorf("expected found to be %d but got: %d", 1, found[0].ID)
 	}
 }
+
+func TestUpdateAPITokenLastUsed(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_tokens_last_used.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	initAPITokens(tester, t)
+	defer cleanup(tester, t)
+
+	tok := tester.initAPITokens[0]
+	lastUsedAt := time.Now().Truncate(time.Second)
+
+	err := tester.repo.APIToken().UpdateAPITokenLastUsed(tok.ID, lastUsedAt, "10.0.0.1")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	found, err := tester.repo.APIToken().ReadAPIToken(tok.ProjectID, tok.UniqueID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if found.LastUsedAt == nil || !found.LastUsedAt.Equal(lastUsedAt) {
+		t.Errorf("expected last used at to be %v but got: %v", lastUsedAt, found.LastUsedAt)
+	}
+
+	if found.LastUsedIP != "10.0.0.1" {
+		t.Errorf("expected last used ip to be %s but got: %s", "10.0.0.1", found.LastUsedIP)
+	}
+
+	if found.Name != tok.Name {
+		t.Errorf("expected name to be unchanged, got: %s", found.Name)
+	}
+}
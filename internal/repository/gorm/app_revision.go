@@ -0,0 +1,42 @@
+package gorm
+
+import (
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AppRevisionRepository uses gorm.DB for querying the database
+type AppRevisionRepository struct {
+	db *gorm.DB
+}
+
+// NewAppRevisionRepository returns an AppRevisionRepository which uses gorm.DB for querying the database
+func NewAppRevisionRepository(db *gorm.DB) repository.AppRevisionRepository {
+	return &AppRevisionRepository{db}
+}
+
+// CreateAppRevision creates a new app revision
+func (repo *AppRevisionRepository) CreateAppRevision(revision *models.AppRevision) (*models.AppRevision, error) {
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+
+	if err := repo.db.Create(revision).Error; err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+// ListAppRevisionsByProjectID lists every app revision in a project, ordered by revision number
+func (repo *AppRevisionRepository) ListAppRevisionsByProjectID(projectID uint) ([]*models.AppRevision, error) {
+	revisions := []*models.AppRevision{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("revision_number asc").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,90 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AppSleepRepository uses gorm.DB for querying the database
+type AppSleepRepository struct {
+	db *gorm.DB
+}
+
+// NewAppSleepRepository returns an AppSleepRepository which uses gorm.DB for querying the database
+func NewAppSleepRepository(db *gorm.DB) repository.AppSleepRepository {
+	return &AppSleepRepository{db}
+}
+
+// CreateSchedule creates a new sleep schedule
+func (repo *AppSleepRepository) CreateSchedule(schedule *models.AppSleepSchedule) (*models.AppSleepSchedule, error) {
+	if err := repo.db.Create(schedule).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ReadSchedule reads a sleep schedule by id in a cluster
+func (repo *AppSleepRepository) ReadSchedule(projectID, clusterID, scheduleID uint) (*models.AppSleepSchedule, error) {
+	schedule := &models.AppSleepSchedule{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND id = ?", projectID, clusterID, scheduleID).First(schedule).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ListSchedulesByClusterID lists the sleep schedules in a cluster
+func (repo *AppSleepRepository) ListSchedulesByClusterID(projectID, clusterID uint) ([]*models.AppSleepSchedule, error) {
+	schedules := []*models.AppSleepSchedule{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// ListSchedules lists the sleep schedules of every project
+func (repo *AppSleepRepository) ListSchedules() ([]*models.AppSleepSchedule, error) {
+	schedules := []*models.AppSleepSchedule{}
+
+	if err := repo.db.Order("project_id asc").Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// DeleteSchedule deletes a sleep schedule
+func (repo *AppSleepRepository) DeleteSchedule(schedule *models.AppSleepSchedule) (*models.AppSleepSchedule, error) {
+	if err := repo.db.Delete(schedule).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// ReadState reads the sleep state of an app in a deployment target
+func (repo *AppSleepRepository) ReadState(projectID uint, deploymentTargetID, appName string) (*models.AppSleepState, error) {
+	state := &models.AppSleepState{}
+
+	if err := repo.db.Where(
+		"project_id = ? AND deployment_target_id = ? AND app_name = ?", projectID, deploymentTargetID, appName,
+	).First(state).Error; err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// SaveState creates or updates the sleep state of an app
+func (repo *AppSleepRepository) SaveState(state *models.AppSleepState) (*models.AppSleepState, error) {
+	if err := repo.db.Save(state).Error; err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -11,13 +12,15 @@ import (
 
 // DeploymentTargetRepository uses gorm.DB for querying the database
 type DeploymentTargetRepository struct {
-	db *gorm.DB
+	db  *gorm.DB
+	key *[32]byte
 }
 
 // NewDeploymentTargetRepository returns a DeploymentTargetRepository which uses
-// gorm.DB for querying the database
-func NewDeploymentTargetRepository(db *gorm.DB) repository.DeploymentTargetRepository {
-	return &DeploymentTargetRepository{db}
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// env overrides
+func NewDeploymentTargetRepository(db *gorm.DB, key *[32]byte) repository.DeploymentTargetRepository {
+	return &DeploymentTargetRepository{db, key}
 }
 
 // DeploymentTargetBySelectorAndSelectorType finds a deployment target for a projectID and clusterID by its selector and selector type
@@ -35,6 +38,56 @@ func (repo *DeploymentTargetRepository) DeploymentTargetBySelectorAndSelectorTyp
 	return deploymentTarget, nil
 }
 
+// DeploymentTargetBySelector finds a deployment target for a projectID and clusterID by its selector, of any selector type
+func (repo *DeploymentTargetRepository) DeploymentTargetBySelector(projectID uint, clusterID uint, selector string) (*models.DeploymentTarget, error) {
+	deploymentTarget := &models.DeploymentTarget{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND selector = ?", projectID, clusterID, selector).First(&deploymentTarget).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptEnvOverrides(deploymentTarget); err != nil {
+		return nil, err
+	}
+
+	return deploymentTarget, nil
+}
+
+// DeploymentTarget finds a deployment target in a project by its id
+func (repo *DeploymentTargetRepository) DeploymentTarget(projectID uint, deploymentTargetID string) (*models.DeploymentTarget, error) {
+	id, err := uuid.Parse(deploymentTargetID)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentTarget := &models.DeploymentTarget{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, id).First(&deploymentTarget).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptEnvOverrides(deploymentTarget); err != nil {
+		return nil, err
+	}
+
+	return deploymentTarget, nil
+}
+
+// DeploymentTargetByName finds a deployment target in a project by its name
+func (repo *DeploymentTargetRepository) DeploymentTargetByName(projectID uint, name string) (*models.DeploymentTarget, error) {
+	deploymentTarget := &models.DeploymentTarget{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).First(&deploymentTarget).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptEnvOverrides(deploymentTarget); err != nil {
+		return nil, err
+	}
+
+	return deploymentTarget, nil
+}
+
 // List finds all deployment targets for a given project
 func (repo *DeploymentTargetRepository) List(projectID uint) ([]*models.DeploymentTarget, error) {
 	deploymentTargets := []*models.DeploymentTarget{}
@@ -43,5 +96,66 @@ func (repo *DeploymentTargetRepository) List(projectID uint) ([]*models.Deployme
 		return nil, err
 	}
 
+	for _, deploymentTarget := range deploymentTargets {
+		if err := repo.decryptEnvOverrides(deploymentTarget); err != nil {
+			return nil, err
+		}
+	}
+
 	return deploymentTargets, nil
 }
+
+// CreateDeploymentTarget creates a new deployment target
+func (repo *DeploymentTargetRepository) CreateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
+	if deploymentTarget.ID == uuid.Nil {
+		deploymentTarget.ID = uuid.New()
+	}
+
+	return deploymentTarget, repo.save(deploymentTarget, repo.db.Create)
+}
+
+// UpdateDeploymentTarget updates an existing deployment target
+func (repo *DeploymentTargetRepository) UpdateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
+	return deploymentTarget, repo.save(deploymentTarget, repo.db.Save)
+}
+
+// DeleteDeploymentTarget deletes a deployment target
+func (repo *DeploymentTargetRepository) DeleteDeploymentTarget(deploymentTarget *models.DeploymentTarget) error {
+	return repo.db.Delete(deploymentTarget).Error
+}
+
+// save encrypts the env overrides of the deployment target before writing it, and leaves the
+// passed deployment target decrypted
+func (repo *DeploymentTargetRepository) save(deploymentTarget *models.DeploymentTarget, write func(value interface{}) *gorm.DB) error {
+	plaintext := deploymentTarget.EnvOverrides
+
+	if len(plaintext) > 0 {
+		cipherData, err := encryption.Encrypt(plaintext, repo.key)
+		if err != nil {
+			return err
+		}
+
+		deploymentTarget.EnvOverrides = cipherData
+	}
+
+	err := write(deploymentTarget).Error
+
+	deploymentTarget.EnvOverrides = plaintext
+
+	return err
+}
+
+func (repo *DeploymentTargetRepository) decryptEnvOverrides(deploymentTarget *models.DeploymentTarget) error {
+	if len(deploymentTarget.EnvOverrides) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(deploymentTarget.EnvOverrides, repo.key)
+	if err != nil {
+		return err
+	}
+
+	deploymentTarget.EnvOverrides = plaintext
+
+	return nil
+}
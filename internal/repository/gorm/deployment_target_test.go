@@ -0,0 +1,79 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCreateDeploymentTargetUniqueNamespace(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_deployment_targets.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	initCluster(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := int(tester.initProjects[0].ID)
+	clusterID := int(tester.initClusters[0].ID)
+
+	target, err := tester.repo.DeploymentTarget().CreateDeploymentTarget(&models.DeploymentTarget{
+		ProjectID:    projectID,
+		ClusterID:    clusterID,
+		Name:         "staging",
+		Selector:     "staging",
+		SelectorType: models.DeploymentTargetSelectorType_Namespace,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.DeploymentTarget().CreateDeploymentTarget(&models.DeploymentTarget{
+		ProjectID:    projectID,
+		ClusterID:    clusterID,
+		Name:         "staging-2",
+		Selector:     "staging",
+		SelectorType: models.DeploymentTargetSelectorType_Namespace,
+	})
+	if err == nil {
+		t.Fatalf("expected creating a second target for the same namespace in the cluster to fail")
+	}
+
+	_, err = tester.repo.DeploymentTarget().CreateDeploymentTarget(&models.DeploymentTarget{
+		ProjectID:    projectID,
+		ClusterID:    clusterID + 1,
+		Name:         "staging-other-cluster",
+		Selector:     "staging",
+		SelectorType: models.DeploymentTargetSelectorType_Namespace,
+	})
+	if err != nil {
+		t.Fatalf("expected the same namespace to be targeted in another cluster, got %v\n", err)
+	}
+
+	found, err := tester.repo.DeploymentTarget().DeploymentTargetBySelector(uint(projectID), uint(clusterID), "staging")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if found.ID != target.ID {
+		t.Errorf("expected found target to be %s but got: %s", target.ID, found.ID)
+	}
+
+	// deleted targets do not keep their namespace from being targeted again
+	if err := tester.repo.DeploymentTarget().DeleteDeploymentTarget(target); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.DeploymentTarget().CreateDeploymentTarget(&models.DeploymentTarget{
+		ProjectID:    projectID,
+		ClusterID:    clusterID,
+		Name:         "staging-2",
+		Selector:     "staging",
+		SelectorType: models.DeploymentTargetSelectorType_Namespace,
+	})
+	if err != nil {
+		t.Fatalf("expected a deleted target's namespace to be targeted again, got %v\n", err)
+	}
+}
@@ -0,0 +1,103 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ImagePushRepository uses gorm.DB for querying the database
+type ImagePushRepository struct {
+	db *gorm.DB
+}
+
+// NewImagePushRepository returns an ImagePushRepository which uses gorm.DB for querying the database
+func NewImagePushRepository(db *gorm.DB) repository.ImagePushRepository {
+	return &ImagePushRepository{db}
+}
+
+// CreatePolicy creates a new image push policy
+func (repo *ImagePushRepository) CreatePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error) {
+	if err := repo.db.Create(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ReadPolicy reads an image push policy by id in a cluster
+func (repo *ImagePushRepository) ReadPolicy(projectID, clusterID, policyID uint) (*models.ImagePushPolicy, error) {
+	policy := &models.ImagePushPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ? AND id = ?", projectID, clusterID, policyID).First(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// ListPoliciesByClusterID lists the image push policies in a cluster
+func (repo *ImagePushRepository) ListPoliciesByClusterID(projectID, clusterID uint) ([]*models.ImagePushPolicy, error) {
+	policies := []*models.ImagePushPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND cluster_id = ?", projectID, clusterID).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// ListPoliciesByRepository lists the image push policies in a project which match pushes to a repository
+func (repo *ImagePushRepository) ListPoliciesByRepository(projectID uint, imageRepository string) ([]*models.ImagePushPolicy, error) {
+	policies := []*models.ImagePushPolicy{}
+
+	if err := repo.db.Where("project_id = ? AND image_repository = ?", projectID, imageRepository).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	return policies, nil
+}
+
+// UpdatePolicy updates an image push policy
+func (repo *ImagePushRepository) UpdatePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error) {
+	if err := repo.db.Save(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// DeletePolicy deletes an image push policy
+func (repo *ImagePushRepository) DeletePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error) {
+	if err := repo.db.Delete(policy).Error; err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// CreateEvent records an automatic upgrade
+func (repo *ImagePushRepository) CreateEvent(event *models.ImagePushEvent) (*models.ImagePushEvent, error) {
+	if err := repo.db.Create(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListEventsByProjectID lists the most recent automatic upgrades in a project, optionally filtered by policy
+func (repo *ImagePushRepository) ListEventsByProjectID(projectID, policyID uint, limit int) ([]*models.ImagePushEvent, error) {
+	events := []*models.ImagePushEvent{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if policyID != 0 {
+		query = query.Where("policy_id = ?", policyID)
+	}
+
+	if err := query.Order("created_at desc").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
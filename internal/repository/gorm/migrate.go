@@ -58,12 +58,22 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.StackEnvGroup{},
 		&models.DbMigration{},
 		&models.MonitorTestResult{},
+		&models.MonitorTestRunResult{},
 		&models.APIContractRevision{},
 		&models.AWSAssumeRoleChain{},
 		&models.PorterApp{},
 		&models.PorterAppEvent{},
 		&models.AppRevision{},
 		&models.DeploymentTarget{},
+		&models.UserTwoFactor{},
+		&models.ImagePushPolicy{},
+		&models.ImagePushEvent{},
+		&models.AppSleepSchedule{},
+		&models.AppSleepState{},
+		&models.RecommenderPolicyCollection{},
+		&models.PolicyOverrideEvent{},
+		&models.PendingNotification{},
+		&models.SentNotificationKey{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -80,5 +90,8 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&ints.GithubAppInstallation{},
 		&ints.GithubAppOAuthIntegration{},
 		&ints.SlackIntegration{},
+		&ints.WebhookIntegration{},
+		&ints.PagingIntegration{},
+		&ints.PagingIncidentAlert{},
 	)
 }
@@ -1,6 +1,8 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -68,3 +70,45 @@ func (m *MonitorTestResultRepository) DeleteOldMonitorTestResults(projectID, clu
 
 	return query.Delete(monitors).Error
 }
+
+// CreateMonitorTestRunResults writes the results of a single run of the recommender
+func (m *MonitorTestResultRepository) CreateMonitorTestRunResults(results []*models.MonitorTestRunResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	return m.db.CreateInBatches(results, 100).Error
+}
+
+// ListMonitorTestRunResults lists the results of every check in a cluster since the given time, oldest first
+func (m *MonitorTestResultRepository) ListMonitorTestRunResults(projectID, clusterID uint, since time.Time) ([]*models.MonitorTestRunResult, error) {
+	results := make([]*models.MonitorTestRunResult, 0)
+
+	query := m.db.Where("project_id = ? AND cluster_id = ? AND tested_at >= ?", projectID, clusterID, since).Order("tested_at asc, id asc")
+
+	if err := query.Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ListMonitorTestRunResultsByObjectID lists the most recent results of a single check, newest first
+func (m *MonitorTestResultRepository) ListMonitorTestRunResultsByObjectID(projectID, clusterID uint, objectID string, limit int) ([]*models.MonitorTestRunResult, error) {
+	results := make([]*models.MonitorTestRunResult, 0)
+
+	query := m.db.Where("project_id = ? AND cluster_id = ? AND object_id = ?", projectID, clusterID, objectID).Order("tested_at desc, id desc").Limit(limit)
+
+	if err := query.Find(&results).Error; err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// DeleteMonitorTestRunResultsBefore deletes the results in a cluster which were tested before the given time
+func (m *MonitorTestResultRepository) DeleteMonitorTestRunResultsBefore(projectID, clusterID uint, before time.Time) error {
+	query := m.db.Unscoped().Where("project_id = ? AND cluster_id = ? AND tested_at < ?", projectID, clusterID, before)
+
+	return query.Delete(&models.MonitorTestRunResult{}).Error
+}
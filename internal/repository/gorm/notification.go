@@ -1,9 +1,12 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type NotificationConfigRepository struct {
@@ -43,6 +46,54 @@ func (repo NotificationConfigRepository) UpdateNotificationConfig(am *models.Not
 	return am, nil
 }
 
+// CreatePendingNotification holds a notification until it is sent in a digest
+func (repo NotificationConfigRepository) CreatePendingNotification(notif *models.PendingNotification) (*models.PendingNotification, error) {
+	if err := repo.db.Create(notif).Error; err != nil {
+		return nil, err
+	}
+
+	return notif, nil
+}
+
+// ListPendingNotifications lists every held notification, oldest first
+func (repo NotificationConfigRepository) ListPendingNotifications() ([]*models.PendingNotification, error) {
+	notifs := []*models.PendingNotification{}
+
+	if err := repo.db.Order("notified_at asc").Find(&notifs).Error; err != nil {
+		return nil, err
+	}
+
+	return notifs, nil
+}
+
+// DeletePendingNotifications deletes held notifications once they have been sent
+func (repo NotificationConfigRepository) DeletePendingNotifications(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return repo.db.Unscoped().Where("id IN (?)", ids).Delete(&models.PendingNotification{}).Error
+}
+
+// ClaimSentNotificationKey records that a notification with key is sent until expiresAt, and returns false
+// if an unexpired record of the key already exists. Expired keys are deleted first, so that the unique index
+// on the key decides which of concurrent claims succeeds.
+func (repo NotificationConfigRepository) ClaimSentNotificationKey(key string, now, expiresAt time.Time) (bool, error) {
+	if err := repo.db.Unscoped().Where("expires_at <= ?", now).Delete(&models.SentNotificationKey{}).Error; err != nil {
+		return false, err
+	}
+
+	res := repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SentNotificationKey{
+		Key:       key,
+		ExpiresAt: expiresAt,
+	})
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
 type JobNotificationConfigRepository struct {
 	db *gorm.DB
 }
@@ -0,0 +1,45 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClaimSentNotificationKey(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_claim_sent_notification_key.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := tester.repo.NotificationConfig()
+
+	claim := func(key string, at time.Time) bool {
+		t.Helper()
+
+		claimed, err := repo.ClaimSentNotificationKey(key, at, at.Add(10*time.Minute))
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		return claimed
+	}
+
+	if !claim("a", now) {
+		t.Errorf("expected the first claim of a key to succeed\n")
+	}
+
+	if claim("a", now.Add(5*time.Minute)) {
+		t.Errorf("expected a key to be claimed only once within its window\n")
+	}
+
+	if !claim("b", now.Add(5*time.Minute)) {
+		t.Errorf("expected a different key to be claimed\n")
+	}
+
+	if !claim("a", now.Add(10*time.Minute)) {
+		t.Errorf("expected an expired key to be claimed again\n")
+	}
+}
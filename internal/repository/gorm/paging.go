@@ -0,0 +1,189 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// PagingIntegrationRepository uses gorm.DB for querying the database
+type PagingIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewPagingIntegrationRepository returns a PagingIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewPagingIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.PagingIntegrationRepository {
+	return &PagingIntegrationRepository{db, key}
+}
+
+// CreatePagingIntegration creates a new paging integration
+func (repo *PagingIntegrationRepository) CreatePagingIntegration(
+	pagingInt *ints.PagingIntegration,
+) (*ints.PagingIntegration, error) {
+	err := repo.EncryptPagingIntegrationData(pagingInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(pagingInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptPagingIntegrationData(pagingInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pagingInt, nil
+}
+
+// ReadPagingIntegration finds a paging integration by project id and id
+func (repo *PagingIntegrationRepository) ReadPagingIntegration(
+	projectID, integrationID uint,
+) (*ints.PagingIntegration, error) {
+	pagingInt := &ints.PagingIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(pagingInt).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptPagingIntegrationData(pagingInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pagingInt, nil
+}
+
+// ListPagingIntegrationsByProjectID finds all paging integrations
+// for a given project id
+func (repo *PagingIntegrationRepository) ListPagingIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.PagingIntegration, error) {
+	pagingInts := []*ints.PagingIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&pagingInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, pagingInt := range pagingInts {
+		repo.DecryptPagingIntegrationData(pagingInt, repo.key)
+	}
+
+	return pagingInts, nil
+}
+
+// UpdatePagingIntegration updates a paging integration
+func (repo *PagingIntegrationRepository) UpdatePagingIntegration(
+	pagingInt *ints.PagingIntegration,
+) (*ints.PagingIntegration, error) {
+	err := repo.EncryptPagingIntegrationData(pagingInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Save(pagingInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptPagingIntegrationData(pagingInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return pagingInt, nil
+}
+
+// DeletePagingIntegration deletes a paging integration by project ID and ID
+func (repo *PagingIntegrationRepository) DeletePagingIntegration(
+	projectID, integrationID uint,
+) error {
+	query := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).Delete(&ints.PagingIntegration{})
+
+	if err := query.Error; err != nil {
+		return err
+	}
+
+	if query.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CreatePagingIncidentAlert records an alert which was opened for an incident
+func (repo *PagingIntegrationRepository) CreatePagingIncidentAlert(
+	alert *ints.PagingIncidentAlert,
+) (*ints.PagingIncidentAlert, error) {
+	if err := repo.db.Create(alert).Error; err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// ListPagingIncidentAlerts finds the alerts which were opened for an incident in a cluster
+func (repo *PagingIntegrationRepository) ListPagingIncidentAlerts(
+	clusterID uint,
+	incidentID string,
+) ([]*ints.PagingIncidentAlert, error) {
+	alerts := []*ints.PagingIncidentAlert{}
+
+	if err := repo.db.Where("cluster_id = ? AND incident_id = ?", clusterID, incidentID).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// DeletePagingIncidentAlerts deletes the alerts which were opened for an incident in a cluster
+func (repo *PagingIntegrationRepository) DeletePagingIncidentAlerts(
+	clusterID uint,
+	incidentID string,
+) error {
+	return repo.db.Where("cluster_id = ? AND incident_id = ?", clusterID, incidentID).Unscoped().Delete(&ints.PagingIncidentAlert{}).Error
+}
+
+// EncryptPagingIntegrationData will encrypt the paging integration data before
+// writing to the DB
+func (repo *PagingIntegrationRepository) EncryptPagingIntegrationData(
+	pagingInt *ints.PagingIntegration,
+	key *[32]byte,
+) error {
+	if len(pagingInt.Key) > 0 {
+		cipherData, err := encryption.Encrypt(pagingInt.Key, key)
+		if err != nil {
+			return err
+		}
+
+		pagingInt.Key = cipherData
+	}
+
+	return nil
+}
+
+// DecryptPagingIntegrationData will decrypt the paging integration data before
+// returning it from the DB
+func (repo *PagingIntegrationRepository) DecryptPagingIntegrationData(
+	pagingInt *ints.PagingIntegration,
+	key *[32]byte,
+) error {
+	if len(pagingInt.Key) > 0 {
+		plaintext, err := encryption.Decrypt(pagingInt.Key, key)
+		if err != nil {
+			return err
+		}
+
+		pagingInt.Key = plaintext
+	}
+
+	return nil
+}
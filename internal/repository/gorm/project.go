@@ -77,6 +77,17 @@ func (repo *ProjectRepository) ReadProject(id uint) (*models.Project, error) {
 	return project, nil
 }
 
+// ReadProjectByImagePushToken gets the project which receives image push events with the given token
+func (repo *ProjectRepository) ReadProjectByImagePushToken(token string) (*models.Project, error) {
+	project := &models.Project{}
+
+	if err := repo.db.Where("image_push_token = ? AND image_push_token <> ''", token).First(&project).Error; err != nil {
+		return nil, err
+	}
+
+	return project, nil
+}
+
 // ReadProject gets a projects specified by a unique id
 func (repo *ProjectRepository) ReadProjectRole(projID, userID uint) (*models.Role, error) {
 	// find the role
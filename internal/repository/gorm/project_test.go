@@ -1,11 +1,13 @@
 package gorm_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/go-test/deep"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 
 	"gorm.io/gorm"
 	orm "gorm.io/gorm"
@@ -345,3 +347,33 @@ func TestDeleteProjectRole(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestTransactionRollsBack(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_transaction_rollback.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	err := tester.repo.Transaction(func(tx repository.Repository) error {
+		if _, err := tx.Project().CreateProject(&models.Project{Name: "project-test"}); err != nil {
+			return err
+		}
+
+		return fmt.Errorf("abort")
+	})
+	if err == nil {
+		t.Fatalf("expected the transaction to return an error\n")
+	}
+
+	var count int64
+
+	if err := tester.db.Model(&models.Project{}).Count(&count).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 0 {
+		t.Errorf("expected the project to be rolled back, found %d projects\n", count)
+	}
+}
@@ -0,0 +1,97 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RecommenderPolicyRepository uses gorm.DB for querying the database
+type RecommenderPolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewRecommenderPolicyRepository returns a RecommenderPolicyRepository which uses gorm.DB for querying the database
+func NewRecommenderPolicyRepository(db *gorm.DB) repository.RecommenderPolicyRepository {
+	return &RecommenderPolicyRepository{db}
+}
+
+// CreateCollection creates a new policy collection
+func (repo *RecommenderPolicyRepository) CreateCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error) {
+	if err := repo.db.Create(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// ReadCollection reads a policy collection by id in a project
+func (repo *RecommenderPolicyRepository) ReadCollection(projectID, collectionID uint) (*models.RecommenderPolicyCollection, error) {
+	collection := &models.RecommenderPolicyCollection{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, collectionID).First(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// ReadCollectionByName reads a policy collection by name in a project
+func (repo *RecommenderPolicyRepository) ReadCollectionByName(projectID uint, name string) (*models.RecommenderPolicyCollection, error) {
+	collection := &models.RecommenderPolicyCollection{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).First(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// ListCollectionsByProjectID lists the policy collections in a project
+func (repo *RecommenderPolicyRepository) ListCollectionsByProjectID(projectID uint) ([]*models.RecommenderPolicyCollection, error) {
+	collections := []*models.RecommenderPolicyCollection{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("name asc").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+// UpdateCollection updates a policy collection
+func (repo *RecommenderPolicyRepository) UpdateCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error) {
+	if err := repo.db.Save(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// DeleteCollection deletes a policy collection
+func (repo *RecommenderPolicyRepository) DeleteCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error) {
+	if err := repo.db.Delete(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+// CreateOverrideEvent records a deploy which overrode blocking policies
+func (repo *RecommenderPolicyRepository) CreateOverrideEvent(event *models.PolicyOverrideEvent) (*models.PolicyOverrideEvent, error) {
+	if err := repo.db.Create(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListOverrideEventsByProjectID lists the policy override events in a project, most recent first
+func (repo *RecommenderPolicyRepository) ListOverrideEventsByProjectID(projectID uint) ([]*models.PolicyOverrideEvent, error) {
+	events := []*models.PolicyOverrideEvent{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("created_at desc").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
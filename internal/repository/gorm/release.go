@@ -49,6 +49,22 @@ func (repo *ReleaseRepository) ListReleasesByImageRepoURI(clusterID uint, imageR
 	return releases, nil
 }
 
+// ListReleasesByTag lists the releases in a cluster which are linked to the tag with the given name
+func (repo *ReleaseRepository) ListReleasesByTag(clusterID uint, tagName string) ([]*models.Release, error) {
+	releases := make([]*models.Release, 0)
+
+	if err := repo.db.Preload("GitActionConfig").Preload("Tags").
+		Joins("JOIN release_tags ON release_tags.release_id = releases.id").
+		Joins("JOIN tags ON tags.id = release_tags.tag_id").
+		Where("releases.cluster_id = ? AND tags.name = ? AND tags.project_id = releases.project_id AND tags.deleted_at IS NULL", clusterID, tagName).
+		Order("releases.namespace asc, releases.name asc").
+		Find(&releases).Error; err != nil {
+		return nil, err
+	}
+
+	return releases, nil
+}
+
 // ReadReleaseByWebhookToken finds a single release based on their unique webhook token.
 func (repo *ReleaseRepository) ReadReleaseByWebhookToken(token string) (*models.Release, error) {
 	release := &models.Release{}
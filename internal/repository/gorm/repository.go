@@ -54,6 +54,17 @@ type GormRepository struct {
 	porterApp                 repository.PorterAppRepository
 	porterAppEvent            repository.PorterAppEventRepository
 	deploymentTarget          repository.DeploymentTargetRepository
+	webhookIntegration        repository.WebhookIntegrationRepository
+	pagingIntegration         repository.PagingIntegrationRepository
+	twoFactor                 repository.TwoFactorRepository
+	imagePush                 repository.ImagePushRepository
+	appRevision               repository.AppRevisionRepository
+	appSleep                  repository.AppSleepRepository
+	recommenderPolicy         repository.RecommenderPolicyRepository
+
+	db             *gorm.DB
+	key            *[32]byte
+	storageBackend credentials.CredentialStorage
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -245,6 +256,48 @@ func (t *GormRepository) DeploymentTarget() repository.DeploymentTargetRepositor
 	return t.deploymentTarget
 }
 
+// WebhookIntegration returns the WebhookIntegrationRepository interface implemented by gorm
+func (t *GormRepository) WebhookIntegration() repository.WebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
+// PagingIntegration returns the PagingIntegrationRepository interface implemented by gorm
+func (t *GormRepository) PagingIntegration() repository.PagingIntegrationRepository {
+	return t.pagingIntegration
+}
+
+// TwoFactor returns the TwoFactorRepository interface implemented by gorm
+func (t *GormRepository) TwoFactor() repository.TwoFactorRepository {
+	return t.twoFactor
+}
+
+// ImagePush returns the ImagePushRepository interface implemented by gorm
+func (t *GormRepository) ImagePush() repository.ImagePushRepository {
+	return t.imagePush
+}
+
+// AppRevision returns the AppRevisionRepository interface implemented by gorm
+func (t *GormRepository) AppRevision() repository.AppRevisionRepository {
+	return t.appRevision
+}
+
+// AppSleep returns the AppSleepRepository interface implemented by gorm
+func (t *GormRepository) AppSleep() repository.AppSleepRepository {
+	return t.appSleep
+}
+
+// RecommenderPolicy returns the RecommenderPolicyRepository interface implemented by gorm
+func (t *GormRepository) RecommenderPolicy() repository.RecommenderPolicyRepository {
+	return t.recommenderPolicy
+}
+
+// Transaction runs fn with a repository backed by a gorm transaction
+func (t *GormRepository) Transaction(fn func(repo repository.Repository) error) error {
+	return t.db.Transaction(func(tx *gorm.DB) error {
+		return fn(NewRepository(tx, t.key, t.storageBackend))
+	})
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
@@ -295,6 +348,16 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		awsAssumeRoleChainer:      NewAWSAssumeRoleChainer(db),
 		porterApp:                 NewPorterAppRepository(db),
 		porterAppEvent:            NewPorterAppEventRepository(db),
-		deploymentTarget:          NewDeploymentTargetRepository(db),
+		deploymentTarget:          NewDeploymentTargetRepository(db, key),
+		webhookIntegration:        NewWebhookIntegrationRepository(db, key),
+		pagingIntegration:         NewPagingIntegrationRepository(db, key),
+		twoFactor:                 NewTwoFactorRepository(db, key),
+		imagePush:                 NewImagePushRepository(db),
+		appRevision:               NewAppRevisionRepository(db),
+		appSleep:                  NewAppSleepRepository(db),
+		recommenderPolicy:         NewRecommenderPolicyRepository(db),
+		db:                        db,
+		key:                       key,
+		storageBackend:            storageBackend,
 	}
 }
@@ -1,6 +1,8 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -24,9 +26,17 @@ func (s *SessionRepository) CreateSession(session *models.Session) (*models.Sess
 	return session, nil
 }
 
-// UpdateSession updates only the Data field using Key as selector.
+// UpdateSession updates the Data field and session metadata using Key as selector.
 func (s *SessionRepository) UpdateSession(session *models.Session) (*models.Session, error) {
-	if err := s.db.Model(session).Where("Key = ?", session.Key).Updates(session).Error; err != nil {
+	// a map is used so that the user id is cleared when a session is logged out
+	if err := s.db.Model(&models.Session{}).Where("Key = ?", session.Key).Updates(map[string]interface{}{
+		"data":         session.Data,
+		"expires_at":   session.ExpiresAt,
+		"user_id":      session.UserID,
+		"ip_address":   session.IPAddress,
+		"user_agent":   session.UserAgent,
+		"last_seen_at": session.LastSeenAt,
+	}).Error; err != nil {
 		return nil, err
 	}
 	return session, nil
@@ -49,3 +59,50 @@ func (s *SessionRepository) SelectSession(session *models.Session) (*models.Sess
 
 	return session, nil
 }
+
+// TouchSession records the last time and client that a session was used
+func (s *SessionRepository) TouchSession(key string, lastSeenAt time.Time, ipAddress, userAgent string) error {
+	return s.db.Model(&models.Session{}).Where("Key = ?", key).Updates(map[string]interface{}{
+		"last_seen_at": lastSeenAt,
+		"ip_address":   ipAddress,
+		"user_agent":   userAgent,
+	}).Error
+}
+
+// ListSessionsByUserID returns the unexpired sessions authenticated as a user
+func (s *SessionRepository) ListSessionsByUserID(userID uint) ([]*models.Session, error) {
+	sessions := []*models.Session{}
+
+	if err := s.db.Where("user_id = ? AND expires_at > ?", userID, time.Now()).Order("created_at desc").Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// DeleteUserSession deletes a single session belonging to a user
+func (s *SessionRepository) DeleteUserSession(userID, sessionID uint) error {
+	res := s.db.Where("user_id = ? AND id = ?", userID, sessionID).Unscoped().Delete(&models.Session{})
+
+	if res.Error != nil {
+		return res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// DeleteSessionsByUserID deletes all sessions belonging to a user, except for the session
+// with the key exceptKey if it is set
+func (s *SessionRepository) DeleteSessionsByUserID(userID uint, exceptKey string) error {
+	query := s.db.Where("user_id = ?", userID)
+
+	if exceptKey != "" {
+		query = query.Where("Key <> ?", exceptKey)
+	}
+
+	return query.Unscoped().Delete(&models.Session{}).Error
+}
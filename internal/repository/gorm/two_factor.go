@@ -0,0 +1,84 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TwoFactorRepository uses gorm.DB for querying the database
+type TwoFactorRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewTwoFactorRepository returns a TwoFactorRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// TOTP secrets
+func NewTwoFactorRepository(db *gorm.DB, key *[32]byte) repository.TwoFactorRepository {
+	return &TwoFactorRepository{db, key}
+}
+
+// ReadUserTwoFactor finds the second factor for a user
+func (repo *TwoFactorRepository) ReadUserTwoFactor(userID uint) (*models.UserTwoFactor, error) {
+	twoFactor := &models.UserTwoFactor{}
+
+	if err := repo.db.Where("user_id = ?", userID).First(twoFactor).Error; err != nil {
+		return nil, err
+	}
+
+	if len(twoFactor.Secret) > 0 {
+		plaintext, err := encryption.Decrypt(twoFactor.Secret, repo.key)
+		if err != nil {
+			return nil, err
+		}
+
+		twoFactor.Secret = plaintext
+	}
+
+	return twoFactor, nil
+}
+
+// CreateUserTwoFactor creates a new second factor for a user
+func (repo *TwoFactorRepository) CreateUserTwoFactor(twoFactor *models.UserTwoFactor) (*models.UserTwoFactor, error) {
+	if err := repo.save(twoFactor, repo.db.Create); err != nil {
+		return nil, err
+	}
+
+	return twoFactor, nil
+}
+
+// UpdateUserTwoFactor updates an existing second factor
+func (repo *TwoFactorRepository) UpdateUserTwoFactor(twoFactor *models.UserTwoFactor) (*models.UserTwoFactor, error) {
+	if err := repo.save(twoFactor, repo.db.Save); err != nil {
+		return nil, err
+	}
+
+	return twoFactor, nil
+}
+
+// DeleteUserTwoFactor deletes the second factor for a user. The row is deleted permanently
+// so that the user can enroll again.
+func (repo *TwoFactorRepository) DeleteUserTwoFactor(twoFactor *models.UserTwoFactor) error {
+	return repo.db.Unscoped().Delete(twoFactor).Error
+}
+
+func (repo *TwoFactorRepository) save(twoFactor *models.UserTwoFactor, write func(value interface{}) *gorm.DB) error {
+	plaintext := twoFactor.Secret
+
+	if len(plaintext) > 0 {
+		cipherData, err := encryption.Encrypt(plaintext, repo.key)
+		if err != nil {
+			return err
+		}
+
+		twoFactor.Secret = cipherData
+	}
+
+	err := write(twoFactor).Error
+
+	twoFactor.Secret = plaintext
+
+	return err
+}
@@ -73,6 +73,15 @@ func (repo *UserRepository) ReadUserByGoogleUserID(id string) (*models.User, err
 	return user, nil
 }
 
+// ReadUserByOIDCSubject finds a single user based on their OIDC issuer and subject
+func (repo *UserRepository) ReadUserByOIDCSubject(issuer, subject string) (*models.User, error) {
+	user := &models.User{}
+	if err := repo.db.Where("oidc_issuer = ? AND oidc_subject = ?", issuer, subject).First(&user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 // UpdateUser modifies an existing User in the database
 func (repo *UserRepository) UpdateUser(user *models.User) (*models.User, error) {
 	if err := repo.db.Save(user).Error; err != nil {
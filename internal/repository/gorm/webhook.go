@@ -0,0 +1,135 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// WebhookIntegrationRepository uses gorm.DB for querying the database
+type WebhookIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewWebhookIntegrationRepository returns a WebhookIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewWebhookIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.WebhookIntegrationRepository {
+	return &WebhookIntegrationRepository{db, key}
+}
+
+// CreateWebhookIntegration creates a new webhook integration
+func (repo *WebhookIntegrationRepository) CreateWebhookIntegration(
+	webhookInt *ints.WebhookIntegration,
+) (*ints.WebhookIntegration, error) {
+	err := repo.EncryptWebhookIntegrationData(webhookInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(webhookInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptWebhookIntegrationData(webhookInt, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookInt, nil
+}
+
+// ListWebhookIntegrationsByProjectID finds all webhook integrations
+// for a given project id
+func (repo *WebhookIntegrationRepository) ListWebhookIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.WebhookIntegration, error) {
+	webhookInts := []*ints.WebhookIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&webhookInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, webhookInt := range webhookInts {
+		repo.DecryptWebhookIntegrationData(webhookInt, repo.key)
+	}
+
+	return webhookInts, nil
+}
+
+// DeleteWebhookIntegration deletes a webhook integration by project ID and ID
+func (repo *WebhookIntegrationRepository) DeleteWebhookIntegration(
+	projectID, integrationID uint,
+) error {
+	query := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).Delete(&ints.WebhookIntegration{})
+
+	if err := query.Error; err != nil {
+		return err
+	}
+
+	if query.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// EncryptWebhookIntegrationData will encrypt the webhook integration data before
+// writing to the DB
+func (repo *WebhookIntegrationRepository) EncryptWebhookIntegrationData(
+	webhookInt *ints.WebhookIntegration,
+	key *[32]byte,
+) error {
+	if len(webhookInt.URL) > 0 {
+		cipherData, err := encryption.Encrypt(webhookInt.URL, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.URL = cipherData
+	}
+
+	if len(webhookInt.SigningSecret) > 0 {
+		cipherData, err := encryption.Encrypt(webhookInt.SigningSecret, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.SigningSecret = cipherData
+	}
+
+	return nil
+}
+
+// DecryptWebhookIntegrationData will decrypt the webhook integration data before
+// returning it from the DB
+func (repo *WebhookIntegrationRepository) DecryptWebhookIntegrationData(
+	webhookInt *ints.WebhookIntegration,
+	key *[32]byte,
+) error {
+	if len(webhookInt.URL) > 0 {
+		plaintext, err := encryption.Decrypt(webhookInt.URL, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.URL = plaintext
+	}
+
+	if len(webhookInt.SigningSecret) > 0 {
+		plaintext, err := encryption.Decrypt(webhookInt.SigningSecret, key)
+		if err != nil {
+			return err
+		}
+
+		webhookInt.SigningSecret = plaintext
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// ImagePushRepository represents the set of queries on the ImagePushPolicy and ImagePushEvent models
+type ImagePushRepository interface {
+	// CreatePolicy creates a new image push policy
+	CreatePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error)
+	// ReadPolicy reads an image push policy by id in a cluster
+	ReadPolicy(projectID, clusterID, policyID uint) (*models.ImagePushPolicy, error)
+	// ListPoliciesByClusterID lists the image push policies in a cluster
+	ListPoliciesByClusterID(projectID, clusterID uint) ([]*models.ImagePushPolicy, error)
+	// ListPoliciesByRepository lists the image push policies in a project which match pushes to a repository
+	ListPoliciesByRepository(projectID uint, imageRepository string) ([]*models.ImagePushPolicy, error)
+	// UpdatePolicy updates an image push policy
+	UpdatePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error)
+	// DeletePolicy deletes an image push policy
+	DeletePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error)
+	// CreateEvent records an automatic upgrade
+	CreateEvent(event *models.ImagePushEvent) (*models.ImagePushEvent, error)
+	// ListEventsByProjectID lists the most recent automatic upgrades in a project, optionally filtered by policy
+	ListEventsByProjectID(projectID, policyID uint, limit int) ([]*models.ImagePushEvent, error)
+}
@@ -53,6 +53,25 @@ type SlackIntegrationRepository interface {
 	DeleteSlackIntegration(integrationID uint) error
 }
 
+// WebhookIntegrationRepository represents the set of queries on a generic webhook integration
+type WebhookIntegrationRepository interface {
+	CreateWebhookIntegration(webhookInt *ints.WebhookIntegration) (*ints.WebhookIntegration, error)
+	ListWebhookIntegrationsByProjectID(projectID uint) ([]*ints.WebhookIntegration, error)
+	DeleteWebhookIntegration(projectID, integrationID uint) error
+}
+
+// PagingIntegrationRepository represents the set of queries on a paging integration
+type PagingIntegrationRepository interface {
+	CreatePagingIntegration(pagingInt *ints.PagingIntegration) (*ints.PagingIntegration, error)
+	ReadPagingIntegration(projectID, integrationID uint) (*ints.PagingIntegration, error)
+	ListPagingIntegrationsByProjectID(projectID uint) ([]*ints.PagingIntegration, error)
+	UpdatePagingIntegration(pagingInt *ints.PagingIntegration) (*ints.PagingIntegration, error)
+	DeletePagingIntegration(projectID, integrationID uint) error
+	CreatePagingIncidentAlert(alert *ints.PagingIncidentAlert) (*ints.PagingIncidentAlert, error)
+	ListPagingIncidentAlerts(clusterID uint, incidentID string) ([]*ints.PagingIncidentAlert, error)
+	DeletePagingIncidentAlerts(clusterID uint, incidentID string) error
+}
+
 // AWSIntegrationRepository represents the set of queries on the AWS auth
 // mechanism
 type AWSIntegrationRepository interface {
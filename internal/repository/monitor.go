@@ -1,6 +1,10 @@
 package repository
 
-import "github.com/porter-dev/porter/internal/models"
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
 
 type MonitorTestResultRepository interface {
 	CreateMonitorTestResult(monitor *models.MonitorTestResult) (*models.MonitorTestResult, error)
@@ -9,4 +13,9 @@ type MonitorTestResultRepository interface {
 
 	ArchiveMonitorTestResults(projectID, clusterID uint, recommenderID string) error
 	DeleteOldMonitorTestResults(projectID, clusterID uint, recommenderID string) error
+
+	CreateMonitorTestRunResults(results []*models.MonitorTestRunResult) error
+	ListMonitorTestRunResults(projectID, clusterID uint, since time.Time) ([]*models.MonitorTestRunResult, error)
+	ListMonitorTestRunResultsByObjectID(projectID, clusterID uint, objectID string, limit int) ([]*models.MonitorTestRunResult, error)
+	DeleteMonitorTestRunResultsBefore(projectID, clusterID uint, before time.Time) error
 }
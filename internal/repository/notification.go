@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -8,6 +10,17 @@ type NotificationConfigRepository interface {
 	CreateNotificationConfig(am *models.NotificationConfig) (*models.NotificationConfig, error)
 	ReadNotificationConfig(id uint) (*models.NotificationConfig, error)
 	UpdateNotificationConfig(am *models.NotificationConfig) (*models.NotificationConfig, error)
+
+	// CreatePendingNotification holds a notification until it is sent in a digest
+	CreatePendingNotification(notif *models.PendingNotification) (*models.PendingNotification, error)
+	// ListPendingNotifications lists every held notification, oldest first
+	ListPendingNotifications() ([]*models.PendingNotification, error)
+	// DeletePendingNotifications deletes held notifications once they have been sent
+	DeletePendingNotifications(ids []uint) error
+
+	// ClaimSentNotificationKey records that a notification with key is sent until expiresAt, and returns false
+	// if an unexpired record of the key already exists
+	ClaimSentNotificationKey(key string, now, expiresAt time.Time) (bool, error)
 }
 
 type JobNotificationConfigRepository interface {
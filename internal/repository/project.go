@@ -14,6 +14,7 @@ type ProjectRepository interface {
 	UpdateProject(project *models.Project) (*models.Project, error)
 	UpdateProjectRole(projID uint, role *models.Role) (*models.Role, error)
 	ReadProject(id uint) (*models.Project, error)
+	ReadProjectByImagePushToken(token string) (*models.Project, error)
 	ReadProjectRole(projID, userID uint) (*models.Role, error)
 	ListProjectRoles(projID uint) ([]models.Role, error)
 	ListProjectsByUserID(userID uint) ([]*models.Project, error)
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// RecommenderPolicyRepository represents the set of queries on the RecommenderPolicyCollection and
+// PolicyOverrideEvent models
+type RecommenderPolicyRepository interface {
+	// CreateCollection creates a new policy collection
+	CreateCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error)
+	// ReadCollection reads a policy collection by id in a project
+	ReadCollection(projectID, collectionID uint) (*models.RecommenderPolicyCollection, error)
+	// ReadCollectionByName reads a policy collection by name in a project
+	ReadCollectionByName(projectID uint, name string) (*models.RecommenderPolicyCollection, error)
+	// ListCollectionsByProjectID lists the policy collections in a project
+	ListCollectionsByProjectID(projectID uint) ([]*models.RecommenderPolicyCollection, error)
+	// UpdateCollection updates a policy collection
+	UpdateCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error)
+	// DeleteCollection deletes a policy collection
+	DeleteCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error)
+	// CreateOverrideEvent records a deploy which overrode blocking policies
+	CreateOverrideEvent(event *models.PolicyOverrideEvent) (*models.PolicyOverrideEvent, error)
+	// ListOverrideEventsByProjectID lists the policy override events in a project, most recent first
+	ListOverrideEventsByProjectID(projectID uint) ([]*models.PolicyOverrideEvent, error)
+}
@@ -48,4 +48,15 @@ type Repository interface {
 	PorterApp() PorterAppRepository
 	PorterAppEvent() PorterAppEventRepository
 	DeploymentTarget() DeploymentTargetRepository
+	WebhookIntegration() WebhookIntegrationRepository
+	PagingIntegration() PagingIntegrationRepository
+	TwoFactor() TwoFactorRepository
+	ImagePush() ImagePushRepository
+	AppRevision() AppRevisionRepository
+	AppSleep() AppSleepRepository
+	RecommenderPolicy() RecommenderPolicyRepository
+
+	// Transaction runs fn with a repository whose queries are part of a single transaction. The transaction
+	// is committed if fn returns nil, and rolled back otherwise.
+	Transaction(fn func(repo Repository) error) error
 }
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -10,4 +12,8 @@ type SessionRepository interface {
 	UpdateSession(session *models.Session) (*models.Session, error)
 	DeleteSession(session *models.Session) (*models.Session, error)
 	SelectSession(session *models.Session) (*models.Session, error)
+	TouchSession(key string, lastSeenAt time.Time, ipAddress, userAgent string) error
+	ListSessionsByUserID(userID uint) ([]*models.Session, error)
+	DeleteUserSession(userID, sessionID uint) error
+	DeleteSessionsByUserID(userID uint, exceptKey string) error
 }
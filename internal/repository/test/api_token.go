@@ -1,6 +1,8 @@
 package test
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 )
@@ -30,3 +32,7 @@ func (repo *APITokenRepository) UpdateAPIToken(
 ) (*models.APIToken, error) {
 	panic("unimplemented")
 }
+
+func (repo *APITokenRepository) UpdateAPITokenLastUsed(id uint, lastUsedAt time.Time, lastUsedIP string) error {
+	panic("unimplemented")
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AppRevisionRepository is a test repository for app revisions
+type AppRevisionRepository struct {
+	canQuery  bool
+	revisions []*models.AppRevision
+}
+
+// NewAppRevisionRepository returns a test AppRevisionRepository
+func NewAppRevisionRepository(canQuery bool) repository.AppRevisionRepository {
+	return &AppRevisionRepository{canQuery: canQuery}
+}
+
+// CreateAppRevision creates a new app revision
+func (repo *AppRevisionRepository) CreateAppRevision(revision *models.AppRevision) (*models.AppRevision, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if revision.ID == uuid.Nil {
+		revision.ID = uuid.New()
+	}
+
+	repo.revisions = append(repo.revisions, revision)
+
+	return revision, nil
+}
+
+// ListAppRevisionsByProjectID lists every app revision in a project, in the order they were created
+func (repo *AppRevisionRepository) ListAppRevisionsByProjectID(projectID uint) ([]*models.AppRevision, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AppRevision, 0)
+
+	for _, revision := range repo.revisions {
+		if revision.ProjectID == int(projectID) {
+			res = append(res, revision)
+		}
+	}
+
+	return res, nil
+}
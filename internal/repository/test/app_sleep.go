@@ -0,0 +1,138 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AppSleepRepository is a test repository for sleep schedules and states
+type AppSleepRepository struct {
+	canQuery  bool
+	schedules []*models.AppSleepSchedule
+	states    []*models.AppSleepState
+}
+
+// NewAppSleepRepository returns a test AppSleepRepository
+func NewAppSleepRepository(canQuery bool) repository.AppSleepRepository {
+	return &AppSleepRepository{canQuery: canQuery}
+}
+
+// CreateSchedule creates a new sleep schedule
+func (repo *AppSleepRepository) CreateSchedule(schedule *models.AppSleepSchedule) (*models.AppSleepSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.schedules = append(repo.schedules, schedule)
+	schedule.ID = uint(len(repo.schedules))
+
+	return schedule, nil
+}
+
+// ReadSchedule reads a sleep schedule by id in a cluster
+func (repo *AppSleepRepository) ReadSchedule(projectID, clusterID, scheduleID uint) (*models.AppSleepSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(scheduleID-1) >= len(repo.schedules) || repo.schedules[scheduleID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	schedule := repo.schedules[scheduleID-1]
+
+	if schedule.ProjectID != projectID || schedule.ClusterID != clusterID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return schedule, nil
+}
+
+// ListSchedulesByClusterID lists the sleep schedules in a cluster
+func (repo *AppSleepRepository) ListSchedulesByClusterID(projectID, clusterID uint) ([]*models.AppSleepSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AppSleepSchedule, 0)
+
+	for _, schedule := range repo.schedules {
+		if schedule != nil && schedule.ProjectID == projectID && schedule.ClusterID == clusterID {
+			res = append(res, schedule)
+		}
+	}
+
+	return res, nil
+}
+
+// ListSchedules lists the sleep schedules of every project
+func (repo *AppSleepRepository) ListSchedules() ([]*models.AppSleepSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.AppSleepSchedule, 0)
+
+	for _, schedule := range repo.schedules {
+		if schedule != nil {
+			res = append(res, schedule)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteSchedule deletes a sleep schedule
+func (repo *AppSleepRepository) DeleteSchedule(schedule *models.AppSleepSchedule) (*models.AppSleepSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(schedule.ID-1) >= len(repo.schedules) || repo.schedules[schedule.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.schedules[schedule.ID-1] = nil
+
+	return schedule, nil
+}
+
+// ReadState reads the sleep state of an app in a deployment target
+func (repo *AppSleepRepository) ReadState(projectID uint, deploymentTargetID, appName string) (*models.AppSleepState, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, state := range repo.states {
+		if state.ProjectID == projectID && state.DeploymentTargetID == deploymentTargetID && state.AppName == appName {
+			return state, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// SaveState creates or updates the sleep state of an app
+func (repo *AppSleepRepository) SaveState(state *models.AppSleepState) (*models.AppSleepState, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if state.ID == 0 {
+		repo.states = append(repo.states, state)
+		state.ID = uint(len(repo.states))
+
+		return state, nil
+	}
+
+	if int(state.ID-1) >= len(repo.states) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.states[state.ID-1] = state
+
+	return state, nil
+}
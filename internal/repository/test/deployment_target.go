@@ -3,26 +3,145 @@ package test
 import (
 	"errors"
 
+	"github.com/google/uuid"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
 // DeploymentTargetRepository is a test repository that implements repository.DeploymentTargetRepository
 type DeploymentTargetRepository struct {
-	canQuery bool
+	canQuery          bool
+	deploymentTargets []*models.DeploymentTarget
 }
 
-// NewDeploymentTargetRepository returns the test DeploymentTargetRepository
-func NewDeploymentTargetRepository() repository.DeploymentTargetRepository {
-	return &DeploymentTargetRepository{canQuery: false}
+// NewDeploymentTargetRepository returns the test DeploymentTargetRepository, which stores deployment targets
+// in memory and returns errors if canQuery is false
+func NewDeploymentTargetRepository(canQuery bool) repository.DeploymentTargetRepository {
+	return &DeploymentTargetRepository{canQuery: canQuery}
 }
 
 // DeploymentTargetBySelectorAndSelectorType finds a deployment target for a projectID and clusterID by its selector and selector type
 func (repo *DeploymentTargetRepository) DeploymentTargetBySelectorAndSelectorType(projectID uint, clusterID uint, selector, selectorType string) (*models.DeploymentTarget, error) {
-	return nil, errors.New("cannot read database")
+	if !repo.canQuery {
+		return nil, errors.New("cannot read database")
+	}
+
+	for _, target := range repo.deploymentTargets {
+		if target.ProjectID == int(projectID) && target.ClusterID == int(clusterID) && target.Selector == selector &&
+			string(target.SelectorType) == selectorType {
+			return target, nil
+		}
+	}
+
+	return nil, errors.New("deployment target not found")
+}
+
+// DeploymentTargetBySelector finds a deployment target for a projectID and clusterID by its selector, of any selector type
+func (repo *DeploymentTargetRepository) DeploymentTargetBySelector(projectID uint, clusterID uint, selector string) (*models.DeploymentTarget, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot read database")
+	}
+
+	for _, target := range repo.deploymentTargets {
+		if target.ProjectID == int(projectID) && target.ClusterID == int(clusterID) && target.Selector == selector {
+			return target, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 // List returns all deployment targets for a project
 func (repo *DeploymentTargetRepository) List(projectID uint) ([]*models.DeploymentTarget, error) {
-	return nil, errors.New("cannot read database")
+	if !repo.canQuery {
+		return nil, errors.New("cannot read database")
+	}
+
+	res := make([]*models.DeploymentTarget, 0)
+
+	for _, target := range repo.deploymentTargets {
+		if target.ProjectID == int(projectID) {
+			res = append(res, target)
+		}
+	}
+
+	return res, nil
+}
+
+// DeploymentTarget finds a deployment target in a project by its id
+func (repo *DeploymentTargetRepository) DeploymentTarget(projectID uint, deploymentTargetID string) (*models.DeploymentTarget, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot read database")
+	}
+
+	for _, target := range repo.deploymentTargets {
+		if target.ProjectID == int(projectID) && target.ID.String() == deploymentTargetID {
+			return target, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// DeploymentTargetByName finds a deployment target in a project by its name
+func (repo *DeploymentTargetRepository) DeploymentTargetByName(projectID uint, name string) (*models.DeploymentTarget, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot read database")
+	}
+
+	for _, target := range repo.deploymentTargets {
+		if target.ProjectID == int(projectID) && target.Name == name {
+			return target, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// CreateDeploymentTarget creates a new deployment target
+func (repo *DeploymentTargetRepository) CreateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot write database")
+	}
+
+	if deploymentTarget.ID == uuid.Nil {
+		deploymentTarget.ID = uuid.New()
+	}
+
+	repo.deploymentTargets = append(repo.deploymentTargets, deploymentTarget)
+
+	return deploymentTarget, nil
+}
+
+// UpdateDeploymentTarget updates an existing deployment target
+func (repo *DeploymentTargetRepository) UpdateDeploymentTarget(deploymentTarget *models.DeploymentTarget) (*models.DeploymentTarget, error) {
+	if !repo.canQuery {
+		return nil, errors.New("cannot write database")
+	}
+
+	for i, target := range repo.deploymentTargets {
+		if target.ID == deploymentTarget.ID {
+			repo.deploymentTargets[i] = deploymentTarget
+			return deploymentTarget, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// DeleteDeploymentTarget deletes a deployment target
+func (repo *DeploymentTargetRepository) DeleteDeploymentTarget(deploymentTarget *models.DeploymentTarget) error {
+	if !repo.canQuery {
+		return errors.New("cannot write database")
+	}
+
+	for i, target := range repo.deploymentTargets {
+		if target.ID == deploymentTarget.ID {
+			repo.deploymentTargets = append(repo.deploymentTargets[:i], repo.deploymentTargets[i+1:]...)
+			return nil
+		}
+	}
+
+	return gorm.ErrRecordNotFound
 }
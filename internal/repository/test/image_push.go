@@ -0,0 +1,147 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ImagePushRepository is a test repository for image push policies and events
+type ImagePushRepository struct {
+	canQuery bool
+	policies []*models.ImagePushPolicy
+	events   []*models.ImagePushEvent
+}
+
+// NewImagePushRepository returns a test ImagePushRepository
+func NewImagePushRepository(canQuery bool) repository.ImagePushRepository {
+	return &ImagePushRepository{canQuery: canQuery}
+}
+
+// CreatePolicy creates a new image push policy
+func (repo *ImagePushRepository) CreatePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.policies = append(repo.policies, policy)
+	policy.ID = uint(len(repo.policies))
+
+	return policy, nil
+}
+
+// ReadPolicy reads an image push policy by id in a cluster
+func (repo *ImagePushRepository) ReadPolicy(projectID, clusterID, policyID uint) (*models.ImagePushPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(policyID-1) >= len(repo.policies) || repo.policies[policyID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	policy := repo.policies[policyID-1]
+
+	if policy.ProjectID != projectID || policy.ClusterID != clusterID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return policy, nil
+}
+
+// ListPoliciesByClusterID lists the image push policies in a cluster
+func (repo *ImagePushRepository) ListPoliciesByClusterID(projectID, clusterID uint) ([]*models.ImagePushPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ImagePushPolicy, 0)
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID && policy.ClusterID == clusterID {
+			res = append(res, policy)
+		}
+	}
+
+	return res, nil
+}
+
+// ListPoliciesByRepository lists the image push policies in a project which match pushes to a repository
+func (repo *ImagePushRepository) ListPoliciesByRepository(projectID uint, imageRepository string) ([]*models.ImagePushPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ImagePushPolicy, 0)
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID && policy.ImageRepository == imageRepository {
+			res = append(res, policy)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdatePolicy updates an image push policy
+func (repo *ImagePushRepository) UpdatePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = policy
+
+	return policy, nil
+}
+
+// DeletePolicy deletes an image push policy
+func (repo *ImagePushRepository) DeletePolicy(policy *models.ImagePushPolicy) (*models.ImagePushPolicy, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = nil
+
+	return policy, nil
+}
+
+// CreateEvent records an automatic upgrade
+func (repo *ImagePushRepository) CreateEvent(event *models.ImagePushEvent) (*models.ImagePushEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.events = append(repo.events, event)
+	event.ID = uint(len(repo.events))
+
+	return event, nil
+}
+
+// ListEventsByProjectID lists the most recent automatic upgrades in a project, optionally filtered by policy
+func (repo *ImagePushRepository) ListEventsByProjectID(projectID, policyID uint, limit int) ([]*models.ImagePushEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.ImagePushEvent, 0)
+
+	for i := len(repo.events) - 1; i >= 0 && len(res) < limit; i-- {
+		event := repo.events[i]
+
+		if event.ProjectID == projectID && (policyID == 0 || event.PolicyID == policyID) {
+			res = append(res, event)
+		}
+	}
+
+	return res, nil
+}
@@ -1,6 +1,8 @@
 package test
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 )
@@ -30,3 +32,19 @@ func (n *MonitorTestResultRepository) ArchiveMonitorTestResults(projectID, clust
 func (n *MonitorTestResultRepository) DeleteOldMonitorTestResults(projectID, clusterID uint, recommenderID string) error {
 	panic("not implemented") // TODO: Implement
 }
+
+func (n *MonitorTestResultRepository) CreateMonitorTestRunResults(results []*models.MonitorTestRunResult) error {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *MonitorTestResultRepository) ListMonitorTestRunResults(projectID, clusterID uint, since time.Time) ([]*models.MonitorTestRunResult, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *MonitorTestResultRepository) ListMonitorTestRunResultsByObjectID(projectID, clusterID uint, objectID string, limit int) ([]*models.MonitorTestRunResult, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *MonitorTestResultRepository) DeleteMonitorTestRunResultsBefore(projectID, clusterID uint, before time.Time) error {
+	panic("not implemented") // TODO: Implement
+}
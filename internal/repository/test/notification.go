@@ -1,6 +1,8 @@
 package test
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 )
@@ -23,6 +25,22 @@ func (n *NotificationConfigRepository) UpdateNotificationConfig(am *models.Notif
 	panic("not implemented") // TODO: Implement
 }
 
+func (n *NotificationConfigRepository) CreatePendingNotification(notif *models.PendingNotification) (*models.PendingNotification, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *NotificationConfigRepository) ListPendingNotifications() ([]*models.PendingNotification, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *NotificationConfigRepository) DeletePendingNotifications(ids []uint) error {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *NotificationConfigRepository) ClaimSentNotificationKey(key string, now, expiresAt time.Time) (bool, error) {
+	panic("not implemented") // TODO: Implement
+}
+
 type JobNotificationConfigRepository struct{}
 
 func NewJobNotificationConfigRepository(canQuery bool) repository.JobNotificationConfigRepository {
@@ -1,40 +1,92 @@
 package test
 
 import (
+	"errors"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
 type PolicyRepository struct {
 	canQuery bool
+	policies []*models.Policy
 }
 
-// NewPolicyRepository returns a PolicyRepository which uses
-// gorm.DB for querying the database
+// NewPolicyRepository returns a PolicyRepository which stores policies in memory
 func NewPolicyRepository(canQuery bool) repository.PolicyRepository {
-	return &PolicyRepository{canQuery}
+	return &PolicyRepository{canQuery: canQuery}
 }
 
 func (repo *PolicyRepository) CreatePolicy(a *models.Policy) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.policies = append(repo.policies, a)
+	a.ID = uint(len(repo.policies))
+
+	return a, nil
 }
 
 func (repo *PolicyRepository) ListPoliciesByProjectID(projectID uint) ([]*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Policy, 0)
+
+	for _, p := range repo.policies {
+		if p != nil && p.ProjectID == projectID {
+			res = append(res, p)
+		}
+	}
+
+	return res, nil
 }
 
 func (repo *PolicyRepository) ReadPolicy(projectID uint, uid string) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, p := range repo.policies {
+		if p != nil && p.ProjectID == projectID && p.UniqueID == uid {
+			return p, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *PolicyRepository) UpdatePolicy(
 	policy *models.Policy,
 ) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = policy
+
+	return policy, nil
 }
 
 func (repo *PolicyRepository) DeletePolicy(
 	policy *models.Policy,
 ) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = nil
+
+	return policy, nil
 }
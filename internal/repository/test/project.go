@@ -135,6 +135,21 @@ func (repo *ProjectRepository) ReadProject(id uint) (*models.Project, error) {
 	return repo.projects[index], nil
 }
 
+// ReadProjectByImagePushToken gets the project which receives image push events with the given token
+func (repo *ProjectRepository) ReadProjectByImagePushToken(token string) (*models.Project, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, project := range repo.projects {
+		if project != nil && token != "" && project.ImagePushToken == token {
+			return project, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
 // ListProjectsByUserID lists projects where a user has an associated role
 func (repo *ProjectRepository) ListProjectsByUserID(userID uint) ([]*models.Project, error) {
 	if !repo.canQuery || strings.Contains(repo.failingMethods, ListProjectsByUserIDMethod) {
@@ -166,7 +181,6 @@ func (repo *ProjectRepository) ListProjectRoles(projID uint) ([]models.Role, err
 	}
 
 	index := int(projID - 1)
-	repo.projects[index] = nil
 
 	return repo.projects[index].Roles, nil
 }
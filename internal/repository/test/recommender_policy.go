@@ -0,0 +1,143 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// RecommenderPolicyRepository is a test repository for recommender policy collections
+type RecommenderPolicyRepository struct {
+	canQuery       bool
+	collections    []*models.RecommenderPolicyCollection
+	overrideEvents []*models.PolicyOverrideEvent
+}
+
+// NewRecommenderPolicyRepository returns a test RecommenderPolicyRepository
+func NewRecommenderPolicyRepository(canQuery bool) repository.RecommenderPolicyRepository {
+	return &RecommenderPolicyRepository{canQuery: canQuery}
+}
+
+// CreateCollection creates a new policy collection
+func (repo *RecommenderPolicyRepository) CreateCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.collections = append(repo.collections, collection)
+	collection.ID = uint(len(repo.collections))
+
+	return collection, nil
+}
+
+// ReadCollection reads a policy collection by id in a project
+func (repo *RecommenderPolicyRepository) ReadCollection(projectID, collectionID uint) (*models.RecommenderPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if collectionID == 0 || int(collectionID-1) >= len(repo.collections) || repo.collections[collectionID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	collection := repo.collections[collectionID-1]
+
+	if collection.ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return collection, nil
+}
+
+// ReadCollectionByName reads a policy collection by name in a project
+func (repo *RecommenderPolicyRepository) ReadCollectionByName(projectID uint, name string) (*models.RecommenderPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, collection := range repo.collections {
+		if collection != nil && collection.ProjectID == projectID && collection.Name == name {
+			return collection, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListCollectionsByProjectID lists the policy collections in a project
+func (repo *RecommenderPolicyRepository) ListCollectionsByProjectID(projectID uint) ([]*models.RecommenderPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.RecommenderPolicyCollection, 0)
+
+	for _, collection := range repo.collections {
+		if collection != nil && collection.ProjectID == projectID {
+			res = append(res, collection)
+		}
+	}
+
+	return res, nil
+}
+
+// UpdateCollection updates a policy collection
+func (repo *RecommenderPolicyRepository) UpdateCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if collection.ID == 0 || int(collection.ID-1) >= len(repo.collections) || repo.collections[collection.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.collections[collection.ID-1] = collection
+
+	return collection, nil
+}
+
+// DeleteCollection deletes a policy collection
+func (repo *RecommenderPolicyRepository) DeleteCollection(collection *models.RecommenderPolicyCollection) (*models.RecommenderPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if collection.ID == 0 || int(collection.ID-1) >= len(repo.collections) || repo.collections[collection.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.collections[collection.ID-1] = nil
+
+	return collection, nil
+}
+
+// CreateOverrideEvent records a deploy which overrode blocking policies
+func (repo *RecommenderPolicyRepository) CreateOverrideEvent(event *models.PolicyOverrideEvent) (*models.PolicyOverrideEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.overrideEvents = append(repo.overrideEvents, event)
+	event.ID = uint(len(repo.overrideEvents))
+
+	return event, nil
+}
+
+// ListOverrideEventsByProjectID lists the policy override events in a project, most recent first
+func (repo *RecommenderPolicyRepository) ListOverrideEventsByProjectID(projectID uint) ([]*models.PolicyOverrideEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.PolicyOverrideEvent, 0)
+
+	for i := len(repo.overrideEvents) - 1; i >= 0; i-- {
+		if repo.overrideEvents[i].ProjectID == projectID {
+			res = append(res, repo.overrideEvents[i])
+		}
+	}
+
+	return res, nil
+}
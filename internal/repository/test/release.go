@@ -93,6 +93,32 @@ func (repo *ReleaseRepository) ListReleasesByImageRepoURI(
 	return res, nil
 }
 
+// ListReleasesByTag lists the releases in a cluster which are linked to the tag with the given name
+func (repo *ReleaseRepository) ListReleasesByTag(
+	clusterID uint, tagName string,
+) ([]*models.Release, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Release, 0)
+
+	for _, release := range repo.releases {
+		if release == nil || release.ClusterID != clusterID {
+			continue
+		}
+
+		for _, tag := range release.Tags {
+			if tag.Name == tagName {
+				res = append(res, release)
+				break
+			}
+		}
+	}
+
+	return res, nil
+}
+
 // UpdateRelease modifies an existing Release in the database
 func (repo *ReleaseRepository) UpdateRelease(
 	release *models.Release,
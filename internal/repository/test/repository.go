@@ -52,6 +52,13 @@ type TestRepository struct {
 	porterApp                 repository.PorterAppRepository
 	porterAppEvent            repository.PorterAppEventRepository
 	deploymentTarget          repository.DeploymentTargetRepository
+	webhookIntegration        repository.WebhookIntegrationRepository
+	pagingIntegration         repository.PagingIntegrationRepository
+	twoFactor                 repository.TwoFactorRepository
+	imagePush                 repository.ImagePushRepository
+	appRevision               repository.AppRevisionRepository
+	appSleep                  repository.AppSleepRepository
+	recommenderPolicy         repository.RecommenderPolicyRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -243,6 +250,46 @@ func (t *TestRepository) DeploymentTarget() repository.DeploymentTargetRepositor
 	return t.deploymentTarget
 }
 
+// WebhookIntegration returns a test WebhookIntegrationRepository
+func (t *TestRepository) WebhookIntegration() repository.WebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
+// PagingIntegration returns a test PagingIntegrationRepository
+func (t *TestRepository) PagingIntegration() repository.PagingIntegrationRepository {
+	return t.pagingIntegration
+}
+
+// TwoFactor returns a test TwoFactorRepository
+func (t *TestRepository) TwoFactor() repository.TwoFactorRepository {
+	return t.twoFactor
+}
+
+// ImagePush returns a test ImagePushRepository
+func (t *TestRepository) ImagePush() repository.ImagePushRepository {
+	return t.imagePush
+}
+
+// AppRevision returns a test AppRevisionRepository
+func (t *TestRepository) AppRevision() repository.AppRevisionRepository {
+	return t.appRevision
+}
+
+// AppSleep returns a test AppSleepRepository
+func (t *TestRepository) AppSleep() repository.AppSleepRepository {
+	return t.appSleep
+}
+
+// RecommenderPolicy returns a test RecommenderPolicyRepository
+func (t *TestRepository) RecommenderPolicy() repository.RecommenderPolicyRepository {
+	return t.recommenderPolicy
+}
+
+// Transaction runs fn with the in-memory repository, which does not support rolling back
+func (t *TestRepository) Transaction(fn func(repo repository.Repository) error) error {
+	return fn(t)
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -293,6 +340,13 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		awsAssumeRoleChainer:      NewAWSAssumeRoleChainer(),
 		porterApp:                 NewPorterAppRepository(canQuery, failingMethods...),
 		porterAppEvent:            NewPorterAppEventRepository(canQuery),
-		deploymentTarget:          NewDeploymentTargetRepository(),
+		deploymentTarget:          NewDeploymentTargetRepository(canQuery),
+		webhookIntegration:        NewWebhookIntegrationRepository(canQuery),
+		pagingIntegration:         NewPagingIntegrationRepository(canQuery),
+		twoFactor:                 NewTwoFactorRepository(canQuery),
+		imagePush:                 NewImagePushRepository(canQuery),
+		appRevision:               NewAppRevisionRepository(canQuery),
+		appSleep:                  NewAppSleepRepository(canQuery),
+		recommenderPolicy:         NewRecommenderPolicyRepository(canQuery),
 	}
 }
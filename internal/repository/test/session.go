@@ -3,6 +3,7 @@ package test
 import (
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -34,7 +35,7 @@ func (repo *SessionRepository) CreateSession(session *models.Session) (*models.S
 
 	// make sure key doesn't exist
 	for _, s := range repo.sessions {
-		if s.Key == session.Key {
+		if s != nil && s.Key == session.Key {
 			return nil, errors.New("Cannot write database")
 		}
 	}
@@ -56,13 +57,18 @@ func (repo *SessionRepository) UpdateSession(session *models.Session) (*models.S
 	var oldSession *models.Session
 
 	for _, s := range repo.sessions {
-		if s.Key == session.Key {
+		if s != nil && s.Key == session.Key {
 			oldSession = s
 		}
 	}
 
 	if oldSession != nil {
 		oldSession.Data = session.Data
+		oldSession.ExpiresAt = session.ExpiresAt
+		oldSession.UserID = session.UserID
+		oldSession.IPAddress = session.IPAddress
+		oldSession.UserAgent = session.UserAgent
+		oldSession.LastSeenAt = session.LastSeenAt
 
 		return oldSession, nil
 	}
@@ -76,14 +82,14 @@ func (repo *SessionRepository) DeleteSession(session *models.Session) (*models.S
 		return nil, errors.New("Cannot write database")
 	}
 
-	if int(session.ID-1) >= len(repo.sessions) || repo.sessions[session.ID-1] == nil {
-		return nil, gorm.ErrRecordNotFound
+	for i, s := range repo.sessions {
+		if s != nil && s.Key == session.Key {
+			repo.sessions[i] = nil
+			return session, nil
+		}
 	}
 
-	index := int(session.ID - 1)
-	repo.sessions[index] = nil
-
-	return session, nil
+	return nil, gorm.ErrRecordNotFound
 }
 
 // SelectSession returns a session with matching key
@@ -93,10 +99,77 @@ func (repo *SessionRepository) SelectSession(session *models.Session) (*models.S
 	}
 
 	for _, s := range repo.sessions {
-		if s.Key == session.Key {
+		if s != nil && s.Key == session.Key {
 			return s, nil
 		}
 	}
 
 	return nil, gorm.ErrRecordNotFound
 }
+
+// TouchSession records the last time and client that a session was used
+func (repo *SessionRepository) TouchSession(key string, lastSeenAt time.Time, ipAddress, userAgent string) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	for _, s := range repo.sessions {
+		if s != nil && s.Key == key {
+			s.LastSeenAt = &lastSeenAt
+			s.IPAddress = ipAddress
+			s.UserAgent = userAgent
+
+			return nil
+		}
+	}
+
+	return gorm.ErrRecordNotFound
+}
+
+// ListSessionsByUserID returns the unexpired sessions authenticated as a user
+func (repo *SessionRepository) ListSessionsByUserID(userID uint) ([]*models.Session, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Session, 0)
+
+	for _, s := range repo.sessions {
+		if s != nil && s.UserID == userID && s.ExpiresAt.After(time.Now()) {
+			res = append(res, s)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteUserSession deletes a single session belonging to a user
+func (repo *SessionRepository) DeleteUserSession(userID, sessionID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(sessionID-1) >= len(repo.sessions) || repo.sessions[sessionID-1] == nil || repo.sessions[sessionID-1].UserID != userID {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.sessions[sessionID-1] = nil
+
+	return nil
+}
+
+// DeleteSessionsByUserID deletes all sessions belonging to a user, except for the session
+// with the key exceptKey if it is set
+func (repo *SessionRepository) DeleteSessionsByUserID(userID uint, exceptKey string) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	for i, s := range repo.sessions {
+		if s != nil && s.UserID == userID && (exceptKey == "" || s.Key != exceptKey) {
+			repo.sessions[i] = nil
+		}
+	}
+
+	return nil
+}
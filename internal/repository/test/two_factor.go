@@ -0,0 +1,77 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TwoFactorRepository is a test repository that implements repository.TwoFactorRepository
+type TwoFactorRepository struct {
+	canQuery   bool
+	twoFactors []*models.UserTwoFactor
+}
+
+// NewTwoFactorRepository returns the test TwoFactorRepository
+func NewTwoFactorRepository(canQuery bool) repository.TwoFactorRepository {
+	return &TwoFactorRepository{canQuery: canQuery}
+}
+
+// ReadUserTwoFactor finds the second factor for a user
+func (repo *TwoFactorRepository) ReadUserTwoFactor(userID uint) (*models.UserTwoFactor, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, twoFactor := range repo.twoFactors {
+		if twoFactor != nil && twoFactor.UserID == userID {
+			return twoFactor, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// CreateUserTwoFactor creates a new second factor for a user
+func (repo *TwoFactorRepository) CreateUserTwoFactor(twoFactor *models.UserTwoFactor) (*models.UserTwoFactor, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.twoFactors = append(repo.twoFactors, twoFactor)
+	twoFactor.ID = uint(len(repo.twoFactors))
+
+	return twoFactor, nil
+}
+
+// UpdateUserTwoFactor updates an existing second factor
+func (repo *TwoFactorRepository) UpdateUserTwoFactor(twoFactor *models.UserTwoFactor) (*models.UserTwoFactor, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(twoFactor.ID-1) >= len(repo.twoFactors) || repo.twoFactors[twoFactor.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.twoFactors[twoFactor.ID-1] = twoFactor
+
+	return twoFactor, nil
+}
+
+// DeleteUserTwoFactor deletes the second factor for a user
+func (repo *TwoFactorRepository) DeleteUserTwoFactor(twoFactor *models.UserTwoFactor) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(twoFactor.ID-1) >= len(repo.twoFactors) || repo.twoFactors[twoFactor.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.twoFactors[twoFactor.ID-1] = nil
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package test
+
+import (
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type WebhookIntegrationRepository struct{}
+
+func NewWebhookIntegrationRepository(canQuery bool) repository.WebhookIntegrationRepository {
+	return &WebhookIntegrationRepository{}
+}
+
+func (s *WebhookIntegrationRepository) CreateWebhookIntegration(webhookInt *ints.WebhookIntegration) (*ints.WebhookIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (s *WebhookIntegrationRepository) ListWebhookIntegrationsByProjectID(projectID uint) ([]*ints.WebhookIntegration, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (s *WebhookIntegrationRepository) DeleteWebhookIntegration(projectID, integrationID uint) error {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// TwoFactorRepository represents the set of queries on the UserTwoFactor model
+type TwoFactorRepository interface {
+	// ReadUserTwoFactor finds the second factor for a user
+	ReadUserTwoFactor(userID uint) (*models.UserTwoFactor, error)
+	// CreateUserTwoFactor creates a new second factor for a user
+	CreateUserTwoFactor(twoFactor *models.UserTwoFactor) (*models.UserTwoFactor, error)
+	// UpdateUserTwoFactor updates an existing second factor
+	UpdateUserTwoFactor(twoFactor *models.UserTwoFactor) (*models.UserTwoFactor, error)
+	// DeleteUserTwoFactor deletes the second factor for a user
+	DeleteUserTwoFactor(twoFactor *models.UserTwoFactor) error
+}
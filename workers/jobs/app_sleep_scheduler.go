@@ -0,0 +1,107 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === App Sleep Scheduler Job ===
+
+   This job applies the sleep schedules of every project. The web and worker services of apps which are outside
+   of the awake hours of their schedule are scaled to zero, and apps whose awake hours have started are scaled
+   back to their original instance counts and autoscaling settings. Apps which were put to sleep or woken up
+   manually are left alone until the next transition of their schedule. This job is meant to be enqueued every
+   few minutes.
+
+*/
+
+type appSleepScheduler struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	repo        repository.Repository
+	ccpClient   porterv1connect.ClusterControlPlaneServiceClient
+}
+
+// AppSleepSchedulerOpts holds the options required to run this job
+type AppSleepSchedulerOpts struct {
+	DBConf *env.DBConf
+
+	// ClusterControlPlaneAddress is the address of the cluster control plane, which applies the scaled apps
+	ClusterControlPlaneAddress string
+}
+
+func NewAppSleepScheduler(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *AppSleepSchedulerOpts,
+) (*appSleepScheduler, error) {
+	if opts.ClusterControlPlaneAddress == "" {
+		return nil, fmt.Errorf("cluster control plane address must be set to run sleep schedules")
+	}
+
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	ccpClient := porterv1connect.NewClusterControlPlaneServiceClient(http.DefaultClient, opts.ClusterControlPlaneAddress)
+
+	return &appSleepScheduler{enqueueTime, db, repo, ccpClient}, nil
+}
+
+func (a *appSleepScheduler) ID() string {
+	return "app-sleep-scheduler"
+}
+
+func (a *appSleepScheduler) EnqueueTime() time.Time {
+	return a.enqueueTime
+}
+
+func (a *appSleepScheduler) Run(ctx context.Context) error {
+	log.Println("running app sleep schedules")
+
+	err := porter_app.RunSleepSchedules(ctx, porter_app.RunSleepSchedulesInput{
+		Now:       time.Now(),
+		CCPClient: a.ccpClient,
+		Repo:      a.repo,
+	})
+	if err != nil {
+		log.Printf("error running app sleep schedules: %v", err)
+		return err
+	}
+
+	log.Println("finished running app sleep schedules")
+
+	return nil
+}
+
+func (a *appSleepScheduler) SetData([]byte) {}
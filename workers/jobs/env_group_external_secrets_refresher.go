@@ -0,0 +1,155 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/integrations/externalsecrets"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Environment Group External Secrets Refresher Job ===
+
+   This job goes through every connected cluster and checks whether the upstream values of external secret
+   references (${vault://...}, ${awssm://...}) in environment groups have changed. References are resolved within
+   the secrets of the project which owns the cluster. If they have, a new version of the environment group is
+   created so that the new values are synced to linked applications on their next deploy.
+
+*/
+
+type envGroupExternalSecretsRefresher struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	doConf      *oauth2.Config
+	repo        repository.Repository
+}
+
+// EnvGroupExternalSecretsRefresherOpts holds the options required to run this job
+type EnvGroupExternalSecretsRefresherOpts struct {
+	DBConf         *env.DBConf
+	ServerURL      string
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+
+	VaultAddress string
+	VaultToken   string
+	AWSRegion    string
+}
+
+func NewEnvGroupExternalSecretsRefresher(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EnvGroupExternalSecretsRefresherOpts,
+) (*envGroupExternalSecretsRefresher, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	if opts.VaultAddress != "" && opts.VaultToken != "" {
+		externalsecrets.RegisterProvider(externalsecrets.NewVaultProvider(opts.VaultAddress, opts.VaultToken))
+	}
+
+	if opts.AWSRegion != "" {
+		awsProvider, err := externalsecrets.NewAWSSecretsManagerProvider(opts.AWSRegion)
+		if err != nil {
+			return nil, err
+		}
+
+		externalsecrets.RegisterProvider(awsProvider)
+	}
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	return &envGroupExternalSecretsRefresher{enqueueTime, db, doConf, repo}, nil
+}
+
+func (n *envGroupExternalSecretsRefresher) ID() string {
+	return "env-group-external-secrets-refresher"
+}
+
+func (n *envGroupExternalSecretsRefresher) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *envGroupExternalSecretsRefresher) Run(ctx context.Context) error {
+	var count int64
+
+	if err := n.db.Model(&models.Cluster{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	log.Println("starting refresh of environment group external secrets")
+
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		var clusters []*models.Cluster
+
+		if err := n.db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&clusters).
+			Error; err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters {
+			k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(ctx, &kubernetes.OutOfClusterConfig{
+				Cluster:                   cluster,
+				Repo:                      n.repo,
+				DigitalOceanOAuth:         n.doConf,
+				AllowInClusterConnections: false,
+				Timeout:                   10 * time.Second,
+			})
+			if err != nil {
+				log.Printf("error getting k8s agent for cluster %s: %v", cluster.Name, err)
+				continue
+			}
+
+			refreshed, err := environment_groups.RefreshAllExternalSecrets(ctx, k8sAgent, cluster.ProjectID)
+			if err != nil {
+				log.Printf("error refreshing external secrets for cluster %s: %v", cluster.Name, err)
+			}
+
+			for _, name := range refreshed {
+				log.Printf("created new version of environment group %s in cluster %s after upstream secrets changed", name, cluster.Name)
+			}
+		}
+	}
+
+	log.Println("finished refresh of environment group external secrets")
+
+	return nil
+}
+
+func (n *envGroupExternalSecretsRefresher) SetData([]byte) {}
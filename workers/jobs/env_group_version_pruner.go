@@ -0,0 +1,189 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/environment_groups"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/porter_app"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Environment Group Version Pruner Job ===
+
+   This job goes through every cluster with an environment group version retention setting and deletes the
+   versions of its environment groups which are older than the retained versions. Versions referenced by an app
+   revision or by a running workload are never pruned. App revisions are listed once per cluster, which is why
+   this is done here rather than on every environment group update.
+
+*/
+
+type envGroupVersionPruner struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	doConf      *oauth2.Config
+	repo        repository.Repository
+	ccpClient   porterv1connect.ClusterControlPlaneServiceClient
+}
+
+// EnvGroupVersionPrunerOpts holds the options required to run this job
+type EnvGroupVersionPrunerOpts struct {
+	DBConf         *env.DBConf
+	ServerURL      string
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+
+	// ClusterControlPlaneAddress is the address of the cluster control plane, which stores the app revisions
+	ClusterControlPlaneAddress string
+}
+
+func NewEnvGroupVersionPruner(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EnvGroupVersionPrunerOpts,
+) (*envGroupVersionPruner, error) {
+	// without the control plane, versions referenced by app revisions cannot be found and would be pruned
+	if opts.ClusterControlPlaneAddress == "" {
+		return nil, fmt.Errorf("cluster control plane address must be set to prune environment group versions")
+	}
+
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	ccpClient := porterv1connect.NewClusterControlPlaneServiceClient(http.DefaultClient, opts.ClusterControlPlaneAddress)
+
+	return &envGroupVersionPruner{enqueueTime, db, doConf, repo, ccpClient}, nil
+}
+
+func (n *envGroupVersionPruner) ID() string {
+	return "env-group-version-pruner"
+}
+
+func (n *envGroupVersionPruner) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *envGroupVersionPruner) Run(ctx context.Context) error {
+	var count int64
+
+	query := n.db.Model(&models.Cluster{}).Where("env_group_version_retention > 0")
+
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+
+	log.Println("starting pruning of environment group versions")
+
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		var clusters []*models.Cluster
+
+		if err := n.db.Where("env_group_version_retention > 0").Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&clusters).
+			Error; err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters {
+			err := n.pruneCluster(ctx, cluster)
+			if err != nil {
+				log.Printf("error pruning environment group versions for cluster %s: %v", cluster.Name, err)
+			}
+		}
+	}
+
+	log.Println("finished pruning of environment group versions")
+
+	return nil
+}
+
+func (n *envGroupVersionPruner) pruneCluster(ctx context.Context, cluster *models.Cluster) error {
+	k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(ctx, &kubernetes.OutOfClusterConfig{
+		Cluster:                   cluster,
+		Repo:                      n.repo,
+		DigitalOceanOAuth:         n.doConf,
+		AllowInClusterConnections: false,
+		Timeout:                   10 * time.Second,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting k8s agent: %w", err)
+	}
+
+	referenced, err := porter_app.EnvGroupVersionsReferencedByRevisions(ctx, porter_app.EnvGroupVersionsReferencedByRevisionsInput{
+		ProjectID:                  cluster.ProjectID,
+		ClusterID:                  cluster.ID,
+		PorterAppRepository:        n.repo.PorterApp(),
+		DeploymentTargetRepository: n.repo.DeploymentTarget(),
+		CCPClient:                  n.ccpClient,
+	})
+	if err != nil {
+		return fmt.Errorf("error finding environment group versions referenced by app revisions: %w", err)
+	}
+
+	environmentGroups, err := environment_groups.ListEnvironmentGroups(ctx, k8sAgent, environment_groups.WithNamespace(environment_groups.Namespace_EnvironmentGroups))
+	if err != nil {
+		return fmt.Errorf("error listing environment groups: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for _, environmentGroup := range environmentGroups {
+		names[environmentGroup.Name] = true
+	}
+
+	for name := range names {
+		pruned, err := environment_groups.PruneEnvironmentGroupVersions(ctx, k8sAgent, environment_groups.PruneEnvironmentGroupVersionsInput{
+			Name:               name,
+			Retain:             int(cluster.EnvGroupVersionRetention),
+			ReferencedVersions: referenced[name],
+		})
+		if err != nil {
+			log.Printf("error pruning versions of environment group %s in cluster %s: %v", name, cluster.Name, err)
+		}
+
+		if len(pruned) > 0 {
+			log.Printf("pruned versions %v of environment group %s in cluster %s", pruned, name, cluster.Name)
+		}
+	}
+
+	return nil
+}
+
+func (n *envGroupVersionPruner) SetData([]byte) {}
@@ -5,7 +5,7 @@
                             === Helm Release Revisions Tracker Job ===
 
 This job keeps a track of helm releases and their revisions and deletes older revisions once they are
-backed up to an S3 bucket.
+archived to object storage (an S3 bucket, a GCS bucket or a local directory).
 
   - The job looks for clusters which have the `monitor_helm_releases` set to true.
   - The clusters are then checked for old helm release revisions.
@@ -13,7 +13,9 @@ backed up to an S3 bucket.
   - For every namespace, the list of releases is fetched.
   - For every release, its revision history is fetched.
   - If the number of revisions exceeds 100, then we intend to only keep the most recent 100 revisions.
-  - For this, the older revisions are first backed up to an S3 bucket and then deleted.
+  - For this, the older revisions are first archived and then deleted.
+  - Archived revisions are listed in the release history of the Porter server, and are restored when a
+    release is rolled back to them.
 
 */
 
@@ -21,8 +23,6 @@ package jobs
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
 	"os"
 	"sync"
@@ -31,11 +31,11 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/pkg/logger"
-	"github.com/porter-dev/porter/provisioner/integrations/storage/s3"
 	"github.com/porter-dev/porter/workers/utils"
 
 	"github.com/porter-dev/porter/ee/integrations/vault"
 	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/helm/archive"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
@@ -48,16 +48,15 @@ import (
 )
 
 type helmRevisionsCountTracker struct {
-	enqueueTime        time.Time
-	db                 *gorm.DB
-	repo               repository.Repository
-	doConf             *oauth2.Config
-	awsAccessKeyID     string
-	awsSecretAccessKey string
-	awsRegion          string
-	s3BucketName       string
-	encryptionKey      *[32]byte
-	revisionsCount     int
+	enqueueTime     time.Time
+	db              *gorm.DB
+	repo            repository.Repository
+	doConf          *oauth2.Config
+	revisionArchive *archive.Archive
+	revisionsCount  int
+
+	// helmSQLConnectionString is the database holding the Helm releases of clusters which use the sql storage
+	helmSQLConnectionString string
 }
 
 // HelmRevisionsCountTrackerOpts holds the options required to run this job
@@ -73,6 +72,12 @@ type HelmRevisionsCountTrackerOpts struct {
 	S3BucketName       string
 	EncryptionKey      string
 	RevisionsCount     int
+
+	// ArchiveBackend is the object storage which pruned revisions are archived to (s3, gcs or local)
+	ArchiveBackend string
+	GCSBucketName  string
+	GCSCredentials string
+	ArchiveDir     string
 }
 
 func NewHelmRevisionsCountTracker(
@@ -106,15 +111,23 @@ func NewHelmRevisionsCountTracker(
 		BaseURL:      opts.ServerURL,
 	})
 
-	var s3Key [32]byte
-
-	for i, b := range []byte(opts.EncryptionKey) {
-		s3Key[i] = b
+	revisionArchive, err := archive.New(ctx, &archive.Options{
+		Backend:            opts.ArchiveBackend,
+		EncryptionKey:      opts.EncryptionKey,
+		AWSRegion:          opts.AWSRegion,
+		AWSAccessKeyID:     opts.AWSAccessKeyID,
+		AWSSecretAccessKey: opts.AWSSecretAccessKey,
+		S3BucketName:       opts.S3BucketName,
+		GCSBucketName:      opts.GCSBucketName,
+		GCSCredentials:     opts.GCSCredentials,
+		LocalDir:           opts.ArchiveDir,
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &helmRevisionsCountTracker{
-		enqueueTime, db, repo, doConf, opts.AWSAccessKeyID, opts.AWSSecretAccessKey, opts.AWSRegion,
-		opts.S3BucketName, &s3Key, opts.RevisionsCount,
+		enqueueTime, db, repo, doConf, revisionArchive, opts.RevisionsCount, opts.DBConf.HelmSQLConnectionString,
 	}, nil
 }
 
@@ -158,15 +171,6 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 					return
 				}
 
-				// create s3 client to store revisions that need to be deleted
-				s3Client, err := s3.NewS3StorageClient(&s3.S3Options{
-					t.awsRegion, t.awsAccessKeyID, t.awsSecretAccessKey, t.s3BucketName, t.encryptionKey,
-				})
-				if err != nil {
-					log.Printf("error creating S3 client for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
-					return
-				}
-
 				k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(ctx, &kubernetes.OutOfClusterConfig{
 					Cluster:                   cluster,
 					Repo:                      t.repo,
@@ -195,6 +199,7 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 						DigitalOceanOAuth:         t.doConf,
 						AllowInClusterConnections: false,
 						Timeout:                   5 * time.Second,
+						SQLConnectionString:       t.helmSQLConnectionString,
 					}, logger.New(true, os.Stdout), 3, time.Second)
 					if err != nil {
 						log.Printf("error fetching helm client for namespace %s in cluster ID %d: %v. "+
@@ -244,18 +249,9 @@ func (t *helmRevisionsCountTracker) Run(ctx context.Context) error {
 						for i := t.revisionsCount; i < len(revisions); i += 1 {
 							rev := revisions[i]
 
-							// store the revision in the s3 bucket before deleting it
-							data, err := json.Marshal(rev)
-							if err != nil {
-								log.Printf("error marshalling revision for release %s, number %d: %v. skipping revision ...",
-									rev.Name, rev.Version, err)
-								continue
-							}
-
-							// write to the bucket with key - <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>
-							err = s3Client.WriteFileWithKey(data, true, fmt.Sprintf("%d/%d/%s/%s/%d", cluster.ProjectID,
-								cluster.ID, rel.Namespace, rel.Name, rev.Version))
-
+							// archive the revision before deleting it, with key -
+							// <project_id>/<cluster_id>/<namespace>/<release_name>/<revision_number>
+							err := t.revisionArchive.WriteRevision(ctx, cluster.ProjectID, cluster.ID, rev)
 							if err != nil {
 								log.Printf("error backing up revision for release %s, number %d: %v. skipping revision ...",
 									rev.Name, rev.Version, err)
@@ -0,0 +1,88 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/notifier/digest"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Notification Digest Sender Job ===
+
+   This job sends the deployment notifications which were held because they exceeded the notification limit of
+   their release, were sent during the quiet hours of their project, or belong to a release with digests enabled.
+   The held notifications of each release are sent as a single digest once they are due. This job is meant to be
+   enqueued every few minutes.
+
+*/
+
+type notificationDigestSender struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	repo        repository.Repository
+}
+
+// NotificationDigestSenderOpts holds the options required to run this job
+type NotificationDigestSenderOpts struct {
+	DBConf *env.DBConf
+}
+
+func NewNotificationDigestSender(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *NotificationDigestSenderOpts,
+) (*notificationDigestSender, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	return &notificationDigestSender{enqueueTime, db, repo}, nil
+}
+
+func (n *notificationDigestSender) ID() string {
+	return "notification-digest-sender"
+}
+
+func (n *notificationDigestSender) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *notificationDigestSender) Run(ctx context.Context) error {
+	log.Println("sending due notification digests")
+
+	if err := digest.SendDueDigests(n.repo, time.Now()); err != nil {
+		log.Printf("error sending notification digests: %v", err)
+		return err
+	}
+
+	log.Println("finished sending notification digests")
+
+	return nil
+}
+
+func (n *notificationDigestSender) SetData([]byte) {}
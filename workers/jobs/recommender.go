@@ -4,7 +4,9 @@
 
                             === Recommender Job ===
 
-This job checks to see if a cluster matches policies set by the OPA config file.
+This job checks to see if a cluster matches policies set by the OPA config file. The result of every check
+is kept in the run history, and a notification is sent when checks at or above the recommender alert severity
+of the cluster change from passing to failing.
 
 */
 
@@ -28,6 +30,7 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/opa"
+	recs "github.com/porter-dev/porter/internal/recommender"
 	"github.com/porter-dev/porter/internal/repository"
 	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
 	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
@@ -35,6 +38,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// recommenderHistoryRetention is how long the results of each recommender run are kept
+const recommenderHistoryRetention = 90 * 24 * time.Hour
+
 type recommender struct {
 	enqueueTime          time.Time
 	db                   *gorm.DB
@@ -44,6 +50,10 @@ type recommender struct {
 	categories           []string
 	policies             *opa.KubernetesPolicies
 	runRecommenderID     string
+	serverURL            string
+
+	// helmSQLConnectionString is the database holding the Helm releases of clusters which use the sql storage
+	helmSQLConnectionString string
 }
 
 // RecommenderOpts holds the options required to run this job
@@ -129,7 +139,8 @@ func NewRecommender(
 	}
 
 	return &recommender{
-		enqueueTime, db, repo, doConf, clusterIDs, parsedInput.Categories, opaPolicies, string(recommenderID),
+		enqueueTime, db, repo, doConf, clusterIDs, parsedInput.Categories, opaPolicies, string(recommenderID), opts.ServerURL,
+		opts.DBConf.HelmSQLConnectionString,
 	}, nil
 }
 
@@ -218,7 +229,9 @@ func (n *recommender) Run(ctx context.Context) error {
 			continue
 		}
 
-		runner := opa.NewRunner(n.policies, cluster, k8sAgent, dynamicClient)
+		policies := n.getPoliciesForProject(ctx, ids.projectID)
+
+		runner := opa.NewRunner(policies, cluster, k8sAgent, dynamicClient, n.helmSQLConnectionString)
 
 		queryResults, err := runner.GetRecommendations(n.categories)
 		if err != nil {
@@ -226,9 +239,15 @@ func (n *recommender) Run(ctx context.Context) error {
 			continue
 		}
 
+		runResults := make([]*models.MonitorTestRunResult, 0, len(queryResults))
+		regressions := make([]*types.RecommenderRegression, 0)
+		testedAt := time.Now()
+
 		for _, queryRes := range queryResults {
 			fmt.Println(queryRes.ObjectID, queryRes.Allow, queryRes.PolicyTitle, queryRes.PolicyMessage)
 
+			runResults = append(runResults, getMonitorTestRunResultFromQueryResult(cluster, queryRes, n.runRecommenderID, testedAt))
+
 			monitor, err := n.repo.MonitorTestResult().ReadMonitorTestResult(ids.projectID, ids.clusterID, queryRes.ObjectID)
 
 			if err != nil {
@@ -238,7 +257,19 @@ func (n *recommender) Run(ctx context.Context) error {
 					continue
 				}
 			} else {
+				previousResult := types.MonitorTestStatus(monitor.LastRunResult)
+
 				monitor, err = n.repo.MonitorTestResult().UpdateMonitorTestResult(mergeMonitorTestResultFromQueryResult(monitor, queryRes, n.runRecommenderID))
+
+				if err == nil && recs.IsRegression(previousResult, types.MonitorTestStatus(monitor.LastRunResult)) {
+					regressions = append(regressions, &types.RecommenderRegression{
+						ObjectID: monitor.ObjectID,
+						Category: monitor.Category,
+						Title:    monitor.Title,
+						Message:  monitor.Message,
+						Severity: types.MonitorTestSeverity(monitor.Severity),
+					})
+				}
 			}
 
 			if err != nil {
@@ -246,6 +277,25 @@ func (n *recommender) Run(ctx context.Context) error {
 			}
 		}
 
+		if err := n.repo.MonitorTestResult().CreateMonitorTestRunResults(runResults); err != nil {
+			log.Printf("error writing test result history for cluster ID %d: %v", ids.clusterID, err)
+		}
+
+		err = recs.NotifyRegressions(recs.NotifyRegressionsInput{
+			Repo:        n.repo,
+			Cluster:     cluster,
+			ServerURL:   n.serverURL,
+			Regressions: regressions,
+		})
+		if err != nil {
+			log.Printf("error sending recommender notifications for cluster ID %d: %v", ids.clusterID, err)
+		}
+
+		err = n.repo.MonitorTestResult().DeleteMonitorTestRunResultsBefore(ids.projectID, ids.clusterID, testedAt.Add(-recommenderHistoryRetention))
+		if err != nil {
+			log.Printf("error deleting test result history for cluster ID %d: %v", ids.clusterID, err)
+		}
+
 		err = n.repo.MonitorTestResult().ArchiveMonitorTestResults(ids.projectID, ids.clusterID, n.runRecommenderID)
 
 		if err != nil {
@@ -300,7 +350,7 @@ func mergeMonitorTestResultFromQueryResult(monitor *models.MonitorTestResult, qu
 
 	currTime := time.Now()
 
-	if isStatusChange := monitor.LastRunResult == string(runResult); isStatusChange {
+	if isStatusChange := monitor.LastRunResult != string(runResult); isStatusChange {
 		monitor.LastStatusChange = &currTime
 	}
 
@@ -317,4 +367,59 @@ func mergeMonitorTestResultFromQueryResult(monitor *models.MonitorTestResult, qu
 	return monitor
 }
 
+// getPoliciesForProject returns the built-in policies together with the custom policy collections of the project.
+// Custom collections which cannot be read or compiled are skipped, so that they do not prevent the built-in
+// policies from running.
+func (n *recommender) getPoliciesForProject(ctx context.Context, projectID uint) *opa.KubernetesPolicies {
+	collections, err := n.repo.RecommenderPolicy().ListCollectionsByProjectID(projectID)
+	if err != nil {
+		log.Printf("error listing custom policy collections for project ID %d: %v", projectID, err)
+		return n.policies
+	}
+
+	if len(collections) == 0 {
+		return n.policies
+	}
+
+	customCollections := make([]*opa.CustomPolicyCollection, 0, len(collections))
+
+	for _, collection := range collections {
+		customCollection, err := opa.CustomPolicyCollectionFromModel(collection)
+		if err != nil {
+			log.Printf("%v. skipping collection ...", err)
+			continue
+		}
+
+		customCollections = append(customCollections, customCollection)
+	}
+
+	policies, errs := n.policies.WithCustomCollections(ctx, customCollections)
+
+	for _, err := range errs {
+		log.Printf("error loading custom policies for project ID %d: %v. skipping collection ...", projectID, err)
+	}
+
+	return policies
+}
+
+func getMonitorTestRunResultFromQueryResult(cluster *models.Cluster, queryRes *opa.OPARecommenderQueryResult, recommenderID string, testedAt time.Time) *models.MonitorTestRunResult {
+	runResult := types.MonitorTestStatusSuccess
+
+	if !queryRes.Allow {
+		runResult = types.MonitorTestStatusFailed
+	}
+
+	return &models.MonitorTestRunResult{
+		ProjectID:        cluster.ProjectID,
+		ClusterID:        cluster.ID,
+		ObjectID:         queryRes.ObjectID,
+		Category:         queryRes.CategoryName,
+		RecommenderRunID: recommenderID,
+		Result:           string(runResult),
+		TestedAt:         testedAt,
+		Title:            queryRes.PolicyTitle,
+		Severity:         queryRes.PolicySeverity,
+	}
+}
+
 func (n *recommender) SetData([]byte) {}
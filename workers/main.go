@@ -65,6 +65,11 @@ type EnvConf struct {
 	S3BucketName       string `env:"S3_BUCKET_NAME"`
 	EncryptionKey      string `env:"S3_ENCRYPTION_KEY"`
 	RevisionsCount     int    `env:"REVISIONS_COUNT,default=20"`
+	// RevisionArchiveBackend is the object storage pruned revisions are archived to (s3, gcs or local)
+	RevisionArchiveBackend string `env:"REVISION_ARCHIVE_BACKEND,default=s3"`
+	GCSBucketName          string `env:"GCS_BUCKET_NAME"`
+	GCSCredentials         string `env:"GCS_CREDENTIALS"`
+	RevisionArchiveDir     string `env:"REVISION_ARCHIVE_DIR"`
 
 	// "recommender"
 	OPAConfigFileDir string `env:"OPA_CONFIG_FILE_DIR,default=./internal/opa"`
@@ -72,6 +77,14 @@ type EnvConf struct {
 
 	// "preview-deployments-ttl-deleter"
 	PreviewDeploymentsTTL string `env:"PREVIEW_DEPLOYMENTS_TTL"`
+
+	// "env-group-external-secrets-refresher"
+	ExternalSecretsVaultAddress string `env:"EXTERNAL_SECRETS_VAULT_ADDR"`
+	ExternalSecretsVaultToken   string `env:"EXTERNAL_SECRETS_VAULT_TOKEN"`
+	ExternalSecretsAWSRegion    string `env:"EXTERNAL_SECRETS_AWS_REGION"`
+
+	// "app-sleep-scheduler", "env-group-version-pruner"
+	ClusterControlPlaneAddress string `env:"CLUSTER_CONTROL_PLANE_ADDRESS"`
 }
 
 func main() {
@@ -222,6 +235,10 @@ func getJob(ctx context.Context, id string, input map[string]interface{}) worker
 			S3BucketName:       envDecoder.S3BucketName,
 			EncryptionKey:      envDecoder.EncryptionKey,
 			RevisionsCount:     envDecoder.RevisionsCount,
+			ArchiveBackend:     envDecoder.RevisionArchiveBackend,
+			GCSBucketName:      envDecoder.GCSBucketName,
+			GCSCredentials:     envDecoder.GCSCredentials,
+			ArchiveDir:         envDecoder.RevisionArchiveDir,
 		})
 		if err != nil {
 			log.Printf("error creating job with ID: helm-revisions-count-tracker. Error: %v", err)
@@ -259,6 +276,59 @@ func getJob(ctx context.Context, id string, input map[string]interface{}) worker
 			return nil
 		}
 
+		return newJob
+	} else if id == "env-group-external-secrets-refresher" {
+		newJob, err := jobs.NewEnvGroupExternalSecretsRefresher(dbConn, time.Now().UTC(), &jobs.EnvGroupExternalSecretsRefresherOpts{
+			DBConf:         &envDecoder.DBConf,
+			ServerURL:      envDecoder.ServerURL,
+			DOClientID:     envDecoder.DOClientID,
+			DOClientSecret: envDecoder.DOClientSecret,
+			DOScopes:       []string{"read", "write"},
+			VaultAddress:   envDecoder.ExternalSecretsVaultAddress,
+			VaultToken:     envDecoder.ExternalSecretsVaultToken,
+			AWSRegion:      envDecoder.ExternalSecretsAWSRegion,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: env-group-external-secrets-refresher. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "notification-digest-sender" {
+		newJob, err := jobs.NewNotificationDigestSender(dbConn, time.Now().UTC(), &jobs.NotificationDigestSenderOpts{
+			DBConf: &envDecoder.DBConf,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: notification-digest-sender. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "app-sleep-scheduler" {
+		newJob, err := jobs.NewAppSleepScheduler(dbConn, time.Now().UTC(), &jobs.AppSleepSchedulerOpts{
+			DBConf:                     &envDecoder.DBConf,
+			ClusterControlPlaneAddress: envDecoder.ClusterControlPlaneAddress,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: app-sleep-scheduler. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "env-group-version-pruner" {
+		newJob, err := jobs.NewEnvGroupVersionPruner(dbConn, time.Now().UTC(), &jobs.EnvGroupVersionPrunerOpts{
+			DBConf:                     &envDecoder.DBConf,
+			ServerURL:                  envDecoder.ServerURL,
+			DOClientID:                 envDecoder.DOClientID,
+			DOClientSecret:             envDecoder.DOClientSecret,
+			DOScopes:                   []string{"read", "write"},
+			ClusterControlPlaneAddress: envDecoder.ClusterControlPlaneAddress,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: env-group-version-pruner. Error: %v", err)
+			return nil
+		}
+
 		return newJob
 	}
 